@@ -51,6 +51,7 @@ type PatchValueFromSource struct {
 // Common Options that all CRs should embed, excluding GrafanaSpec
 // Ensure alignment on handling ResyncPeriod, InstanceSelector, and AllowCrossNamespaceImport
 // +kubebuilder:validation:XValidation:rule="!oldSelf.allowCrossNamespaceImport || (oldSelf.allowCrossNamespaceImport && self.allowCrossNamespaceImport)", message="disabling spec.allowCrossNamespaceImport requires a recreate to ensure desired state"
+// +kubebuilder:validation:XValidation:rule="((!has(oldSelf.orgRef) && !has(self.orgRef)) || (has(oldSelf.orgRef) && has(self.orgRef)))", message="spec.orgRef is immutable"
 type GrafanaCommonSpec struct {
 	// How often the resource is synced, defaults to 10m0s if not set
 	// +optional
@@ -70,8 +71,31 @@ type GrafanaCommonSpec struct {
 	// Suspend pauses synchronizing attempts and tells the operator to ignore changes
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
+
+	// Name of a GrafanaOrganization in the same namespace to synchronize the resource into.
+	// Defaults to the organization of the credentials used for the Grafana instance
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec.orgRef is immutable"
+	OrgRef string `json:"orgRef,omitempty"`
+
+	// How changes made directly in Grafana are handled, defaults to the operator wide policy.
+	// enforce overwrites them, detect reports them through the Drifted condition without applying changes
+	// to existing resources, ignore leaves existing resources untouched.
+	// Supported by dashboards, datasources, folders, alert rule groups, contact points, mute timings and notification templates
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=enforce;detect;ignore
+type DriftPolicy string
+
+const (
+	DriftPolicyEnforce DriftPolicy = "enforce"
+	DriftPolicyDetect  DriftPolicy = "detect"
+	DriftPolicyIgnore  DriftPolicy = "ignore"
+)
+
 // Common Functions that all CRs should implement, excluding Grafana
 // +kubebuilder:object:generate=false
 type CommonResource interface {
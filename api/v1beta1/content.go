@@ -50,8 +50,42 @@ type GrafanaContentURLBasicAuth struct {
 	Password *corev1.SecretKeySelector `json:"password,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!(has(self.basicAuth) && has(self.bearerToken))", message="only one of basicAuth or bearerToken can be set"
 type GrafanaContentURLAuthorization struct {
 	BasicAuth *GrafanaContentURLBasicAuth `json:"basicAuth,omitempty"`
+
+	// Token sent as bearer token in the Authorization header
+	// +optional
+	BearerToken *corev1.SecretKeySelector `json:"bearerToken,omitempty"`
+
+	// Secrets in the same namespace whose keys and values are sent as request headers
+	// +optional
+	HeadersFrom []corev1.LocalObjectReference `json:"headersFrom,omitempty"`
+
+	// TLS settings of the connection. The server certificate is verified against the system roots by default
+	// +optional
+	TLS *GrafanaContentURLTLS `json:"tls,omitempty"`
+}
+
+// GrafanaContentURLTLS configures server verification and client certificates of URL content sources
+// +kubebuilder:validation:XValidation:rule="has(self.cert) == has(self.key)", message="cert and key must be set together"
+// +kubebuilder:validation:XValidation:rule="!(has(self.ca) && has(self.insecureSkipVerify) && self.insecureSkipVerify)", message="ca and insecureSkipVerify cannot be set at the same time"
+type GrafanaContentURLTLS struct {
+	// PEM encoded CA bundle used to verify the server certificate instead of the system roots
+	// +optional
+	CA *corev1.SecretKeySelector `json:"ca,omitempty"`
+
+	// PEM encoded client certificate, e.g. tls.crt of a kubernetes.io/tls Secret
+	// +optional
+	Cert *corev1.SecretKeySelector `json:"cert,omitempty"`
+
+	// PEM encoded client key, e.g. tls.key of a kubernetes.io/tls Secret
+	// +optional
+	Key *corev1.SecretKeySelector `json:"key,omitempty"`
+
+	// Disable the verification of the server certificate
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 type JsonnetProjectBuild struct {
@@ -69,16 +103,31 @@ type GrafanaComContentReference struct {
 // GrafanaContentOCI references a dashboard JSON file inside an OCI artifact in a container registry.
 // Bytes are fetched at reconcile time and never persisted to etcd; recommended for dashboards that
 // exceed the etcd object-size limit (~1 MiB).
+// Tags are resolved to a digest and the artifact is only fetched again once the digest changes.
+// +kubebuilder:validation:XValidation:rule="has(self.semverConstraint) != (self.reference.contains(':') || self.reference.contains('@'))", message="reference must include a tag or digest, unless semverConstraint is set"
 type GrafanaContentOCI struct {
 	// Reference is the full OCI artifact reference including a tag or digest,
 	// e.g. "ghcr.io/team/dashboards:v1.4.7" or
 	// "ghcr.io/team/dashboards@sha256:abc123...". Prefer a digest for
-	// reproducible deployments.
+	// reproducible deployments. With semverConstraint, the reference is the repository without tag or digest.
 	// +kubebuilder:validation:MinLength=3
 	// +kubebuilder:validation:MaxLength=512
-	// +kubebuilder:validation:Pattern=`^[^:@]+(:[^:@/]+|@sha256:[a-fA-F0-9]{64})$`
+	// +kubebuilder:validation:Pattern=`^[^:@]+(:[^:@/]+|@sha256:[a-fA-F0-9]{64})?$`
 	Reference string `json:"reference"`
 
+	// SemverConstraint selects the highest tag of the repository matching the constraint, e.g. "~1.4", "^2.0.0"
+	// or ">=1.4.0 <2.0.0". Tags may have a "v" prefix, pre-releases are ignored
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	SemverConstraint string `json:"semverConstraint,omitempty"`
+
+	// PollInterval limits how often tags are resolved and new tags matching semverConstraint are looked up.
+	// Defaults to every reconcile; resources are reconciled at least once per pollInterval
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
 	// Path is the path of the file to extract from the artifact.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=512
@@ -94,6 +143,88 @@ type GrafanaContentOCI struct {
 	// certificates are not supported. Default false.
 	// +optional
 	InsecurePlainHTTP bool `json:"insecurePlainHTTP,omitempty"`
+
+	// Verify requires a valid cosign signature of the artifact before its content is extracted.
+	// Signatures are looked up at the cosign default tag sha256-<digest>.sig and in the OCI referrers of the artifact.
+	// +optional
+	Verify *GrafanaContentOCIVerify `json:"verify,omitempty"`
+}
+
+// GrafanaContentOCIVerify is the signature verification policy of an OCI artifact
+// +kubebuilder:validation:XValidation:rule="has(self.publicKey) != has(self.keyless)", message="exactly one of publicKey or keyless must be set"
+type GrafanaContentOCIVerify struct {
+	// PEM encoded cosign public key, e.g. cosign.pub
+	// +optional
+	PublicKey *GrafanaContentEnvFromSource `json:"publicKey,omitempty"`
+
+	// Keyless verifies signatures made with short-lived certificates issued to an OIDC identity
+	// +optional
+	Keyless *GrafanaContentOCIKeyless `json:"keyless,omitempty"`
+}
+
+// GrafanaContentOCIKeyless is the identity expected in the signing certificate and the trust material of
+// the certificate authority and transparency log, e.g. those of the public sigstore instance
+type GrafanaContentOCIKeyless struct {
+	// OIDC issuer of the identity, e.g. "https://token.actions.githubusercontent.com"
+	// +kubebuilder:validation:MinLength=1
+	Issuer string `json:"issuer"`
+
+	// Identity in the signing certificate, an email address or URI,
+	// e.g. "https://github.com/team/dashboards/.github/workflows/release.yaml@refs/heads/main"
+	// +kubebuilder:validation:MinLength=1
+	Subject string `json:"subject"`
+
+	// PEM encoded root and intermediate certificates of the certificate authority (Fulcio)
+	Roots GrafanaContentEnvFromSource `json:"roots"`
+
+	// PEM encoded public key of the transparency log (Rekor). The signature must have been logged
+	// while the short-lived signing certificate was valid
+	RekorPublicKey GrafanaContentEnvFromSource `json:"rekorPublicKey"`
+}
+
+// GrafanaContentGit references a file in a git repository.
+// The repository is shallow cloned into a cache shared by all resources referencing the same URL and ref.
+// +kubebuilder:validation:XValidation:rule="!(has(self.ref) && has(self.commit))", message="only one of ref or commit can be set"
+type GrafanaContentGit struct {
+	// URL of the repository, e.g. "https://github.com/team/dashboards.git" or "git@github.com:team/dashboards.git".
+	// Only https and ssh remotes are supported
+	// +kubebuilder:validation:Pattern=`^(https://|ssh://|[a-zA-Z0-9._-]+@[a-zA-Z0-9.-]+:).+$`
+	URL string `json:"url"`
+
+	// Ref is the branch or tag to check out. Defaults to the default branch of the repository
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Ref string `json:"ref,omitempty"`
+
+	// Commit is the full SHA of the commit to check out
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{40}$`
+	Commit string `json:"commit,omitempty"`
+
+	// Path is the path of the file within the repository
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Path string `json:"path"`
+
+	// SecretRef references a Secret in the same namespace as the CR holding the credentials.
+	// SSH URLs use the `ssh-privatekey` and `known_hosts` keys, host keys are always verified.
+	// HTTPS URLs use the `token` and optional `username` keys.
+	// If omitted, the repository is cloned anonymously.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// Revision returns the commit or ref to fetch, HEAD refers to the default branch
+func (in *GrafanaContentGit) Revision() string {
+	if in.Commit != "" {
+		return in.Commit
+	}
+
+	if in.Ref != "" {
+		return in.Ref
+	}
+
+	return "HEAD"
 }
 
 type GrafanaContentSpec struct {
@@ -141,7 +272,12 @@ type GrafanaContentSpec struct {
 	// +optional
 	OCI *GrafanaContentOCI `json:"oci,omitempty"`
 
-	// Cache duration for models fetched from URLs
+	// model from a file in a git repository
+	// +optional
+	Git *GrafanaContentGit `json:"git,omitempty"`
+
+	// Cache duration for models fetched from URLs, grafana.com, OCI artifacts and git repositories.
+	// Once expired, URLs are fetched with conditional requests if the server returned an ETag or Last-Modified header
 	// +optional
 	ContentCacheDuration metav1.Duration `json:"contentCacheDuration,omitempty"`
 
@@ -156,14 +292,75 @@ type GrafanaContentSpec struct {
 	// environments variables from secrets or config maps
 	// +optional
 	EnvsFrom []GrafanaContentEnvFromSource `json:"envFrom,omitempty"`
+
+	// Render the fetched content as Go template before it is parsed
+	// +optional
+	Templating *GrafanaContentTemplating `json:"templating,omitempty"`
+}
+
+// GrafanaContentTemplating renders content with text/template and a subset of the sprig functions.
+// Envs and envFrom are available as .Env.<name>, values of the Grafana instance as .Grafana.<name>
+// +kubebuilder:validation:XValidation:rule="has(self.leftDelimiter) == has(self.rightDelimiter)", message="leftDelimiter and rightDelimiter must be set together"
+type GrafanaContentTemplating struct {
+	// Delimiter starting template actions, defaults to "{{". Change the delimiters if the content uses "{{"
+	// itself, e.g. in legend formats or alert templates
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	LeftDelimiter string `json:"leftDelimiter,omitempty"`
+
+	// Delimiter ending template actions, defaults to "}}"
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	RightDelimiter string `json:"rightDelimiter,omitempty"`
+
+	// Fields of the Grafana instance the content is applied to. The content is rendered for each instance
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	GrafanaRefs []GrafanaContentTemplatingRef `json:"grafanaRefs,omitempty"`
+}
+
+// GrafanaContentTemplatingRef exposes a field of the Grafana instance to templates
+type GrafanaContentTemplatingRef struct {
+	// Name of the value in .Grafana
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+
+	// Field of the Grafana instance, e.g. "metadata.labels.env" or "spec.config.server.root_url"
+	GrafanaRef corev1.ObjectFieldSelector `json:"grafanaRef"`
 }
 
 type GrafanaContentStatus struct {
+	// Deprecated: content is kept in the content cache of the operator, only read for resources cached by previous versions
 	ContentCache     []byte      `json:"contentCache,omitempty"`
 	ContentTimestamp metav1.Time `json:"contentTimestamp,omitempty"`
 	ContentURL       string      `json:"contentUrl,omitempty"`
 	Hash             string      `json:"hash,omitempty"`
 	UID              string      `json:"uid,omitempty"`
+
+	// Digest of the content in the content cache of the operator
+	ContentDigest string `json:"contentDigest,omitempty"`
+
+	// ETag of the cached content, sent as If-None-Match once the cache expires
+	ContentETag string `json:"contentETag,omitempty"`
+
+	// Last-Modified of the cached content, sent as If-Modified-Since once the cache expires
+	ContentLastModified string `json:"contentLastModified,omitempty"`
+
+	// Commit SHA the content was resolved from, only set for git sources
+	GitCommit string `json:"gitCommit,omitempty"`
+
+	// Digest of the OCI manifest the content was loaded from, only set for OCI sources
+	OCIDigest string `json:"ociDigest,omitempty"`
+
+	// Tag the OCI manifest was resolved from, the highest tag matching the semver constraint if set
+	OCITag string `json:"ociTag,omitempty"`
+
+	// Time the OCI reference was last resolved against the registry
+	OCIResolvedTimestamp metav1.Time `json:"ociResolvedTimestamp,omitempty"`
+
+	// Digest of the OCI artifact whose signature was verified, only set for OCI sources with a verification policy
+	VerifiedDigest string `json:"verifiedDigest,omitempty"`
 }
 
 // Common interface for any resource that embeds or references Grafana-native model content.
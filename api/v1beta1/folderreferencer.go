@@ -8,5 +8,6 @@ type FolderReferencer interface {
 	FolderNamespace() string
 	FolderRef() string
 	FolderUID() string
+	FolderPath() string
 	GetGeneration() int64
 }
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -44,10 +45,16 @@ const (
 	OperatorStageService        OperatorStageName = "service"
 	OperatorStageIngress        OperatorStageName = "ingress"
 	OperatorStagePlugins        OperatorStageName = "plugins"
+	OperatorStageRenderer       OperatorStageName = "renderer"
 	OperatorStageDeployment     OperatorStageName = "deployment"
 	OperatorStageComplete       OperatorStageName = "complete"
 )
 
+const (
+	RendererModeSidecar    = "Sidecar"
+	RendererModeDeployment = "Deployment"
+)
+
 const (
 	OperatorStageResultSuccess    OperatorStageStatus = "success"
 	OperatorStageResultFailed     OperatorStageStatus = "failed"
@@ -62,6 +69,9 @@ type OperatorReconcileVars struct {
 	// env var value for installed plugins
 	Plugins string
 
+	// plugins installed from archives by the init container, OCI references are resolved to digests
+	PluginArchives PluginList
+
 	// used to restart the Grafana container when referenced secrets or configmaps change
 	SecretsHash string
 }
@@ -103,6 +113,15 @@ type GrafanaSpec struct {
 	// DisableDefaultSecurityContext prevents the operator from populating securityContext on deployments
 	// +kubebuilder:validation:Enum=Pod;Container;All
 	DisableDefaultSecurityContext string `json:"disableDefaultSecurityContext,omitempty"`
+	// HighAvailability runs multiple replicas sharing an external database and forming an alertmanager cluster
+	// +optional
+	HighAvailability *GrafanaHighAvailability `json:"highAvailability,omitempty"`
+	// Renderer deploys the Grafana image renderer and configures Grafana to use it
+	// +optional
+	Renderer *GrafanaRenderer `json:"renderer,omitempty"`
+	// PluginPolicy restricts the plugins dashboards, datasources and library panels may install on the instance
+	// +optional
+	PluginPolicy *GrafanaPluginPolicy `json:"pluginPolicy,omitempty"`
 }
 
 func (in *GrafanaSpec) GetAllContainers() []corev1.Container {
@@ -224,6 +243,95 @@ type GrafanaPreferences struct {
 	HomeDashboardUID string `json:"homeDashboardUid,omitempty"`
 }
 
+// GrafanaHighAvailability configures the settings required to run more than one replica
+type GrafanaHighAvailability struct {
+	// Number of replicas of the deployment
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=2
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Database shared by all replicas
+	Database GrafanaDatabase `json:"database"`
+	// Gossip settings of the alertmanager cluster formed by the replicas
+	// +optional
+	Alerting *GrafanaAlertingHA `json:"alerting,omitempty"`
+	// PodDisruptionBudget of the replicas, defaults to maxUnavailable: 1
+	// +optional
+	PodDisruptionBudget *GrafanaPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+// GrafanaDatabase defines the connection to an external database
+type GrafanaDatabase struct {
+	// +kubebuilder:validation:Enum=postgres;mysql
+	Type string `json:"type"`
+	// Host and port of the database, e.g. postgres.db:5432
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+	// Name of the database
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Secret key holding the user
+	User corev1.SecretKeySelector `json:"user"`
+	// Secret key holding the password
+	Password corev1.SecretKeySelector `json:"password"`
+	// Value of ssl_mode, e.g. require or verify-full for postgres and true or skip-verify for mysql
+	// +optional
+	SSLMode string `json:"sslMode,omitempty"`
+}
+
+// GrafanaAlertingHA defines how unified alerting replicas gossip with each other
+type GrafanaAlertingHA struct {
+	// Port used for gossip between the replicas
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=9094
+	Port int32 `json:"port,omitempty"`
+	// Interval between gossip messages, defaults to the Grafana default
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	GossipInterval *metav1.Duration `json:"gossipInterval,omitempty"`
+	// Interval between full state synchronizations, defaults to the Grafana default
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	PushPullInterval *metav1.Duration `json:"pushPullInterval,omitempty"`
+}
+
+// GrafanaRenderer configures the Grafana image renderer
+type GrafanaRenderer struct {
+	// Sidecar runs the renderer in the Grafana pod, Deployment runs it in a separate Deployment behind a Service
+	// +optional
+	// +kubebuilder:validation:Enum=Sidecar;Deployment
+	// +kubebuilder:default=Sidecar
+	Mode string `json:"mode,omitempty"`
+	// Image of the renderer, defaults to docker.io/grafana/grafana-image-renderer with the version pinned by the operator
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Number of replicas of the renderer Deployment, ignored for sidecars
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Resources of the renderer container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// IsDeployment returns true when the renderer runs in its own Deployment
+func (in *GrafanaRenderer) IsDeployment() bool {
+	return in != nil && in.Mode == RendererModeDeployment
+}
+
+// GrafanaPodDisruptionBudget holds either minAvailable or maxUnavailable
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))", message="Only one of minAvailable or maxUnavailable can be set"
+type GrafanaPodDisruptionBudget struct {
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
 // GrafanaStatus defines the observed state of Grafana
 type GrafanaStatus struct {
 	Stage                 OperatorStageName      `json:"stage,omitempty"`
@@ -239,11 +347,22 @@ type GrafanaStatus struct {
 	LibraryPanels         NamespacedResourceList `json:"libraryPanels,omitempty"`
 	MuteTimings           NamespacedResourceList `json:"muteTimings,omitempty"`
 	NotificationTemplates NamespacedResourceList `json:"notificationTemplates,omitempty"`
+	Organizations         NamespacedResourceList `json:"organizations,omitempty"`
+	Silences              NamespacedResourceList `json:"silences,omitempty"`
+	Teams                 NamespacedResourceList `json:"teams,omitempty"`
+	Users                 NamespacedResourceList `json:"users,omitempty"`
 	Manifests             NamespacedResourceList `json:"manifests,omitempty"`
 	Version               string                 `json:"version,omitempty"`
 	Conditions            []metav1.Condition     `json:"conditions,omitempty"`
 	Replicas              int32                  `json:"replicas,omitempty"`
 	Selector              string                 `json:"selector,omitempty"`
+
+	// Plugins installed through the plugin admin API of external instances, with their installed versions
+	Plugins PluginList `json:"plugins,omitempty"`
+
+	// Requested plugin versions reported as unsigned by the instance, rejected while spec.pluginPolicy.rejectUnsigned
+	// is set. Entries are removed once no longer requested or reported as signed
+	UnsignedPlugins PluginList `json:"unsignedPlugins,omitempty"`
 }
 
 func (in *GrafanaStatus) StatusList(cr client.Object) (*NamespacedResourceList, string, error) {
@@ -264,6 +383,14 @@ func (in *GrafanaStatus) StatusList(cr client.Object) (*NamespacedResourceList,
 		return &in.MuteTimings, "muteTimings", nil
 	case *GrafanaNotificationTemplate:
 		return &in.NotificationTemplates, "notificationTemplates", nil
+	case *GrafanaOrganization:
+		return &in.Organizations, "organizations", nil
+	case *GrafanaSilence:
+		return &in.Silences, "silences", nil
+	case *GrafanaTeam:
+		return &in.Teams, "teams", nil
+	case *GrafanaUser:
+		return &in.Users, "users", nil
 	case *GrafanaManifest:
 		return &in.Manifests, "manifests", nil
 	default:
@@ -327,6 +454,19 @@ func (in *Grafana) IsExternal() bool {
 	return in.Spec.External != nil
 }
 
+// GetReplicas returns the replicas requested through the high availability settings, nil otherwise
+func (in *Grafana) GetReplicas() *int32 {
+	if in.Spec.HighAvailability == nil {
+		return nil
+	}
+
+	if in.Spec.HighAvailability.Replicas == nil {
+		return new(int32(2))
+	}
+
+	return in.Spec.HighAvailability.Replicas
+}
+
 // Adds a resource to the end of the Grafana status list matching 'kind'
 func (in *Grafana) AddNamespacedResource(ctx context.Context, cl client.Client, cr client.Object, r NamespacedResource) error {
 	list, kind, err := in.Status.StatusList(cr)
@@ -411,12 +551,17 @@ func (in *Grafana) Conditions() *[]metav1.Condition {
 // Refs are collected from:
 //   - Deployment pod template — container Env ValueFrom (SecretKeyRef/ConfigMapKeyRef) and
 //     EnvFrom (SecretRef/ConfigMapRef), plus volume Secret and ConfigMap.
+//   - Database credentials of the high availability settings.
 //
 // The deployment reconciler uses this to compute a hash of those resources' ResourceVersions
 // and sets the checksum/secrets pod template annotation.
 func (in *Grafana) ReferencedSecretsAndConfigMaps() (secrets, configMaps []string) {
 	secrets, configMaps = in.deploymentRefs()
 
+	if ha := in.Spec.HighAvailability; ha != nil {
+		secrets = append(secrets, ha.Database.User.Name, ha.Database.Password.Name)
+	}
+
 	slices.Sort(secrets)
 	secrets = slices.Compact(secrets)
 
@@ -246,6 +246,7 @@ var _ = Describe("Grafana status NamespacedResourceList all CRs works", func() {
 		}
 		muteTiming := &GrafanaMuteTiming{ObjectMeta: meta()}
 		notificationTemplate := &GrafanaNotificationTemplate{ObjectMeta: meta()}
+		silence := &GrafanaSilence{ObjectMeta: meta()}
 
 		crList := []struct {
 			obj client.Object
@@ -283,6 +284,10 @@ var _ = Describe("Grafana status NamespacedResourceList all CRs works", func() {
 				obj: notificationTemplate,
 				nr:  notificationTemplate.NamespacedResource(),
 			},
+			{
+				obj: silence,
+				nr:  silence.NamespacedResource(),
+			},
 		}
 
 		crGrafana := &Grafana{
@@ -905,6 +910,24 @@ func TestContainerEnvRefs(t *testing.T) {
 }
 
 func TestGrafana_ReferencedSecretsAndConfigMaps(t *testing.T) {
+	t.Run("high availability database credentials", func(t *testing.T) {
+		cr := &Grafana{
+			Spec: GrafanaSpec{
+				HighAvailability: &GrafanaHighAvailability{
+					Database: GrafanaDatabase{
+						User:     corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "user"},
+						Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password"},
+					},
+				},
+			},
+		}
+
+		secrets, configMaps := cr.ReferencedSecretsAndConfigMaps()
+
+		assert.Equal(t, []string{"db"}, secrets)
+		assert.Empty(t, configMaps)
+	})
+
 	t.Run("initContainer env references", func(t *testing.T) {
 		initContainers := []corev1.Container{
 			{
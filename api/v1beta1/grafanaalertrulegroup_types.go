@@ -23,10 +23,11 @@ import (
 )
 
 // GrafanaAlertRuleGroupSpec defines the desired state of GrafanaAlertRuleGroup
-// +kubebuilder:validation:XValidation:rule="(has(self.folderUID) && !(has(self.folderRef))) || (has(self.folderRef) && !(has(self.folderUID)))", message="Only one of FolderUID or FolderRef can be set and one must be defined"
+// +kubebuilder:validation:XValidation:rule="[has(self.folderUID), has(self.folderRef), has(self.folderPath)].filter(x, x).size() == 1", message="Only one of FolderUID, FolderRef or FolderPath can be set and one must be defined"
 // +kubebuilder:validation:XValidation:rule="((!has(oldSelf.editable) && !has(self.editable)) || (has(oldSelf.editable) && has(self.editable)))", message="spec.editable is immutable"
 // +kubebuilder:validation:XValidation:rule="((!has(oldSelf.folderUID) && !has(self.folderUID)) || (has(oldSelf.folderUID) && has(self.folderUID)))", message="spec.folderUID is immutable"
 // +kubebuilder:validation:XValidation:rule="((!has(oldSelf.folderRef) && !has(self.folderRef)) || (has(oldSelf.folderRef) && has(self.folderRef)))", message="spec.folderRef is immutable"
+// +kubebuilder:validation:XValidation:rule="((!has(oldSelf.folderPath) && !has(self.folderPath)) || (has(oldSelf.folderPath) && has(self.folderPath)))", message="spec.folderPath is immutable"
 type GrafanaAlertRuleGroupSpec struct {
 	GrafanaCommonSpec `json:",inline"`
 
@@ -43,6 +44,13 @@ type GrafanaAlertRuleGroupSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	FolderRef string `json:"folderRef,omitempty"`
 
+	// Path of nested folders separated by "/", e.g. "Platform/Kubernetes/Nodes". Missing folders are created
+	// in each instance and removed again once they are empty
+	// +kubebuilder:validation:Pattern=`^[^/]+(/[^/]+)*$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	FolderPath string `json:"folderPath,omitempty"`
+
 	// +kubebuilder:validation:MinItems=1
 	Rules []AlertRule `json:"rules"`
 
@@ -200,6 +208,11 @@ func (in *GrafanaAlertRuleGroup) Conditions() *[]metav1.Condition {
 	return &in.Status.Conditions
 }
 
+// FolderPath implements FolderReferencer.
+func (in *GrafanaAlertRuleGroup) FolderPath() string {
+	return in.Spec.FolderPath
+}
+
 // FolderNamespace implements FolderReferencer.
 func (in *GrafanaAlertRuleGroup) FolderNamespace() string {
 	return in.Namespace
@@ -165,6 +165,33 @@ var _ = Describe("AlertRuleGroup type", func() {
 			err := cl.Create(ctx, arg)
 			require.Error(t, err)
 		})
+
+		It("Accepts spec.folderPath instead of spec.folderRef", func() {
+			arg := newAlertRuleGroup("folder-path", new(true))
+			arg.Spec.FolderRef = ""
+			arg.Spec.FolderPath = "Platform/Kubernetes"
+
+			By("Creating new AlertRuleGroup with folderPath")
+
+			err := cl.Create(ctx, arg)
+			require.NoError(t, err)
+
+			By("Changing folderPath")
+
+			arg.Spec.FolderPath = "Platform/Nodes"
+			err = cl.Update(ctx, arg)
+			require.Error(t, err)
+		})
+
+		It("Only one of spec.folderRef or spec.folderPath is defined", func() {
+			arg := newAlertRuleGroup("folder-path-and-ref", new(true))
+			arg.Spec.FolderPath = "Platform/Kubernetes"
+
+			By("Creating new AlertRuleGroup with folderRef and folderPath")
+
+			err := cl.Create(ctx, arg)
+			require.Error(t, err)
+		})
 	})
 })
 
@@ -47,6 +47,11 @@ type GrafanaContactPointSpec struct {
 	// +optional
 	Editable bool `json:"editable,omitempty"`
 
+	// Send a test notification through each receiver after the contact point changed and report the outcome
+	// in the Verified condition. A test can also be requested once with the operator.grafana.com/verify annotation
+	// +optional
+	Verify bool `json:"verify,omitempty"`
+
 	// Deprecated: define the receiver under .spec.receivers[]
 	// Manually specify the UID the Contact Point is created with. Can be any string consisting of alphanumeric characters, - and _ with a maximum length of 40
 	// +optional
@@ -28,6 +28,7 @@ import (
 // GrafanaDashboardSpec defines the desired state of GrafanaDashboard
 // +kubebuilder:validation:XValidation:rule="(has(self.folderUID) && !(has(self.folderRef))) || (has(self.folderRef) && !(has(self.folderUID))) || !(has(self.folderRef) && (has(self.folderUID)))", message="Only one of folderUID or folderRef can be declared at the same time"
 // +kubebuilder:validation:XValidation:rule="(has(self.folder) && !(has(self.folderRef) || has(self.folderUID))) || !(has(self.folder))", message="folder field cannot be set when folderUID or folderRef is already declared"
+// +kubebuilder:validation:XValidation:rule="!(has(self.folderPath) && (has(self.folder) || has(self.folderRef) || has(self.folderUID)))", message="folderPath cannot be set when folder, folderUID or folderRef is already declared"
 // +kubebuilder:validation:XValidation:rule="((!has(oldSelf.uid) && !has(self.uid)) || (has(oldSelf.uid) && has(self.uid)))", message="spec.uid is immutable"
 type GrafanaDashboardSpec struct {
 	GrafanaCommonSpec  `json:",inline"`
@@ -45,6 +46,12 @@ type GrafanaDashboardSpec struct {
 	// +optional
 	FolderRef string `json:"folderRef,omitempty"`
 
+	// Path of nested folders separated by "/", e.g. "Platform/Kubernetes/Nodes". Missing folders are created
+	// in each instance and removed again once they are empty
+	// +kubebuilder:validation:Pattern=`^[^/]+(/[^/]+)*$`
+	// +optional
+	FolderPath string `json:"folderPath,omitempty"`
+
 	// overrides the default (current) value of named template variables in the dashboard model.
 	// Variables that are not present in the model are ignored.
 	// +optional
@@ -57,6 +64,10 @@ type GrafanaDashboardSpec struct {
 	// Allows configuration of sharing the dashboard publicly
 	// +optional
 	PublicSharing *GrafanaDashboardPublicSharing `json:"publicSharing,omitempty"`
+
+	// Permissions of the dashboard in Grafana, permissions not listed are removed
+	// +optional
+	ACL []GrafanaPermission `json:"acl,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="((!has(oldSelf.accessToken) && !has(self.accessToken)) || (has(oldSelf.accessToken) && has(self.accessToken)))", message="spec.publicDashboard.accessToken is immutable"
@@ -90,6 +101,10 @@ type GrafanaDashboardStatus struct {
 
 	// The dashboard instanceSelector can't find matching grafana instances
 	NoMatchingInstances bool `json:"NoMatchingInstances,omitempty"`
+
+	// Effective permissions per instance, only recorded when spec.acl is set
+	// +optional
+	ACL []GrafanaEffectiveACL `json:"acl,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -129,6 +144,11 @@ func (in *GrafanaDashboard) FolderUID() string {
 	return in.Spec.FolderUID
 }
 
+// FolderPath implements FolderReferencer.
+func (in *GrafanaDashboard) FolderPath() string {
+	return in.Spec.FolderPath
+}
+
 // FolderNamespace implements FolderReferencer.
 func (in *GrafanaDashboard) FolderNamespace() string {
 	return in.Namespace
@@ -2,11 +2,14 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -154,3 +157,120 @@ var _ = Describe("Dashboard type", func() {
 		})
 	})
 })
+
+var _ = Describe("Dashboard url authorization", func() {
+	t := GinkgoT()
+	ctx := context.Background()
+
+	secretKey := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}, Key: key}
+	}
+
+	tests := []struct {
+		name          string
+		authorization *GrafanaContentURLAuthorization
+		wantErr       string
+	}{
+		{
+			name: "bearer token with client certificate",
+			authorization: &GrafanaContentURLAuthorization{
+				BearerToken: secretKey("token"),
+				TLS:         &GrafanaContentURLTLS{CA: secretKey("ca.crt"), Cert: secretKey("tls.crt"), Key: secretKey("tls.key")},
+			},
+		},
+		{
+			name: "basic auth and bearer token",
+			authorization: &GrafanaContentURLAuthorization{
+				BasicAuth:   &GrafanaContentURLBasicAuth{Username: secretKey("username"), Password: secretKey("password")},
+				BearerToken: secretKey("token"),
+			},
+			wantErr: "only one of basicAuth or bearerToken can be set",
+		},
+		{
+			name: "cert without key",
+			authorization: &GrafanaContentURLAuthorization{
+				TLS: &GrafanaContentURLTLS{Cert: secretKey("tls.crt")},
+			},
+			wantErr: "cert and key must be set together",
+		},
+		{
+			name: "ca with insecureSkipVerify",
+			authorization: &GrafanaContentURLAuthorization{
+				TLS: &GrafanaContentURLTLS{CA: secretKey("ca.crt"), InsecureSkipVerify: true},
+			},
+			wantErr: "ca and insecureSkipVerify cannot be set at the same time",
+		},
+	}
+
+	for i, tt := range tests {
+		It(tt.name, func() {
+			dash := newDashboard(fmt.Sprintf("url-authorization-%d", i), "")
+			dash.Spec.URL = "https://example.com/dashboard.json"
+			dash.Spec.URLAuthorization = tt.authorization
+
+			err := cl.Create(ctx, dash)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+})
+
+var _ = Describe("Dashboard plugin sources", func() {
+	t := GinkgoT()
+	ctx := context.Background()
+	checksum := strings.Repeat("a", 64)
+
+	tests := []struct {
+		name    string
+		source  *GrafanaPluginSource
+		wantErr string
+	}{
+		{
+			name:   "url with checksum",
+			source: &GrafanaPluginSource{URL: "https://plugins.local/clock-panel.zip", SHA256: checksum},
+		},
+		{
+			name:   "oci artifact",
+			source: &GrafanaPluginSource{OCI: &GrafanaPluginOCISource{Reference: "registry.local/plugins/clock-panel:2.1.8"}},
+		},
+		{
+			name:    "url without checksum",
+			source:  &GrafanaPluginSource{URL: "https://plugins.local/clock-panel.zip"},
+			wantErr: "sha256 is required for url sources",
+		},
+		{
+			name:    "no source",
+			source:  &GrafanaPluginSource{SHA256: checksum},
+			wantErr: "exactly one of oci, url, configMap or persistentVolumeClaim must be set",
+		},
+		{
+			name: "multiple sources",
+			source: &GrafanaPluginSource{
+				URL:                   "https://plugins.local/clock-panel.zip",
+				SHA256:                checksum,
+				PersistentVolumeClaim: &GrafanaPluginVolumeSource{ClaimName: "plugins", Path: "clock-panel.zip"},
+			},
+			wantErr: "exactly one of oci, url, configMap or persistentVolumeClaim must be set",
+		},
+	}
+
+	for i, tt := range tests {
+		It(tt.name, func() {
+			dash := newDashboard(fmt.Sprintf("plugin-source-%d", i), "")
+			dash.Spec.JSON = "{}"
+			dash.Spec.Plugins = PluginList{{Name: "grafana-clock-panel", Version: "2.1.8", Source: tt.source}}
+
+			err := cl.Create(ctx, dash)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+})
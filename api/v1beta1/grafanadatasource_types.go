@@ -37,7 +37,7 @@ type GrafanaDatasourceInternal struct {
 	BasicAuth     *bool  `json:"basicAuth,omitempty"`
 	BasicAuthUser string `json:"basicAuthUser,omitempty"`
 
-	// Deprecated field, it has no effect
+	// Deprecated field, it has no effect. Use spec.orgRef instead
 	OrgID *int64 `json:"orgId,omitempty"`
 
 	// Whether to enable/disable editing of the datasource in Grafana UI
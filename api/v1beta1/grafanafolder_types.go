@@ -29,6 +29,7 @@ import (
 // GrafanaFolderSpec defines the desired state of GrafanaFolder
 // +kubebuilder:validation:XValidation:rule="(has(self.parentFolderUID) && !(has(self.parentFolderRef))) || (has(self.parentFolderRef) && !(has(self.parentFolderUID))) || !(has(self.parentFolderRef) && (has(self.parentFolderUID)))", message="Only one of parentFolderUID or parentFolderRef can be set"
 // +kubebuilder:validation:XValidation:rule="((!has(oldSelf.uid) && !has(self.uid)) || (has(oldSelf.uid) && has(self.uid)))", message="spec.uid is immutable"
+// +kubebuilder:validation:XValidation:rule="!(has(self.permissions) && has(self.acl))", message="Only one of permissions or acl can be set"
 type GrafanaFolderSpec struct {
 	GrafanaCommonSpec `json:",inline"`
 
@@ -43,10 +44,15 @@ type GrafanaFolderSpec struct {
 	// +optional
 	Title string `json:"title,omitempty"`
 
-	// Raw json with folder permissions, potentially exported from Grafana
+	// Raw json with folder permissions, potentially exported from Grafana.
+	// Items may use teamRef with the name of a GrafanaTeam in the same namespace instead of teamId
 	// +optional
 	Permissions string `json:"permissions,omitempty"`
 
+	// Permissions of the folder in Grafana, permissions not listed are removed. Alternative to permissions
+	// +optional
+	ACL []GrafanaPermission `json:"acl,omitempty"`
+
 	// UID of the folder in which the current folder should be created
 	// +optional
 	ParentFolderUID string `json:"parentFolderUID,omitempty"`
@@ -63,6 +69,10 @@ type GrafanaFolderStatus struct {
 	Hash string `json:"hash,omitempty"`
 	// The folder instanceSelector can't find matching grafana instances
 	NoMatchingInstances bool `json:"NoMatchingInstances,omitempty"`
+
+	// Effective permissions per instance, only recorded when spec.acl is set
+	// +optional
+	ACL []GrafanaEffectiveACL `json:"acl,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -88,6 +98,11 @@ func (in *GrafanaFolder) Conditions() *[]metav1.Condition {
 	return &in.Status.Conditions
 }
 
+// FolderPath implements FolderReferencer, nested folders are created through parentFolderRef instead
+func (in *GrafanaFolder) FolderPath() string {
+	return ""
+}
+
 // FolderNamespace implements FolderReferencer.
 func (in *GrafanaFolder) FolderNamespace() string {
 	return in.Namespace
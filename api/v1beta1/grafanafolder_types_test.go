@@ -159,4 +159,55 @@ var _ = Describe("Folder type", func() {
 			require.Error(t, err)
 		})
 	})
+
+	Context("Ensure Folder acl entries are valid", func() {
+		t := GinkgoT()
+
+		ctx := context.Background()
+
+		It("Should accept an entry per kind of assignee", func() {
+			folder := newFolder("valid-acl", "")
+			folder.Spec.ACL = []GrafanaPermission{
+				{Role: "Viewer", Permission: "View"},
+				{Team: "platform", Permission: "Edit"},
+				{TeamRef: "platform", Permission: "Edit"},
+				{User: "jane@example.com", Permission: "Admin"},
+				{ServiceAccountRef: "ci", Permission: "View"},
+			}
+
+			err := cl.Create(ctx, folder)
+			require.NoError(t, err)
+		})
+
+		It("Should reject entries with multiple assignees", func() {
+			folder := newFolder("multiple-assignees", "")
+			folder.Spec.ACL = []GrafanaPermission{
+				{Role: "Viewer", User: "jane", Permission: "View"},
+			}
+
+			err := cl.Create(ctx, folder)
+			require.Error(t, err)
+		})
+
+		It("Should reject entries without assignee", func() {
+			folder := newFolder("no-assignee", "")
+			folder.Spec.ACL = []GrafanaPermission{
+				{Permission: "View"},
+			}
+
+			err := cl.Create(ctx, folder)
+			require.Error(t, err)
+		})
+
+		It("Should reject combining acl and permissions", func() {
+			folder := newFolder("acl-and-permissions", "")
+			folder.Spec.Permissions = `{"items":[]}`
+			folder.Spec.ACL = []GrafanaPermission{
+				{Role: "Viewer", Permission: "View"},
+			}
+
+			err := cl.Create(ctx, folder)
+			require.Error(t, err)
+		})
+	})
 })
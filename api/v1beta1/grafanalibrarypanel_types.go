@@ -8,6 +8,7 @@ import (
 
 // GrafanaLibraryPanelSpec defines the desired state of GrafanaLibraryPanel
 // +kubebuilder:validation:XValidation:rule="(has(self.folderUID) && !(has(self.folderRef))) || (has(self.folderRef) && !(has(self.folderUID))) || !(has(self.folderRef) && (has(self.folderUID)))", message="Only one of folderUID or folderRef can be declared at the same time"
+// +kubebuilder:validation:XValidation:rule="!(has(self.folderPath) && (has(self.folderRef) || has(self.folderUID)))", message="folderPath cannot be set when folderUID or folderRef is already declared"
 // +kubebuilder:validation:XValidation:rule="((!has(oldSelf.uid) && !has(self.uid)) || (has(oldSelf.uid) && has(self.uid)))", message="spec.uid is immutable"
 type GrafanaLibraryPanelSpec struct {
 	GrafanaCommonSpec  `json:",inline"`
@@ -21,6 +22,12 @@ type GrafanaLibraryPanelSpec struct {
 	// +optional
 	FolderRef string `json:"folderRef,omitempty"`
 
+	// Path of nested folders separated by "/", e.g. "Platform/Kubernetes/Nodes". Missing folders are created
+	// in each instance and removed again once they are empty
+	// +kubebuilder:validation:Pattern=`^[^/]+(/[^/]+)*$`
+	// +optional
+	FolderPath string `json:"folderPath,omitempty"`
+
 	// plugins
 	// +optional
 	Plugins PluginList `json:"plugins,omitempty"`
@@ -68,6 +75,11 @@ func (in *GrafanaLibraryPanel) FolderUID() string {
 	return in.Spec.FolderUID
 }
 
+// FolderPath implements FolderReferencer.
+func (in *GrafanaLibraryPanel) FolderPath() string {
+	return in.Spec.FolderPath
+}
+
 // FolderNamespace implements FolderReferencer.
 func (in *GrafanaLibraryPanel) FolderNamespace() string {
 	return in.Namespace
@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrafanaOrganizationUser references an existing Grafana user by email or login
+// +kubebuilder:validation:XValidation:rule="(has(self.email) && !has(self.login)) || (!has(self.email) && has(self.login))", message="Either email or login must be set"
+type GrafanaOrganizationUser struct {
+	// Email of the user
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Email string `json:"email,omitempty"`
+
+	// Login of the user
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Login string `json:"login,omitempty"`
+
+	// Role of the user in the organization
+	// +kubebuilder:validation:Enum=Viewer;Editor;Admin;None
+	Role string `json:"role"`
+}
+
+// Identifier returns the value used to look the user up in Grafana
+func (in GrafanaOrganizationUser) Identifier() string {
+	if in.Email != "" {
+		return in.Email
+	}
+
+	return in.Login
+}
+
+// GrafanaOrganizationPreferences holds the preferences of an organization
+type GrafanaOrganizationPreferences struct {
+	// UID of the home dashboard of the organization
+	// +optional
+	HomeDashboardUID string `json:"homeDashboardUid,omitempty"`
+
+	// Default theme, e.g. light, dark or system
+	// +optional
+	Theme string `json:"theme,omitempty"`
+
+	// Default timezone, e.g. utc, browser or an IANA time zone
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// First day of the week, e.g. monday, sunday or saturday
+	// +optional
+	WeekStart string `json:"weekStart,omitempty"`
+}
+
+// GrafanaOrganizationSpec defines the desired state of GrafanaOrganization
+// +kubebuilder:validation:XValidation:rule="((!has(oldSelf.name) && !has(self.name)) || (has(oldSelf.name) && has(self.name)))", message="spec.name is immutable"
+// +kubebuilder:validation:XValidation:rule="!has(self.orgRef)", message="spec.orgRef is not supported on organizations"
+type GrafanaOrganizationSpec struct {
+	GrafanaCommonSpec `json:",inline"`
+
+	// Name of the organization in Grafana, defaults to metadata.name if not set
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec.name is immutable"
+	Name string `json:"name,omitempty"`
+
+	// Preferences of the organization
+	// +optional
+	Preferences *GrafanaOrganizationPreferences `json:"preferences,omitempty"`
+
+	// Users of the organization. Users must already exist in Grafana.
+	// Users added to the organization outside of the operator are removed on the next sync,
+	// except for the user the operator authenticates as.
+	// +optional
+	Users []GrafanaOrganizationUser `json:"users,omitempty"`
+}
+
+// GrafanaOrganizationStatus defines the observed state of GrafanaOrganization
+type GrafanaOrganizationStatus struct {
+	GrafanaCommonStatus `json:",inline"`
+
+	// ID of the organization in Grafana. Only set when the ID is identical across all matching instances,
+	// refer to the Grafana status for per instance IDs
+	// +optional
+	OrgID int64 `json:"orgId,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// GrafanaOrganization is the Schema for the grafanaorganizations API
+// +kubebuilder:printcolumn:name="Org ID",type="integer",JSONPath=".status.orgId",description=""
+// +kubebuilder:printcolumn:name="Last resync",type="date",format="date-time",JSONPath=".status.lastResync",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+// +kubebuilder:resource:categories={all,grafana-operator}
+type GrafanaOrganization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrafanaOrganizationSpec   `json:"spec"`
+	Status GrafanaOrganizationStatus `json:"status,omitempty"`
+}
+
+var _ CommonResource = (*GrafanaOrganization)(nil)
+
+// GetGrafanaName returns the name of the organization in Grafana
+func (in *GrafanaOrganization) GetGrafanaName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+
+	return in.Name
+}
+
+func (in *GrafanaOrganization) MatchLabels() *metav1.LabelSelector {
+	return in.Spec.InstanceSelector
+}
+
+func (in *GrafanaOrganization) MatchNamespace() string {
+	return in.Namespace
+}
+
+func (in *GrafanaOrganization) Metadata() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *GrafanaOrganization) AllowCrossNamespace() bool {
+	return in.Spec.AllowCrossNamespaceImport
+}
+
+func (in *GrafanaOrganization) NamespacedResource(orgID string) NamespacedResource {
+	return NewNamespacedResource(in.Namespace, in.Name, orgID)
+}
+
+func (in *GrafanaOrganization) CommonStatus() *GrafanaCommonStatus {
+	return &in.Status.GrafanaCommonStatus
+}
+
+func (in *GrafanaOrganization) Conditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// GrafanaOrganizationList contains a list of GrafanaOrganization
+type GrafanaOrganizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrafanaOrganization `json:"items"`
+}
+
+func (in *GrafanaOrganizationList) Exists(namespace, name string) bool {
+	for _, item := range in.Items {
+		if item.Namespace == namespace && item.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
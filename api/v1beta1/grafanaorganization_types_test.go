@@ -0,0 +1,109 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGrafanaStatusListOrganization(t *testing.T) {
+	t.Run("&Organization{} maps to NamespacedResource list", func(t *testing.T) {
+		g := &Grafana{}
+		arg := &GrafanaOrganization{}
+		_, _, err := g.Status.StatusList(arg)
+		assert.NoError(t, err, "Organization does not have a case in Grafana.Status.StatusList")
+	})
+}
+
+func TestGrafanaOrganizationGetGrafanaName(t *testing.T) {
+	cr := &GrafanaOrganization{ObjectMeta: metav1.ObjectMeta{Name: "cr-name"}}
+	assert.Equal(t, "cr-name", cr.GetGrafanaName())
+
+	cr.Spec.Name = "spec-name"
+	assert.Equal(t, "spec-name", cr.GetGrafanaName())
+}
+
+func newOrganization(name string, users []GrafanaOrganizationUser) *GrafanaOrganization {
+	return &GrafanaOrganization{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       "GrafanaOrganization",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: GrafanaOrganizationSpec{
+			GrafanaCommonSpec: GrafanaCommonSpec{
+				InstanceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"test": "organization",
+					},
+				},
+			},
+			Name:  name,
+			Users: users,
+		},
+	}
+}
+
+var _ = Describe("Organization type", func() {
+	t := GinkgoT()
+
+	Context("Ensure Organization spec.name is immutable", func() {
+		ctx := context.Background()
+
+		It("Should block changing value of name", func() {
+			org := newOrganization("changing-name", nil)
+
+			By("Create new Organization with existing name")
+
+			err := cl.Create(ctx, org)
+			require.NoError(t, err)
+
+			By("Changing the existing name")
+
+			org.Spec.Name = "new-name"
+			err = cl.Update(ctx, org)
+			require.Error(t, err)
+		})
+	})
+
+	Context("Ensure Organization is not scoped to another organization", func() {
+		ctx := context.Background()
+
+		It("Should reject spec.orgRef", func() {
+			org := newOrganization("with-org-ref", nil)
+			org.Spec.OrgRef = "other"
+
+			err := cl.Create(ctx, org)
+			require.Error(t, err)
+		})
+	})
+
+	Context("Ensure Organization users reference a user", func() {
+		ctx := context.Background()
+
+		It("Should reject users with both email and login", func() {
+			org := newOrganization("both-email-and-login", []GrafanaOrganizationUser{
+				{Email: "user@example.com", Login: "user", Role: "Viewer"},
+			})
+
+			err := cl.Create(ctx, org)
+			require.Error(t, err)
+		})
+
+		It("Should reject unknown roles", func() {
+			org := newOrganization("unknown-role", []GrafanaOrganizationUser{
+				{Login: "user", Role: "Owner"},
+			})
+
+			err := cl.Create(ctx, org)
+			require.Error(t, err)
+		})
+	})
+})
@@ -17,10 +17,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"slices"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GrafanaServiceAccountTokenSpec defines a token for a service account
+// +kubebuilder:validation:XValidation:rule="!(has(self.expires) && has(self.rotation))", message="expires and rotation are mutually exclusive"
 type GrafanaServiceAccountTokenSpec struct {
 	// Name of the token
 	// +kubebuilder:validation:Required
@@ -37,10 +41,50 @@ type GrafanaServiceAccountTokenSpec struct {
 	// +optional
 	// +kubebuilder:validation:MinLength=1
 	SecretName string `json:"secretName,omitempty"`
+
+	// Rotation renews the token before it expires and updates its secret in place
+	// +optional
+	Rotation *GrafanaServiceAccountTokenRotation `json:"rotation,omitempty"`
+}
+
+// GrafanaServiceAccountTokenRotation defines how often a token is renewed
+// +kubebuilder:validation:XValidation:rule="!has(self.renewBefore) || duration(self.renewBefore) < duration(self.validity)", message="renewBefore must be shorter than validity"
+type GrafanaServiceAccountTokenRotation struct {
+	// How long each token is valid
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="validity must be greater than 0"
+	Validity metav1.Duration `json:"validity"`
+
+	// How long before its expiration the token is renewed, defaults to a third of validity
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// How long the replaced token stays valid after the secret was updated, giving consumers time to reload it. Defaults to 5m
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// GrafanaServiceAccountSecretTarget defines a namespace the token secrets are copied to
+type GrafanaServiceAccountSecretTarget struct {
+	// Namespace to copy the token secrets to, it must be allowed by the operator configuration
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Names of the tokens whose secrets are copied, all tokens if empty
+	// +optional
+	// +listType=set
+	Tokens []string `json:"tokens,omitempty"`
 }
 
 // GrafanaServiceAccountSpec defines the desired state of a GrafanaServiceAccount.
 // +kubebuilder:validation:XValidation:rule="((!has(oldSelf.name) && !has(self.name)) || (has(oldSelf.name) && has(self.name)))", message="spec.name is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.instanceName) != has(self.instanceSelector)", message="exactly one of spec.instanceName or spec.instanceSelector must be set"
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.instanceName) == has(self.instanceName)", message="switching between spec.instanceName and spec.instanceSelector is not allowed"
 type GrafanaServiceAccountSpec struct {
 	// How often the resource is synced, defaults to 10m0s if not set
 	// +optional
@@ -55,10 +99,15 @@ type GrafanaServiceAccountSpec struct {
 	Suspend bool `json:"suspend,omitempty"`
 
 	// Name of the Grafana instance to create the service account for
-	// +kubebuilder:validation:Required
+	// +optional
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec.instanceName is immutable"
-	InstanceName string `json:"instanceName"`
+	InstanceName string `json:"instanceName,omitempty"`
+
+	// Selects the Grafana instances in the namespace of the service account to create it for.
+	// Each selected instance gets its own service account and token secrets
+	// +optional
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
 
 	// Name of the service account in Grafana
 	// +kubebuilder:validation:MinLength=1
@@ -80,6 +129,12 @@ type GrafanaServiceAccountSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	Tokens []GrafanaServiceAccountTokenSpec `json:"tokens,omitempty"`
+
+	// Namespaces the token secrets are copied to, e.g. the namespaces of the workloads consuming them
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	SecretTargets []GrafanaServiceAccountSecretTarget `json:"secretTargets,omitempty"`
 }
 
 // GrafanaServiceAccountSecretStatus describes a Secret created in Kubernetes to store the service account token.
@@ -102,6 +157,22 @@ type GrafanaServiceAccountTokenStatus struct {
 
 	// Name of the secret containing the token
 	Secret *GrafanaServiceAccountSecretStatus `json:"secret,omitempty"`
+
+	// Time the token is renewed at, only set for tokens with a rotation policy
+	// +optional
+	RenewAt *metav1.Time `json:"renewAt,omitempty"`
+}
+
+// GrafanaServiceAccountRetiredTokenStatus describes a token replaced by a rotation which is still valid.
+type GrafanaServiceAccountRetiredTokenStatus struct {
+	// Name of the token in the spec
+	Name string `json:"name"`
+
+	// ID of the token in Grafana
+	ID int64 `json:"id"`
+
+	// Time the token is revoked at
+	RevokeAt metav1.Time `json:"revokeAt"`
 }
 
 // GrafanaServiceAccountInfo describes the Grafana service account information.
@@ -121,6 +192,10 @@ type GrafanaServiceAccountInfo struct {
 	// Information about tokens
 	// +optional
 	Tokens []GrafanaServiceAccountTokenStatus `json:"tokens,omitempty"`
+
+	// Tokens replaced by a rotation, they are revoked once their grace period is over
+	// +optional
+	RetiredTokens []GrafanaServiceAccountRetiredTokenStatus `json:"retiredTokens,omitempty"`
 }
 
 // GrafanaServiceAccountStatus defines the observed state of a GrafanaServiceAccount
@@ -129,6 +204,24 @@ type GrafanaServiceAccountStatus struct {
 
 	// Info contains the Grafana service account information
 	Account *GrafanaServiceAccountInfo `json:"account,omitempty"`
+
+	// Service accounts of the instances selected by spec.instanceSelector
+	// +optional
+	// +listType=map
+	// +listMapKey=instance
+	Instances []GrafanaServiceAccountInstanceStatus `json:"instances,omitempty"`
+
+	// Token secrets copied to the namespaces of spec.secretTargets
+	// +optional
+	SecretCopies []GrafanaServiceAccountSecretStatus `json:"secretCopies,omitempty"`
+}
+
+// GrafanaServiceAccountInstanceStatus describes the service account created in a selected Grafana instance.
+type GrafanaServiceAccountInstanceStatus struct {
+	// Name of the Grafana instance
+	Instance string `json:"instance"`
+
+	Account GrafanaServiceAccountInfo `json:"account"`
 }
 
 //+kubebuilder:object:root=true
@@ -168,8 +261,12 @@ func (in *GrafanaServiceAccountList) Find(namespace, name string) *GrafanaServic
 	return nil
 }
 
-// MatchLabels is a no-op for GrafanaServiceAccount
+// MatchLabels returns spec.instanceSelector, service accounts targeting a single instance by name match nothing
 func (in *GrafanaServiceAccount) MatchLabels() *metav1.LabelSelector {
+	if in.Spec.InstanceSelector != nil {
+		return in.Spec.InstanceSelector
+	}
+
 	labels := &metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			"non-existent set of labels": "no-op",
@@ -208,3 +305,69 @@ func (in *GrafanaServiceAccount) GetGrafanaName() string {
 
 	return in.Name
 }
+
+// InstanceAccount returns the service account created in the named Grafana instance, nil if there is none
+func (in *GrafanaServiceAccount) InstanceAccount(instance string) *GrafanaServiceAccountInfo {
+	if in.Spec.InstanceName != "" {
+		if instance != in.Spec.InstanceName {
+			return nil
+		}
+
+		return in.Status.Account
+	}
+
+	for i := range in.Status.Instances {
+		if in.Status.Instances[i].Instance == instance {
+			return &in.Status.Instances[i].Account
+		}
+	}
+
+	return nil
+}
+
+// SetInstanceAccount records the service account created in the named Grafana instance, nil removes it
+func (in *GrafanaServiceAccount) SetInstanceAccount(instance string, account *GrafanaServiceAccountInfo) {
+	if in.Spec.InstanceName != "" {
+		if instance == in.Spec.InstanceName {
+			in.Status.Account = account
+		}
+
+		return
+	}
+
+	idx := slices.IndexFunc(in.Status.Instances, func(s GrafanaServiceAccountInstanceStatus) bool {
+		return s.Instance == instance
+	})
+
+	switch {
+	case account == nil && idx >= 0:
+		in.Status.Instances = slices.Delete(in.Status.Instances, idx, idx+1)
+	case account == nil:
+	case idx >= 0:
+		in.Status.Instances[idx].Account = *account
+	default:
+		in.Status.Instances = append(in.Status.Instances, GrafanaServiceAccountInstanceStatus{Instance: instance, Account: *account})
+		slices.SortFunc(in.Status.Instances, func(a, b GrafanaServiceAccountInstanceStatus) int {
+			return strings.Compare(a.Instance, b.Instance)
+		})
+	}
+}
+
+// InstanceAccounts returns the service accounts created in Grafana by instance name
+func (in *GrafanaServiceAccount) InstanceAccounts() map[string]*GrafanaServiceAccountInfo {
+	accounts := make(map[string]*GrafanaServiceAccountInfo, len(in.Status.Instances)+1)
+
+	if in.Spec.InstanceName != "" {
+		if in.Status.Account != nil {
+			accounts[in.Spec.InstanceName] = in.Status.Account
+		}
+
+		return accounts
+	}
+
+	for i := range in.Status.Instances {
+		accounts[in.Status.Instances[i].Instance] = &in.Status.Instances[i].Account
+	}
+
+	return accounts
+}
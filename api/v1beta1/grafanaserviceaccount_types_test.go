@@ -0,0 +1,112 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newServiceAccount(name string) *GrafanaServiceAccount {
+	return &GrafanaServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       "GrafanaServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: GrafanaServiceAccountSpec{
+			Role: "Viewer",
+		},
+	}
+}
+
+func TestServiceAccountInstanceAccounts(t *testing.T) {
+	t.Run("instanceName stores the account in status.account", func(t *testing.T) {
+		sa := newServiceAccount("single")
+		sa.Spec.InstanceName = "grafana"
+
+		sa.SetInstanceAccount("other", &GrafanaServiceAccountInfo{ID: 2})
+		assert.Nil(t, sa.Status.Account, "accounts of other instances are ignored")
+
+		sa.SetInstanceAccount("grafana", &GrafanaServiceAccountInfo{ID: 1})
+		require.NotNil(t, sa.Status.Account)
+		assert.Equal(t, int64(1), sa.InstanceAccount("grafana").ID)
+		assert.Nil(t, sa.InstanceAccount("other"))
+		assert.Len(t, sa.InstanceAccounts(), 1)
+		assert.Empty(t, sa.Status.Instances)
+	})
+
+	t.Run("instanceSelector stores accounts by instance", func(t *testing.T) {
+		sa := newServiceAccount("selector")
+		sa.Spec.InstanceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}}
+
+		sa.SetInstanceAccount("b", &GrafanaServiceAccountInfo{ID: 2})
+		sa.SetInstanceAccount("a", &GrafanaServiceAccountInfo{ID: 1})
+		sa.SetInstanceAccount("b", &GrafanaServiceAccountInfo{ID: 3})
+
+		require.Len(t, sa.Status.Instances, 2)
+		assert.Equal(t, "a", sa.Status.Instances[0].Instance, "instances are sorted")
+		assert.Equal(t, int64(3), sa.InstanceAccount("b").ID)
+		assert.Nil(t, sa.Status.Account)
+
+		sa.InstanceAccount("a").Role = "Admin"
+		assert.Equal(t, "Admin", sa.Status.Instances[0].Account.Role, "the returned account points into the status")
+
+		sa.SetInstanceAccount("a", nil)
+		assert.Nil(t, sa.InstanceAccount("a"))
+		assert.Len(t, sa.InstanceAccounts(), 1)
+		assert.Equal(t, sa.Spec.InstanceSelector, sa.MatchLabels())
+	})
+}
+
+var _ = Describe("ServiceAccount type", func() {
+	t := GinkgoT()
+
+	Context("Ensure the target instances are well defined", func() {
+		ctx := context.Background()
+
+		It("Should accept an instanceSelector", func() {
+			sa := newServiceAccount("selector")
+			sa.Spec.InstanceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}}
+
+			err := cl.Create(ctx, sa)
+			require.NoError(t, err)
+		})
+
+		It("Should reject missing instanceName and instanceSelector", func() {
+			sa := newServiceAccount("no-instance")
+
+			err := cl.Create(ctx, sa)
+			require.ErrorContains(t, err, "exactly one of spec.instanceName or spec.instanceSelector must be set")
+		})
+
+		It("Should reject both instanceName and instanceSelector", func() {
+			sa := newServiceAccount("both-instances")
+			sa.Spec.InstanceName = "grafana"
+			sa.Spec.InstanceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}}
+
+			err := cl.Create(ctx, sa)
+			require.ErrorContains(t, err, "exactly one of spec.instanceName or spec.instanceSelector must be set")
+		})
+
+		It("Should reject switching from instanceName to instanceSelector", func() {
+			sa := newServiceAccount("switch-instances")
+			sa.Spec.InstanceName = "grafana"
+
+			err := cl.Create(ctx, sa)
+			require.NoError(t, err)
+
+			sa.Spec.InstanceName = ""
+			sa.Spec.InstanceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}}
+
+			err = cl.Update(ctx, sa)
+			require.ErrorContains(t, err, "switching between spec.instanceName and spec.instanceSelector is not allowed")
+		})
+	})
+})
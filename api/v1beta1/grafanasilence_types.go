@@ -0,0 +1,164 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrafanaSilenceSpec defines the desired state of GrafanaSilence
+// +kubebuilder:validation:XValidation:rule="has(self.endsAt) != has(self.duration)", message="Exactly one of endsAt or duration must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.startsAt) || !has(self.endsAt) || self.endsAt > self.startsAt", message="endsAt must be after startsAt"
+type GrafanaSilenceSpec struct {
+	GrafanaCommonSpec `json:",inline"`
+
+	// Matchers selecting the silenced alerts, an alert must match all of them
+	// +kubebuilder:validation:MinItems=1
+	Matchers []SilenceMatcher `json:"matchers"`
+
+	// Start of the silence, defaults to the creation of the resource
+	// +optional
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+
+	// End of the silence
+	// +optional
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+
+	// Duration of the silence, counted from the start of the silence
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Reason for the silence, shown in the Grafana UI
+	// +kubebuilder:validation:MinLength=1
+	Comment string `json:"comment"`
+
+	// Author of the silence shown in the Grafana UI, defaults to grafana-operator
+	// +optional
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+type SilenceMatcher struct {
+	// Label name
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Label value or regular expression
+	Value string `json:"value"`
+
+	// Whether value is a regular expression
+	// +optional
+	IsRegex bool `json:"isRegex,omitempty"`
+
+	// Whether the label must match or must not match value
+	// +optional
+	// +kubebuilder:default=true
+	IsEqual bool `json:"isEqual"`
+}
+
+// GrafanaSilenceStatus defines the observed state of GrafanaSilence
+type GrafanaSilenceStatus struct {
+	GrafanaCommonStatus `json:",inline"`
+
+	// Silences created in the Alertmanager of each instance
+	// +optional
+	// +listType=map
+	// +listMapKey=instance
+	Silences []GrafanaSilenceInstanceStatus `json:"silences,omitempty"`
+}
+
+type GrafanaSilenceInstanceStatus struct {
+	// Namespace and name of the Grafana instance
+	Instance string `json:"instance"`
+
+	// ID of the silence in the Alertmanager of the instance
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// State of the silence, one of pending, active or expired
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// GrafanaSilence is the Schema for the GrafanaSilence API
+// +kubebuilder:printcolumn:name="Starts at",type="date",format="date-time",JSONPath=".spec.startsAt",description=""
+// +kubebuilder:printcolumn:name="Ends at",type="date",format="date-time",JSONPath=".spec.endsAt",description=""
+// +kubebuilder:printcolumn:name="Duration",type="string",JSONPath=".spec.duration",description=""
+// +kubebuilder:printcolumn:name="Last resync",type="date",format="date-time",JSONPath=".status.lastResync",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+// +kubebuilder:resource:categories={all,grafana-operator}
+type GrafanaSilence struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrafanaSilenceSpec   `json:"spec"`
+	Status GrafanaSilenceStatus `json:"status,omitempty"`
+}
+
+var _ CommonResource = (*GrafanaSilence)(nil)
+
+func (in *GrafanaSilence) MatchLabels() *metav1.LabelSelector {
+	return in.Spec.InstanceSelector
+}
+
+func (in *GrafanaSilence) MatchNamespace() string {
+	return in.Namespace
+}
+
+func (in *GrafanaSilence) Metadata() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *GrafanaSilence) AllowCrossNamespace() bool {
+	return in.Spec.AllowCrossNamespaceImport
+}
+
+func (in *GrafanaSilence) NamespacedResource() NamespacedResource {
+	return NewNamespacedResource(in.Namespace, in.Name, in.Name)
+}
+
+func (in *GrafanaSilence) CommonStatus() *GrafanaCommonStatus {
+	return &in.Status.GrafanaCommonStatus
+}
+
+func (in *GrafanaSilence) Conditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// GrafanaSilenceList contains a list of GrafanaSilence
+type GrafanaSilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrafanaSilence `json:"items"`
+}
+
+func (in *GrafanaSilenceList) Exists(namespace, name string) bool {
+	for _, item := range in.Items {
+		if item.Namespace == namespace && item.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGrafanaStatusListSilence(t *testing.T) {
+	t.Run("&Silence{} maps to NamespacedResource list", func(t *testing.T) {
+		g := &Grafana{}
+		arg := &GrafanaSilence{}
+		_, _, err := g.Status.StatusList(arg)
+		assert.NoError(t, err, "Silence does not have a case in Grafana.Status.StatusList")
+	})
+}
+
+func newSilence(name string) *GrafanaSilence {
+	return &GrafanaSilence{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       "GrafanaSilence",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: GrafanaSilenceSpec{
+			GrafanaCommonSpec: GrafanaCommonSpec{
+				InstanceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"test": "silence",
+					},
+				},
+			},
+			Matchers: []SilenceMatcher{
+				{Name: "namespace", Value: "databases", IsEqual: true},
+			},
+			Comment: "Database maintenance",
+		},
+	}
+}
+
+var _ = Describe("Silence type", func() {
+	t := GinkgoT()
+
+	Context("Ensure the end of the silence is well defined", func() {
+		ctx := context.Background()
+
+		It("Should accept a duration", func() {
+			silence := newSilence("duration")
+			silence.Spec.Duration = &metav1.Duration{Duration: time.Hour}
+
+			err := cl.Create(ctx, silence)
+			require.NoError(t, err)
+		})
+
+		It("Should accept endsAt after startsAt", func() {
+			silence := newSilence("window")
+			silence.Spec.StartsAt = &metav1.Time{Time: time.Now().Add(time.Hour)}
+			silence.Spec.EndsAt = &metav1.Time{Time: time.Now().Add(2 * time.Hour)}
+
+			err := cl.Create(ctx, silence)
+			require.NoError(t, err)
+		})
+
+		It("Should reject missing endsAt and duration", func() {
+			silence := newSilence("no-end")
+
+			err := cl.Create(ctx, silence)
+			require.ErrorContains(t, err, "Exactly one of endsAt or duration must be set")
+		})
+
+		It("Should reject both endsAt and duration", func() {
+			silence := newSilence("both-ends")
+			silence.Spec.EndsAt = &metav1.Time{Time: time.Now().Add(time.Hour)}
+			silence.Spec.Duration = &metav1.Duration{Duration: time.Hour}
+
+			err := cl.Create(ctx, silence)
+			require.ErrorContains(t, err, "Exactly one of endsAt or duration must be set")
+		})
+
+		It("Should reject endsAt before startsAt", func() {
+			silence := newSilence("reversed")
+			silence.Spec.StartsAt = &metav1.Time{Time: time.Now().Add(2 * time.Hour)}
+			silence.Spec.EndsAt = &metav1.Time{Time: time.Now().Add(time.Hour)}
+
+			err := cl.Create(ctx, silence)
+			require.ErrorContains(t, err, "endsAt must be after startsAt")
+		})
+	})
+})
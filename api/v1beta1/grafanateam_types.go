@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrafanaTeamMember references an existing Grafana user by email or login
+// +kubebuilder:validation:XValidation:rule="(has(self.email) && !has(self.login)) || (!has(self.email) && has(self.login))", message="Either email or login must be set"
+type GrafanaTeamMember struct {
+	// Email of the user
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Email string `json:"email,omitempty"`
+
+	// Login of the user
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Login string `json:"login,omitempty"`
+}
+
+// Identifier returns the value used to look the member up in Grafana
+func (in GrafanaTeamMember) Identifier() string {
+	if in.Email != "" {
+		return in.Email
+	}
+
+	return in.Login
+}
+
+// GrafanaTeamSpec defines the desired state of GrafanaTeam
+// +kubebuilder:validation:XValidation:rule="((!has(oldSelf.name) && !has(self.name)) || (has(oldSelf.name) && has(self.name)))", message="spec.name is immutable"
+type GrafanaTeamSpec struct {
+	GrafanaCommonSpec `json:",inline"`
+
+	// Name of the team in Grafana, defaults to metadata.name if not set
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec.name is immutable"
+	Name string `json:"name,omitempty"`
+
+	// Email of the team
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// Members of the team. Users must already exist in Grafana.
+	// Members added to the team outside of the operator are removed on the next sync.
+	// +optional
+	Members []GrafanaTeamMember `json:"members,omitempty"`
+}
+
+// GrafanaTeamStatus defines the observed state of GrafanaTeam
+type GrafanaTeamStatus struct {
+	GrafanaCommonStatus `json:",inline"`
+
+	// ID of the team in Grafana. Only set when the ID is identical across all matching instances,
+	// refer to the Grafana status for per instance IDs
+	// +optional
+	TeamID int64 `json:"teamId,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// GrafanaTeam is the Schema for the grafanateams API
+// +kubebuilder:printcolumn:name="Team ID",type="integer",JSONPath=".status.teamId",description=""
+// +kubebuilder:printcolumn:name="Last resync",type="date",format="date-time",JSONPath=".status.lastResync",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+// +kubebuilder:resource:categories={all,grafana-operator}
+type GrafanaTeam struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrafanaTeamSpec   `json:"spec"`
+	Status GrafanaTeamStatus `json:"status,omitempty"`
+}
+
+var _ CommonResource = (*GrafanaTeam)(nil)
+
+// GetGrafanaName returns the name of the team in Grafana
+func (in *GrafanaTeam) GetGrafanaName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+
+	return in.Name
+}
+
+func (in *GrafanaTeam) MatchLabels() *metav1.LabelSelector {
+	return in.Spec.InstanceSelector
+}
+
+func (in *GrafanaTeam) MatchNamespace() string {
+	return in.Namespace
+}
+
+func (in *GrafanaTeam) Metadata() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *GrafanaTeam) AllowCrossNamespace() bool {
+	return in.Spec.AllowCrossNamespaceImport
+}
+
+// NamespacedResource records the team id together with the id of its organization as `<orgID>:<teamID>`,
+// references to the team are only resolved within the same organization
+func (in *GrafanaTeam) NamespacedResource(orgID, teamID string) NamespacedResource {
+	return NewNamespacedResource(in.Namespace, in.Name, orgID+":"+teamID)
+}
+
+func (in *GrafanaTeam) CommonStatus() *GrafanaCommonStatus {
+	return &in.Status.GrafanaCommonStatus
+}
+
+func (in *GrafanaTeam) Conditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// GrafanaTeamList contains a list of GrafanaTeam
+type GrafanaTeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrafanaTeam `json:"items"`
+}
+
+func (in *GrafanaTeamList) Exists(namespace, name string) bool {
+	for _, item := range in.Items {
+		if item.Namespace == namespace && item.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,110 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGrafanaStatusListTeam(t *testing.T) {
+	t.Run("&Team{} maps to NamespacedResource list", func(t *testing.T) {
+		g := &Grafana{}
+		arg := &GrafanaTeam{}
+		_, _, err := g.Status.StatusList(arg)
+		assert.NoError(t, err, "Team does not have a case in Grafana.Status.StatusList")
+	})
+}
+
+func TestGrafanaTeamGetGrafanaName(t *testing.T) {
+	cr := &GrafanaTeam{ObjectMeta: metav1.ObjectMeta{Name: "cr-name"}}
+	assert.Equal(t, "cr-name", cr.GetGrafanaName())
+
+	cr.Spec.Name = "spec-name"
+	assert.Equal(t, "spec-name", cr.GetGrafanaName())
+}
+
+func newTeam(name string, members []GrafanaTeamMember) *GrafanaTeam {
+	return &GrafanaTeam{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       "GrafanaTeam",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: GrafanaTeamSpec{
+			GrafanaCommonSpec: GrafanaCommonSpec{
+				InstanceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"test": "team",
+					},
+				},
+			},
+			Name:    name,
+			Members: members,
+		},
+	}
+}
+
+var _ = Describe("Team type", func() {
+	t := GinkgoT()
+
+	Context("Ensure Team spec.name is immutable", func() {
+		ctx := context.Background()
+
+		It("Should block changing value of name", func() {
+			team := newTeam("changing-name", nil)
+
+			By("Create new Team with existing name")
+
+			err := cl.Create(ctx, team)
+			require.NoError(t, err)
+
+			By("Changing the existing name")
+
+			team.Spec.Name = "new-name"
+			err = cl.Update(ctx, team)
+			require.Error(t, err)
+		})
+
+		It("Should block removing name", func() {
+			team := newTeam("removing-name", nil)
+
+			By("Create new Team with existing name")
+
+			err := cl.Create(ctx, team)
+			require.NoError(t, err)
+
+			By("Removing the existing name")
+
+			team.Spec.Name = ""
+			err = cl.Update(ctx, team)
+			require.Error(t, err)
+		})
+	})
+
+	Context("Ensure Team members reference a user", func() {
+		ctx := context.Background()
+
+		It("Should reject members with both email and login", func() {
+			team := newTeam("both-email-and-login", []GrafanaTeamMember{
+				{Email: "user@example.com", Login: "user"},
+			})
+
+			err := cl.Create(ctx, team)
+			require.Error(t, err)
+		})
+
+		It("Should reject members without email or login", func() {
+			team := newTeam("no-email-or-login", []GrafanaTeamMember{{}})
+
+			err := cl.Create(ctx, team)
+			require.Error(t, err)
+		})
+	})
+})
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrafanaUserSpec defines the desired state of GrafanaUser
+// +kubebuilder:validation:XValidation:rule="((!has(oldSelf.login) && !has(self.login)) || (has(oldSelf.login) && has(self.login)))", message="spec.login is immutable"
+type GrafanaUserSpec struct {
+	GrafanaCommonSpec `json:",inline"`
+
+	// Login of the user in Grafana, defaults to metadata.name if not set
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec.login is immutable"
+	Login string `json:"login,omitempty"`
+
+	// Email of the user
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// Display name of the user
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Password of the user. The password is updated in Grafana whenever the referenced value changes
+	// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef)", message="password must be read from a Secret"
+	Password ValueFromSource `json:"password"`
+
+	// Role of the user in the organization, either the default organization or the one referenced through orgRef
+	// +optional
+	// +kubebuilder:validation:Enum=None;Viewer;Editor;Admin
+	// +kubebuilder:default=Viewer
+	Role string `json:"role,omitempty"`
+
+	// Grant the user Grafana server administrator permissions
+	// +optional
+	GrafanaAdmin bool `json:"grafanaAdmin,omitempty"`
+
+	// Prevent the user from logging in
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// GrafanaUserStatus defines the observed state of GrafanaUser
+type GrafanaUserStatus struct {
+	GrafanaCommonStatus `json:",inline"`
+
+	// ID of the user in Grafana. Only set when the ID is identical across all matching instances,
+	// refer to the Grafana status for per instance IDs
+	// +optional
+	UserID int64 `json:"userId,omitempty"`
+
+	// UID, key and resourceVersion of the password Secret applied to all matching instances,
+	// used to detect changes of the referenced value
+	// +optional
+	PasswordSecretVersion string `json:"passwordSecretVersion,omitempty"`
+
+	// Last time the password was set in Grafana
+	// +optional
+	LastPasswordRotation *metav1.Time `json:"lastPasswordRotation,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// GrafanaUser is the Schema for the grafanausers API
+// +kubebuilder:printcolumn:name="User ID",type="integer",JSONPath=".status.userId",description=""
+// +kubebuilder:printcolumn:name="Last rotation",type="date",format="date-time",JSONPath=".status.lastPasswordRotation",description=""
+// +kubebuilder:printcolumn:name="Last resync",type="date",format="date-time",JSONPath=".status.lastResync",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+// +kubebuilder:resource:categories={all,grafana-operator}
+type GrafanaUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrafanaUserSpec   `json:"spec"`
+	Status GrafanaUserStatus `json:"status,omitempty"`
+}
+
+var _ CommonResource = (*GrafanaUser)(nil)
+
+// GetGrafanaLogin returns the login of the user in Grafana
+func (in *GrafanaUser) GetGrafanaLogin() string {
+	if in.Spec.Login != "" {
+		return in.Spec.Login
+	}
+
+	return in.Name
+}
+
+// GetRole returns the organization role, defaulting to Viewer
+func (in *GrafanaUser) GetRole() string {
+	if in.Spec.Role != "" {
+		return in.Spec.Role
+	}
+
+	return "Viewer"
+}
+
+func (in *GrafanaUser) MatchLabels() *metav1.LabelSelector {
+	return in.Spec.InstanceSelector
+}
+
+func (in *GrafanaUser) MatchNamespace() string {
+	return in.Namespace
+}
+
+func (in *GrafanaUser) Metadata() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *GrafanaUser) AllowCrossNamespace() bool {
+	return in.Spec.AllowCrossNamespaceImport
+}
+
+func (in *GrafanaUser) NamespacedResource(userID string) NamespacedResource {
+	return NewNamespacedResource(in.Namespace, in.Name, userID)
+}
+
+func (in *GrafanaUser) CommonStatus() *GrafanaCommonStatus {
+	return &in.Status.GrafanaCommonStatus
+}
+
+func (in *GrafanaUser) Conditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// GrafanaUserList contains a list of GrafanaUser
+type GrafanaUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrafanaUser `json:"items"`
+}
+
+func (in *GrafanaUserList) Exists(namespace, name string) bool {
+	for _, item := range in.Items {
+		if item.Namespace == namespace && item.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGrafanaStatusListUser(t *testing.T) {
+	t.Run("&User{} maps to NamespacedResource list", func(t *testing.T) {
+		g := &Grafana{}
+		arg := &GrafanaUser{}
+		_, _, err := g.Status.StatusList(arg)
+		assert.NoError(t, err, "User does not have a case in Grafana.Status.StatusList")
+	})
+}
+
+func TestGrafanaUserDefaults(t *testing.T) {
+	cr := &GrafanaUser{ObjectMeta: metav1.ObjectMeta{Name: "cr-name"}}
+	assert.Equal(t, "cr-name", cr.GetGrafanaLogin())
+	assert.Equal(t, "Viewer", cr.GetRole())
+
+	cr.Spec.Login = "spec-login"
+	cr.Spec.Role = "Admin"
+	assert.Equal(t, "spec-login", cr.GetGrafanaLogin())
+	assert.Equal(t, "Admin", cr.GetRole())
+}
+
+func newUser(name string, password ValueFromSource) *GrafanaUser {
+	return &GrafanaUser{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       "GrafanaUser",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: GrafanaUserSpec{
+			GrafanaCommonSpec: GrafanaCommonSpec{
+				InstanceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"test": "user",
+					},
+				},
+			},
+			Login:    name,
+			Password: password,
+		},
+	}
+}
+
+var _ = Describe("User type", func() {
+	t := GinkgoT()
+
+	secretPassword := ValueFromSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+			Key:                  "password",
+		},
+	}
+
+	Context("Ensure User spec.login is immutable", func() {
+		ctx := context.Background()
+
+		It("Should block changing value of login", func() {
+			user := newUser("changing-login", secretPassword)
+
+			By("Create new User with existing login")
+
+			err := cl.Create(ctx, user)
+			require.NoError(t, err)
+
+			By("Changing the existing login")
+
+			user.Spec.Login = "new-login"
+			err = cl.Update(ctx, user)
+			require.Error(t, err)
+		})
+
+		It("Should block removing login", func() {
+			user := newUser("removing-login", secretPassword)
+
+			By("Create new User with existing login")
+
+			err := cl.Create(ctx, user)
+			require.NoError(t, err)
+
+			By("Removing the existing login")
+
+			user.Spec.Login = ""
+			err = cl.Update(ctx, user)
+			require.Error(t, err)
+		})
+	})
+
+	Context("Ensure User password is read from a Secret", func() {
+		ctx := context.Background()
+
+		It("Should reject ConfigMap references", func() {
+			user := newUser("configmap-password", ValueFromSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+					Key:                  "password",
+				},
+			})
+
+			err := cl.Create(ctx, user)
+			require.Error(t, err)
+		})
+
+		It("Should default the role to Viewer", func() {
+			user := newUser("default-role", secretPassword)
+
+			err := cl.Create(ctx, user)
+			require.NoError(t, err)
+			assert.Equal(t, "Viewer", user.Spec.Role)
+		})
+	})
+})
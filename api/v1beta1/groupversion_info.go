@@ -49,7 +49,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&GrafanaNotificationPolicyRoute{}, &GrafanaNotificationPolicyRouteList{},
 		&GrafanaNotificationPolicy{}, &GrafanaNotificationPolicyList{},
 		&GrafanaNotificationTemplate{}, &GrafanaNotificationTemplateList{},
+		&GrafanaOrganization{}, &GrafanaOrganizationList{},
 		&GrafanaServiceAccount{}, &GrafanaServiceAccountList{},
+		&GrafanaSilence{}, &GrafanaSilenceList{},
+		&GrafanaTeam{}, &GrafanaTeamList{},
+		&GrafanaUser{}, &GrafanaUserList{},
 		&Grafana{}, &GrafanaList{},
 	)
 
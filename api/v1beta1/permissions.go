@@ -0,0 +1,65 @@
+package v1beta1
+
+// GrafanaPermission grants a permission on a folder or dashboard to exactly one team, user, role or service account
+// +kubebuilder:validation:XValidation:rule="[has(self.team), has(self.teamRef), has(self.user), has(self.role), has(self.serviceAccountRef)].filter(x, x).size() == 1", message="exactly one of team, teamRef, user, role or serviceAccountRef must be set"
+type GrafanaPermission struct {
+	// Name of a team in Grafana
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Team string `json:"team,omitempty"`
+
+	// Name of a GrafanaTeam in the same namespace
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	TeamRef string `json:"teamRef,omitempty"`
+
+	// Login or email of a user in Grafana
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	User string `json:"user,omitempty"`
+
+	// Basic role of the organization
+	// +optional
+	// +kubebuilder:validation:Enum=Viewer;Editor;Admin
+	Role string `json:"role,omitempty"`
+
+	// Name of a GrafanaServiceAccount in the same namespace
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ServiceAccountRef string `json:"serviceAccountRef,omitempty"`
+
+	// +kubebuilder:validation:Enum=View;Edit;Admin
+	Permission string `json:"permission"`
+}
+
+// GrafanaEffectivePermission is a permission as reported by Grafana
+type GrafanaEffectivePermission struct {
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// Login of the user
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// Login of the service account
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	Permission string `json:"permission"`
+
+	// The permission is inherited from a parent folder
+	// +optional
+	Inherited bool `json:"inherited,omitempty"`
+}
+
+// GrafanaEffectiveACL lists the permissions of a folder or dashboard on one Grafana instance
+type GrafanaEffectiveACL struct {
+	// Grafana instance in the form namespace/name
+	Instance string `json:"instance"`
+
+	// +optional
+	Items []GrafanaEffectivePermission `json:"items,omitempty"`
+}
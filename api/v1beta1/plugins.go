@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/blang/semver/v4"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type GrafanaPlugin struct {
@@ -16,6 +17,77 @@ type GrafanaPlugin struct {
 	// TODO: kubernetes 1.34+ supports isSemver function, we should migrate to it after 1.33 reaches EOL. For now, using the official pattern https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
 	// +kubebuilder:validation:Pattern=`^((0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?|latest)$`
 	Version string `json:"version"`
+
+	// Source installs the plugin from an archive instead of the plugin catalog of grafana.com, e.g. in clusters
+	// without internet access. Only supported by instances managed by the operator
+	// +optional
+	Source *GrafanaPluginSource `json:"source,omitempty"`
+}
+
+// GrafanaPluginSource is a plugin archive, a zip file with the plugin directory at its root as published on grafana.com.
+// Archives are unpacked into the plugins directory by an init container of the Grafana pods, references to
+// Secrets, ConfigMaps and PersistentVolumeClaims are resolved in the namespace of the Grafana instance. These are
+// only accepted from resources in the namespace of the instance
+// +kubebuilder:validation:XValidation:rule="[has(self.oci), has(self.url), has(self.configMap), has(self.persistentVolumeClaim)].filter(x, x).size() == 1", message="exactly one of oci, url, configMap or persistentVolumeClaim must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.url) || has(self.sha256)", message="sha256 is required for url sources"
+type GrafanaPluginSource struct {
+	// OCI artifact holding the archive, either as its only layer or as a layer titled *.zip
+	// +optional
+	OCI *GrafanaPluginOCISource `json:"oci,omitempty"`
+
+	// URL of the archive, downloaded by the Grafana pods
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// ConfigMap key holding the archive in binaryData
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+
+	// PersistentVolumeClaim holding the archive, mounted read-only by the init container
+	// +optional
+	PersistentVolumeClaim *GrafanaPluginVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// SHA256 checksum of the archive, verified before it is unpacked. Required for url, archives of OCI artifacts
+	// are always verified against their layer digest
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// ReferencesObjects reports whether the source references Secrets, ConfigMaps or PersistentVolumeClaims
+func (in *GrafanaPluginSource) ReferencesObjects() bool {
+	return in.ConfigMap != nil || in.PersistentVolumeClaim != nil || (in.OCI != nil && in.OCI.PullSecretRef != nil)
+}
+
+// GrafanaPluginOCISource references an OCI artifact holding a plugin archive. Tags are resolved to a digest by the
+// operator, the pods only pull the resolved digest
+type GrafanaPluginOCISource struct {
+	// Reference is the full OCI artifact reference including a tag or digest, e.g. "registry.local/plugins/clock-panel:2.1.8"
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^[^:@]+(:[^:@/]+|@sha256:[a-fA-F0-9]{64})$`
+	Reference string `json:"reference"`
+
+	// PullSecretRef references a kubernetes.io/dockerconfigjson Secret in the namespace of the Grafana instance.
+	// If omitted, anonymous pull is attempted.
+	// +optional
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
+
+	// InsecurePlainHTTP switches the registry connection to plain HTTP (non-TLS) instead of HTTPS
+	// +optional
+	InsecurePlainHTTP bool `json:"insecurePlainHTTP,omitempty"`
+}
+
+// GrafanaPluginVolumeSource references an archive on a PersistentVolumeClaim
+type GrafanaPluginVolumeSource struct {
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName"`
+
+	// Path of the archive relative to the root of the volume
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[^/]`
+	Path string `json:"path"`
 }
 
 func (p GrafanaPlugin) HasValidVersion() bool {
@@ -82,6 +154,34 @@ func (p *GrafanaPlugin) Update(version string) {
 	}
 }
 
+// GrafanaPluginPolicy restricts the plugins resources may install on an instance
+type GrafanaPluginPolicy struct {
+	// Allowed lists the plugins which may be installed, other plugins are rejected. If empty, any plugin is allowed
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Allowed []GrafanaPluginPolicyRule `json:"allowed,omitempty"`
+
+	// RejectUnsigned rejects plugin versions which are unsigned in the plugin catalog of grafana.com before they are
+	// installed, as well as plugins with a source, whose signature is only known once installed. Versions the instance
+	// reports as unsigned, or with an invalid or modified signature, are removed again once detected. Instances managed
+	// by the operator refuse to load unsigned plugins, plugins.allow_loading_unsigned_plugins of spec.config is ignored
+	// +optional
+	RejectUnsigned bool `json:"rejectUnsigned,omitempty"`
+}
+
+// GrafanaPluginPolicyRule allows a plugin, optionally restricted to a range of versions
+type GrafanaPluginPolicyRule struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Versions is the range of allowed versions, e.g. ">=2.0.0 <3.0.0", "~1.4" or "^2.0.0".
+	// If set, plugins requested with the latest version are rejected
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	Versions string `json:"versions,omitempty"`
+}
+
 // Helps to simplify version consolidation
 type PluginMap map[string]GrafanaPlugin
 
@@ -92,7 +192,14 @@ func (m PluginMap) Merge(plugins PluginList) {
 		}
 
 		if plugin, ok := m[p.Name]; ok {
+			version := plugin.Version
 			plugin.Update(p.Version)
+
+			// The source belongs to the requested version
+			if plugin.Version != version || (plugin.Source == nil && p.Version == version) {
+				plugin.Source = p.Source
+			}
+
 			m[p.Name] = plugin
 		} else {
 			m[p.Name] = p
@@ -137,6 +244,19 @@ func (l PluginList) String() string {
 	return strings.Join(plugins, ",")
 }
 
+// Split separates plugins installed from the plugin catalog from those installed from archives
+func (l PluginList) Split() (catalog PluginList, archives PluginList) {
+	for _, plugin := range l {
+		if plugin.Source != nil {
+			archives = append(archives, plugin)
+		} else {
+			catalog = append(catalog, plugin)
+		}
+	}
+
+	return catalog, archives
+}
+
 // Sanitize remove duplicates and enforce semver
 func (l PluginList) Sanitize() PluginList {
 	plugins := NewPluginMapFromList(l)
@@ -6,6 +6,7 @@ import (
 	"testing/quick"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestGrafanaPluginHasValidVersion(t *testing.T) {
@@ -418,3 +419,35 @@ func TestPluginMapMerge(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestPluginMapMergeSources(t *testing.T) {
+	oldSource := &GrafanaPluginSource{URL: "https://plugins.local/a-1.0.0.zip", SHA256: strings.Repeat("a", 64)}
+	newSource := &GrafanaPluginSource{URL: "https://plugins.local/a-1.1.0.zip", SHA256: strings.Repeat("b", 64)}
+
+	got := PluginMap{}
+	got.Merge(PluginList{
+		{Name: "a", Version: "1.0.0", Source: oldSource},
+		{Name: "a", Version: "1.1.0", Source: newSource},
+		{Name: "a", Version: "1.0.0"},
+		{Name: "b", Version: "2.0.0"},
+		{Name: "b", Version: "2.0.0", Source: oldSource},
+		{Name: "b", Version: "1.0.0", Source: newSource},
+	})
+
+	assert.Equal(t, newSource, got["a"].Source, "the source of the highest version is kept")
+	assert.Equal(t, oldSource, got["b"].Source, "a source of the same version is added, sources of older versions are ignored")
+}
+
+func TestPluginListSplit(t *testing.T) {
+	plugins := PluginList{
+		{Name: "a", Version: "1.0.0"},
+		{Name: "b", Version: "1.0.0", Source: &GrafanaPluginSource{ConfigMap: &corev1.ConfigMapKeySelector{Key: "b.zip"}}},
+		{Name: "c", Version: "latest"},
+	}
+
+	catalog, archives := plugins.Split()
+
+	assert.Equal(t, PluginList{plugins[0], plugins[2]}, catalog)
+	assert.Equal(t, PluginList{plugins[1]}, archives)
+	assert.Equal(t, "a 1.0.0,c", catalog.String())
+}
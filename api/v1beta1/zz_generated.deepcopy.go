@@ -29,6 +29,7 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -544,6 +545,31 @@ func (in *GrafanaAlertRuleGroupSpec) DeepCopy() *GrafanaAlertRuleGroupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaAlertingHA) DeepCopyInto(out *GrafanaAlertingHA) {
+	*out = *in
+	if in.GossipInterval != nil {
+		in, out := &in.GossipInterval, &out.GossipInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PushPullInterval != nil {
+		in, out := &in.PushPullInterval, &out.PushPullInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaAlertingHA.
+func (in *GrafanaAlertingHA) DeepCopy() *GrafanaAlertingHA {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaAlertingHA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaClient) DeepCopyInto(out *GrafanaClient) {
 	*out = *in
@@ -795,14 +821,44 @@ func (in *GrafanaContentEnvFromSource) DeepCopy() *GrafanaContentEnvFromSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaContentGit) DeepCopyInto(out *GrafanaContentGit) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentGit.
+func (in *GrafanaContentGit) DeepCopy() *GrafanaContentGit {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaContentGit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaContentOCI) DeepCopyInto(out *GrafanaContentOCI) {
 	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.PullSecretRef != nil {
 		in, out := &in.PullSecretRef, &out.PullSecretRef
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(GrafanaContentOCIVerify)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentOCI.
@@ -815,6 +871,48 @@ func (in *GrafanaContentOCI) DeepCopy() *GrafanaContentOCI {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaContentOCIKeyless) DeepCopyInto(out *GrafanaContentOCIKeyless) {
+	*out = *in
+	in.Roots.DeepCopyInto(&out.Roots)
+	in.RekorPublicKey.DeepCopyInto(&out.RekorPublicKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentOCIKeyless.
+func (in *GrafanaContentOCIKeyless) DeepCopy() *GrafanaContentOCIKeyless {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaContentOCIKeyless)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaContentOCIVerify) DeepCopyInto(out *GrafanaContentOCIVerify) {
+	*out = *in
+	if in.PublicKey != nil {
+		in, out := &in.PublicKey, &out.PublicKey
+		*out = new(GrafanaContentEnvFromSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(GrafanaContentOCIKeyless)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentOCIVerify.
+func (in *GrafanaContentOCIVerify) DeepCopy() *GrafanaContentOCIVerify {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaContentOCIVerify)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaContentSpec) DeepCopyInto(out *GrafanaContentSpec) {
 	*out = *in
@@ -848,6 +946,11 @@ func (in *GrafanaContentSpec) DeepCopyInto(out *GrafanaContentSpec) {
 		*out = new(GrafanaContentOCI)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GrafanaContentGit)
+		(*in).DeepCopyInto(*out)
+	}
 	out.ContentCacheDuration = in.ContentCacheDuration
 	if in.Datasources != nil {
 		in, out := &in.Datasources, &out.Datasources
@@ -868,6 +971,11 @@ func (in *GrafanaContentSpec) DeepCopyInto(out *GrafanaContentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Templating != nil {
+		in, out := &in.Templating, &out.Templating
+		*out = new(GrafanaContentTemplating)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentSpec.
@@ -889,6 +997,7 @@ func (in *GrafanaContentStatus) DeepCopyInto(out *GrafanaContentStatus) {
 		copy(*out, *in)
 	}
 	in.ContentTimestamp.DeepCopyInto(&out.ContentTimestamp)
+	in.OCIResolvedTimestamp.DeepCopyInto(&out.OCIResolvedTimestamp)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentStatus.
@@ -901,6 +1010,42 @@ func (in *GrafanaContentStatus) DeepCopy() *GrafanaContentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaContentTemplating) DeepCopyInto(out *GrafanaContentTemplating) {
+	*out = *in
+	if in.GrafanaRefs != nil {
+		in, out := &in.GrafanaRefs, &out.GrafanaRefs
+		*out = make([]GrafanaContentTemplatingRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentTemplating.
+func (in *GrafanaContentTemplating) DeepCopy() *GrafanaContentTemplating {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaContentTemplating)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaContentTemplatingRef) DeepCopyInto(out *GrafanaContentTemplatingRef) {
+	*out = *in
+	out.GrafanaRef = in.GrafanaRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentTemplatingRef.
+func (in *GrafanaContentTemplatingRef) DeepCopy() *GrafanaContentTemplatingRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaContentTemplatingRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaContentURLAuthorization) DeepCopyInto(out *GrafanaContentURLAuthorization) {
 	*out = *in
@@ -909,6 +1054,21 @@ func (in *GrafanaContentURLAuthorization) DeepCopyInto(out *GrafanaContentURLAut
 		*out = new(GrafanaContentURLBasicAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(GrafanaContentURLTLS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentURLAuthorization.
@@ -946,6 +1106,36 @@ func (in *GrafanaContentURLBasicAuth) DeepCopy() *GrafanaContentURLBasicAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaContentURLTLS) DeepCopyInto(out *GrafanaContentURLTLS) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaContentURLTLS.
+func (in *GrafanaContentURLTLS) DeepCopy() *GrafanaContentURLTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaContentURLTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaContentVariable) DeepCopyInto(out *GrafanaContentVariable) {
 	*out = *in
@@ -1048,13 +1238,20 @@ func (in *GrafanaDashboardSpec) DeepCopyInto(out *GrafanaDashboardSpec) {
 	if in.Plugins != nil {
 		in, out := &in.Plugins, &out.Plugins
 		*out = make(PluginList, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.PublicSharing != nil {
 		in, out := &in.PublicSharing, &out.PublicSharing
 		*out = new(GrafanaDashboardPublicSharing)
 		**out = **in
 	}
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = make([]GrafanaPermission, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboardSpec.
@@ -1072,6 +1269,13 @@ func (in *GrafanaDashboardStatus) DeepCopyInto(out *GrafanaDashboardStatus) {
 	*out = *in
 	in.GrafanaCommonStatus.DeepCopyInto(&out.GrafanaCommonStatus)
 	in.GrafanaContentStatus.DeepCopyInto(&out.GrafanaContentStatus)
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = make([]GrafanaEffectiveACL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboardStatus.
@@ -1084,6 +1288,23 @@ func (in *GrafanaDashboardStatus) DeepCopy() *GrafanaDashboardStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaDatabase) DeepCopyInto(out *GrafanaDatabase) {
+	*out = *in
+	in.User.DeepCopyInto(&out.User)
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDatabase.
+func (in *GrafanaDatabase) DeepCopy() *GrafanaDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaDatasource) DeepCopyInto(out *GrafanaDatasource) {
 	*out = *in
@@ -1200,7 +1421,9 @@ func (in *GrafanaDatasourceSpec) DeepCopyInto(out *GrafanaDatasourceSpec) {
 	if in.Plugins != nil {
 		in, out := &in.Plugins, &out.Plugins
 		*out = make(PluginList, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ValuesFrom != nil {
 		in, out := &in.ValuesFrom, &out.ValuesFrom
@@ -1237,6 +1460,41 @@ func (in *GrafanaDatasourceStatus) DeepCopy() *GrafanaDatasourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaEffectiveACL) DeepCopyInto(out *GrafanaEffectiveACL) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrafanaEffectivePermission, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaEffectiveACL.
+func (in *GrafanaEffectiveACL) DeepCopy() *GrafanaEffectiveACL {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaEffectiveACL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaEffectivePermission) DeepCopyInto(out *GrafanaEffectivePermission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaEffectivePermission.
+func (in *GrafanaEffectivePermission) DeepCopy() *GrafanaEffectivePermission {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaEffectivePermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaFolder) DeepCopyInto(out *GrafanaFolder) {
 	*out = *in
@@ -1300,6 +1558,11 @@ func (in *GrafanaFolderList) DeepCopyObject() runtime.Object {
 func (in *GrafanaFolderSpec) DeepCopyInto(out *GrafanaFolderSpec) {
 	*out = *in
 	in.GrafanaCommonSpec.DeepCopyInto(&out.GrafanaCommonSpec)
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = make([]GrafanaPermission, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaFolderSpec.
@@ -1316,6 +1579,13 @@ func (in *GrafanaFolderSpec) DeepCopy() *GrafanaFolderSpec {
 func (in *GrafanaFolderStatus) DeepCopyInto(out *GrafanaFolderStatus) {
 	*out = *in
 	in.GrafanaCommonStatus.DeepCopyInto(&out.GrafanaCommonStatus)
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = make([]GrafanaEffectiveACL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaFolderStatus.
@@ -1328,6 +1598,37 @@ func (in *GrafanaFolderStatus) DeepCopy() *GrafanaFolderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaHighAvailability) DeepCopyInto(out *GrafanaHighAvailability) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Database.DeepCopyInto(&out.Database)
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(GrafanaAlertingHA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(GrafanaPodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaHighAvailability.
+func (in *GrafanaHighAvailability) DeepCopy() *GrafanaHighAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaHighAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaLibraryPanel) DeepCopyInto(out *GrafanaLibraryPanel) {
 	*out = *in
@@ -1395,7 +1696,9 @@ func (in *GrafanaLibraryPanelSpec) DeepCopyInto(out *GrafanaLibraryPanelSpec) {
 	if in.Plugins != nil {
 		in, out := &in.Plugins, &out.Plugins
 		*out = make(PluginList, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1929,56 +2232,58 @@ func (in *GrafanaNotificationTemplateSpec) DeepCopy() *GrafanaNotificationTempla
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrafanaPlugin) DeepCopyInto(out *GrafanaPlugin) {
+func (in *GrafanaOrganization) DeepCopyInto(out *GrafanaOrganization) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPlugin.
-func (in *GrafanaPlugin) DeepCopy() *GrafanaPlugin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaOrganization.
+func (in *GrafanaOrganization) DeepCopy() *GrafanaOrganization {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaPlugin)
+	out := new(GrafanaOrganization)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrafanaPreferences) DeepCopyInto(out *GrafanaPreferences) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPreferences.
-func (in *GrafanaPreferences) DeepCopy() *GrafanaPreferences {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrafanaOrganization) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(GrafanaPreferences)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrafanaServiceAccount) DeepCopyInto(out *GrafanaServiceAccount) {
+func (in *GrafanaOrganizationList) DeepCopyInto(out *GrafanaOrganizationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrafanaOrganization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccount.
-func (in *GrafanaServiceAccount) DeepCopy() *GrafanaServiceAccount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaOrganizationList.
+func (in *GrafanaOrganizationList) DeepCopy() *GrafanaOrganizationList {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaServiceAccount)
+	out := new(GrafanaOrganizationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrafanaServiceAccount) DeepCopyObject() runtime.Object {
+func (in *GrafanaOrganizationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1986,34 +2291,356 @@ func (in *GrafanaServiceAccount) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrafanaServiceAccountInfo) DeepCopyInto(out *GrafanaServiceAccountInfo) {
+func (in *GrafanaOrganizationPreferences) DeepCopyInto(out *GrafanaOrganizationPreferences) {
 	*out = *in
-	if in.Tokens != nil {
-		in, out := &in.Tokens, &out.Tokens
-		*out = make([]GrafanaServiceAccountTokenStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountInfo.
-func (in *GrafanaServiceAccountInfo) DeepCopy() *GrafanaServiceAccountInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaOrganizationPreferences.
+func (in *GrafanaOrganizationPreferences) DeepCopy() *GrafanaOrganizationPreferences {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaServiceAccountInfo)
+	out := new(GrafanaOrganizationPreferences)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrafanaServiceAccountList) DeepCopyInto(out *GrafanaServiceAccountList) {
+func (in *GrafanaOrganizationSpec) DeepCopyInto(out *GrafanaOrganizationSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
+	in.GrafanaCommonSpec.DeepCopyInto(&out.GrafanaCommonSpec)
+	if in.Preferences != nil {
+		in, out := &in.Preferences, &out.Preferences
+		*out = new(GrafanaOrganizationPreferences)
+		**out = **in
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]GrafanaOrganizationUser, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaOrganizationSpec.
+func (in *GrafanaOrganizationSpec) DeepCopy() *GrafanaOrganizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaOrganizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaOrganizationStatus) DeepCopyInto(out *GrafanaOrganizationStatus) {
+	*out = *in
+	in.GrafanaCommonStatus.DeepCopyInto(&out.GrafanaCommonStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaOrganizationStatus.
+func (in *GrafanaOrganizationStatus) DeepCopy() *GrafanaOrganizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaOrganizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaOrganizationUser) DeepCopyInto(out *GrafanaOrganizationUser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaOrganizationUser.
+func (in *GrafanaOrganizationUser) DeepCopy() *GrafanaOrganizationUser {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaOrganizationUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPermission) DeepCopyInto(out *GrafanaPermission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPermission.
+func (in *GrafanaPermission) DeepCopy() *GrafanaPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPlugin) DeepCopyInto(out *GrafanaPlugin) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(GrafanaPluginSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPlugin.
+func (in *GrafanaPlugin) DeepCopy() *GrafanaPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPluginOCISource) DeepCopyInto(out *GrafanaPluginOCISource) {
+	*out = *in
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPluginOCISource.
+func (in *GrafanaPluginOCISource) DeepCopy() *GrafanaPluginOCISource {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPluginOCISource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPluginPolicy) DeepCopyInto(out *GrafanaPluginPolicy) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]GrafanaPluginPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPluginPolicy.
+func (in *GrafanaPluginPolicy) DeepCopy() *GrafanaPluginPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPluginPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPluginPolicyRule) DeepCopyInto(out *GrafanaPluginPolicyRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPluginPolicyRule.
+func (in *GrafanaPluginPolicyRule) DeepCopy() *GrafanaPluginPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPluginPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPluginSource) DeepCopyInto(out *GrafanaPluginSource) {
+	*out = *in
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(GrafanaPluginOCISource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(GrafanaPluginVolumeSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPluginSource.
+func (in *GrafanaPluginSource) DeepCopy() *GrafanaPluginSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPluginSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPluginVolumeSource) DeepCopyInto(out *GrafanaPluginVolumeSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPluginVolumeSource.
+func (in *GrafanaPluginVolumeSource) DeepCopy() *GrafanaPluginVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPluginVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPodDisruptionBudget) DeepCopyInto(out *GrafanaPodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPodDisruptionBudget.
+func (in *GrafanaPodDisruptionBudget) DeepCopy() *GrafanaPodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaPreferences) DeepCopyInto(out *GrafanaPreferences) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaPreferences.
+func (in *GrafanaPreferences) DeepCopy() *GrafanaPreferences {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaPreferences)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaRenderer) DeepCopyInto(out *GrafanaRenderer) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaRenderer.
+func (in *GrafanaRenderer) DeepCopy() *GrafanaRenderer {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaRenderer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccount) DeepCopyInto(out *GrafanaServiceAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccount.
+func (in *GrafanaServiceAccount) DeepCopy() *GrafanaServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrafanaServiceAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccountInfo) DeepCopyInto(out *GrafanaServiceAccountInfo) {
+	*out = *in
+	if in.Tokens != nil {
+		in, out := &in.Tokens, &out.Tokens
+		*out = make([]GrafanaServiceAccountTokenStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RetiredTokens != nil {
+		in, out := &in.RetiredTokens, &out.RetiredTokens
+		*out = make([]GrafanaServiceAccountRetiredTokenStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountInfo.
+func (in *GrafanaServiceAccountInfo) DeepCopy() *GrafanaServiceAccountInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccountInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccountInstanceStatus) DeepCopyInto(out *GrafanaServiceAccountInstanceStatus) {
+	*out = *in
+	in.Account.DeepCopyInto(&out.Account)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountInstanceStatus.
+func (in *GrafanaServiceAccountInstanceStatus) DeepCopy() *GrafanaServiceAccountInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccountInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccountList) DeepCopyInto(out *GrafanaServiceAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
 		*out = make([]GrafanaServiceAccount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
@@ -2039,6 +2666,22 @@ func (in *GrafanaServiceAccountList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccountRetiredTokenStatus) DeepCopyInto(out *GrafanaServiceAccountRetiredTokenStatus) {
+	*out = *in
+	in.RevokeAt.DeepCopyInto(&out.RevokeAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountRetiredTokenStatus.
+func (in *GrafanaServiceAccountRetiredTokenStatus) DeepCopy() *GrafanaServiceAccountRetiredTokenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccountRetiredTokenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaServiceAccountSecretStatus) DeepCopyInto(out *GrafanaServiceAccountSecretStatus) {
 	*out = *in
@@ -2054,10 +2697,35 @@ func (in *GrafanaServiceAccountSecretStatus) DeepCopy() *GrafanaServiceAccountSe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccountSecretTarget) DeepCopyInto(out *GrafanaServiceAccountSecretTarget) {
+	*out = *in
+	if in.Tokens != nil {
+		in, out := &in.Tokens, &out.Tokens
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountSecretTarget.
+func (in *GrafanaServiceAccountSecretTarget) DeepCopy() *GrafanaServiceAccountSecretTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccountSecretTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaServiceAccountSpec) DeepCopyInto(out *GrafanaServiceAccountSpec) {
 	*out = *in
 	out.ResyncPeriod = in.ResyncPeriod
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tokens != nil {
 		in, out := &in.Tokens, &out.Tokens
 		*out = make([]GrafanaServiceAccountTokenSpec, len(*in))
@@ -2065,6 +2733,13 @@ func (in *GrafanaServiceAccountSpec) DeepCopyInto(out *GrafanaServiceAccountSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SecretTargets != nil {
+		in, out := &in.SecretTargets, &out.SecretTargets
+		*out = make([]GrafanaServiceAccountSecretTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountSpec.
@@ -2086,57 +2761,233 @@ func (in *GrafanaServiceAccountStatus) DeepCopyInto(out *GrafanaServiceAccountSt
 		*out = new(GrafanaServiceAccountInfo)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]GrafanaServiceAccountInstanceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecretCopies != nil {
+		in, out := &in.SecretCopies, &out.SecretCopies
+		*out = make([]GrafanaServiceAccountSecretStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountStatus.
+func (in *GrafanaServiceAccountStatus) DeepCopy() *GrafanaServiceAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccountTokenRotation) DeepCopyInto(out *GrafanaServiceAccountTokenRotation) {
+	*out = *in
+	out.Validity = in.Validity
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountTokenRotation.
+func (in *GrafanaServiceAccountTokenRotation) DeepCopy() *GrafanaServiceAccountTokenRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccountTokenRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccountTokenSpec) DeepCopyInto(out *GrafanaServiceAccountTokenSpec) {
+	*out = *in
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = (*in).DeepCopy()
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(GrafanaServiceAccountTokenRotation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountTokenSpec.
+func (in *GrafanaServiceAccountTokenSpec) DeepCopy() *GrafanaServiceAccountTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccountTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaServiceAccountTokenStatus) DeepCopyInto(out *GrafanaServiceAccountTokenStatus) {
+	*out = *in
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = (*in).DeepCopy()
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(GrafanaServiceAccountSecretStatus)
+		**out = **in
+	}
+	if in.RenewAt != nil {
+		in, out := &in.RenewAt, &out.RenewAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountTokenStatus.
+func (in *GrafanaServiceAccountTokenStatus) DeepCopy() *GrafanaServiceAccountTokenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaServiceAccountTokenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaSilence) DeepCopyInto(out *GrafanaSilence) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSilence.
+func (in *GrafanaSilence) DeepCopy() *GrafanaSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrafanaSilence) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaSilenceInstanceStatus) DeepCopyInto(out *GrafanaSilenceInstanceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSilenceInstanceStatus.
+func (in *GrafanaSilenceInstanceStatus) DeepCopy() *GrafanaSilenceInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaSilenceInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaSilenceList) DeepCopyInto(out *GrafanaSilenceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrafanaSilence, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountStatus.
-func (in *GrafanaServiceAccountStatus) DeepCopy() *GrafanaServiceAccountStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSilenceList.
+func (in *GrafanaSilenceList) DeepCopy() *GrafanaSilenceList {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaServiceAccountStatus)
+	out := new(GrafanaSilenceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrafanaSilenceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrafanaServiceAccountTokenSpec) DeepCopyInto(out *GrafanaServiceAccountTokenSpec) {
+func (in *GrafanaSilenceSpec) DeepCopyInto(out *GrafanaSilenceSpec) {
 	*out = *in
-	if in.Expires != nil {
-		in, out := &in.Expires, &out.Expires
+	in.GrafanaCommonSpec.DeepCopyInto(&out.GrafanaCommonSpec)
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]SilenceMatcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartsAt != nil {
+		in, out := &in.StartsAt, &out.StartsAt
 		*out = (*in).DeepCopy()
 	}
+	if in.EndsAt != nil {
+		in, out := &in.EndsAt, &out.EndsAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountTokenSpec.
-func (in *GrafanaServiceAccountTokenSpec) DeepCopy() *GrafanaServiceAccountTokenSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSilenceSpec.
+func (in *GrafanaSilenceSpec) DeepCopy() *GrafanaSilenceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaServiceAccountTokenSpec)
+	out := new(GrafanaSilenceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrafanaServiceAccountTokenStatus) DeepCopyInto(out *GrafanaServiceAccountTokenStatus) {
+func (in *GrafanaSilenceStatus) DeepCopyInto(out *GrafanaSilenceStatus) {
 	*out = *in
-	if in.Expires != nil {
-		in, out := &in.Expires, &out.Expires
-		*out = (*in).DeepCopy()
-	}
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(GrafanaServiceAccountSecretStatus)
-		**out = **in
+	in.GrafanaCommonStatus.DeepCopyInto(&out.GrafanaCommonStatus)
+	if in.Silences != nil {
+		in, out := &in.Silences, &out.Silences
+		*out = make([]GrafanaSilenceInstanceStatus, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaServiceAccountTokenStatus.
-func (in *GrafanaServiceAccountTokenStatus) DeepCopy() *GrafanaServiceAccountTokenStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSilenceStatus.
+func (in *GrafanaSilenceStatus) DeepCopy() *GrafanaSilenceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaServiceAccountTokenStatus)
+	out := new(GrafanaSilenceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2217,6 +3068,21 @@ func (in *GrafanaSpec) DeepCopyInto(out *GrafanaSpec) {
 		*out = new(GrafanaPreferences)
 		**out = **in
 	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(GrafanaHighAvailability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Renderer != nil {
+		in, out := &in.Renderer, &out.Renderer
+		*out = new(GrafanaRenderer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginPolicy != nil {
+		in, out := &in.PluginPolicy, &out.PluginPolicy
+		*out = new(GrafanaPluginPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSpec.
@@ -2277,6 +3143,26 @@ func (in *GrafanaStatus) DeepCopyInto(out *GrafanaStatus) {
 		*out = make(NamespacedResourceList, len(*in))
 		copy(*out, *in)
 	}
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make(NamespacedResourceList, len(*in))
+		copy(*out, *in)
+	}
+	if in.Silences != nil {
+		in, out := &in.Silences, &out.Silences
+		*out = make(NamespacedResourceList, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make(NamespacedResourceList, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make(NamespacedResourceList, len(*in))
+		copy(*out, *in)
+	}
 	if in.Manifests != nil {
 		in, out := &in.Manifests, &out.Manifests
 		*out = make(NamespacedResourceList, len(*in))
@@ -2289,6 +3175,20 @@ func (in *GrafanaStatus) DeepCopyInto(out *GrafanaStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make(PluginList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnsignedPlugins != nil {
+		in, out := &in.UnsignedPlugins, &out.UnsignedPlugins
+		*out = make(PluginList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaStatus.
@@ -2301,6 +3201,213 @@ func (in *GrafanaStatus) DeepCopy() *GrafanaStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaTeam) DeepCopyInto(out *GrafanaTeam) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaTeam.
+func (in *GrafanaTeam) DeepCopy() *GrafanaTeam {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaTeam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrafanaTeam) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaTeamList) DeepCopyInto(out *GrafanaTeamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrafanaTeam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaTeamList.
+func (in *GrafanaTeamList) DeepCopy() *GrafanaTeamList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaTeamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrafanaTeamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaTeamMember) DeepCopyInto(out *GrafanaTeamMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaTeamMember.
+func (in *GrafanaTeamMember) DeepCopy() *GrafanaTeamMember {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaTeamMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaTeamSpec) DeepCopyInto(out *GrafanaTeamSpec) {
+	*out = *in
+	in.GrafanaCommonSpec.DeepCopyInto(&out.GrafanaCommonSpec)
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]GrafanaTeamMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaTeamSpec.
+func (in *GrafanaTeamSpec) DeepCopy() *GrafanaTeamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaTeamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaTeamStatus) DeepCopyInto(out *GrafanaTeamStatus) {
+	*out = *in
+	in.GrafanaCommonStatus.DeepCopyInto(&out.GrafanaCommonStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaTeamStatus.
+func (in *GrafanaTeamStatus) DeepCopy() *GrafanaTeamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaTeamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaUser) DeepCopyInto(out *GrafanaUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaUser.
+func (in *GrafanaUser) DeepCopy() *GrafanaUser {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrafanaUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaUserList) DeepCopyInto(out *GrafanaUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrafanaUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaUserList.
+func (in *GrafanaUserList) DeepCopy() *GrafanaUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrafanaUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaUserSpec) DeepCopyInto(out *GrafanaUserSpec) {
+	*out = *in
+	in.GrafanaCommonSpec.DeepCopyInto(&out.GrafanaCommonSpec)
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaUserSpec.
+func (in *GrafanaUserSpec) DeepCopy() *GrafanaUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaUserStatus) DeepCopyInto(out *GrafanaUserStatus) {
+	*out = *in
+	in.GrafanaCommonStatus.DeepCopyInto(&out.GrafanaCommonStatus)
+	if in.LastPasswordRotation != nil {
+		in, out := &in.LastPasswordRotation, &out.LastPasswordRotation
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaUserStatus.
+func (in *GrafanaUserStatus) DeepCopy() *GrafanaUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRouteV1) DeepCopyInto(out *HTTPRouteV1) {
 	*out = *in
@@ -2540,6 +3647,13 @@ func (in *OpenshiftTLSConfig) DeepCopy() *OpenshiftTLSConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorReconcileVars) DeepCopyInto(out *OperatorReconcileVars) {
 	*out = *in
+	if in.PluginArchives != nil {
+		in, out := &in.PluginArchives, &out.PluginArchives
+		*out = make(PluginList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorReconcileVars.
@@ -2737,7 +3851,9 @@ func (in PluginList) DeepCopyInto(out *PluginList) {
 	{
 		in := &in
 		*out = make(PluginList, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -2757,7 +3873,7 @@ func (in PluginMap) DeepCopyInto(out *PluginMap) {
 		in := &in
 		*out = make(PluginMap, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 }
@@ -3032,6 +4148,21 @@ func (in *ServiceV1) DeepCopy() *ServiceV1 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceMatcher) DeepCopyInto(out *SilenceMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceMatcher.
+func (in *SilenceMatcher) DeepCopy() *SilenceMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// grafana-export writes the folders, dashboards, datasources and alerting resources of an existing
+// Grafana instance as grafana-operator custom resources
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/pkg/export"
+)
+
+var exportConfig struct {
+	Grafana            string   `name:"grafana"              help:"Grafana CR to read the connection settings and credentials from, as <namespace>/<name>. Uses the current kubeconfig context."`
+	URL                string   `name:"url"                  env:"GRAFANA_URL"            help:"URL of the Grafana instance. Overrides the admin URL of --grafana, e.g. when the instance is reached through a port-forward."`
+	APIKey             string   `name:"api-key"              env:"GRAFANA_API_KEY"        help:"API key or service account token. Preferred over admin user and password."`
+	AdminUser          string   `name:"admin-user"           env:"GRAFANA_ADMIN_USER"     help:"Admin user for basic authentication."`
+	AdminPassword      string   `name:"admin-password"       env:"GRAFANA_ADMIN_PASSWORD" help:"Admin password for basic authentication."`
+	InsecureSkipVerify bool     `name:"insecure-skip-verify" default:"false"              help:"Skip TLS verification of the Grafana certificate."`
+	Namespace          string   `name:"namespace"            default:"default"            help:"Namespace set on the generated resources."`
+	InstanceSelector   string   `name:"instance-selector"    default:""                   help:"Label selector set as spec.instanceSelector on the generated resources, e.g. 'dashboards=grafana'."`
+	Kinds              []string `name:"kinds"                default:"GrafanaFolder,GrafanaDatasource,GrafanaDashboard,GrafanaAlertRuleGroup,GrafanaContactPoint,GrafanaMuteTiming" help:"Comma separated kinds to export."`
+	Output             string   `name:"output"               short:"o"                    help:"Directory to write one file per resource to. Writes a single YAML stream to stdout when empty."`
+}
+
+func main() {
+	kong.Parse(&exportConfig,
+		kong.Name("grafana-export"),
+		kong.Description("Generate grafana-operator custom resources from the state of an existing Grafana instance."),
+		kong.UsageOnError(),
+		kong.ConfigureHelp(kong.HelpOptions{
+			Compact: true,
+			Summary: false,
+		}),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	selector, err := metav1.ParseToLabelSelector(exportConfig.InstanceSelector)
+	if err != nil {
+		return fmt.Errorf("parsing instance selector: %w", err)
+	}
+
+	gClient, err := newGrafanaClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	objects, err := export.NewExporter(gClient, exportConfig.Namespace, selector).Export(ctx, exportConfig.Kinds)
+	if err != nil {
+		return err
+	}
+
+	if exportConfig.Output == "" {
+		return export.WriteYAML(os.Stdout, objects)
+	}
+
+	return writeFiles(exportConfig.Output, objects)
+}
+
+// newGrafanaClient connects through the Grafana CR when set, otherwise through the URL and credentials flags
+func newGrafanaClient(ctx context.Context) (*genapi.GrafanaHTTPAPI, error) {
+	if exportConfig.Grafana == "" {
+		if exportConfig.URL == "" {
+			return nil, errors.New("either --grafana or --url must be set")
+		}
+
+		tlsConfig := grafanaclient.DefaultTLSConfiguration
+		if exportConfig.InsecureSkipVerify {
+			tlsConfig = grafanaclient.InsecureTLSConfiguration
+		}
+
+		return grafanaclient.NewGeneratedGrafanaClientWithCredentials(ctx, exportConfig.URL, grafanaclient.Credentials{
+			APIKey:        exportConfig.APIKey,
+			AdminUser:     exportConfig.AdminUser,
+			AdminPassword: exportConfig.AdminPassword,
+		}, tlsConfig)
+	}
+
+	namespace, name, found := strings.Cut(exportConfig.Grafana, "/")
+	if !found || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid --grafana %q, expected <namespace>/<name>", exportConfig.Grafana)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("creating kubernetes client: %w", err)
+	}
+
+	instance := &v1beta1.Grafana{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, instance); err != nil {
+		return nil, fmt.Errorf("fetching Grafana %s: %w", exportConfig.Grafana, err)
+	}
+
+	if exportConfig.URL != "" {
+		instance.Status.AdminURL = exportConfig.URL
+	}
+
+	return grafanaclient.NewGeneratedGrafanaClient(ctx, cl, instance)
+}
+
+// writeFiles writes every object into <dir>/<kind>/<name>.yaml
+func writeFiles(dir string, objects []client.Object) error {
+	for _, obj := range objects {
+		kindDir := filepath.Join(dir, strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind))
+		if err := os.MkdirAll(kindDir, 0o755); err != nil {
+			return err
+		}
+
+		f, err := os.Create(filepath.Join(kindDir, obj.GetName()+".yaml"))
+		if err != nil {
+			return err
+		}
+
+		err = export.WriteYAML(f, []client.Object{obj})
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -37,14 +39,13 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 )
 
 const (
 	conditionAlertGroupSynchronized = "AlertGroupSynchronized"
 	conditionReasonInvalidDuration  = "InvalidDuration"
 
-	LogMsgMissingFolderReference = "folder uid not found, AlertRuleGroup must include a folder reference (folderUID/folderRef)"
+	LogMsgMissingFolderReference = "folder uid not found, AlertRuleGroup must include a folder reference (folderUID/folderRef/folderPath)"
 	LogMsgConvertingToAPIModel   = "failed to convert GrafanaAlertRuleGroup to Grafana model"
 )
 
@@ -127,7 +128,8 @@ func (r *GrafanaAlertRuleGroupReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgResolvingFolderUID, err)
 	}
 
-	if folderUID == "" {
+	// folders of folderPath are resolved for each instance
+	if folderUID == "" && cr.Spec.FolderPath == "" {
 		log.Error(err, LogMsgMissingFolderReference)
 		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgMissingFolderReference, err)
 	}
@@ -151,8 +153,10 @@ func (r *GrafanaAlertRuleGroupReconciler) Reconcile(ctx context.Context, req ctr
 
 	applyErrors := make(map[string]string)
 
+	drift := newDriftTracker(r.Cfg, "GrafanaAlertRuleGroup", cr, cr.Spec.GrafanaCommonSpec)
+
 	for _, grafana := range instances {
-		err := r.reconcileWithInstance(ctx, &grafana, cr, &mGroup, disableProvenance)
+		err := r.reconcileWithInstance(ctx, &grafana, cr, &mGroup, disableProvenance, drift)
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 		}
@@ -160,6 +164,7 @@ func (r *GrafanaAlertRuleGroupReconciler) Reconcile(ctx context.Context, req ctr
 
 	condition := buildSynchronizedCondition("Alert Rule Group", conditionAlertGroupSynchronized, cr.Generation, applyErrors, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	drift.finish(&cr.Status.Conditions, cr.Generation, len(instances))
 
 	if len(applyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
@@ -277,6 +282,47 @@ func (r *GrafanaAlertRuleGroupReconciler) matchesStateInGrafana(exists bool, mod
 	return matchesRemoteState
 }
 
+// alertRuleGroupDiff lists the interval and the rules differing from the group in Grafana.
+// Rules are matched by uid, fields maintained by Grafana are not compared
+func alertRuleGroupDiff(model, remote *models.AlertRuleGroup) ([]string, error) {
+	var diff []string
+
+	if model.Interval != remote.Interval {
+		diff = append(diff, "interval")
+	}
+
+	remoteRules := make(map[string]*models.ProvisionedAlertRule, len(remote.Rules))
+	for _, rule := range remote.Rules {
+		remoteRules[rule.UID] = rule
+	}
+
+	for _, rule := range model.Rules {
+		remoteRule, ok := remoteRules[rule.UID]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("rules[%s]", rule.UID))
+			continue
+		}
+
+		delete(remoteRules, rule.UID)
+
+		fields, err := diffFields(rule, remoteRule, "id", "orgID", "provenance", "updated")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range fields {
+			diff = append(diff, fmt.Sprintf("rules[%s].%s", rule.UID, field))
+		}
+	}
+
+	// Rules added in Grafana
+	for _, uid := range slices.Sorted(maps.Keys(remoteRules)) {
+		diff = append(diff, fmt.Sprintf("rules[%s]", uid))
+	}
+
+	return diff, nil
+}
+
 func normalizeAlertRuleGroupForComparison(group *models.AlertRuleGroup) *models.AlertRuleGroup {
 	if group == nil {
 		return nil
@@ -295,14 +341,28 @@ func normalizeAlertRuleGroupForComparison(group *models.AlertRuleGroup) *models.
 	return &normalized
 }
 
-func (r *GrafanaAlertRuleGroupReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaAlertRuleGroup, mGroup *models.AlertRuleGroup, disableProvenance *string) error {
+func (r *GrafanaAlertRuleGroupReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaAlertRuleGroup, mGroup *models.AlertRuleGroup, disableProvenance *string, drift *driftTracker) error {
 	log := logf.FromContext(ctx)
 
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return fmt.Errorf("building grafana client: %w", err)
 	}
 
+	if cr.Spec.FolderPath != "" {
+		folderUID, err := getOrCreateFolderPath(ctx, r.Client, gClient, cr, cr.Spec.FolderPath)
+		if err != nil {
+			return err
+		}
+
+		group, err := crToModel(cr, folderUID)
+		if err != nil {
+			return err
+		}
+
+		mGroup = &group
+	}
+
 	folderUID := mGroup.FolderUID
 
 	_, err = gClient.Folders.GetFolderByUID(folderUID) //nolint:errcheck
@@ -323,6 +383,20 @@ func (r *GrafanaAlertRuleGroupReconciler) reconcileWithInstance(ctx context.Cont
 
 	exists := applied != nil
 
+	if exists {
+		skip, err := drift.skipsUpdate(instance, func() ([]string, error) {
+			return alertRuleGroupDiff(mGroup, applied.Payload)
+		})
+		if err != nil {
+			return err
+		}
+
+		if skip {
+			log.V(1).Info("alert rule group exists, skipping update due to drift policy", "driftPolicy", drift.policy)
+			return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource())
+		}
+	}
+
 	matchesStateInGrafana := r.matchesStateInGrafana(exists, mGroup, applied)
 
 	if matchesStateInGrafana {
@@ -401,19 +475,31 @@ func (r *GrafanaAlertRuleGroupReconciler) finalize(ctx context.Context, cr *v1be
 	}
 
 	for _, instance := range instances {
-		// Skip cleanup in instances
-		if isCleanupInGrafanaRequired {
-			gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, &instance)
-			if err != nil {
-				return fmt.Errorf("building grafana client: %w", err)
+		gClient, err := newOrgScopedClient(ctx, r.Client, &instance, cr.Namespace, cr.Spec.OrgRef)
+		if err != nil && !isOrgRemoved(err) {
+			return fmt.Errorf("building grafana client: %w", err)
+		}
+
+		// Skip cleanup in instances, the rule groups of removed organizations are gone with them
+		if isCleanupInGrafanaRequired && err == nil {
+			instanceFolderUID := folderUID
+			if cr.Spec.FolderPath != "" {
+				instanceFolderUID, _, err = walkFolderPath(gClient, cr.Spec.FolderPath, false)
+				if err != nil {
+					return err
+				}
 			}
 
-			_, err = gClient.Provisioning.DeleteAlertRuleGroup(cr.GroupName(), folderUID) //nolint:errcheck
-			if err != nil {
-				if IsNotErrorType[*provisioning.DeleteAlertRuleGroupNotFound](err) {
-					return fmt.Errorf("deleting alert rule group: %w", err)
+			if instanceFolderUID != "" {
+				_, err = gClient.Provisioning.DeleteAlertRuleGroup(cr.GroupName(), instanceFolderUID) //nolint:errcheck
+				if err != nil {
+					if IsNotErrorType[*provisioning.DeleteAlertRuleGroupNotFound](err) {
+						return fmt.Errorf("deleting alert rule group: %w", err)
+					}
 				}
 			}
+
+			deleteManagedFolders(ctx, gClient, cr)
 		}
 
 		// Update grafana instance Status
@@ -423,6 +509,8 @@ func (r *GrafanaAlertRuleGroupReconciler) finalize(ctx context.Context, cr *v1be
 		}
 	}
 
+	clearDriftMetrics("GrafanaAlertRuleGroup", driftResource(cr))
+
 	return nil
 }
 
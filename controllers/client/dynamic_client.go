@@ -39,7 +39,22 @@ func instanceNamespace(instance *v1beta1.Grafana) string {
 	return "default"
 }
 
+// orgNamespace maps an organization to the namespace used by the Grafana apiserver
+func orgNamespace(instance *v1beta1.Grafana, orgID int64) string {
+	if orgID <= 1 {
+		return instanceNamespace(instance)
+	}
+
+	return fmt.Sprintf("org-%d", orgID)
+}
+
 func NewDynamicClient(ctx context.Context, cl client.Client, cr *v1beta1.Grafana) (*DynamicClient, error) {
+	return NewDynamicClientForOrg(ctx, cl, cr, 0)
+}
+
+// NewDynamicClientForOrg returns a client defaulting to the namespace of the given organization.
+// An orgID of 0 uses the namespace of the instance.
+func NewDynamicClientForOrg(ctx context.Context, cl client.Client, cr *v1beta1.Grafana, orgID int64) (*DynamicClient, error) {
 	config, err := restConfigFor(ctx, cl, cr)
 	if err != nil {
 		return nil, fmt.Errorf("building rest config for client: %w", err)
@@ -58,7 +73,7 @@ func NewDynamicClient(ctx context.Context, cl client.Client, cr *v1beta1.Grafana
 	return &DynamicClient{
 		Interface:                dynamicClient,
 		discoveryClient:          dc,
-		defaultResourceNamespace: instanceNamespace(cr),
+		defaultResourceNamespace: orgNamespace(cr, orgID),
 	}, nil
 }
 
@@ -144,12 +159,34 @@ func (c *DynamicClient) Apply(ctx context.Context, obj *unstructured.Unstructure
 	return nil
 }
 
+// Get returns the current state of obj, nil when it does not exist
+func (c *DynamicClient) Get(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	gvr, err := c.LookupGVR(obj.GetAPIVersion(), obj.GetKind())
+	if err != nil {
+		return nil, fmt.Errorf("looking up api endpoints: %w", err)
+	}
+
+	existing, err := c.Resource(gvr).Namespace(c.NamespaceFor(obj)).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("fetching existing resource: %w", err)
+	}
+
+	return existing, nil
+}
+
 func (c *DynamicClient) ApplyObject(ctx context.Context, obj runtime.Object) error {
+	return c.Apply(ctx, ToUnstructured(obj))
+}
+
+// ToUnstructured converts a typed kubernetes resource through its json representation
+func ToUnstructured(obj runtime.Object) *unstructured.Unstructured {
 	enc, _ := json.Marshal(obj) //nolint:errcheck // cannot fail as it's from the serialized kubernetes resource
 	out := &unstructured.Unstructured{}
 	_ = json.Unmarshal(enc, out) //nolint:errcheck // unmarshaling previously marshaled object with required fields
 
-	return c.Apply(ctx, out)
+	return out
 }
 
 func (c *DynamicClient) delete(ctx context.Context, gvr schema.GroupVersionResource, name, namespace string) error {
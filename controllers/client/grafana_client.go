@@ -2,7 +2,10 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"time"
@@ -14,7 +17,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+var ErrOrgScopedAPIKey = errors.New("organization scoped requests require admin user credentials, api keys are bound to a single organization")
+
 func NewGeneratedGrafanaClient(ctx context.Context, cl client.Client, cr *v1beta1.Grafana) (*genapi.GrafanaHTTPAPI, error) {
+	return NewGeneratedGrafanaClientForOrg(ctx, cl, cr, 0)
+}
+
+// NewGeneratedGrafanaClientForOrg returns a client scoped to the given organization through the X-Grafana-Org-Id header.
+// An orgID of 0 uses the default organization of the credentials.
+func NewGeneratedGrafanaClientForOrg(ctx context.Context, cl client.Client, cr *v1beta1.Grafana, orgID int64) (*genapi.GrafanaHTTPAPI, error) {
 	tlsConfig, err := buildTLSConfiguration(ctx, cl, cr)
 	if err != nil {
 		return nil, fmt.Errorf("building tls config: %w", err)
@@ -33,6 +44,46 @@ func NewGeneratedGrafanaClient(ctx context.Context, cl client.Client, cr *v1beta
 		return nil, err
 	}
 
+	return newGeneratedGrafanaClient(ctx, gURL, credentials, httpClient, tlsConfig, orgID)
+}
+
+// Credentials are the static credentials used to connect to a Grafana instance that is not backed by a Grafana CR.
+// APIKey is preferred over AdminUser and AdminPassword when both are set.
+type Credentials struct {
+	APIKey        string
+	AdminUser     string
+	AdminPassword string
+}
+
+// NewGeneratedGrafanaClientWithCredentials returns a client for the Grafana instance at adminURL without looking up a Grafana CR,
+// e.g. for command line tools. A nil tlsConfig uses DefaultTLSConfiguration.
+func NewGeneratedGrafanaClientWithCredentials(ctx context.Context, adminURL string, credentials Credentials, tlsConfig *tls.Config) (*genapi.GrafanaHTTPAPI, error) {
+	if tlsConfig == nil {
+		tlsConfig = DefaultTLSConfiguration
+	}
+
+	gURL, err := ParseAdminURL(adminURL)
+	if err != nil {
+		return nil, err
+	}
+
+	adminCredentials := &grafanaAdminCredentials{}
+	if credentials.APIKey != "" {
+		adminCredentials.apikey = credentials.APIKey
+	} else {
+		adminCredentials.adminUser = credentials.AdminUser
+		adminCredentials.adminPassword = credentials.AdminPassword
+	}
+
+	httpClient := &http.Client{
+		Transport: NewInstrumentedRoundTripper(true, tlsConfig),
+		Timeout:   10 * time.Second,
+	}
+
+	return newGeneratedGrafanaClient(ctx, gURL, adminCredentials, httpClient, tlsConfig, 0)
+}
+
+func newGeneratedGrafanaClient(ctx context.Context, gURL *url.URL, credentials *grafanaAdminCredentials, httpClient *http.Client, tlsConfig *tls.Config, orgID int64) (*genapi.GrafanaHTTPAPI, error) {
 	cfg := &genapi.TransportConfig{
 		Schemes:  []string{gURL.Scheme},
 		BasePath: gURL.Path,
@@ -48,6 +99,15 @@ func NewGeneratedGrafanaClient(ctx context.Context, cl client.Client, cr *v1beta
 		cfg.BasicAuth = url.UserPassword(credentials.adminUser, credentials.adminPassword)
 	}
 
+	if orgID != 0 {
+		// API keys and service account tokens are bound to the organization they were created in
+		if credentials.apikey != "" {
+			return nil, ErrOrgScopedAPIKey
+		}
+
+		cfg.OrgID = orgID
+	}
+
 	gClient := genapi.NewHTTPClientWithConfig(nil, cfg)
 
 	runtime, ok := gClient.Transport.(*httptransport.Runtime)
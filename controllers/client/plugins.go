@@ -0,0 +1,161 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+)
+
+// Plugins are managed through the plugin admin API of Grafana, it is not part of the generated client
+const (
+	PluginsEndpoint         = "/plugins"
+	PluginInstallEndpoint   = "/plugins/{pluginId}/install"
+	PluginUninstallEndpoint = "/plugins/{pluginId}/uninstall"
+	PluginErrorsEndpoint    = "/plugins/errors"
+)
+
+// InstalledPlugin is a plugin installed in Grafana, core plugins are not listed
+type InstalledPlugin struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Signature string `json:"signature"`
+	Info      struct {
+		Version string `json:"version"`
+	} `json:"info"`
+}
+
+type pluginResponse struct {
+	code int
+	data []byte
+}
+
+// ListInstalledPlugins returns the plugins installed in Grafana, excluding core plugins
+func ListInstalledPlugins(gClient *genapi.GrafanaHTTPAPI) ([]InstalledPlugin, error) {
+	resp, err := submitPluginRequest(gClient, "listPlugins", PluginsEndpoint, http.MethodGet, "", map[string]string{"core": "0"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.code != http.StatusOK {
+		return nil, fmt.Errorf("listing plugins failed with status %d: %s", resp.code, upstreamMessage(resp.data))
+	}
+
+	plugins := []InstalledPlugin{}
+	if err := json.Unmarshal(resp.data, &plugins); err != nil {
+		return nil, fmt.Errorf("parsing plugins: %w", err)
+	}
+
+	return plugins, nil
+}
+
+// InstallPlugin installs or upgrades a plugin from the plugin catalog, an empty version installs the latest one.
+// Installing the version which is already installed is a no-op
+func InstallPlugin(gClient *genapi.GrafanaHTTPAPI, id, version string) error {
+	body := map[string]string{}
+	if version != "" {
+		body["version"] = version
+	}
+
+	resp, err := submitPluginRequest(gClient, "installPlugin", PluginInstallEndpoint, http.MethodPost, id, nil, body)
+	if err != nil {
+		return err
+	}
+
+	// 409 is returned if the version is already installed
+	if resp.code != http.StatusOK && resp.code != http.StatusConflict {
+		return fmt.Errorf("installing plugin %s failed with status %d: %s", id, resp.code, upstreamMessage(resp.data))
+	}
+
+	return nil
+}
+
+// UninstallPlugin removes a plugin, plugins which are not installed are ignored
+func UninstallPlugin(gClient *genapi.GrafanaHTTPAPI, id string) error {
+	resp, err := submitPluginRequest(gClient, "uninstallPlugin", PluginUninstallEndpoint, http.MethodPost, id, nil, map[string]string{})
+	if err != nil {
+		return err
+	}
+
+	if resp.code != http.StatusOK && resp.code != http.StatusNotFound {
+		return fmt.Errorf("uninstalling plugin %s failed with status %d: %s", id, resp.code, upstreamMessage(resp.data))
+	}
+
+	return nil
+}
+
+func submitPluginRequest(gClient *genapi.GrafanaHTTPAPI, operation, path, method, id string, query map[string]string, body any) (*pluginResponse, error) {
+	out, err := gClient.Transport.Submit(&runtime.ClientOperation{
+		ID:                 operation,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{runtime.JSONMime},
+		ConsumesMediaTypes: []string{runtime.JSONMime},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			if id != "" {
+				if err := r.SetPathParam("pluginId", id); err != nil {
+					return err
+				}
+			}
+
+			for k, v := range query {
+				if err := r.SetQueryParam(k, v); err != nil {
+					return err
+				}
+			}
+
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(resp runtime.ClientResponse, _ runtime.Consumer) (any, error) {
+			data, err := io.ReadAll(resp.Body())
+			if err != nil {
+				return nil, fmt.Errorf("reading response: %w", err)
+			}
+
+			return &pluginResponse{code: resp.Code(), data: data}, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := out.(*pluginResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", out)
+	}
+
+	return resp, nil
+}
+
+// PluginError is a plugin Grafana failed to load, e.g. due to a missing or invalid signature
+type PluginError struct {
+	PluginID  string `json:"pluginId"`
+	ErrorCode string `json:"errorCode"`
+}
+
+// ListPluginErrors returns the plugins Grafana failed to load
+func ListPluginErrors(gClient *genapi.GrafanaHTTPAPI) ([]PluginError, error) {
+	resp, err := submitPluginRequest(gClient, "listPluginErrors", PluginErrorsEndpoint, http.MethodGet, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.code != http.StatusOK {
+		return nil, fmt.Errorf("listing plugin errors failed with status %d: %s", resp.code, upstreamMessage(resp.data))
+	}
+
+	pluginErrors := []PluginError{}
+	if err := json.Unmarshal(resp.data, &pluginErrors); err != nil {
+		return nil, fmt.Errorf("parsing plugin errors: %w", err)
+	}
+
+	return pluginErrors, nil
+}
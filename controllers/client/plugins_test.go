@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlugins(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/plugins", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "0", r.URL.Query().Get("core"), "core plugins are excluded")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"grafana-clock-panel","type":"panel","signature":"valid","info":{"version":"2.1.8"}}]`)) //nolint:errcheck
+	})
+	mux.HandleFunc("POST /api/plugins/{id}/install", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("id") {
+		case "grafana-clock-panel":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message":"Plugin already installed"}`)) //nolint:errcheck
+		case "forbidden-app":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"Permission denied"}`)) //nolint:errcheck
+		}
+	})
+	mux.HandleFunc("POST /api/plugins/{id}/uninstall", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /api/plugins/errors", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"pluginId":"unsigned-panel","errorCode":"signatureMissing"}]`)) //nolint:errcheck
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL + "/api")
+	require.NoError(t, err)
+
+	gClient, err := newGeneratedGrafanaClient(context.Background(), u, &grafanaAdminCredentials{adminUser: "admin", adminPassword: "admin"}, http.DefaultClient, nil, 0)
+	require.NoError(t, err)
+
+	plugins, err := ListInstalledPlugins(gClient)
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "grafana-clock-panel", plugins[0].ID)
+	assert.Equal(t, "2.1.8", plugins[0].Info.Version)
+
+	require.NoError(t, InstallPlugin(gClient, "grafana-clock-panel", "2.1.8"), "installed versions are a no-op")
+	require.NoError(t, InstallPlugin(gClient, "grafana-piechart-panel", ""))
+	require.ErrorContains(t, InstallPlugin(gClient, "forbidden-app", "1.0.0"), "status 403: Permission denied")
+
+	require.NoError(t, UninstallPlugin(gClient, "grafana-piechart-panel"), "missing plugins are already gone")
+
+	pluginErrors, err := ListPluginErrors(gClient)
+	require.NoError(t, err)
+	assert.Equal(t, []PluginError{{PluginID: "unsigned-panel", ErrorCode: "signatureMissing"}}, pluginErrors)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+)
+
+// ReceiverTestEndpoint sends a test notification through receivers, it is not part of the generated client
+const ReceiverTestEndpoint = "/alertmanager/grafana/config/api/v1/receivers/test"
+
+// ReceiverTestStatusOK is the status of receivers which delivered the test notification
+const ReceiverTestStatusOK = "ok"
+
+// TestedReceiver is a receiver of a contact point as expected by the receiver test endpoint
+type TestedReceiver struct {
+	UID                   string `json:"uid"`
+	Name                  string `json:"name"`
+	Type                  string `json:"type"`
+	Settings              any    `json:"settings"`
+	DisableResolveMessage bool   `json:"disableResolveMessage"`
+}
+
+// ReceiverTestResult is the outcome of the test notification of a single receiver
+type ReceiverTestResult struct {
+	UID    string `json:"uid"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type receiverTestBody struct {
+	Receivers []receiverTestGroup `json:"receivers"`
+}
+
+type receiverTestGroup struct {
+	Name    string           `json:"name"`
+	Configs []TestedReceiver `json:"grafana_managed_receiver_configs"`
+}
+
+type receiverTestResponse struct {
+	Receivers []struct {
+		Configs []ReceiverTestResult `json:"grafana_managed_receiver_configs"`
+	} `json:"receivers"`
+}
+
+// TestReceivers sends a test notification through each receiver of the contact point name.
+// Settings omitted from the receivers are taken from the receivers with the same uid in Grafana.
+// Failed deliveries are reported in the results, errors are only returned if the test could not be run
+func TestReceivers(gClient *genapi.GrafanaHTTPAPI, name string, receivers []TestedReceiver) ([]ReceiverTestResult, error) {
+	body := receiverTestBody{
+		Receivers: []receiverTestGroup{{Name: name, Configs: receivers}},
+	}
+
+	out, err := gClient.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "testReceivers",
+		Method:             http.MethodPost,
+		PathPattern:        ReceiverTestEndpoint,
+		ProducesMediaTypes: []string{runtime.JSONMime},
+		ConsumesMediaTypes: []string{runtime.JSONMime},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			return r.SetBodyParam(body)
+		}),
+		Reader: runtime.ClientResponseReaderFunc(readReceiverTestResponse),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results, ok := out.([]ReceiverTestResult)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", out)
+	}
+
+	return results, nil
+}
+
+func readReceiverTestResponse(resp runtime.ClientResponse, _ runtime.Consumer) (any, error) {
+	data, err := io.ReadAll(resp.Body())
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	// 207 is returned when some of the receivers failed
+	if resp.Code() != http.StatusOK && resp.Code() != http.StatusMultiStatus {
+		return nil, fmt.Errorf("testing receivers failed with status %d: %s", resp.Code(), upstreamMessage(data))
+	}
+
+	var parsed receiverTestResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parsing receiver test results: %w", err)
+	}
+
+	results := []ReceiverTestResult{}
+	for _, r := range parsed.Receivers {
+		results = append(results, r.Configs...)
+	}
+
+	return results, nil
+}
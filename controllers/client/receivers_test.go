@@ -0,0 +1,121 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReceiverTestServer emulates the receiver test endpoint of Grafana, webhooks are delivered to the url in their settings
+func newReceiverTestServer(t *testing.T) *url.URL {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api"+ReceiverTestEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		var body receiverTestBody
+
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil || len(body.Receivers) == 0 || len(body.Receivers[0].Configs) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"invalid receivers"}`)) //nolint:errcheck
+
+			return
+		}
+
+		status := http.StatusOK
+		results := []ReceiverTestResult{}
+
+		for _, rec := range body.Receivers[0].Configs {
+			result := ReceiverTestResult{UID: rec.UID, Name: rec.Name, Status: ReceiverTestStatusOK}
+
+			settings, _ := rec.Settings.(map[string]any)
+			target, _ := settings["url"].(string)
+
+			resp, err := http.Post(target, "application/json", bytes.NewBufferString(`{"status":"firing"}`)) //nolint:noctx
+			if err == nil {
+				resp.Body.Close()
+
+				if resp.StatusCode >= 300 {
+					err = &url.Error{Op: "Post", URL: target, Err: http.ErrNotSupported}
+				}
+			}
+
+			if err != nil {
+				result.Status = "failed"
+				result.Error = err.Error()
+				status = http.StatusMultiStatus
+			}
+
+			results = append(results, result)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+
+		json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+			"receivers": []map[string]any{
+				{"name": body.Receivers[0].Name, "grafana_managed_receiver_configs": results},
+			},
+		})
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL + "/api")
+	require.NoError(t, err)
+
+	return u
+}
+
+func TestTestReceivers(t *testing.T) {
+	received := 0
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received++
+	}))
+	t.Cleanup(webhook.Close)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(failing.Close)
+
+	gClient, err := newGeneratedGrafanaClient(context.Background(), newReceiverTestServer(t), &grafanaAdminCredentials{adminUser: "admin", adminPassword: "admin"}, http.DefaultClient, nil, 0)
+	require.NoError(t, err)
+
+	t.Run("all receivers deliver", func(t *testing.T) {
+		results, err := TestReceivers(gClient, "team", []TestedReceiver{
+			{UID: "a", Name: "team", Type: "webhook", Settings: map[string]any{"url": webhook.URL}},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, ReceiverTestStatusOK, results[0].Status)
+		assert.Equal(t, 1, received)
+	})
+
+	t.Run("failed receivers are reported", func(t *testing.T) {
+		results, err := TestReceivers(gClient, "team", []TestedReceiver{
+			{UID: "a", Name: "team", Type: "webhook", Settings: map[string]any{"url": webhook.URL}},
+			{UID: "b", Name: "team", Type: "webhook", Settings: map[string]any{"url": failing.URL}},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, ReceiverTestStatusOK, results[0].Status)
+		assert.Equal(t, "b", results[1].UID)
+		assert.Equal(t, "failed", results[1].Status)
+		assert.Contains(t, results[1].Error, failing.URL)
+	})
+
+	t.Run("invalid requests return the upstream message", func(t *testing.T) {
+		_, err := TestReceivers(gClient, "team", nil)
+		require.ErrorContains(t, err, "status 400: invalid receivers")
+	})
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+)
+
+// Silences are managed through the Alertmanager API of Grafana, they are not part of the generated client
+const (
+	SilencesEndpoint = "/alertmanager/grafana/api/v2/silences"
+	SilenceEndpoint  = "/alertmanager/grafana/api/v2/silence/{silenceId}"
+)
+
+// States of a silence as reported by the Alertmanager
+const (
+	SilenceStatePending = "pending"
+	SilenceStateActive  = "active"
+	SilenceStateExpired = "expired"
+)
+
+var ErrSilenceNotFound = errors.New("silence not found")
+
+type SilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+type SilenceStatus struct {
+	State string `json:"state"`
+}
+
+// Silence is a silence of the Grafana Alertmanager, the status is only set on silences returned by Grafana
+type Silence struct {
+	ID        string           `json:"id,omitempty"`
+	Matchers  []SilenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+	Status    *SilenceStatus   `json:"status,omitempty"`
+}
+
+type silenceResponse struct {
+	code int
+	data []byte
+}
+
+// GetSilence returns the silence with id, ErrSilenceNotFound is returned once the Alertmanager forgot about it
+func GetSilence(gClient *genapi.GrafanaHTTPAPI, id string) (*Silence, error) {
+	resp, err := submitSilenceRequest(gClient, "getSilence", http.MethodGet, SilenceEndpoint, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.code {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrSilenceNotFound
+	default:
+		return nil, fmt.Errorf("getting silence failed with status %d: %s", resp.code, upstreamMessage(resp.data))
+	}
+
+	silence := &Silence{}
+	if err := json.Unmarshal(resp.data, silence); err != nil {
+		return nil, fmt.Errorf("parsing silence: %w", err)
+	}
+
+	return silence, nil
+}
+
+// PostSilence creates a silence, or updates it if its id is set, and returns its id.
+// The Alertmanager replaces silences which can't be updated in place, the returned id may hence differ from silence.ID
+func PostSilence(gClient *genapi.GrafanaHTTPAPI, silence *Silence) (string, error) {
+	resp, err := submitSilenceRequest(gClient, "postSilence", http.MethodPost, SilencesEndpoint, "", silence)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.code != http.StatusOK && resp.code != http.StatusAccepted {
+		return "", fmt.Errorf("posting silence failed with status %d: %s", resp.code, upstreamMessage(resp.data))
+	}
+
+	parsed := struct {
+		SilenceID string `json:"silenceID"`
+	}{}
+	if err := json.Unmarshal(resp.data, &parsed); err != nil {
+		return "", fmt.Errorf("parsing silence id: %w", err)
+	}
+
+	return parsed.SilenceID, nil
+}
+
+// DeleteSilence expires the silence with id, silences which no longer exist are ignored
+func DeleteSilence(gClient *genapi.GrafanaHTTPAPI, id string) error {
+	resp, err := submitSilenceRequest(gClient, "deleteSilence", http.MethodDelete, SilenceEndpoint, id, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.code != http.StatusOK && resp.code != http.StatusNotFound {
+		return fmt.Errorf("deleting silence failed with status %d: %s", resp.code, upstreamMessage(resp.data))
+	}
+
+	return nil
+}
+
+func submitSilenceRequest(gClient *genapi.GrafanaHTTPAPI, operation, method, path, id string, body any) (*silenceResponse, error) {
+	out, err := gClient.Transport.Submit(&runtime.ClientOperation{
+		ID:                 operation,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{runtime.JSONMime},
+		ConsumesMediaTypes: []string{runtime.JSONMime},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			if id != "" {
+				if err := r.SetPathParam("silenceId", id); err != nil {
+					return err
+				}
+			}
+
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(resp runtime.ClientResponse, _ runtime.Consumer) (any, error) {
+			data, err := io.ReadAll(resp.Body())
+			if err != nil {
+				return nil, fmt.Errorf("reading response: %w", err)
+			}
+
+			return &silenceResponse{code: resp.Code(), data: data}, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := out.(*silenceResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", out)
+	}
+
+	return resp, nil
+}
+
+// upstreamMessage extracts the error message of a Grafana response, falling back to the raw body
+func upstreamMessage(data []byte) string {
+	message := struct {
+		Message string `json:"message"`
+	}{}
+	if json.Unmarshal(data, &message) != nil || message.Message == "" {
+		return string(data)
+	}
+
+	return message.Message
+}
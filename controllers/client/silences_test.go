@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSilences(t *testing.T) {
+	silences := map[string]*Silence{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api"+SilencesEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		silence := &Silence{}
+
+		err := json.NewDecoder(r.Body).Decode(silence)
+		if err != nil || len(silence.Matchers) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"silence must have at least one matcher"}`)) //nolint:errcheck
+
+			return
+		}
+
+		if silence.ID == "" {
+			silence.ID = "new"
+		}
+
+		silence.Status = &SilenceStatus{State: SilenceStateActive}
+		silences[silence.ID] = silence
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"silenceID": silence.ID}) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /api/alertmanager/grafana/api/v2/silence/{id}", func(w http.ResponseWriter, r *http.Request) {
+		silence, ok := silences[r.PathValue("id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(silence) //nolint:errcheck
+	})
+	mux.HandleFunc("DELETE /api/alertmanager/grafana/api/v2/silence/{id}", func(w http.ResponseWriter, r *http.Request) {
+		silence, ok := silences[r.PathValue("id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		silence.Status.State = SilenceStateExpired
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL + "/api")
+	require.NoError(t, err)
+
+	gClient, err := newGeneratedGrafanaClient(context.Background(), u, &grafanaAdminCredentials{adminUser: "admin", adminPassword: "admin"}, http.DefaultClient, nil, 0)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	silence := &Silence{
+		Matchers: []SilenceMatcher{{Name: "namespace", Value: "databases", IsEqual: true}},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+		Comment:  "maintenance",
+	}
+
+	id, err := PostSilence(gClient, silence)
+	require.NoError(t, err)
+	assert.Equal(t, "new", id)
+
+	got, err := GetSilence(gClient, id)
+	require.NoError(t, err)
+	assert.Equal(t, silence.Matchers, got.Matchers)
+	assert.True(t, silence.EndsAt.Equal(got.EndsAt))
+	assert.Equal(t, SilenceStateActive, got.Status.State)
+
+	require.NoError(t, DeleteSilence(gClient, id))
+
+	got, err = GetSilence(gClient, id)
+	require.NoError(t, err)
+	assert.Equal(t, SilenceStateExpired, got.Status.State)
+
+	_, err = GetSilence(gClient, "missing")
+	require.ErrorIs(t, err, ErrSilenceNotFound)
+
+	require.NoError(t, DeleteSilence(gClient, "missing"), "missing silences are already gone")
+
+	_, err = PostSilence(gClient, &Silence{})
+	require.ErrorContains(t, err, "status 400: silence must have at least one matcher")
+}
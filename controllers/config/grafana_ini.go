@@ -65,6 +65,27 @@ func SetDefaults(cfg map[string]map[string]string, version string) map[string]ma
 	return cfg
 }
 
+// SetRendering points Grafana to a remote image renderer, values already present in the config take precedence
+func SetRendering(cfg map[string]map[string]string, serverURL, callbackURL string) map[string]map[string]string {
+	if cfg == nil {
+		cfg = make(map[string]map[string]string)
+	}
+
+	if cfg["rendering"] == nil {
+		cfg["rendering"] = make(map[string]string)
+	}
+
+	if cfg["rendering"]["server_url"] == "" {
+		cfg["rendering"]["server_url"] = serverURL
+	}
+
+	if cfg["rendering"]["callback_url"] == "" {
+		cfg["rendering"]["callback_url"] = callbackURL
+	}
+
+	return cfg
+}
+
 func WriteIni(cfg map[string]map[string]string) string {
 	sections := make([]string, 0, len(cfg))
 	hasGlobal := false
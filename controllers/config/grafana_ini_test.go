@@ -201,3 +201,29 @@ func TestGetHash(t *testing.T) {
 		assert.Equal(t, want, got)
 	})
 }
+
+func TestSetRendering(t *testing.T) {
+	t.Run("Adds renderer urls", func(t *testing.T) {
+		got := SetRendering(nil, "http://localhost:8081/render", "http://localhost:3000/")
+
+		assert.Equal(t, map[string]map[string]string{
+			"rendering": {
+				"server_url":   "http://localhost:8081/render",
+				"callback_url": "http://localhost:3000/",
+			},
+		}, got)
+	})
+
+	t.Run("Config values take precedence", func(t *testing.T) {
+		cfg := map[string]map[string]string{
+			"rendering": {
+				"callback_url": "https://grafana.example.com/",
+			},
+		}
+
+		got := SetRendering(cfg, "http://localhost:8081/render", "http://localhost:3000/")
+
+		assert.Equal(t, "http://localhost:8081/render", got["rendering"]["server_url"])
+		assert.Equal(t, "https://grafana.example.com/", got["rendering"]["callback_url"])
+	})
+}
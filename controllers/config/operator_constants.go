@@ -5,13 +5,28 @@ const (
 	GrafanaImage   = "docker.io/grafana/grafana"
 	GrafanaVersion = "13.1.3"
 
+	// Init container installing plugins from archives, runs the operator image
+	GrafanaPluginInstallerContainerName = "install-plugins"
+	OperatorImage                       = "ghcr.io/grafana/grafana-operator"
+
+	// Image renderer
+	GrafanaRendererImage           = "docker.io/grafana/grafana-image-renderer"
+	GrafanaRendererVersion         = "3.12.9"
+	GrafanaRendererPort        int = 8081
+	GrafanaRendererPortName        = "renderer"
+	GrafanaRendererTokenEnvVar     = "GF_RENDERING_RENDERER_TOKEN" // #nosec G101
+	RendererAuthTokenEnvVar        = "AUTH_TOKEN"                  // #nosec G101
+	RendererTokenSecretKey         = "token"
+
 	// Paths
 	GrafanaDataPath               = "/var/lib/grafana"
 	GrafanaLogsPath               = "/var/log/grafana"
 	GrafanaPluginsPath            = "/var/lib/grafana/plugins"
+	GrafanaPluginArchivesPath     = "/etc/grafana-plugin-archives"
 	GrafanaProvisioningPath       = "/etc/grafana/provisioning/"
 	GrafanaTmpPath                = "/tmp"
 	GrafanaDashboardsRuntimeBuild = "/tmp/dashboards"
+	GrafanaContentGitCache        = "/tmp/dashboards/git"
 
 	// Default limits
 	GrafanaDashboardVersionsToKeep = "20"
@@ -24,6 +39,10 @@ const (
 	GrafanaAdminPasswordEnvVar = "GF_SECURITY_ADMIN_PASSWORD" // #nosec G101
 	GrafanaPluginsEnvVar       = "GF_INSTALL_PLUGINS"
 
+	// Database credentials of high availability setups
+	GrafanaDatabaseUserEnvVar     = "GF_DATABASE_USER"
+	GrafanaDatabasePasswordEnvVar = "GF_DATABASE_PASSWORD" // #nosec G101
+
 	// grafana-operator env vars
 	GrafanaTestVersionEnvVar = "GF_TEST_CONTAINER_VERSION"
 
@@ -37,6 +56,7 @@ const (
 	// Data storage
 	GrafanaProvisionPluginVolumeName    = "grafana-provision-plugins"
 	GrafanaPluginsVolumeName            = "grafana-plugins"
+	GrafanaPluginArchiveVolumePrefix    = "grafana-plugin-archive"
 	GrafanaProvisionDashboardVolumeName = "grafana-provision-dashboards"
 	GrafanaProvisionNotifierVolumeName  = "grafana-provision-notifiers"
 	GrafanaLogsVolumeName               = "grafana-logs"
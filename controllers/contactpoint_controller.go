@@ -21,11 +21,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -49,9 +53,18 @@ const (
 	conditionContactPointSynchronized  = "ContactPointSynchronized"
 	conditionReasonInvalidSettings     = "InvalidSettings"
 	conditionReasonInvalidContactPoint = "InvalidContactPoint"
+	conditionContactPointVerified      = "Verified"
+	conditionReasonVerificationSuccess = "VerificationSucceeded"
+	conditionReasonVerificationFailed  = "VerificationFailed"
+
+	// annotationVerifyContactPoint requests a single test of the receivers, it is removed once the test ran
+	annotationVerifyContactPoint = "operator.grafana.com/verify"
 
 	LogMsgContactPointSettings = "building contactpoint settings"
 	LogMsgInvalidContactPoint  = "invalid Contact Point spec"
+
+	// redactedContactPointSetting replaces secure settings in responses of the provisioning API
+	redactedContactPointSetting = "[REDACTED]"
 )
 
 var ErrMissingContactPointReceiver = errors.New("at least one receiver is needed to create a contact point")
@@ -159,8 +172,10 @@ func (r *GrafanaContactPointReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	applyErrors := make(map[string]string)
 
+	drift := newDriftTracker(r.Cfg, "GrafanaContactPoint", cr, cr.Spec.GrafanaCommonSpec)
+
 	for _, grafana := range instances {
-		err := r.reconcileWithInstance(ctx, &grafana, cr, settings)
+		err := r.reconcileWithInstance(ctx, &grafana, cr, settings, drift)
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 		}
@@ -168,6 +183,7 @@ func (r *GrafanaContactPointReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	condition := buildSynchronizedCondition("Contact point", conditionContactPointSynchronized, cr.Generation, applyErrors, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	drift.finish(&cr.Status.Conditions, cr.Generation, len(instances))
 
 	if len(applyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
@@ -176,13 +192,144 @@ func (r *GrafanaContactPointReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgApplyErrors, err)
 	}
 
+	switch {
+	case verificationRequested(cr):
+		r.verify(ctx, cr, instances, settings)
+	case !cr.Spec.Verify:
+		// results of a one-off test are kept until the spec changes
+		verified := meta.FindStatusCondition(cr.Status.Conditions, conditionContactPointVerified)
+		if verified != nil && verified.ObservedGeneration != cr.Generation {
+			meta.RemoveStatusCondition(&cr.Status.Conditions, conditionContactPointVerified)
+		}
+	}
+
 	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
 }
 
-func (r *GrafanaContactPointReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaContactPoint, settings []models.JSON) error {
+// verificationRequested is true if the receivers were not tested since the spec changed or a test was requested with an annotation
+func verificationRequested(cr *v1beta1.GrafanaContactPoint) bool {
+	if cr.GetAnnotations()[annotationVerifyContactPoint] != "" {
+		return true
+	}
+
+	if !cr.Spec.Verify {
+		return false
+	}
+
+	verified := meta.FindStatusCondition(cr.Status.Conditions, conditionContactPointVerified)
+
+	return verified == nil || verified.ObservedGeneration != cr.Generation
+}
+
+// verify sends test notifications through the receivers in all instances and sets the Verified condition.
+// Failed deliveries do not fail the reconciliation, the contact point was applied regardless
+func (r *GrafanaContactPointReconciler) verify(ctx context.Context, cr *v1beta1.GrafanaContactPoint, instances []v1beta1.Grafana, settings []models.JSON) {
 	log := logf.FromContext(ctx)
 
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	// outcome per instance, nil when all receivers delivered the test notification
+	results := make(map[string]error, len(instances))
+
+	for _, grafana := range instances {
+		gClient, err := newOrgScopedClient(ctx, r.Client, &grafana, cr.Namespace, cr.Spec.OrgRef)
+		if err == nil {
+			err = verifyReceivers(gClient, cr, settings)
+		}
+
+		results[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err
+	}
+
+	condition := buildVerifiedCondition(cr.Generation, results)
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+
+	if condition.Status == metav1.ConditionFalse {
+		log.Info("contact point verification failed", "results", condition.Message)
+	}
+
+	err := removeAnnotation(ctx, r.Client, cr, annotationVerifyContactPoint)
+	if err != nil {
+		log.Error(err, "removing verify annotation")
+	}
+}
+
+// verifyReceivers sends a test notification through each receiver, the errors of all failed receivers are returned
+func verifyReceivers(gClient *genapi.GrafanaHTTPAPI, cr *v1beta1.GrafanaContactPoint, settings []models.JSON) error {
+	receivers := make([]grafanaclient.TestedReceiver, 0, len(cr.Spec.Receivers))
+	receiverTypes := make(map[string]string, len(cr.Spec.Receivers))
+
+	for i, rec := range cr.Spec.Receivers {
+		uid := rec.GetGrafanaUID(cr.UID, i)
+		receiverTypes[uid] = rec.Type
+
+		receivers = append(receivers, grafanaclient.TestedReceiver{
+			UID:                   uid,
+			Name:                  cr.NameFromSpecOrMeta(),
+			Type:                  rec.Type,
+			Settings:              settings[i],
+			DisableResolveMessage: rec.DisableResolveMessage,
+		})
+	}
+
+	results, err := grafanaclient.TestReceivers(gClient, cr.NameFromSpecOrMeta(), receivers)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, result := range results {
+		if result.Status != grafanaclient.ReceiverTestStatusOK {
+			errs = append(errs, fmt.Errorf("receiver %s (%s): %s", result.UID, receiverTypes[result.UID], result.Error))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// buildVerifiedCondition lists the outcome of the test notifications of every instance, the condition is only true
+// if the receivers delivered them in all instances
+func buildVerifiedCondition(generation int64, results map[string]error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               conditionContactPointVerified,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Time{
+			Time: time.Now(),
+		},
+	}
+
+	failed := 0
+
+	var sb strings.Builder
+
+	for _, key := range slices.Sorted(maps.Keys(results)) {
+		if results[key] == nil {
+			fmt.Fprintf(&sb, "\n- %s: delivered", key)
+			continue
+		}
+
+		failed++
+
+		fmt.Fprintf(&sb, "\n- %s: %s", key, results[key])
+	}
+
+	if failed == 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = conditionReasonVerificationSuccess
+		condition.Message = fmt.Sprintf("Test notifications were delivered by all receivers in %d instances:%s", len(results), sb.String())
+
+		return condition
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = conditionReasonVerificationFailed
+	condition.Message = fmt.Sprintf("Test notifications failed for %d out of %d instances:%s", failed, len(results), sb.String())
+
+	return condition
+}
+
+func (r *GrafanaContactPointReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaContactPoint, settings []models.JSON, drift *driftTracker) error {
+	log := logf.FromContext(ctx)
+
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return fmt.Errorf("building grafana client: %w", err)
 	}
@@ -194,6 +341,20 @@ func (r *GrafanaContactPointReconciler) reconcileWithInstance(ctx context.Contex
 
 	log.V(1).Info("contact point receivers found", "count", len(remoteReceivers))
 
+	if len(remoteReceivers) > 0 {
+		skip, err := drift.skipsUpdate(instance, func() ([]string, error) {
+			return contactPointDiff(cr, settings, remoteReceivers)
+		})
+		if err != nil {
+			return err
+		}
+
+		if skip {
+			log.V(1).Info("contact point exists, skipping update due to drift policy", "driftPolicy", drift.policy)
+			return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource())
+		}
+	}
+
 	for i, rec := range cr.Spec.Receivers {
 		recUID := rec.GetGrafanaUID(cr.UID, i)
 		existingIdx := -1
@@ -254,6 +415,73 @@ func (r *GrafanaContactPointReconciler) reconcileWithInstance(ctx context.Contex
 	return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource())
 }
 
+// contactPointDiff lists the receivers and receiver fields differing from the receivers in Grafana.
+// Secure settings are redacted by Grafana and cannot be compared
+func contactPointDiff(cr *v1beta1.GrafanaContactPoint, settings []models.JSON, remoteReceivers []*models.EmbeddedContactPoint) ([]string, error) {
+	var diff []string
+
+	remoteByUID := make(map[string]*models.EmbeddedContactPoint, len(remoteReceivers))
+	for _, rec := range remoteReceivers {
+		remoteByUID[rec.UID] = rec
+	}
+
+	for i, rec := range cr.Spec.Receivers {
+		recUID := rec.GetGrafanaUID(cr.UID, i)
+
+		remote, ok := remoteByUID[recUID]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("receivers[%s]", recUID))
+			continue
+		}
+
+		delete(remoteByUID, recUID)
+
+		if remote.Type == nil || *remote.Type != rec.Type {
+			diff = append(diff, fmt.Sprintf("receivers[%s].type", recUID))
+		}
+
+		if remote.DisableResolveMessage != rec.DisableResolveMessage {
+			diff = append(diff, fmt.Sprintf("receivers[%s].disableResolveMessage", recUID))
+		}
+
+		desiredSettings, err := toJSONObject(settings[i])
+		if err != nil {
+			return nil, err
+		}
+
+		remoteSettings, err := toJSONObject(remote.Settings)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := slices.Collect(maps.Keys(desiredSettings))
+		for key := range remoteSettings {
+			if _, ok := desiredSettings[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			if remoteSettings[key] == redactedContactPointSetting {
+				continue
+			}
+
+			if !reflect.DeepEqual(desiredSettings[key], remoteSettings[key]) {
+				diff = append(diff, fmt.Sprintf("receivers[%s].settings.%s", recUID, key))
+			}
+		}
+	}
+
+	// Receivers added in Grafana
+	for _, recUID := range slices.Sorted(maps.Keys(remoteByUID)) {
+		diff = append(diff, fmt.Sprintf("receivers[%s]", recUID))
+	}
+
+	return diff, nil
+}
+
 func (r *GrafanaContactPointReconciler) TopLevelReceiverFallback(cr *v1beta1.GrafanaContactPoint) error {
 	// Skip Spec level receiver when list is set
 	if len(cr.Spec.Receivers) > 0 {
@@ -335,20 +563,23 @@ func (r *GrafanaContactPointReconciler) finalize(ctx context.Context, cr *v1beta
 	}
 
 	for _, instance := range instances {
-		gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, &instance)
-		if err != nil {
+		gClient, err := newOrgScopedClient(ctx, r.Client, &instance, cr.Namespace, cr.Spec.OrgRef)
+		if err != nil && !isOrgRemoved(err) {
 			return fmt.Errorf("building grafana client: %w", err)
 		}
 
-		remoteReceivers, err := r.getReceivers(gClient, cr)
-		if err != nil {
-			return err
-		}
-
-		for _, rec := range remoteReceivers {
-			_, err = gClient.Provisioning.DeleteContactpoints(rec.UID) //nolint:errcheck
+		// Skip cleanup in instances, the contact points of removed organizations are gone with them
+		if err == nil {
+			remoteReceivers, err := r.getReceivers(gClient, cr)
 			if err != nil {
-				return fmt.Errorf("deleting contact point: %w", err)
+				return err
+			}
+
+			for _, rec := range remoteReceivers {
+				_, err = gClient.Provisioning.DeleteContactpoints(rec.UID) //nolint:errcheck
+				if err != nil {
+					return fmt.Errorf("deleting contact point: %w", err)
+				}
 			}
 		}
 
@@ -359,6 +590,8 @@ func (r *GrafanaContactPointReconciler) finalize(ctx context.Context, cr *v1beta
 		}
 	}
 
+	clearDriftMetrics("GrafanaContactPoint", driftResource(cr))
+
 	return nil
 }
 
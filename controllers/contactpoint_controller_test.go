@@ -1,15 +1,24 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -438,3 +447,133 @@ func TestContactPointIndexing(t *testing.T) {
 		require.Empty(t, result)
 	})
 }
+
+func TestVerificationRequested(t *testing.T) {
+	verified := metav1.Condition{Type: conditionContactPointVerified, Status: metav1.ConditionTrue, ObservedGeneration: 2}
+
+	tests := []struct {
+		name        string
+		verify      bool
+		annotations map[string]string
+		generation  int64
+		conditions  []metav1.Condition
+		want        bool
+	}{
+		{
+			name: "verify disabled",
+			want: false,
+		},
+		{
+			name:        "requested by annotation",
+			annotations: map[string]string{annotationVerifyContactPoint: "now"},
+			generation:  2,
+			conditions:  []metav1.Condition{verified},
+			want:        true,
+		},
+		{
+			name:       "not verified yet",
+			verify:     true,
+			generation: 1,
+			want:       true,
+		},
+		{
+			name:       "verified generation",
+			verify:     true,
+			generation: 2,
+			conditions: []metav1.Condition{verified},
+			want:       false,
+		},
+		{
+			name:       "spec changed",
+			verify:     true,
+			generation: 3,
+			conditions: []metav1.Condition{verified},
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1beta1.GrafanaContactPoint{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations, Generation: tt.generation},
+				Spec:       v1beta1.GrafanaContactPointSpec{Verify: tt.verify},
+			}
+			cr.Status.Conditions = tt.conditions
+
+			assert.Equal(t, tt.want, verificationRequested(cr))
+		})
+	}
+}
+
+func TestVerifyReceivers(t *testing.T) {
+	var requested map[string]any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api"+grafanaclient.ReceiverTestEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&requested) //nolint:errcheck
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"receivers":[{"name":"team","grafana_managed_receiver_configs":[` + //nolint:errcheck
+			`{"uid":"first","status":"ok"},` +
+			`{"uid":"uid_1","status":"failed","error":"failed to send notification: 401 Unauthorized"}]}]}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	gClient := genapi.NewHTTPClientWithConfig(nil, &genapi.TransportConfig{Host: u.Host, BasePath: "/api", Schemes: []string{"http"}})
+
+	cr := &v1beta1.GrafanaContactPoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", UID: "uid"},
+		Spec: v1beta1.GrafanaContactPointSpec{
+			Receivers: []v1beta1.ContactPointReceiver{
+				{CustomUID: "first", Type: "webhook"},
+				{Type: "slack"},
+			},
+		},
+	}
+
+	settings := []models.JSON{
+		map[string]any{"url": "http://localhost/webhook"},
+		map[string]any{"recipient": "#alerts"},
+	}
+
+	err = verifyReceivers(gClient, cr, settings)
+	require.EqualError(t, err, "receiver uid_1 (slack): failed to send notification: 401 Unauthorized")
+
+	require.Contains(t, requested, "receivers")
+	assert.JSONEq(t, `[{"name":"team","grafana_managed_receiver_configs":[`+
+		`{"uid":"first","name":"team","type":"webhook","settings":{"url":"http://localhost/webhook"},"disableResolveMessage":false},`+
+		`{"uid":"uid_1","name":"team","type":"slack","settings":{"recipient":"#alerts"},"disableResolveMessage":false}]}]`,
+		mustMarshal(t, requested["receivers"]))
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestBuildVerifiedCondition(t *testing.T) {
+	condition := buildVerifiedCondition(3, map[string]error{"default/a": nil, "default/b": nil})
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, conditionReasonVerificationSuccess, condition.Reason)
+	assert.Equal(t, int64(3), condition.ObservedGeneration)
+	assert.Equal(t, "Test notifications were delivered by all receivers in 2 instances:\n- default/a: delivered\n- default/b: delivered", condition.Message)
+
+	condition = buildVerifiedCondition(3, map[string]error{
+		"default/c": errors.New("receiver uid_0 (slack): timeout"),
+		"default/b": nil,
+		"default/a": errors.New("receiver uid_0 (slack): 401 Unauthorized"),
+	})
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, conditionReasonVerificationFailed, condition.Reason)
+	assert.Equal(t, "Test notifications failed for 2 out of 3 instances:\n- default/a: receiver uid_0 (slack): 401 Unauthorized\n- default/b: delivered\n- default/c: receiver uid_0 (slack): timeout", condition.Message)
+}
@@ -1,16 +1,36 @@
 package cache
 
 import (
-	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/metrics"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// DefaultMaxSize is the default size limit of the content cache in bytes
+const DefaultMaxSize int64 = 256 << 20
+
+var store Store = NewLRUStore(DefaultMaxSize)
+
+// SetStore replaces the store of the content cache, it must be called before any controller starts
+func SetStore(s Store) {
+	store = s
+}
+
+// storeKey identifies content by its source and digest
+func storeKey(url, digest string) string {
+	return url + "@" + digest
+}
+
+func digest(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
 func GetContentCache(cr v1beta1.GrafanaContentResource) []byte {
 	spec := cr.GrafanaContentSpec()
 	if spec == nil {
@@ -22,10 +42,39 @@ func GetContentCache(cr v1beta1.GrafanaContentResource) []byte {
 		return nil
 	}
 
-	return getContentCache(status, spec.URL, spec.ContentCacheDuration.Duration)
+	return getContentCache(status, contentURL(spec), spec.ContentCacheDuration.Duration)
 }
 
-// getContentCache returns content cache when the following conditions are met: url is the same, data is not expired, gzipped data is not corrupted
+// GetExpiredContentCache returns the content cache regardless of its age, used when a conditional request confirmed it is unchanged
+func GetExpiredContentCache(cr v1beta1.GrafanaContentResource) []byte {
+	spec := cr.GrafanaContentSpec()
+	if spec == nil {
+		return nil
+	}
+
+	status := cr.GrafanaContentStatus()
+	if status == nil {
+		return nil
+	}
+
+	return getContentCache(status, contentURL(spec), 0)
+}
+
+// contentURL identifies the remote source of the cached content, changing the source invalidates the cache
+func contentURL(spec *v1beta1.GrafanaContentSpec) string {
+	if spec.Git != nil {
+		return fmt.Sprintf("%s#%s:%s", spec.Git.URL, spec.Git.Revision(), spec.Git.Path)
+	}
+
+	if spec.OCI != nil {
+		return fmt.Sprintf("oci://%s#%s", spec.OCI.Reference, spec.OCI.Path)
+	}
+
+	return spec.URL
+}
+
+// getContentCache returns content cache when the following conditions are met: url is the same, data is not expired,
+// content is present in the store and matches the digest
 func getContentCache(in *v1beta1.GrafanaContentStatus, url string, cacheDuration time.Duration) []byte {
 	if in.ContentURL != url {
 		return []byte{}
@@ -36,8 +85,23 @@ func getContentCache(in *v1beta1.GrafanaContentStatus, url string, cacheDuration
 		return []byte{}
 	}
 
-	cache, err := Gunzip(in.ContentCache)
-	if err != nil {
+	// content cached in the status by previous versions of the operator
+	if in.ContentDigest == "" {
+		cache, err := Gunzip(in.ContentCache)
+		if err != nil {
+			return []byte{}
+		}
+
+		return cache
+	}
+
+	compressed, ok := store.Get(storeKey(url, in.ContentDigest))
+	if !ok {
+		return []byte{}
+	}
+
+	cache, err := Gunzip(compressed)
+	if err != nil || digest(cache) != in.ContentDigest {
 		return []byte{}
 	}
 
@@ -48,7 +112,15 @@ func SetContentCache(cr v1beta1.GrafanaContentResource, data map[string]any) err
 	spec := cr.GrafanaContentSpec()
 	status := cr.GrafanaContentStatus()
 
-	return setContentCache(status, spec.URL, data, spec.ContentCacheDuration.Duration)
+	return setContentCache(status, contentURL(spec), data, spec.ContentCacheDuration.Duration)
+}
+
+// SetRawContentCache caches content as fetched, used for templates which are rendered again on every reconcile
+func SetRawContentCache(cr v1beta1.GrafanaContentResource, content []byte) error {
+	spec := cr.GrafanaContentSpec()
+	status := cr.GrafanaContentStatus()
+
+	return setRawContentCache(status, contentURL(spec), content, spec.ContentCacheDuration.Duration)
 }
 
 func setContentCache(in *v1beta1.GrafanaContentStatus, url string, data map[string]any, cacheDuration time.Duration) error {
@@ -58,19 +130,40 @@ func setContentCache(in *v1beta1.GrafanaContentStatus, url string, data map[stri
 		return fmt.Errorf("marshaling content: %w", err)
 	}
 
-	gz, err := Gzip(encoded)
-	if err != nil {
-		return fmt.Errorf("compressing content: %w", err)
-	}
+	return setRawContentCache(in, url, encoded, cacheDuration)
+}
+
+func setRawContentCache(in *v1beta1.GrafanaContentStatus, url string, encoded []byte, cacheDuration time.Duration) error {
+	contentDigest := digest(encoded)
+	key := storeKey(url, contentDigest)
+
+	_, stored := store.Get(key)
 
 	notExpired := cacheDuration <= 0 || in.ContentTimestamp.Add(cacheDuration).After(time.Now())
+	unchanged := in.ContentURL == url && in.ContentDigest == contentDigest
 
-	// NOTE: as encoded and compressed result should always be same, we can rely on bytes.Equal
-	if notExpired && bytes.Equal(in.ContentCache, gz) && in.ContentURL == url {
+	if notExpired && unchanged && stored {
 		return nil
 	}
 
-	in.ContentCache = gz
+	switch {
+	case stored && !unchanged:
+		// another resource already cached the same content
+		metrics.ContentCacheDeduplicated.Inc()
+	case !stored:
+		gz, err := Gzip(encoded)
+		if err != nil {
+			return fmt.Errorf("compressing content: %w", err)
+		}
+
+		err = store.Set(key, gz)
+		if err != nil {
+			return err
+		}
+	}
+
+	in.ContentCache = nil
+	in.ContentDigest = contentDigest
 	in.ContentTimestamp = metav1.Time{Time: time.Now()}
 	in.ContentURL = url
 
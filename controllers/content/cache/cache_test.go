@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -110,8 +113,8 @@ func TestSetContentCache(t *testing.T) {
 	gz1, err := Gzip(j1)
 	require.NoError(t, err)
 
-	gz2, err := Gzip(j2)
-	require.NoError(t, err)
+	d1 := digest(j1)
+	d2 := digest(j2)
 
 	now := metav1.NewTime(time.Now())
 	hourAgo := metav1.NewTime(time.Now().Add(-time.Hour))
@@ -121,6 +124,7 @@ func TestSetContentCache(t *testing.T) {
 		url             string
 		data            map[string]any
 		contentDuration time.Duration
+		stored          bool
 		status          v1beta1.GrafanaContentStatus
 		want            v1beta1.GrafanaContentStatus
 	}{
@@ -131,7 +135,7 @@ func TestSetContentCache(t *testing.T) {
 			contentDuration: 24 * time.Hour,
 			status:          v1beta1.GrafanaContentStatus{},
 			want: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: now,
 				ContentURL:       url1,
 			},
@@ -141,13 +145,14 @@ func TestSetContentCache(t *testing.T) {
 			url:             url1,
 			data:            data1,
 			contentDuration: 24 * time.Hour,
+			stored:          true,
 			status: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: hourAgo,
 				ContentURL:       url1,
 			},
 			want: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: hourAgo,
 				ContentURL:       url1,
 			},
@@ -157,13 +162,14 @@ func TestSetContentCache(t *testing.T) {
 			url:             url2,
 			data:            data2,
 			contentDuration: 24 * time.Hour,
+			stored:          true,
 			status: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: hourAgo,
 				ContentURL:       url1,
 			},
 			want: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz2,
+				ContentDigest:    d2,
 				ContentTimestamp: now,
 				ContentURL:       url2,
 			},
@@ -173,45 +179,46 @@ func TestSetContentCache(t *testing.T) {
 			url:             url1,
 			data:            data1,
 			contentDuration: 5 * time.Minute,
+			stored:          true,
 			status: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: hourAgo,
 				ContentURL:       url1,
 			},
 			want: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: now,
 				ContentURL:       url1,
 			},
 		},
 		{
-			name:            "corrupted cache (broken gzip): cache is updated",
+			name:            "evicted cache: cache is updated",
 			url:             url1,
 			data:            data1,
 			contentDuration: 24 * time.Hour,
 			status: v1beta1.GrafanaContentStatus{
-				ContentCache:     []byte{1, 2, 3},
+				ContentDigest:    d1,
 				ContentTimestamp: hourAgo,
 				ContentURL:       url1,
 			},
 			want: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: now,
 				ContentURL:       url1,
 			},
 		},
 		{
-			name:            "corrupted cache (missing content): cache is updated",
+			name:            "legacy cache (content in status): content is moved to the store",
 			url:             url1,
 			data:            data1,
 			contentDuration: 24 * time.Hour,
 			status: v1beta1.GrafanaContentStatus{
-				// ContentCache:     gz1,
+				ContentCache:     gz1,
 				ContentTimestamp: hourAgo,
 				ContentURL:       url1,
 			},
 			want: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: now,
 				ContentURL:       url1,
 			},
@@ -221,13 +228,14 @@ func TestSetContentCache(t *testing.T) {
 			url:             url1,
 			data:            data1,
 			contentDuration: 24 * time.Hour,
+			stored:          true,
 			status: v1beta1.GrafanaContentStatus{
-				ContentCache: gz1,
+				ContentDigest: d1,
 				// ContentTimestamp: hourAgo,
 				ContentURL: url1,
 			},
 			want: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: now,
 				ContentURL:       url1,
 			},
@@ -238,12 +246,12 @@ func TestSetContentCache(t *testing.T) {
 			data:            data1,
 			contentDuration: 24 * time.Hour,
 			status: v1beta1.GrafanaContentStatus{
-				ContentCache:     []byte{},
+				ContentDigest:    d1,
 				ContentTimestamp: hourAgo,
 				// ContentURL:       url1,
 			},
 			want: v1beta1.GrafanaContentStatus{
-				ContentCache:     gz1,
+				ContentDigest:    d1,
 				ContentTimestamp: now,
 				ContentURL:       url1,
 			},
@@ -252,18 +260,90 @@ func TestSetContentCache(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			useStore(t, NewLRUStore(DefaultMaxSize))
+
+			if tt.stored {
+				err := store.Set(storeKey(tt.status.ContentURL, tt.status.ContentDigest), gz1)
+				require.NoError(t, err)
+			}
+
 			status := tt.status.DeepCopy()
 
 			err := setContentCache(status, tt.url, tt.data, tt.contentDuration)
 			require.NoError(t, err)
 
-			assert.Equal(t, tt.want.ContentCache, status.ContentCache)
+			assert.Nil(t, status.ContentCache, "content must not be kept in the status")
+			assert.Equal(t, tt.want.ContentDigest, status.ContentDigest)
 			assert.WithinDuration(t, tt.want.ContentTimestamp.Time, status.ContentTimestamp.Time, 5*time.Second)
 			assert.Equal(t, tt.want.ContentURL, status.ContentURL)
+
+			assert.Equal(t, tt.data, mustUnmarshal(t, getContentCache(status, tt.url, tt.contentDuration)))
 		})
 	}
 }
 
+// useStore replaces the store for the duration of the test
+func useStore(t *testing.T, s Store) {
+	t.Helper()
+
+	previous := store
+	store = s
+
+	t.Cleanup(func() {
+		store = previous
+	})
+}
+
+func mustUnmarshal(t *testing.T, content []byte) map[string]any {
+	t.Helper()
+
+	var data map[string]any
+
+	err := json.Unmarshal(content, &data)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestContentCacheDeduplication(t *testing.T) {
+	useStore(t, NewLRUStore(DefaultMaxSize))
+
+	url := "http://localhost:8080/shared.json"
+	data := map[string]any{"title": "Shared"}
+
+	first := &v1beta1.GrafanaContentStatus{}
+	second := &v1beta1.GrafanaContentStatus{}
+
+	before := counterValue(t, metrics.ContentCacheDeduplicated)
+
+	err := setContentCache(first, url, data, time.Hour)
+	require.NoError(t, err)
+	assert.InDelta(t, before, counterValue(t, metrics.ContentCacheDeduplicated), 0)
+
+	err = setContentCache(second, url, data, time.Hour)
+	require.NoError(t, err)
+	assert.InDelta(t, before+1, counterValue(t, metrics.ContentCacheDeduplicated), 0)
+	assert.Equal(t, first.ContentDigest, second.ContentDigest)
+
+	// refreshing the own entry is no deduplication
+	second.ContentTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+	err = setContentCache(second, url, data, time.Hour)
+	require.NoError(t, err)
+	assert.InDelta(t, before+1, counterValue(t, metrics.ContentCacheDeduplicated), 0)
+}
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	t.Helper()
+
+	m := &dto.Metric{}
+
+	err := c.Write(m)
+	require.NoError(t, err)
+
+	return m.GetCounter().GetValue()
+}
+
 func TestSetAndGetContent(t *testing.T) {
 	status := &v1beta1.GrafanaContentStatus{}
 
@@ -282,3 +362,63 @@ func TestSetAndGetContent(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestGetContentCacheFromStore(t *testing.T) {
+	url := "http://localhost:8080/1.json"
+
+	j, err := json.Marshal(map[string]any{"title": "Test1"})
+	require.NoError(t, err)
+
+	gz, err := Gzip(j)
+	require.NoError(t, err)
+
+	status := &v1beta1.GrafanaContentStatus{
+		ContentDigest:    digest(j),
+		ContentTimestamp: metav1.Now(),
+		ContentURL:       url,
+	}
+
+	t.Run("stored content is returned", func(t *testing.T) {
+		useStore(t, NewLRUStore(DefaultMaxSize))
+		require.NoError(t, store.Set(storeKey(url, status.ContentDigest), gz))
+
+		assert.Equal(t, j, getContentCache(status, url, time.Hour))
+	})
+
+	t.Run("evicted content is a miss", func(t *testing.T) {
+		useStore(t, NewLRUStore(DefaultMaxSize))
+
+		assert.Empty(t, getContentCache(status, url, time.Hour))
+	})
+
+	t.Run("content not matching the digest is a miss", func(t *testing.T) {
+		useStore(t, NewLRUStore(DefaultMaxSize))
+
+		other, err := Gzip([]byte(`{"title":"Other"}`))
+		require.NoError(t, err)
+		require.NoError(t, store.Set(storeKey(url, status.ContentDigest), other))
+
+		assert.Empty(t, getContentCache(status, url, time.Hour))
+	})
+}
+
+func TestGitContentCache(t *testing.T) {
+	cr := &v1beta1.GrafanaDashboard{
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				Git: &v1beta1.GrafanaContentGit{URL: "https://github.com/team/dashboards.git", Ref: "main", Path: "board.json"},
+			},
+		},
+	}
+
+	data := map[string]any{"title": "Test1"}
+
+	err := SetContentCache(cr, data)
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/team/dashboards.git#main:board.json", cr.Status.ContentURL)
+	assert.NotEmpty(t, GetContentCache(cr))
+
+	cr.Spec.Git.Ref = "release"
+
+	assert.Empty(t, GetContentCache(cr), "changing the ref must invalidate the cache")
+}
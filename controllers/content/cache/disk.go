@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DiskStore is a Store keeping content in files below dir. Once maxSize bytes are exceeded,
+// the least recently used files are removed
+type DiskStore struct {
+	mu      sync.Mutex
+	dir     string
+	maxSize int64
+}
+
+var _ Store = (*DiskStore)(nil)
+
+func NewDiskStore(dir string, maxSize int64) (*DiskStore, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("creating content cache directory: %w", err)
+	}
+
+	return &DiskStore{
+		dir:     dir,
+		maxSize: maxSize,
+	}, nil
+}
+
+func (s *DiskStore) path(key string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%x", sha256.Sum256([]byte(key))))
+}
+
+func (s *DiskStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path(key)
+
+	value, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	// the modification time tracks the last use for eviction
+	now := time.Now()
+	os.Chtimes(path, now, now) //nolint:errcheck
+
+	return value, true
+}
+
+func (s *DiskStore) Set(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path(key)
+
+	// write to a temporary file first so that readers never observe partial content
+	tmp, err := os.CreateTemp(s.dir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("writing content cache: %w", err)
+	}
+
+	_, err = tmp.Write(value)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name()) //nolint:errcheck
+		return fmt.Errorf("writing content cache: %w", err)
+	}
+
+	return s.evict(path)
+}
+
+// evict removes the least recently used files until the cache fits into maxSize, keep is never removed
+func (s *DiskStore) evict(keep string) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("listing content cache: %w", err)
+	}
+
+	type file struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var (
+		files []file
+		size  int64
+	)
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		files = append(files, file{path: filepath.Join(s.dir, entry.Name()), size: info.Size(), modTime: info.ModTime()})
+		size += info.Size()
+	}
+
+	slices.SortFunc(files, func(a, b file) int {
+		return a.modTime.Compare(b.modTime)
+	})
+
+	for _, f := range files {
+		if size <= s.maxSize {
+			break
+		}
+
+		if f.path == keep {
+			continue
+		}
+
+		err := os.Remove(f.path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("evicting content cache: %w", err)
+		}
+
+		size -= f.size
+	}
+
+	return nil
+}
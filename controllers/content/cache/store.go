@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Store keeps fetched content outside of the CR status so that resources referencing the same source share one copy.
+// Keys identify the source and the digest of the content
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte) error
+}
+
+// LRUStore is an in-memory Store evicting the least recently used content once maxSize bytes are exceeded
+type LRUStore struct {
+	mu      sync.Mutex
+	maxSize int64
+	size    int64
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+var _ Store = (*LRUStore)(nil)
+
+func NewLRUStore(maxSize int64) *LRUStore {
+	return &LRUStore{
+		maxSize: maxSize,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (s *LRUStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	s.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry).value, true //nolint:errcheck
+}
+
+func (s *LRUStore) Set(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		entry := element.Value.(*lruEntry) //nolint:errcheck
+		s.size += int64(len(value)) - int64(len(entry.value))
+		entry.value = value
+		s.order.MoveToFront(element)
+	} else {
+		s.entries[key] = s.order.PushFront(&lruEntry{key: key, value: value})
+		s.size += int64(len(value))
+	}
+
+	// always keep the most recent entry, even if it exceeds maxSize on its own
+	for s.size > s.maxSize && s.order.Len() > 1 {
+		oldest := s.order.Back()
+		entry := oldest.Value.(*lruEntry) //nolint:errcheck
+
+		s.order.Remove(oldest)
+		delete(s.entries, entry.key)
+		s.size -= int64(len(entry.value))
+	}
+
+	return nil
+}
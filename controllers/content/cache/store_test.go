@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUStore(t *testing.T) {
+	s := NewLRUStore(10)
+
+	require.NoError(t, s.Set("a", []byte("aaaa")))
+	require.NoError(t, s.Set("b", []byte("bbbb")))
+
+	// a becomes the most recently used entry
+	got, ok := s.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("aaaa"), got)
+
+	require.NoError(t, s.Set("c", []byte("cccc")))
+
+	_, ok = s.Get("b")
+	assert.False(t, ok, "least recently used entry must be evicted")
+
+	_, ok = s.Get("a")
+	assert.True(t, ok)
+
+	_, ok = s.Get("c")
+	assert.True(t, ok)
+
+	// entries larger than maxSize are kept until the next Set
+	require.NoError(t, s.Set("d", []byte("dddddddddddd")))
+
+	got, ok = s.Get("d")
+	require.True(t, ok)
+	assert.Equal(t, []byte("dddddddddddd"), got)
+
+	_, ok = s.Get("a")
+	assert.False(t, ok)
+}
+
+func TestDiskStore(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewDiskStore(dir, 10)
+	require.NoError(t, err)
+
+	_, ok := s.Get("a")
+	assert.False(t, ok)
+
+	require.NoError(t, s.Set("a", []byte("aaaa")))
+	require.NoError(t, s.Set("b", []byte("bbbb")))
+
+	got, ok := s.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("aaaa"), got)
+
+	// modification times track usage, make the order explicit
+	hourAgo := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(s.path("b"), hourAgo, hourAgo))
+
+	require.NoError(t, s.Set("c", []byte("cccc")))
+
+	_, ok = s.Get("b")
+	assert.False(t, ok, "least recently used file must be evicted")
+
+	_, ok = s.Get("a")
+	assert.True(t, ok)
+
+	_, ok = s.Get("c")
+	assert.True(t, ok)
+
+	// content survives a restart of the operator
+	s, err = NewDiskStore(dir, 10)
+	require.NoError(t, err)
+
+	got, ok = s.Get("c")
+	require.True(t, ok)
+	assert.Equal(t, []byte("cccc"), got)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files must be left behind")
+}
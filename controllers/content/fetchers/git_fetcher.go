@@ -0,0 +1,348 @@
+package fetchers
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/config"
+	"github.com/grafana/grafana-operator/v5/controllers/content/cache"
+)
+
+const (
+	gitSSHPrivateKey = "ssh-privatekey"
+	gitKnownHosts    = "known_hosts"
+	gitToken         = "token"
+	gitUsername      = "username"
+
+	// gitFetchedRef holds the fetched revision in the clones
+	gitFetchedRef = plumbing.ReferenceName("refs/fetched")
+)
+
+// gitCacheDir holds the shallow clones shared by all content resources
+var gitCacheDir = config.GrafanaContentGitCache
+
+var gitRepositories = &gitRepositoryCache{entries: map[string]*gitRepository{}}
+
+type gitRepositoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*gitRepository
+}
+
+// gitRepository is a shallow clone of a single revision of a repository
+type gitRepository struct {
+	mu        sync.Mutex
+	dir       string
+	commit    string
+	fetchedAt time.Time
+}
+
+func (c *gitRepositoryCache) get(key string) *gitRepository {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	repo, ok := c.entries[key]
+	if !ok {
+		repo = &gitRepository{dir: filepath.Join(gitCacheDir, key)}
+		c.entries[key] = repo
+	}
+
+	return repo
+}
+
+// fresh reports whether the clone can be reused. Clones of a commit never change, clones of a ref are
+// reused within the cache duration of the requesting resource
+func (r *gitRepository) fresh(commit string, cacheDuration time.Duration) bool {
+	if r.commit == "" {
+		return false
+	}
+
+	if commit != "" {
+		return r.commit == commit
+	}
+
+	return cacheDuration > 0 && r.fetchedAt.Add(cacheDuration).After(time.Now())
+}
+
+// FetchFromGit returns the file referenced by spec.git and the SHA of the commit it was read from
+func FetchFromGit(ctx context.Context, cr v1beta1.GrafanaContentResource, cl client.Client) ([]byte, string, error) {
+	spec := cr.GrafanaContentSpec()
+	g := spec.Git
+
+	cached := cache.GetContentCache(cr)
+	if len(cached) > 0 {
+		return cached, cr.GrafanaContentStatus().GitCommit, nil
+	}
+
+	endpoint, err := gitEndpoint(g.URL)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid git url on %v/%v: %w", cr.GetNamespace(), cr.GetName(), err)
+	}
+
+	// credentials are part of the key so that resources without access to a Secret never read clones made with it
+	key := g.URL + "\x00" + g.Revision()
+	if g.SecretRef != nil {
+		key += "\x00" + cr.GetNamespace() + "/" + g.SecretRef.Name
+	}
+
+	repo := gitRepositories.get(fmt.Sprintf("%x", sha256.Sum256([]byte(key))))
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if !repo.fresh(g.Commit, spec.ContentCacheDuration.Duration) {
+		auth, err := gitAuth(ctx, cl, cr.GetNamespace(), endpoint, g)
+		if err != nil {
+			return nil, "", fmt.Errorf("resolve git credentials: %w", err)
+		}
+
+		commit, err := shallowFetch(ctx, repo.dir, g.URL, g.Revision(), auth)
+		if err != nil {
+			repo.commit = ""
+			return nil, "", fmt.Errorf("fetch %s from %s: %w", g.Revision(), g.URL, err)
+		}
+
+		repo.commit = commit
+		repo.fetchedAt = time.Now()
+	}
+
+	data, err := readGitFile(repo.dir, repo.commit, filepath.ToSlash(g.Path))
+	if err != nil {
+		return nil, "", fmt.Errorf("file %q not found in %s at %s: %w", g.Path, g.URL, repo.commit, err)
+	}
+
+	return data, repo.commit, nil
+}
+
+// gitEndpoint parses url, accepting https and ssh remotes only. Local paths and other transports would let
+// resources read repositories from the filesystem of the operator
+func gitEndpoint(url string) (*transport.Endpoint, error) {
+	endpoint, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Protocol != "https" && endpoint.Protocol != "ssh" {
+		return nil, fmt.Errorf("unsupported protocol %q, only https and ssh are supported", endpoint.Protocol)
+	}
+
+	return endpoint, nil
+}
+
+// shallowFetch fetches a single revision into an empty bare repository and returns the SHA of its commit
+func shallowFetch(ctx context.Context, dir, url, revision string, auth transport.AuthMethod) (string, error) {
+	err := os.RemoveAll(dir)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := git.PlainInit(dir, true)
+	if err != nil {
+		return "", err
+	}
+
+	remote, err := repo.CreateRemote(&gitconfig.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{url}})
+	if err != nil {
+		return "", err
+	}
+
+	source := revision
+	if !plumbing.IsHash(revision) {
+		refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+		if err != nil {
+			return "", err
+		}
+
+		name, err := resolveGitRef(refs, revision)
+		if err != nil {
+			return "", err
+		}
+
+		source = name.String()
+	}
+
+	err = remote.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: []gitconfig.RefSpec{gitconfig.RefSpec(source + ":" + gitFetchedRef.String())},
+		Depth:    1,
+		Auth:     auth,
+		Tags:     git.NoTags,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return "", err
+	}
+
+	ref, err := repo.Reference(gitFetchedRef, true)
+	if err != nil {
+		return "", err
+	}
+
+	// annotated tags point to a tag object instead of the commit
+	hash := ref.Hash()
+	if tag, err := repo.TagObject(hash); err == nil {
+		commit, err := tag.Commit()
+		if err != nil {
+			return "", err
+		}
+
+		hash = commit.Hash
+	}
+
+	return hash.String(), nil
+}
+
+// resolveGitRef returns the name of the remote reference matching ref, looking up HEAD, branches and tags
+// in the same order as git
+func resolveGitRef(refs []*plumbing.Reference, ref string) (plumbing.ReferenceName, error) {
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference, len(refs))
+	for _, r := range refs {
+		byName[r.Name()] = r
+	}
+
+	candidates := []plumbing.ReferenceName{
+		plumbing.ReferenceName(ref),
+		plumbing.NewBranchReferenceName(ref),
+		plumbing.NewTagReferenceName(ref),
+	}
+
+	for _, name := range candidates {
+		r, ok := byName[name]
+		if !ok {
+			continue
+		}
+
+		if r.Type() == plumbing.SymbolicReference {
+			if _, ok := byName[r.Target()]; !ok {
+				return "", fmt.Errorf("reference %s points to missing %s", name, r.Target())
+			}
+
+			return r.Target(), nil
+		}
+
+		return name, nil
+	}
+
+	return "", fmt.Errorf("reference %s not found", ref)
+}
+
+func readGitFile(dir, commit, path string) ([]byte, error) {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := c.File(path)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(contents), nil
+}
+
+// gitAuth returns the credentials of secretRef for endpoint, or nil to clone anonymously
+func gitAuth(ctx context.Context, cl client.Client, namespace string, endpoint *transport.Endpoint, g *v1beta1.GrafanaContentGit) (transport.AuthMethod, error) {
+	if g.SecretRef == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+
+	err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: g.SecretRef.Name}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Protocol != "ssh" {
+		token, ok := secret.Data[gitToken]
+		if !ok {
+			return nil, fmt.Errorf("secret %s/%s missing key %s", namespace, g.SecretRef.Name, gitToken)
+		}
+
+		username := "git"
+		if u := secret.Data[gitUsername]; len(u) > 0 {
+			username = string(u)
+		}
+
+		return &githttp.BasicAuth{Username: username, Password: string(token)}, nil
+	}
+
+	key, ok := secret.Data[gitSSHPrivateKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s missing key %s", namespace, g.SecretRef.Name, gitSSHPrivateKey)
+	}
+
+	// host keys are never trusted on first use
+	knownHosts, ok := secret.Data[gitKnownHosts]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s missing key %s, required to verify the host key of ssh remotes", namespace, g.SecretRef.Name, gitKnownHosts)
+	}
+
+	user := endpoint.User
+	if user == "" {
+		user = "git"
+	}
+
+	auth, err := gitssh.NewPublicKeys(user, key, "")
+	if err != nil {
+		return nil, fmt.Errorf("parse %s of secret %s/%s: %w", gitSSHPrivateKey, namespace, g.SecretRef.Name, err)
+	}
+
+	auth.HostKeyCallback, err = knownHostsCallback(knownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s of secret %s/%s: %w", gitKnownHosts, namespace, g.SecretRef.Name, err)
+	}
+
+	return auth, nil
+}
+
+// knownHostsCallback verifies host keys against knownHosts. The parser only reads files, the file is
+// removed once parsed
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	err := os.MkdirAll(gitCacheDir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp(gitCacheDir, "known_hosts-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck
+
+	_, err = f.Write(knownHosts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return knownhosts.New(f.Name())
+}
@@ -0,0 +1,345 @@
+package fetchers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	gitclient "github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
+)
+
+// testGitRepository is a work tree pushing to a bare repository served over https by git http-backend
+type testGitRepository struct {
+	t    *testing.T
+	work string
+	bare string
+	url  string
+}
+
+func newTestGitRepository(t *testing.T) *testGitRepository {
+	t.Helper()
+
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+
+	r := &testGitRepository{
+		t:    t,
+		work: filepath.Join(dir, "work"),
+		bare: filepath.Join(dir, "dashboards.git"),
+	}
+
+	r.git(dir, "init", "--quiet", "--bare", "--initial-branch=main", r.bare)
+	r.git(r.bare, "config", "uploadpack.allowAnySHA1InWant", "true")
+	r.git(dir, "init", "--quiet", "--initial-branch=main", r.work)
+	r.git(r.work, "remote", "add", "origin", r.bare)
+
+	ts := httptest.NewTLSServer(&cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + dir, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	t.Cleanup(ts.Close)
+
+	gitclient.InstallProtocol("https", githttp.NewClient(ts.Client()))
+	t.Cleanup(func() {
+		gitclient.InstallProtocol("https", githttp.DefaultClient)
+	})
+
+	r.url = ts.URL + "/dashboards.git"
+
+	return r
+}
+
+func (r *testGitRepository) git(dir string, args ...string) string {
+	r.t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(r.t, err, string(out))
+
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes files, pushes them to branch and returns the commit SHA
+func (r *testGitRepository) commit(branch string, files map[string]string) string {
+	r.t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(r.work, name)
+
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		require.NoError(r.t, err)
+
+		err = os.WriteFile(path, []byte(content), 0o600)
+		require.NoError(r.t, err)
+	}
+
+	r.git(r.work, "add", "--all")
+	r.git(r.work, "commit", "--quiet", "--message", "update")
+	r.git(r.work, "push", "--quiet", "origin", "HEAD:"+branch)
+
+	return r.git(r.work, "rev-parse", "HEAD")
+}
+
+// useGitCache points the shared clone cache to a temporary directory
+func useGitCache(t *testing.T) {
+	t.Helper()
+
+	dir := gitCacheDir
+	gitCacheDir = t.TempDir()
+	gitRepositories = &gitRepositoryCache{entries: map[string]*gitRepository{}}
+
+	t.Cleanup(func() {
+		gitCacheDir = dir
+	})
+}
+
+func gitDashboard(git *v1beta1.GrafanaContentGit, cacheDuration time.Duration) *v1beta1.GrafanaDashboard {
+	return &v1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				Git:                  git,
+				ContentCacheDuration: metav1.Duration{Duration: cacheDuration},
+			},
+		},
+	}
+}
+
+func TestFetchFromGit(t *testing.T) {
+	const (
+		firstJSON  = `{"title":"first"}`
+		secondJSON = `{"title":"second"}`
+	)
+
+	t.Run("default branch", func(t *testing.T) {
+		useGitCache(t)
+
+		repo := newTestGitRepository(t)
+		sha := repo.commit("main", map[string]string{"boards/board.json": firstJSON})
+
+		cr := gitDashboard(&v1beta1.GrafanaContentGit{URL: repo.url, Path: "boards/board.json"}, 0)
+
+		got, commit, err := FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.JSONEq(t, firstJSON, string(got))
+		assert.Equal(t, sha, commit)
+	})
+
+	t.Run("ref and commit", func(t *testing.T) {
+		useGitCache(t)
+
+		repo := newTestGitRepository(t)
+		first := repo.commit("main", map[string]string{"board.json": firstJSON})
+		repo.commit("release", map[string]string{"board.json": secondJSON})
+
+		cr := gitDashboard(&v1beta1.GrafanaContentGit{URL: repo.url, Ref: "release", Path: "board.json"}, 0)
+
+		got, _, err := FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.JSONEq(t, secondJSON, string(got))
+
+		cr = gitDashboard(&v1beta1.GrafanaContentGit{URL: repo.url, Commit: first, Path: "board.json"}, 0)
+
+		got, commit, err := FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.JSONEq(t, firstJSON, string(got))
+		assert.Equal(t, first, commit)
+
+		repo.git(repo.work, "tag", "--annotate", "--message", "v1", "v1", first)
+		repo.git(repo.work, "push", "--quiet", "origin", "v1")
+
+		cr = gitDashboard(&v1beta1.GrafanaContentGit{URL: repo.url, Ref: "v1", Path: "board.json"}, 0)
+
+		got, commit, err = FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.JSONEq(t, firstJSON, string(got))
+		assert.Equal(t, first, commit, "annotated tags must resolve to their commit")
+	})
+
+	t.Run("clones are shared within the cache duration", func(t *testing.T) {
+		useGitCache(t)
+
+		repo := newTestGitRepository(t)
+		first := repo.commit("main", map[string]string{"board.json": firstJSON})
+
+		cr := gitDashboard(&v1beta1.GrafanaContentGit{URL: repo.url, Ref: "main", Path: "board.json"}, time.Hour)
+
+		_, commit, err := FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.Equal(t, first, commit)
+
+		second := repo.commit("main", map[string]string{"board.json": secondJSON})
+
+		_, commit, err = FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.Equal(t, first, commit, "clone must be reused")
+
+		cr.Spec.ContentCacheDuration.Duration = 0
+
+		got, commit, err := FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.Equal(t, second, commit)
+		assert.JSONEq(t, secondJSON, string(got))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		useGitCache(t)
+
+		repo := newTestGitRepository(t)
+		repo.commit("main", map[string]string{"board.json": firstJSON})
+
+		cr := gitDashboard(&v1beta1.GrafanaContentGit{URL: repo.url, Path: "missing.json"}, 0)
+
+		_, _, err := FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.ErrorContains(t, err, `file "missing.json" not found`)
+	})
+
+	t.Run("missing ref", func(t *testing.T) {
+		useGitCache(t)
+
+		repo := newTestGitRepository(t)
+		repo.commit("main", map[string]string{"board.json": firstJSON})
+
+		cr := gitDashboard(&v1beta1.GrafanaContentGit{URL: repo.url, Ref: "missing", Path: "board.json"}, 0)
+
+		_, _, err := FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.ErrorContains(t, err, "fetch missing from")
+	})
+}
+
+func TestGitEndpoint(t *testing.T) {
+	for _, url := range []string{
+		"https://github.com/team/dashboards.git",
+		"ssh://git@github.com/team/dashboards.git",
+		"git@github.com:team/dashboards.git",
+	} {
+		_, err := gitEndpoint(url)
+		require.NoError(t, err, url)
+	}
+
+	for _, url := range []string{
+		"http://github.com/team/dashboards.git",
+		"file:///srv/git/dashboards.git",
+		"/srv/git/dashboards.git",
+		"git://github.com/team/dashboards.git",
+	} {
+		_, err := gitEndpoint(url)
+		require.ErrorContains(t, err, "unsupported protocol", url)
+	}
+
+	useGitCache(t)
+
+	cr := gitDashboard(&v1beta1.GrafanaContentGit{URL: "/srv/git/dashboards.git", Path: "board.json"}, 0)
+
+	_, _, err := FetchFromGit(context.Background(), cr, tk8s.GetFakeClient(t))
+	require.ErrorContains(t, err, "invalid git url")
+}
+
+func TestGitAuth(t *testing.T) {
+	useGitCache(t)
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(private, "")
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(private)
+	require.NoError(t, err)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "git"},
+		Data: map[string][]byte{
+			gitToken:         []byte("secret-token"),
+			gitSSHPrivateKey: pem.EncodeToMemory(block),
+			gitKnownHosts:    []byte(knownhosts.Line([]string{"github.com"}, signer.PublicKey())),
+		},
+	}
+
+	cl := tk8s.GetFakeClient(t, secret)
+	ref := &corev1.LocalObjectReference{Name: "git"}
+
+	auth := func(cl client.Client, g *v1beta1.GrafanaContentGit) (transport.AuthMethod, error) {
+		endpoint, err := gitEndpoint(g.URL)
+		require.NoError(t, err)
+
+		return gitAuth(context.Background(), cl, "default", endpoint, g)
+	}
+
+	t.Run("https token", func(t *testing.T) {
+		got, err := auth(cl, &v1beta1.GrafanaContentGit{URL: "https://github.com/team/dashboards.git", SecretRef: ref})
+		require.NoError(t, err)
+		assert.Equal(t, &githttp.BasicAuth{Username: "git", Password: "secret-token"}, got)
+	})
+
+	t.Run("ssh key", func(t *testing.T) {
+		got, err := auth(cl, &v1beta1.GrafanaContentGit{URL: "deploy@github.com:team/dashboards.git", SecretRef: ref})
+		require.NoError(t, err)
+
+		keys, ok := got.(*gitssh.PublicKeys)
+		require.True(t, ok)
+		assert.Equal(t, "deploy", keys.User)
+
+		addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+		require.NoError(t, keys.HostKeyCallback("github.com:22", addr, signer.PublicKey()))
+
+		other, err := ssh.NewSignerFromKey(ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))
+		require.NoError(t, err)
+		require.Error(t, keys.HostKeyCallback("github.com:22", addr, other.PublicKey()), "unknown host keys must be rejected")
+		require.Error(t, keys.HostKeyCallback("gitlab.com:22", addr, signer.PublicKey()), "unknown hosts must be rejected")
+
+		files, err := filepath.Glob(filepath.Join(gitCacheDir, "*"))
+		require.NoError(t, err)
+		assert.Empty(t, files, "known_hosts must be removed once parsed")
+	})
+
+	t.Run("ssh without known hosts", func(t *testing.T) {
+		withoutKnownHosts := secret.DeepCopy()
+		withoutKnownHosts.Name = "git-without-known-hosts"
+		delete(withoutKnownHosts.Data, gitKnownHosts)
+
+		_, err := auth(tk8s.GetFakeClient(t, withoutKnownHosts), &v1beta1.GrafanaContentGit{
+			URL:       "git@github.com:team/dashboards.git",
+			SecretRef: &corev1.LocalObjectReference{Name: withoutKnownHosts.Name},
+		})
+		require.ErrorContains(t, err, "missing key known_hosts")
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := auth(cl, &v1beta1.GrafanaContentGit{URL: "https://github.com/team/dashboards.git", SecretRef: &corev1.LocalObjectReference{Name: "missing"}})
+		require.Error(t, err)
+	})
+}
@@ -20,15 +20,16 @@ import (
 
 const grafanaComDashboardsAPIEndpoint = "https://grafana.com/api/dashboards"
 
-func FetchFromGrafanaCom(ctx context.Context, cr v1beta1.GrafanaContentResource, cl client.Client) ([]byte, error) {
+func FetchFromGrafanaCom(ctx context.Context, cr v1beta1.GrafanaContentResource, cl client.Client) ([]byte, URLValidators, error) {
 	c := cache.GetContentCache(cr)
 	if len(c) > 0 {
-		return c, nil
+		status := cr.GrafanaContentStatus()
+		return c, URLValidators{ETag: status.ContentETag, LastModified: status.ContentLastModified}, nil
 	}
 
 	spec := cr.GrafanaContentSpec()
 	if spec == nil {
-		return nil, fmt.Errorf("missing content spec definition on resource")
+		return nil, URLValidators{}, fmt.Errorf("missing content spec definition on resource")
 	}
 
 	source := spec.GrafanaCom
@@ -38,7 +39,7 @@ func FetchFromGrafanaCom(ctx context.Context, cr v1beta1.GrafanaContentResource,
 	if source.Revision == nil {
 		rev, err := getLatestGrafanaComRevision(ctx, cr, tlsConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get latest revision for dashboard id %d: %w", source.ID, err)
+			return nil, URLValidators{}, fmt.Errorf("failed to get latest revision for dashboard id %d: %w", source.ID, err)
 		}
 
 		source.Revision = &rev
@@ -5,43 +5,79 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"net/url"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/blang/semver/v4"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
-	corev1 "k8s.io/api/core/v1"
-	oras "oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
-	"oras.land/oras-go/v2/registry/remote/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/content/cache"
+	"github.com/grafana/grafana-operator/v5/pkg/oci"
 )
 
-func FetchFromOCI(ctx context.Context, cr v1beta1.GrafanaContentResource, cl client.Client) ([]byte, error) {
+// OCIRevision identifies the OCI artifact content was loaded from
+type OCIRevision struct {
+	// Digest of the manifest
+	Digest string
+	// Tag the digest was resolved from, empty for digest references
+	Tag string
+	// Verified is set once the signature of the artifact satisfied the verification policy
+	Verified bool
+	// ResolvedAt is the time the reference was last resolved against the registry
+	ResolvedAt time.Time
+}
+
+// FetchFromOCI returns the file referenced by spec.oci and the artifact it was loaded from. Tags are resolved to
+// a digest first, the artifact is only pulled once the digest differs from the cached one. Nothing is extracted
+// from artifacts failing verification
+func FetchFromOCI(ctx context.Context, cr v1beta1.GrafanaContentResource, cl client.Client) ([]byte, OCIRevision, error) {
 	o := cr.GrafanaContentSpec().OCI
+	status := cr.GrafanaContentStatus()
+
+	previous := OCIRevision{
+		Digest:     status.OCIDigest,
+		Tag:        status.OCITag,
+		Verified:   status.VerifiedDigest != "" && status.VerifiedDigest == status.OCIDigest,
+		ResolvedAt: status.OCIResolvedTimestamp.Time,
+	}
+
+	// within the poll interval the registry is not contacted at all
+	if previous.Digest != "" && o.PollInterval != nil && time.Since(previous.ResolvedAt) < o.PollInterval.Duration &&
+		(o.Verify == nil || previous.Verified) {
+		cached := cache.GetExpiredContentCache(cr)
+		if len(cached) > 0 {
+			return cached, previous, nil
+		}
+	}
 
 	parsed, err := registry.ParseReference(o.Reference)
 	if err != nil {
-		return nil, fmt.Errorf("parse oci reference %q: %w", o.Reference, err)
+		return nil, OCIRevision{}, fmt.Errorf("parse oci reference %q: %w", o.Reference, err)
 	}
 
-	if parsed.Reference == "" {
-		return nil, fmt.Errorf("oci reference must include tag or digest on %v/%v", cr.GetNamespace(), cr.GetName())
+	if o.SemverConstraint != "" && parsed.Reference != "" {
+		return nil, OCIRevision{}, fmt.Errorf("oci reference must not include tag or digest with a semver constraint on %v/%v", cr.GetNamespace(), cr.GetName())
+	}
+
+	if o.SemverConstraint == "" && parsed.Reference == "" {
+		return nil, OCIRevision{}, fmt.Errorf("oci reference must include tag or digest on %v/%v", cr.GetNamespace(), cr.GetName())
 	}
 
 	repo, err := remote.NewRepository(parsed.Registry + "/" + parsed.Repository)
 	if err != nil {
-		return nil, fmt.Errorf("parse oci reference %q: %w", o.Reference, err)
+		return nil, OCIRevision{}, fmt.Errorf("parse oci reference %q: %w", o.Reference, err)
 	}
 
 	if o.InsecurePlainHTTP {
@@ -51,26 +87,67 @@ func FetchFromOCI(ctx context.Context, cr v1beta1.GrafanaContentResource, cl cli
 	var credFunc auth.CredentialFunc
 
 	if o.PullSecretRef != nil {
-		credFunc, err = authFromPullSecret(ctx, cl, cr.GetNamespace(), o.PullSecretRef.Name, repo.Reference.Registry)
+		credFunc, err = oci.AuthFromPullSecret(ctx, cl, cr.GetNamespace(), o.PullSecretRef.Name, repo.Reference.Registry)
 		if err != nil {
-			return nil, fmt.Errorf("resolve pull secret: %w", err)
+			return nil, OCIRevision{}, fmt.Errorf("resolve pull secret: %w", err)
 		}
 	}
 
-	repo.Client = &auth.Client{
-		Client:     retry.DefaultClient,
-		Cache:      auth.NewCache(),
-		Credential: credFunc,
+	repo.Client = oci.NewAuthClient(credFunc)
+
+	revision := OCIRevision{ResolvedAt: time.Now()}
+
+	reference := parsed.Reference
+
+	switch {
+	case o.SemverConstraint != "":
+		reference, err = latestMatchingTag(ctx, repo, o.SemverConstraint)
+		if err != nil {
+			return nil, OCIRevision{}, fmt.Errorf("select tag of %s matching %q: %w", o.Reference, o.SemverConstraint, err)
+		}
+
+		revision.Tag = reference
+	case parsed.ValidateReferenceAsDigest() != nil:
+		revision.Tag = reference
 	}
 
-	_, manifestBytes, err := oras.FetchBytes(ctx, repo, parsed.Reference, oras.DefaultFetchBytesOptions)
+	desc, err := repo.Resolve(ctx, reference)
 	if err != nil {
-		return nil, fmt.Errorf("pull oci manifest %s: %w", o.Reference, err)
+		return nil, OCIRevision{}, fmt.Errorf("resolve oci reference %s: %w", o.Reference, err)
+	}
+
+	revision.Digest = desc.Digest.String()
+
+	if o.Verify != nil {
+		verifier, err := newSignatureVerifier(ctx, cl, cr.GetNamespace(), o.Verify)
+		if err != nil {
+			return nil, OCIRevision{}, fmt.Errorf("verification policy of %v/%v: %w", cr.GetNamespace(), cr.GetName(), err)
+		}
+
+		err = verifyOCISignature(ctx, repo, desc, verifier)
+		if err != nil {
+			return nil, OCIRevision{}, fmt.Errorf("verify %s: %w", o.Reference, err)
+		}
+
+		revision.Verified = true
+	}
+
+	// the content of a digest never changes
+	if revision.Digest == previous.Digest {
+		cached := cache.GetExpiredContentCache(cr)
+		if len(cached) > 0 {
+			return cached, revision, nil
+		}
+	}
+
+	manifestBytes, err := content.FetchAll(ctx, repo, desc)
+	if err != nil {
+		return nil, OCIRevision{}, fmt.Errorf("pull oci manifest %s: %w", o.Reference, err)
 	}
 
 	var manifest ocispec.Manifest
 	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
-		return nil, fmt.Errorf("parse manifest for %s: %w", o.Reference, err)
+		return nil, OCIRevision{}, fmt.Errorf("parse manifest for %s: %w", o.Reference, err)
 	}
 
 	target := filepath.ToSlash(o.Path)
@@ -81,39 +158,42 @@ func FetchFromOCI(ctx context.Context, cr v1beta1.GrafanaContentResource, cl cli
 		if filepath.ToSlash(layer.Annotations[ocispec.AnnotationTitle]) == target {
 			blob, err := content.FetchAll(ctx, repo, layer)
 			if err != nil {
-				return nil, fmt.Errorf("fetch layer %s for %s: %w", layer.Digest, o.Reference, err)
+				return nil, OCIRevision{}, fmt.Errorf("fetch layer %s for %s: %w", layer.Digest, o.Reference, err)
 			}
 
-			return blob, nil
+			return blob, revision, nil
 		}
 
-		data, found, err := fetchFileFromImageLayer(ctx, repo, layer, target)
+		data, found, err := fetchFileFromImageLayer(ctx, repo, layer, target, o.Verify != nil)
 		if err != nil {
-			return nil, fmt.Errorf("scan layer %s of %s: %w", layer.Digest, o.Reference, err)
+			return nil, OCIRevision{}, fmt.Errorf("scan layer %s of %s: %w", layer.Digest, o.Reference, err)
 		}
 
 		if found {
-			return data, nil
+			return data, revision, nil
 		}
 	}
 
-	return nil, fmt.Errorf("file %q not found in %s", o.Path, o.Reference)
+	return nil, OCIRevision{}, fmt.Errorf("file %q not found in %s", o.Path, o.Reference)
 }
 
 // fetchFileFromImageLayer streams a single layer blob and looks for target inside it,
 // treating the blob as a (possibly gzipped) tarball. Returns (data, true, nil) on hit,
 // (nil, false, nil) when the layer is not a tar or simply does not contain target.
-func fetchFileFromImageLayer(ctx context.Context, repo *remote.Repository, layer ocispec.Descriptor, target string) ([]byte, bool, error) {
+// With verifyDigest, a hit is only returned once the whole blob matched the layer digest.
+func fetchFileFromImageLayer(ctx context.Context, repo *remote.Repository, layer ocispec.Descriptor, target string, verifyDigest bool) ([]byte, bool, error) {
 	rc, err := repo.Fetch(ctx, layer)
 	if err != nil {
 		return nil, false, err
 	}
 	defer rc.Close()
 
-	var tarReader io.Reader = rc
+	vr := content.NewVerifyReader(rc, layer)
+
+	var tarReader io.Reader = vr
 
 	if isGzipMediaType(layer.MediaType) {
-		gz, gzErr := gzip.NewReader(rc)
+		gz, gzErr := gzip.NewReader(vr)
 		if gzErr != nil {
 			return nil, false, nil
 		}
@@ -149,6 +229,17 @@ func fetchFileFromImageLayer(ctx context.Context, repo *remote.Repository, layer
 				return nil, false, err
 			}
 
+			if verifyDigest {
+				// the signature covers the layer digest, read the rest of the blob to check it
+				if _, err := io.Copy(io.Discard, vr); err != nil {
+					return nil, false, err
+				}
+
+				if err := vr.Verify(); err != nil {
+					return nil, false, fmt.Errorf("%w: %w", ErrSignatureVerification, err)
+				}
+			}
+
 			return buf.Bytes(), true, nil
 		}
 	}
@@ -159,77 +250,39 @@ func isGzipMediaType(mt string) bool {
 	return strings.HasSuffix(mt, "+gzip") || strings.HasSuffix(mt, ".gz") || strings.HasSuffix(mt, ".gzip")
 }
 
-// dockerConfigJSON mirrors the relevant subset of the kubernetes.io/dockerconfigjson secret format.
-type dockerConfigJSON struct {
-	Auths map[string]dockerConfigAuth `json:"auths"`
-}
-
-type dockerConfigAuth struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Auth     string `json:"auth"` // base64("username:password")
-}
-
-func authFromPullSecret(ctx context.Context, cl client.Client, namespace, secretName, registryHost string) (auth.CredentialFunc, error) {
-	secret := &corev1.Secret{}
-
-	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, secret); err != nil {
-		return nil, err
-	}
-
-	if secret.Type != corev1.SecretTypeDockerConfigJson {
-		return nil, fmt.Errorf("pull secret %s/%s must be type %s, got %s",
-			namespace, secretName, corev1.SecretTypeDockerConfigJson, secret.Type)
-	}
-
-	raw, ok := secret.Data[corev1.DockerConfigJsonKey]
-	if !ok {
-		return nil, fmt.Errorf("pull secret %s/%s missing key %s", namespace, secretName, corev1.DockerConfigJsonKey)
-	}
-
-	var cfg dockerConfigJSON
-
-	if err := json.Unmarshal(raw, &cfg); err != nil {
-		return nil, fmt.Errorf("parse pull secret %s/%s: %w", namespace, secretName, err)
+// latestMatchingTag returns the tag of the highest release matching constraint
+func latestMatchingTag(ctx context.Context, repo *remote.Repository, constraint string) (string, error) {
+	matches, err := oci.ParseSemverConstraint(constraint)
+	if err != nil {
+		return "", err
 	}
 
-	for host, a := range cfg.Auths {
-		if !hostMatches(host, registryHost) {
-			continue
-		}
-
-		username, password := a.Username, a.Password
+	var (
+		latestTag     string
+		latestVersion semver.Version
+	)
 
-		if a.Auth != "" {
-			decoded, err := base64.StdEncoding.DecodeString(a.Auth)
-			if err != nil {
-				return nil, fmt.Errorf("decode auth field in pull secret %s/%s: %w", namespace, secretName, err)
+	err = repo.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			version, err := semver.ParseTolerant(tag)
+			if err != nil || len(version.Pre) > 0 || !matches(version) {
+				continue
 			}
 
-			parts := strings.SplitN(string(decoded), ":", 2)
-			if len(parts) == 2 {
-				username, password = parts[0], parts[1]
+			if latestTag == "" || version.GT(latestVersion) {
+				latestTag, latestVersion = tag, version
 			}
 		}
 
-		return auth.StaticCredential(registryHost, auth.Credential{Username: username, Password: password}), nil
-	}
-
-	return nil, nil
-}
-
-// hostMatches returns true when the config host key matches the registry hostname.
-// Docker config files may store the registry as "https://index.docker.io/v1/" for Docker Hub,
-// or plain hostname for other registries.
-func hostMatches(configHost, registryHost string) bool {
-	if !strings.Contains(configHost, "://") {
-		configHost = "https://" + configHost
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("list tags: %w", err)
 	}
 
-	u, err := url.Parse(configHost)
-	if err != nil {
-		return false
+	if latestTag == "" {
+		return "", errors.New("no matching tag found")
 	}
 
-	return u.Host == registryHost
+	return latestTag, nil
 }
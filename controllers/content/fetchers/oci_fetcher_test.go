@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/content/cache"
 	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
 )
 
@@ -66,7 +68,7 @@ func TestFetchFromOCI(t *testing.T) {
 
 		cr := ociDashboard(host+"/team/boards:v1", "board.json", nil)
 
-		got, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		got, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.NoError(t, err)
 		assert.JSONEq(t, wantJSON, string(got))
 	})
@@ -77,7 +79,7 @@ func TestFetchFromOCI(t *testing.T) {
 
 		cr := ociDashboard(host+"/team/boards@"+digest, "board.json", nil)
 
-		got, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		got, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.NoError(t, err)
 		assert.JSONEq(t, wantJSON, string(got))
 	})
@@ -88,7 +90,7 @@ func TestFetchFromOCI(t *testing.T) {
 
 		cr := ociDashboard(host+"/team/boards:v1", "subdir/board.json", nil)
 
-		got, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		got, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.NoError(t, err)
 		assert.JSONEq(t, wantJSON, string(got))
 	})
@@ -99,7 +101,7 @@ func TestFetchFromOCI(t *testing.T) {
 
 		cr := ociDashboard(host+"/team/boards:v1", "absent.json", nil)
 
-		_, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		_, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "absent.json")
 	})
@@ -109,7 +111,7 @@ func TestFetchFromOCI(t *testing.T) {
 
 		cr := ociDashboard(host+"/team/boards", "board.json", nil)
 
-		_, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		_, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "tag or digest")
 	})
@@ -117,7 +119,7 @@ func TestFetchFromOCI(t *testing.T) {
 	t.Run("invalid image reference", func(t *testing.T) {
 		cr := ociDashboard("not a valid image!!:v1", "board.json", nil)
 
-		_, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		_, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "parse oci reference")
 	})
@@ -139,7 +141,7 @@ func TestFetchFromOCI(t *testing.T) {
 		cr := ociDashboard(host+"/team/boards:v1", "board.json",
 			&corev1.LocalObjectReference{Name: "regcred"})
 
-		got, err := FetchFromOCI(context.Background(), cr, cl)
+		got, _, err := FetchFromOCI(context.Background(), cr, cl)
 		require.NoError(t, err)
 		assert.JSONEq(t, wantJSON, string(got))
 	})
@@ -150,7 +152,7 @@ func TestFetchFromOCI(t *testing.T) {
 		cr := ociDashboard(host+"/team/boards:v1", "board.json",
 			&corev1.LocalObjectReference{Name: "does-not-exist"})
 
-		_, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		_, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.Error(t, err)
 	})
 
@@ -166,7 +168,7 @@ func TestFetchFromOCI(t *testing.T) {
 		cr := ociDashboard(host+"/team/boards:v1", "board.json",
 			&corev1.LocalObjectReference{Name: "bad-secret"})
 
-		_, err := FetchFromOCI(context.Background(), cr, cl)
+		_, _, err := FetchFromOCI(context.Background(), cr, cl)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), string(corev1.SecretTypeDockerConfigJson))
 	})
@@ -179,7 +181,7 @@ func TestFetchFromOCI(t *testing.T) {
 
 		cr := ociDashboard(host+"/team/boards:v1", "board.json", nil)
 
-		got, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		got, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.NoError(t, err)
 		assert.JSONEq(t, wantJSON, string(got))
 	})
@@ -193,7 +195,7 @@ func TestFetchFromOCI(t *testing.T) {
 
 		cr := ociDashboard(host+"/team/boards:v1", "board.json", nil)
 
-		got, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		got, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.NoError(t, err)
 		assert.JSONEq(t, wantJSON, string(got))
 	})
@@ -206,7 +208,7 @@ func TestFetchFromOCI(t *testing.T) {
 
 		cr := ociDashboard(host+"/team/boards:v1", "opt/grafana/board.json", nil)
 
-		got, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		got, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
 		require.NoError(t, err)
 		assert.JSONEq(t, wantJSON, string(got))
 	})
@@ -223,28 +225,137 @@ func TestFetchFromOCI(t *testing.T) {
 		cr := ociDashboard(host+"/team/boards:v1", "board.json",
 			&corev1.LocalObjectReference{Name: "malformed"})
 
-		_, err := FetchFromOCI(context.Background(), cr, cl)
+		_, _, err := FetchFromOCI(context.Background(), cr, cl)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "parse pull secret")
 	})
 }
 
-func TestHostMatches(t *testing.T) {
-	cases := []struct {
-		configHost   string
-		registryHost string
-		want         bool
-	}{
-		{"ghcr.io", "ghcr.io", true},
-		{"https://ghcr.io", "ghcr.io", true},
-		{"https://index.docker.io/v1/", "index.docker.io", true},
-		{"gcr.io", "ghcr.io", false},
-		{"https://registry.example.com", "registry.example.com", true},
-	}
+// cacheOCIContent stores the fetched content and revision the way the resolver does after a successful apply
+func cacheOCIContent(t *testing.T, cr *v1beta1.GrafanaDashboard, got []byte, revision OCIRevision) {
+	t.Helper()
 
-	for _, tc := range cases {
-		t.Run(fmt.Sprintf("%s~%s", tc.configHost, tc.registryHost), func(t *testing.T) {
-			assert.Equal(t, tc.want, hostMatches(tc.configHost, tc.registryHost))
-		})
-	}
+	var model map[string]any
+
+	err := json.Unmarshal(got, &model)
+	require.NoError(t, err)
+
+	err = cache.SetContentCache(cr, model)
+	require.NoError(t, err)
+
+	cr.Status.OCIDigest = revision.Digest
+	cr.Status.OCITag = revision.Tag
+	cr.Status.OCIResolvedTimestamp = metav1.NewTime(revision.ResolvedAt)
+}
+
+func TestFetchFromOCIDigestPinning(t *testing.T) {
+	const (
+		firstJSON  = `{"title":"first"}`
+		secondJSON = `{"title":"second"}`
+	)
+
+	t.Run("tag is resolved and only fetched once the digest changes", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		first := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(firstJSON)})
+
+		cr := ociDashboard(host+"/team/boards:v1", "board.json", nil)
+		cl := tk8s.GetFakeClient(t)
+
+		got, revision, err := FetchFromOCI(context.Background(), cr, cl)
+		require.NoError(t, err)
+		assert.JSONEq(t, firstJSON, string(got))
+		assert.Equal(t, first, revision.Digest)
+		assert.Equal(t, "v1", revision.Tag)
+		assert.WithinDuration(t, time.Now(), revision.ResolvedAt, 5*time.Second)
+
+		cacheOCIContent(t, cr, got, revision)
+
+		blobs := reg.requests["blobs"]
+
+		got, revision, err = FetchFromOCI(context.Background(), cr, cl)
+		require.NoError(t, err)
+		assert.JSONEq(t, firstJSON, string(got))
+		assert.Equal(t, first, revision.Digest)
+		assert.Equal(t, blobs, reg.requests["blobs"], "unchanged digest must not be pulled again")
+
+		second := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(secondJSON)})
+
+		got, revision, err = FetchFromOCI(context.Background(), cr, cl)
+		require.NoError(t, err)
+		assert.JSONEq(t, secondJSON, string(got))
+		assert.Equal(t, second, revision.Digest)
+		assert.Greater(t, reg.requests["blobs"], blobs)
+	})
+
+	t.Run("digest reference has no tag", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		first := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(firstJSON)})
+
+		_, revision, err := FetchFromOCI(context.Background(), ociDashboard(host+"/team/boards@"+first, "board.json", nil), tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.Equal(t, first, revision.Digest)
+		assert.Empty(t, revision.Tag)
+	})
+
+	t.Run("registry is not contacted within the poll interval", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		first := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(firstJSON)})
+
+		cr := ociDashboard(host+"/team/boards:v1", "board.json", nil)
+		cr.Spec.OCI.PollInterval = &metav1.Duration{Duration: time.Hour}
+		cl := tk8s.GetFakeClient(t)
+
+		got, revision, err := FetchFromOCI(context.Background(), cr, cl)
+		require.NoError(t, err)
+
+		cacheOCIContent(t, cr, got, revision)
+
+		reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(secondJSON)})
+
+		manifests := reg.requests["manifests"]
+
+		got, revision, err = FetchFromOCI(context.Background(), cr, cl)
+		require.NoError(t, err)
+		assert.JSONEq(t, firstJSON, string(got))
+		assert.Equal(t, first, revision.Digest)
+		assert.Equal(t, manifests, reg.requests["manifests"])
+
+		// poll interval expired
+		cr.Status.OCIResolvedTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+		got, _, err = FetchFromOCI(context.Background(), cr, cl)
+		require.NoError(t, err)
+		assert.JSONEq(t, secondJSON, string(got))
+	})
+
+	t.Run("semver constraint selects the highest matching release", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+
+		for _, tag := range []string{"v1.3.9", "v1.4.0", "1.4.2", "v1.4.3-rc.1", "v1.5.0", "latest"} {
+			reg.pushArtifact(t, "team/boards", tag, map[string][]byte{"board.json": fmt.Appendf(nil, `{"title":%q}`, tag)})
+		}
+
+		cr := ociDashboard(host+"/team/boards", "board.json", nil)
+		cr.Spec.OCI.SemverConstraint = "~1.4"
+
+		got, revision, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"title":"1.4.2"}`, string(got))
+		assert.Equal(t, "1.4.2", revision.Tag)
+
+		cr.Spec.OCI.SemverConstraint = "~2"
+
+		_, _, err = FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.ErrorContains(t, err, "no matching tag found")
+	})
+
+	t.Run("semver constraint with tag", func(t *testing.T) {
+		_, host := newFakeRegistry(t)
+
+		cr := ociDashboard(host+"/team/boards:v1", "board.json", nil)
+		cr.Spec.OCI.SemverConstraint = "~1.4"
+
+		_, _, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t))
+		require.ErrorContains(t, err, "must not include tag or digest")
+	})
 }
@@ -22,11 +22,15 @@ import (
 // pull API. It supports only the routes oras-go's Resolve + FetchBytes hit, and
 // its state is populated directly via pushArtifact (no upload protocol).
 type fakeRegistry struct {
-	blobs     map[string][]byte // "sha256:..." -> content
-	manifests map[string][]byte // "<repo>/<reference>" -> manifest bytes (ref is tag OR digest)
+	blobs     map[string][]byte               // "sha256:..." -> content
+	manifests map[string][]byte               // "<repo>/<reference>" -> manifest bytes (ref is tag OR digest)
+	referrers map[string][]ocispec.Descriptor // "<repo>/<digest>" -> manifests with that subject
 
 	requireAuth bool
 	user, pass  string
+
+	// requests counts the requests served per route: "manifests", "blobs", "referrers" and "tags"
+	requests map[string]int
 }
 
 // newFakeRegistry starts an httptest server backed by an empty registry and
@@ -37,6 +41,8 @@ func newFakeRegistry(t *testing.T) (*fakeRegistry, string) {
 	r := &fakeRegistry{
 		blobs:     map[string][]byte{},
 		manifests: map[string][]byte{},
+		referrers: map[string][]ocispec.Descriptor{},
+		requests:  map[string]int{},
 	}
 
 	srv := httptest.NewServer(r.handler())
@@ -156,6 +162,80 @@ func (r *fakeRegistry) pushImage(t *testing.T, repo, tag string, layers []map[st
 	return manifestDigest
 }
 
+// pushSignature stores a cosign signature of manifestDigest at the tag cosign
+// uses by default: one simple signing layer per signature, carrying payload and
+// the signature annotations.
+func (r *fakeRegistry) pushSignature(t *testing.T, repo, manifestDigest string, payload []byte, annotations map[string]string) {
+	t.Helper()
+
+	manifestBytes := r.signatureManifest(t, nil, payload, annotations)
+
+	r.manifests[repo+"/"+strings.Replace(manifestDigest, ":", "-", 1)+".sig"] = manifestBytes
+}
+
+// pushSignatureReferrer stores a cosign signature of manifestDigest the way
+// cosign attaches it with --registry-referrers-mode=oci-1-1: a manifest with the
+// signed manifest as subject, listed by the referrers API.
+func (r *fakeRegistry) pushSignatureReferrer(t *testing.T, repo, manifestDigest string, payload []byte, annotations map[string]string) {
+	t.Helper()
+
+	subject := &ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.Digest(manifestDigest),
+		Size:      int64(len(r.manifests[repo+"/"+manifestDigest])),
+	}
+
+	manifestBytes := r.signatureManifest(t, subject, payload, annotations)
+	signatureDigest := sha256Digest(manifestBytes)
+
+	r.manifests[repo+"/"+signatureDigest] = manifestBytes
+	r.referrers[repo+"/"+manifestDigest] = append(r.referrers[repo+"/"+manifestDigest], ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.dev.cosign.artifact.sig.v1+json",
+		Digest:       digest.Digest(signatureDigest),
+		Size:         int64(len(manifestBytes)),
+	})
+}
+
+// signatureManifest stores the signature payload and returns a manifest with a
+// single simple signing layer, referrers carry a subject and an artifact type.
+func (r *fakeRegistry) signatureManifest(t *testing.T, subject *ocispec.Descriptor, payload []byte, annotations map[string]string) []byte {
+	t.Helper()
+
+	d := sha256Digest(payload)
+	r.blobs[d] = payload
+
+	emptyJSON := []byte("{}")
+	emptyDigest := sha256Digest(emptyJSON)
+	r.blobs[emptyDigest] = emptyJSON
+
+	manifest := ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config: ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeEmptyJSON,
+			Digest:    digest.Digest(emptyDigest),
+			Size:      int64(len(emptyJSON)),
+		},
+		Layers: []ocispec.Descriptor{{
+			MediaType:   "application/vnd.dev.cosign.simplesigning.v1+json",
+			Digest:      digest.Digest(d),
+			Size:        int64(len(payload)),
+			Annotations: annotations,
+		}},
+		Subject: subject,
+	}
+	manifest.SchemaVersion = 2
+
+	if subject != nil {
+		manifest.ArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	return manifestBytes
+}
+
 func (r *fakeRegistry) handler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		if r.requireAuth {
@@ -185,12 +265,21 @@ func (r *fakeRegistry) handler() http.Handler {
 		rest := strings.TrimPrefix(path, "/v2/")
 
 		switch {
+		case strings.HasSuffix(rest, "/tags/list"):
+			r.requests["tags"]++
+			r.serveTags(w, strings.TrimSuffix(rest, "/tags/list"))
 		case strings.Contains(rest, "/manifests/"):
+			r.requests["manifests"]++
 			idx := strings.LastIndex(rest, "/manifests/")
 			repo := rest[:idx]
 			ref := rest[idx+len("/manifests/"):]
 			r.serveManifest(w, req, repo, ref)
+		case strings.Contains(rest, "/referrers/"):
+			r.requests["referrers"]++
+			idx := strings.LastIndex(rest, "/referrers/")
+			r.serveReferrers(w, rest[:idx], rest[idx+len("/referrers/"):])
 		case strings.Contains(rest, "/blobs/"):
+			r.requests["blobs"]++
 			idx := strings.LastIndex(rest, "/blobs/")
 			ref := rest[idx+len("/blobs/"):]
 			r.serveBlob(w, req, ref)
@@ -220,6 +309,49 @@ func (r *fakeRegistry) serveManifest(w http.ResponseWriter, req *http.Request, r
 	writeOrFail(w, body)
 }
 
+// serveReferrers lists the manifests with the subject ref, without server side filtering
+func (r *fakeRegistry) serveReferrers(w http.ResponseWriter, repo, ref string) {
+	index := ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: r.referrers[repo+"/"+ref],
+	}
+	index.SchemaVersion = 2
+
+	if index.Manifests == nil {
+		index.Manifests = []ocispec.Descriptor{}
+	}
+
+	body, err := json.Marshal(index)
+	if err != nil {
+		panic(err)
+	}
+
+	w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+	writeOrFail(w, body)
+}
+
+// serveTags lists the tags of repo in a single page, digests and signature tags are skipped
+func (r *fakeRegistry) serveTags(w http.ResponseWriter, repo string) {
+	tags := []string{}
+
+	for key := range r.manifests {
+		tag, ok := strings.CutPrefix(key, repo+"/")
+		if !ok || strings.HasPrefix(tag, "sha256:") || strings.HasSuffix(tag, ".sig") {
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	body, err := json.Marshal(map[string]any{"name": repo, "tags": tags})
+	if err != nil {
+		panic(err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	writeOrFail(w, body)
+}
+
 func (r *fakeRegistry) serveBlob(w http.ResponseWriter, req *http.Request, ref string) {
 	body, ok := r.blobs[ref]
 	if !ok {
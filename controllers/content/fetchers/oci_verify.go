@@ -0,0 +1,468 @@
+package fetchers
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	corev1 "k8s.io/api/core/v1"
+	oras "oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+)
+
+const (
+	cosignSignatureMediaType    = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	cosignSignatureType         = "cosign container image signature"
+	cosignSignatureAnnotation   = "dev.cosignproject.cosign/signature"
+	cosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	cosignChainAnnotation       = "dev.sigstore.cosign/chain"
+	cosignBundleAnnotation      = "dev.sigstore.cosign/bundle"
+
+	// signatures stored as annotations carry an inclusion promise but no inclusion proof, the first bundle
+	// version accepting them
+	sigstoreBundleMediaType = "application/vnd.dev.sigstore.bundle+json;version=0.1"
+)
+
+// ErrSignatureVerification is returned when an OCI artifact has no signature satisfying the verification policy
+var ErrSignatureVerification = errors.New("signature verification failed")
+
+// simpleSigning is the subset of the cosign signature payload identifying the signed manifest
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// rekorBundle is the transparency log entry attached to keyless signatures
+type rekorBundle struct {
+	SignedEntryTimestamp []byte             `json:"SignedEntryTimestamp"`
+	Payload              rekorBundlePayload `json:"Payload"`
+}
+
+type rekorBundlePayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// rekorEntryKind is the type of a transparency log entry, sigstore-go checks the entry against the bundle
+type rekorEntryKind struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// signatureVerifier checks cosign signatures with sigstore-go against the trust material of the verification policy
+type signatureVerifier struct {
+	verifier *verify.Verifier
+	identity verify.PolicyOption
+	keyless  bool
+}
+
+// newSignatureVerifier loads the trust material of the verification policy from Secrets or ConfigMaps
+func newSignatureVerifier(ctx context.Context, cl client.Client, namespace string, policy *v1beta1.GrafanaContentOCIVerify) (*signatureVerifier, error) {
+	if policy.PublicKey != nil {
+		key, err := loadPublicKey(ctx, cl, namespace, *policy.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading public key: %w", err)
+		}
+
+		keyVerifier, err := signature.LoadDefaultVerifier(key)
+		if err != nil {
+			return nil, fmt.Errorf("loading public key: %w", err)
+		}
+
+		trusted := root.NewTrustedPublicKeyMaterial(func(string) (root.TimeConstrainedVerifier, error) {
+			return root.NewExpiringKey(keyVerifier, time.Time{}, time.Time{}), nil
+		})
+
+		// signatures made with a key pair are often not uploaded to a transparency log
+		verifier, err := verify.NewVerifier(trusted, verify.WithNoObserverTimestamps())
+		if err != nil {
+			return nil, err
+		}
+
+		return &signatureVerifier{verifier: verifier, identity: verify.WithKey()}, nil
+	}
+
+	if policy.Keyless == nil {
+		return nil, errors.New("verification requires a public key or a keyless identity")
+	}
+
+	material, err := getVerificationMaterial(ctx, cl, namespace, policy.Keyless.Roots)
+	if err != nil {
+		return nil, fmt.Errorf("loading certificate authority: %w", err)
+	}
+
+	authorities, err := certificateAuthorities(material)
+	if err != nil {
+		return nil, fmt.Errorf("loading certificate authority: %w", err)
+	}
+
+	rekorKey, err := loadPublicKey(ctx, cl, namespace, policy.Keyless.RekorPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading transparency log public key: %w", err)
+	}
+
+	rekorLog, err := transparencyLog(rekorKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading transparency log public key: %w", err)
+	}
+
+	trusted, err := root.NewTrustedRoot(root.TrustedRootMediaType01, authorities, nil, nil,
+		map[string]*root.TransparencyLog{hex.EncodeToString(rekorLog.ID): rekorLog})
+	if err != nil {
+		return nil, err
+	}
+
+	// the signing certificate is short-lived, it must have been valid when the signature was logged
+	verifier, err := verify.NewVerifier(trusted, verify.WithTransparencyLog(1), verify.WithIntegratedTimestamps(1))
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := verify.NewShortCertificateIdentity(policy.Keyless.Issuer, "", policy.Keyless.Subject, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &signatureVerifier{verifier: verifier, identity: verify.WithCertificateIdentity(identity), keyless: true}, nil
+}
+
+// certificateAuthorities splits material into one authority per root certificate, intermediates are shared
+func certificateAuthorities(material []byte) ([]root.CertificateAuthority, error) {
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(material)
+	if err != nil {
+		return nil, err
+	}
+
+	var roots, intermediates []*x509.Certificate
+
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+			roots = append(roots, cert)
+		} else {
+			intermediates = append(intermediates, cert)
+		}
+	}
+
+	if len(roots) == 0 {
+		return nil, errors.New("no root certificate found")
+	}
+
+	authorities := make([]root.CertificateAuthority, 0, len(roots))
+	for _, cert := range roots {
+		authorities = append(authorities, &root.FulcioCertificateAuthority{Root: cert, Intermediates: intermediates})
+	}
+
+	return authorities, nil
+}
+
+// transparencyLog returns the log signing with key, logs are identified by the SHA-256 of their public key.
+// The policy holds no validity period, the key is trusted for all entries
+func transparencyLog(key crypto.PublicKey) (*root.TransparencyLog, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	id := sha256.Sum256(der)
+
+	return &root.TransparencyLog{
+		ID:                  id[:],
+		ValidityPeriodStart: time.Unix(0, 0),
+		HashFunc:            crypto.SHA256,
+		PublicKey:           key,
+		SignatureHashFunc:   crypto.SHA256,
+	}, nil
+}
+
+// verifyOCISignature checks that at least one cosign signature of the manifest satisfies the verifier.
+// Only signatures failing verification are reported as ErrSignatureVerification, registry errors are not
+func verifyOCISignature(ctx context.Context, repo *remote.Repository, desc ocispec.Descriptor, verifier *signatureVerifier) error {
+	manifestDigest := desc.Digest.String()
+
+	manifests, err := signatureManifests(ctx, repo, desc)
+	if err != nil {
+		return fmt.Errorf("lookup signatures of %s: %w", manifestDigest, err)
+	}
+
+	var errs, fetchErrs []error
+
+	for _, manifest := range manifests {
+		for _, layer := range manifest.Layers {
+			if layer.MediaType != cosignSignatureMediaType {
+				continue
+			}
+
+			payload, err := content.FetchAll(ctx, repo, layer)
+			if err != nil {
+				fetchErrs = append(fetchErrs, fmt.Errorf("fetch signature %s: %w", layer.Digest, err))
+				continue
+			}
+
+			err = verifier.verify(layer.Annotations, payload, manifestDigest)
+			if err == nil {
+				return nil
+			}
+
+			errs = append(errs, fmt.Errorf("signature %s: %w", layer.Digest, err))
+		}
+	}
+
+	// a signature that could not be fetched may be valid
+	if len(fetchErrs) > 0 {
+		return fmt.Errorf("lookup signatures of %s: %w", manifestDigest, errors.Join(append(fetchErrs, errs...)...))
+	}
+
+	if len(errs) == 0 {
+		return fmt.Errorf("%w: no signature found for %s", ErrSignatureVerification, manifestDigest)
+	}
+
+	return fmt.Errorf("%w: no valid signature for %s: %w", ErrSignatureVerification, manifestDigest, errors.Join(errs...))
+}
+
+// signatureManifests returns the cosign signature manifests of desc, cosign stores them in the tag sha256-<hex>.sig
+// by default and attaches them as OCI 1.1 referrers with --registry-referrers-mode=oci-1-1
+func signatureManifests(ctx context.Context, repo *remote.Repository, desc ocispec.Descriptor) ([]ocispec.Manifest, error) {
+	var manifests []ocispec.Manifest
+
+	add := func(manifestBytes []byte) error {
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+			return fmt.Errorf("parse signature manifest: %w", err)
+		}
+
+		manifests = append(manifests, manifest)
+
+		return nil
+	}
+
+	tag := strings.Replace(desc.Digest.String(), ":", "-", 1) + ".sig"
+
+	_, manifestBytes, err := oras.FetchBytes(ctx, repo, tag, oras.DefaultFetchBytesOptions)
+
+	switch {
+	case err == nil:
+		if err := add(manifestBytes); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, errdef.ErrNotFound):
+		return nil, fmt.Errorf("fetch signature tag %s: %w", tag, err)
+	}
+
+	// oras falls back to the referrers tag schema for registries without the referrers API
+	err = repo.Referrers(ctx, desc, cosignSignatureArtifactType, func(referrers []ocispec.Descriptor) error {
+		for _, referrer := range referrers {
+			manifestBytes, err := content.FetchAll(ctx, repo, referrer)
+			if err != nil {
+				return fmt.Errorf("fetch signature manifest %s: %w", referrer.Digest, err)
+			}
+
+			if err := add(manifestBytes); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list referrers: %w", err)
+	}
+
+	return manifests, nil
+}
+
+// verify checks a single signature layer, its payload must reference the manifest
+func (v *signatureVerifier) verify(annotations map[string]string, payload []byte, manifestDigest string) error {
+	entity, err := v.signedEntity(annotations, payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = v.verifier.Verify(entity, verify.NewPolicy(verify.WithArtifact(bytes.NewReader(payload)), v.identity))
+	if err != nil {
+		return err
+	}
+
+	var signed simpleSigning
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return fmt.Errorf("parse signature payload: %w", err)
+	}
+
+	if signed.Critical.Type != cosignSignatureType {
+		return fmt.Errorf("unexpected signature type %q", signed.Critical.Type)
+	}
+
+	if signed.Critical.Image.DockerManifestDigest != manifestDigest {
+		return fmt.Errorf("signature is for %s", signed.Critical.Image.DockerManifestDigest)
+	}
+
+	return nil
+}
+
+// signedEntity converts the annotations of a cosign signature layer to a sigstore bundle
+func (v *signatureVerifier) signedEntity(annotations map[string]string, payload []byte) (*bundle.Bundle, error) {
+	sig, err := base64.StdEncoding.DecodeString(annotations[cosignSignatureAnnotation])
+	if err != nil || len(sig) == 0 {
+		return nil, errors.New("missing or malformed signature annotation")
+	}
+
+	digest := sha256.Sum256(payload)
+
+	material := &protobundle.VerificationMaterial{
+		Content: &protobundle.VerificationMaterial_PublicKey{PublicKey: &protocommon.PublicKeyIdentifier{}},
+	}
+
+	if v.keyless {
+		chain, err := certificateChain(annotations)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := transparencyLogEntry(annotations[cosignBundleAnnotation])
+		if err != nil {
+			return nil, err
+		}
+
+		material = &protobundle.VerificationMaterial{
+			Content:     &protobundle.VerificationMaterial_X509CertificateChain{X509CertificateChain: chain},
+			TlogEntries: []*protorekor.TransparencyLogEntry{entry},
+		}
+	}
+
+	return bundle.NewBundle(&protobundle.Bundle{
+		MediaType:            sigstoreBundleMediaType,
+		VerificationMaterial: material,
+		Content: &protobundle.Bundle_MessageSignature{
+			MessageSignature: &protocommon.MessageSignature{
+				MessageDigest: &protocommon.HashOutput{Algorithm: protocommon.HashAlgorithm_SHA2_256, Digest: digest[:]},
+				Signature:     sig,
+			},
+		},
+	})
+}
+
+// certificateChain returns the signing certificate followed by the intermediates of the chain annotation
+func certificateChain(annotations map[string]string) (*protocommon.X509CertificateChain, error) {
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(annotations[cosignCertificateAnnotation]))
+	if err != nil || len(certs) == 0 {
+		return nil, errors.New("missing or malformed certificate annotation")
+	}
+
+	chain := &protocommon.X509CertificateChain{
+		Certificates: []*protocommon.X509Certificate{{RawBytes: certs[0].Raw}},
+	}
+
+	if annotation, ok := annotations[cosignChainAnnotation]; ok {
+		certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(annotation))
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate chain: %w", err)
+		}
+
+		for _, cert := range certs {
+			if !bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+				chain.Certificates = append(chain.Certificates, &protocommon.X509Certificate{RawBytes: cert.Raw})
+			}
+		}
+	}
+
+	return chain, nil
+}
+
+// transparencyLogEntry converts the transparency log bundle annotation to an entry with an inclusion promise
+func transparencyLogEntry(annotation string) (*protorekor.TransparencyLogEntry, error) {
+	if annotation == "" {
+		return nil, errors.New("missing transparency log bundle")
+	}
+
+	var rekor rekorBundle
+	if err := json.Unmarshal([]byte(annotation), &rekor); err != nil {
+		return nil, fmt.Errorf("parse transparency log bundle: %w", err)
+	}
+
+	body, err := base64.StdEncoding.DecodeString(rekor.Payload.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decode transparency log entry: %w", err)
+	}
+
+	var kind rekorEntryKind
+	if err := json.Unmarshal(body, &kind); err != nil {
+		return nil, fmt.Errorf("parse transparency log entry: %w", err)
+	}
+
+	logID, err := hex.DecodeString(rekor.Payload.LogID)
+	if err != nil {
+		return nil, fmt.Errorf("parse transparency log id: %w", err)
+	}
+
+	return &protorekor.TransparencyLogEntry{
+		LogIndex:          rekor.Payload.LogIndex,
+		LogId:             &protocommon.LogId{KeyId: logID},
+		KindVersion:       &protorekor.KindVersion{Kind: kind.Kind, Version: kind.APIVersion},
+		IntegratedTime:    rekor.Payload.IntegratedTime,
+		InclusionPromise:  &protorekor.InclusionPromise{SignedEntryTimestamp: rekor.SignedEntryTimestamp},
+		CanonicalizedBody: body,
+	}, nil
+}
+
+func loadPublicKey(ctx context.Context, cl client.Client, namespace string, source v1beta1.GrafanaContentEnvFromSource) (crypto.PublicKey, error) {
+	material, err := getVerificationMaterial(ctx, cl, namespace, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return cryptoutils.UnmarshalPEMToPublicKey(material)
+}
+
+// getVerificationMaterial reads a key of a Secret or ConfigMap in the namespace of the resource
+func getVerificationMaterial(ctx context.Context, cl client.Client, namespace string, source v1beta1.GrafanaContentEnvFromSource) ([]byte, error) {
+	if source.SecretKeyRef != nil {
+		return grafanaclient.GetValueFromSecretKey(ctx, cl, namespace, source.SecretKeyRef)
+	}
+
+	if source.ConfigMapKeyRef != nil {
+		cm := &corev1.ConfigMap{}
+
+		err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: source.ConfigMapKeyRef.Name}, cm)
+		if err != nil {
+			return nil, err
+		}
+
+		if val, ok := cm.Data[source.ConfigMapKeyRef.Key]; ok {
+			return []byte(val), nil
+		}
+
+		return nil, fmt.Errorf("missing key %s in configmap %s/%s", source.ConfigMapKeyRef.Key, namespace, source.ConfigMapKeyRef.Name)
+	}
+
+	return nil, errors.New("secretKeyRef or configMapKeyRef must be set")
+}
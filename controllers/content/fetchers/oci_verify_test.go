@@ -0,0 +1,460 @@
+package fetchers
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
+)
+
+const (
+	testIssuer  = "https://token.actions.githubusercontent.com"
+	testSubject = "https://github.com/team/dashboards/.github/workflows/release.yaml@refs/heads/main"
+)
+
+// oidFulcioIssuerV2 is the extension holding the OIDC issuer in Fulcio certificates
+var oidFulcioIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, payload []byte) []byte {
+	t.Helper()
+
+	hash := sha256.Sum256(payload)
+
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	return signature
+}
+
+// signaturePayload is the simple signing payload cosign signs for manifestDigest
+func signaturePayload(t *testing.T, manifestDigest string) []byte {
+	t.Helper()
+
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]any{"docker-reference": "registry/team/boards"},
+			"image":    map[string]any{"docker-manifest-digest": manifestDigest},
+			"type":     "cosign container image signature",
+		},
+		"optional": nil,
+	})
+	require.NoError(t, err)
+
+	return payload
+}
+
+func verifiedOCIDashboard(reference string, verify *v1beta1.GrafanaContentOCIVerify) *v1beta1.GrafanaDashboard {
+	cr := ociDashboard(reference, "board.json", nil)
+	cr.Spec.OCI.Verify = verify
+
+	return cr
+}
+
+func keyRef(name, key string) v1beta1.GrafanaContentEnvFromSource {
+	return v1beta1.GrafanaContentEnvFromSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		},
+	}
+}
+
+func TestFetchFromOCIWithPublicKey(t *testing.T) {
+	const wantJSON = `{"title":"signed"}`
+
+	key := newTestKey(t)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cosign"},
+		Data:       map[string][]byte{"cosign.pub": []byte(publicKeyPEM(t, &key.PublicKey))},
+	}
+
+	verify := &v1beta1.GrafanaContentOCIVerify{PublicKey: new(keyRef("cosign", "cosign.pub"))}
+
+	t.Run("valid signature", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		manifestDigest := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+
+		payload := signaturePayload(t, manifestDigest)
+		reg.pushSignature(t, "team/boards", manifestDigest, payload, map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, key, payload)),
+		})
+
+		got, revision, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t, secret))
+		require.NoError(t, err)
+		assert.JSONEq(t, wantJSON, string(got))
+		assert.Equal(t, manifestDigest, revision.Digest)
+		assert.True(t, revision.Verified)
+	})
+
+	t.Run("signature attached as referrer", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		manifestDigest := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+
+		payload := signaturePayload(t, manifestDigest)
+		reg.pushSignatureReferrer(t, "team/boards", manifestDigest, payload, map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, key, payload)),
+		})
+
+		got, revision, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t, secret))
+		require.NoError(t, err)
+		assert.JSONEq(t, wantJSON, string(got))
+		assert.True(t, revision.Verified)
+	})
+
+	t.Run("invalid signature tag and valid referrer", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		manifestDigest := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+
+		payload := signaturePayload(t, manifestDigest)
+		reg.pushSignature(t, "team/boards", manifestDigest, payload, map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, newTestKey(t), payload)),
+		})
+		reg.pushSignatureReferrer(t, "team/boards", manifestDigest, payload, map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, key, payload)),
+		})
+
+		_, revision, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t, secret))
+		require.NoError(t, err)
+		assert.True(t, revision.Verified)
+	})
+
+	t.Run("public key from configmap", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		manifestDigest := reg.pushImage(t, "team/boards", "v1", []map[string][]byte{{"board.json": []byte(wantJSON)}})
+
+		payload := signaturePayload(t, manifestDigest)
+		reg.pushSignature(t, "team/boards", manifestDigest, payload, map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, key, payload)),
+		})
+
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cosign"},
+			Data:       map[string]string{"cosign.pub": publicKeyPEM(t, &key.PublicKey)},
+		}
+
+		cr := verifiedOCIDashboard(host+"/team/boards:v1", &v1beta1.GrafanaContentOCIVerify{
+			PublicKey: &v1beta1.GrafanaContentEnvFromSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "cosign"},
+					Key:                  "cosign.pub",
+				},
+			},
+		})
+
+		got, revision, err := FetchFromOCI(context.Background(), cr, tk8s.GetFakeClient(t, cm))
+		require.NoError(t, err)
+		assert.JSONEq(t, wantJSON, string(got))
+		assert.Equal(t, manifestDigest, revision.Digest)
+		assert.True(t, revision.Verified)
+	})
+
+	t.Run("unsigned artifact", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+
+		_, _, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t, secret))
+		require.ErrorIs(t, err, ErrSignatureVerification)
+		assert.Contains(t, err.Error(), "no signature found")
+	})
+
+	t.Run("signed with another key", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		manifestDigest := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+
+		payload := signaturePayload(t, manifestDigest)
+		reg.pushSignature(t, "team/boards", manifestDigest, payload, map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, newTestKey(t), payload)),
+		})
+
+		_, _, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t, secret))
+		require.ErrorIs(t, err, ErrSignatureVerification)
+		assert.Contains(t, err.Error(), "invalid signature")
+	})
+
+	t.Run("signature of another artifact", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		manifestDigest := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+		otherDigest := reg.pushArtifact(t, "team/boards", "v2", map[string][]byte{"board.json": []byte(`{"title":"other"}`)})
+
+		// a valid signature of v2 copied to v1
+		payload := signaturePayload(t, otherDigest)
+		reg.pushSignature(t, "team/boards", manifestDigest, payload, map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, key, payload)),
+		})
+
+		_, _, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t, secret))
+		require.ErrorIs(t, err, ErrSignatureVerification)
+		assert.Contains(t, err.Error(), "signature is for "+otherDigest)
+	})
+
+	t.Run("registry errors are not verification failures", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		manifestDigest := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+
+		payload := signaturePayload(t, manifestDigest)
+		reg.pushSignature(t, "team/boards", manifestDigest, payload, map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, key, payload)),
+		})
+		delete(reg.blobs, sha256Digest(payload))
+
+		_, _, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t, secret))
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrSignatureVerification)
+		assert.Contains(t, err.Error(), "fetch signature")
+	})
+
+	t.Run("missing public key", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+
+		_, _, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t))
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrSignatureVerification)
+		assert.Contains(t, err.Error(), "loading public key")
+	})
+}
+
+// testSigstore is a certificate authority and transparency log issuing keyless signatures
+type testSigstore struct {
+	t        *testing.T
+	caKey    *ecdsa.PrivateKey
+	ca       *x509.Certificate
+	caPEM    string
+	rekorKey *ecdsa.PrivateKey
+}
+
+func newTestSigstore(t *testing.T) *testSigstore {
+	t.Helper()
+
+	caKey := newTestKey(t)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sigstore"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testSigstore{
+		t:        t,
+		caKey:    caKey,
+		ca:       ca,
+		caPEM:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		rekorKey: newTestKey(t),
+	}
+}
+
+// sign returns the annotations of a keyless signature of payload by subject, logged at integratedTime
+func (s *testSigstore) sign(payload []byte, subject string, integratedTime time.Time) map[string]string {
+	s.t.Helper()
+
+	key := newTestKey(s.t)
+
+	issuer, err := asn1.Marshal(testIssuer)
+	require.NoError(s.t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-5 * time.Minute),
+		NotAfter:     time.Now().Add(5 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{
+			{Id: oidFulcioIssuerV2, Value: issuer},
+		},
+	}
+
+	// workflow identities are URIs, user identities email addresses
+	if uri, err := url.Parse(subject); err == nil && uri.Scheme != "" {
+		template.URIs = []*url.URL{uri}
+	} else {
+		template.EmailAddresses = []string{subject}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, s.ca, &key.PublicKey, s.caKey)
+	require.NoError(s.t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	signature := sign(s.t, key, payload)
+	hash := sha256.Sum256(payload)
+
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data": map[string]any{
+				"hash": map[string]any{"algorithm": "sha256", "value": hex.EncodeToString(hash[:])},
+			},
+			"signature": map[string]any{
+				"content":   signature,
+				"publicKey": map[string]any{"content": certPEM},
+			},
+		},
+	})
+	require.NoError(s.t, err)
+
+	rekorLog, err := transparencyLog(&s.rekorKey.PublicKey)
+	require.NoError(s.t, err)
+
+	entry := rekorBundlePayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: integratedTime.Unix(),
+		LogID:          hex.EncodeToString(rekorLog.ID),
+		LogIndex:       42,
+	}
+
+	canonical, err := json.Marshal(entry)
+	require.NoError(s.t, err)
+
+	bundle, err := json.Marshal(rekorBundle{
+		SignedEntryTimestamp: sign(s.t, s.rekorKey, canonical),
+		Payload:              entry,
+	})
+	require.NoError(s.t, err)
+
+	return map[string]string{
+		cosignSignatureAnnotation:   base64.StdEncoding.EncodeToString(signature),
+		cosignCertificateAnnotation: string(certPEM),
+		cosignChainAnnotation:       s.caPEM,
+		cosignBundleAnnotation:      string(bundle),
+	}
+}
+
+func TestFetchFromOCIKeyless(t *testing.T) {
+	const wantJSON = `{"title":"signed"}`
+
+	sigstore := newTestSigstore(t)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sigstore"},
+		Data: map[string][]byte{
+			"fulcio.crt": []byte(sigstore.caPEM),
+			"rekor.pub":  []byte(publicKeyPEM(t, &sigstore.rekorKey.PublicKey)),
+		},
+	}
+
+	verify := &v1beta1.GrafanaContentOCIVerify{
+		Keyless: &v1beta1.GrafanaContentOCIKeyless{
+			Issuer:         testIssuer,
+			Subject:        testSubject,
+			Roots:          keyRef("sigstore", "fulcio.crt"),
+			RekorPublicKey: keyRef("sigstore", "rekor.pub"),
+		},
+	}
+
+	fetch := func(t *testing.T, annotations func(payload []byte) map[string]string) (string, []byte, OCIRevision, error) {
+		t.Helper()
+
+		reg, host := newFakeRegistry(t)
+		manifestDigest := reg.pushArtifact(t, "team/boards", "v1", map[string][]byte{"board.json": []byte(wantJSON)})
+
+		payload := signaturePayload(t, manifestDigest)
+		reg.pushSignature(t, "team/boards", manifestDigest, payload, annotations(payload))
+
+		got, revision, err := FetchFromOCI(context.Background(), verifiedOCIDashboard(host+"/team/boards:v1", verify), tk8s.GetFakeClient(t, secret))
+
+		return manifestDigest, got, revision, err
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		manifestDigest, got, revision, err := fetch(t, func(payload []byte) map[string]string {
+			return sigstore.sign(payload, testSubject, time.Now())
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, wantJSON, string(got))
+		assert.Equal(t, manifestDigest, revision.Digest)
+		assert.True(t, revision.Verified)
+	})
+
+	t.Run("other identity", func(t *testing.T) {
+		_, _, _, err := fetch(t, func(payload []byte) map[string]string {
+			return sigstore.sign(payload, "someone@example.com", time.Now())
+		})
+		require.ErrorIs(t, err, ErrSignatureVerification)
+		assert.Contains(t, err.Error(), "expected SAN value")
+	})
+
+	t.Run("logged after the certificate expired", func(t *testing.T) {
+		_, _, _, err := fetch(t, func(payload []byte) map[string]string {
+			return sigstore.sign(payload, testSubject, time.Now().Add(30*time.Minute))
+		})
+		require.ErrorIs(t, err, ErrSignatureVerification)
+		assert.Contains(t, err.Error(), "integrated time outside certificate validity")
+	})
+
+	t.Run("tampered transparency log entry", func(t *testing.T) {
+		_, _, _, err := fetch(t, func(payload []byte) map[string]string {
+			annotations := sigstore.sign(payload, testSubject, time.Now())
+
+			var bundle rekorBundle
+			require.NoError(t, json.Unmarshal([]byte(annotations[cosignBundleAnnotation]), &bundle))
+
+			bundle.Payload.LogIndex++
+
+			tampered, err := json.Marshal(bundle)
+			require.NoError(t, err)
+
+			annotations[cosignBundleAnnotation] = string(tampered)
+
+			return annotations
+		})
+		require.ErrorIs(t, err, ErrSignatureVerification)
+		assert.Contains(t, err.Error(), "failed to verify log inclusion")
+	})
+
+	t.Run("certificate of another authority", func(t *testing.T) {
+		_, _, _, err := fetch(t, func(payload []byte) map[string]string {
+			return newTestSigstore(t).sign(payload, testSubject, time.Now())
+		})
+		require.ErrorIs(t, err, ErrSignatureVerification)
+	})
+}
@@ -3,11 +3,14 @@ package fetchers
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
@@ -17,22 +20,48 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-func FetchFromURL(ctx context.Context, cr v1beta1.GrafanaContentResource, cl client.Client, tlsConfig *tls.Config) ([]byte, error) {
+// URLValidators identify the version of a document fetched from a URL, used for conditional requests
+type URLValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// FetchFromURL returns the document at spec.url and its validators. Once the content cache expired, the cached
+// document is revalidated through If-None-Match and If-Modified-Since and reused if the server responds with 304
+func FetchFromURL(ctx context.Context, cr v1beta1.GrafanaContentResource, cl client.Client, tlsConfig *tls.Config) ([]byte, URLValidators, error) {
 	spec := cr.GrafanaContentSpec()
+	status := cr.GrafanaContentStatus()
+
+	cachedValidators := URLValidators{
+		ETag:         status.ContentETag,
+		LastModified: status.ContentLastModified,
+	}
 
 	u, err := url.Parse(spec.URL)
 	if err != nil {
-		return nil, err
+		return nil, URLValidators{}, err
 	}
 
 	cached := cache.GetContentCache(cr)
 	if len(cached) > 0 {
-		return cached, nil
+		return cached, cachedValidators, nil
 	}
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
 	if err != nil {
-		return nil, err
+		return nil, URLValidators{}, err
+	}
+
+	// only revalidate when the expired document is still available
+	expired := cache.GetExpiredContentCache(cr)
+	if len(expired) > 0 {
+		if cachedValidators.ETag != "" {
+			request.Header.Set("If-None-Match", cachedValidators.ETag)
+		}
+
+		if cachedValidators.LastModified != "" {
+			request.Header.Set("If-Modified-Since", cachedValidators.LastModified)
+		}
 	}
 
 	contentMetric, err := metrics.ContentURLRequests.CurryWith(prometheus.Labels{
@@ -40,7 +69,7 @@ func FetchFromURL(ctx context.Context, cr v1beta1.GrafanaContentResource, cl cli
 		"resource": fmt.Sprintf("%v/%v", cr.GetNamespace(), cr.GetName()),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("building dashboards metric: %w", err)
+		return nil, URLValidators{}, fmt.Errorf("building dashboards metric: %w", err)
 	}
 
 	// this is a documented deprecated metric but we don't want to fail lint
@@ -49,43 +78,165 @@ func FetchFromURL(ctx context.Context, cr v1beta1.GrafanaContentResource, cl cli
 		"dashboard": fmt.Sprintf("%v/%v", cr.GetNamespace(), cr.GetName()),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("building dashboards metric: %w", err)
+		return nil, URLValidators{}, fmt.Errorf("building dashboards metric: %w", err)
+	}
+
+	authorization := spec.URLAuthorization
+	if authorization != nil {
+		err = setURLAuthorization(ctx, cl, cr, authorization, request)
+		if err != nil {
+			return nil, URLValidators{}, err
+		}
+
+		if authorization.TLS != nil {
+			tlsConfig, err = buildURLTLSConfig(ctx, cl, cr.GetNamespace(), authorization.TLS)
+			if err != nil {
+				return nil, URLValidators{}, fmt.Errorf("building tls configuration: %w", err)
+			}
+		}
 	}
 
 	httpClient := grafanaclient.NewInstrumentedRoundTripper(true, tlsConfig, contentMetric, dashboardMetric)
-	// basic auth is supported for dashboards from url
-	if spec.URLAuthorization != nil && spec.URLAuthorization.BasicAuth != nil {
-		username, err := grafanaclient.GetValueFromSecretKey(ctx, cl, cr.GetNamespace(), spec.URLAuthorization.BasicAuth.Username)
+
+	response, err := httpClient.RoundTrip(request)
+	if err != nil {
+		return nil, URLValidators{}, err
+	}
+	defer response.Body.Close()
+
+	validators := URLValidators{
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+	}
+
+	if response.StatusCode == http.StatusNotModified && len(expired) > 0 {
+		// a 304 may omit validators that did not change
+		if validators.ETag == "" {
+			validators.ETag = cachedValidators.ETag
+		}
+
+		if validators.LastModified == "" {
+			validators.LastModified = cachedValidators.LastModified
+		}
+
+		return expired, validators, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, URLValidators{}, fmt.Errorf("unexpected status code from dashboard url request, get %v for dashboard %v", response.StatusCode, cr.GetName())
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, URLValidators{}, err
+	}
+
+	return content, validators, nil
+}
+
+// setURLAuthorization adds the headers of headersFrom followed by basic auth or the bearer token to the request
+func setURLAuthorization(ctx context.Context, cl client.Client, cr v1beta1.GrafanaContentResource, authorization *v1beta1.GrafanaContentURLAuthorization, request *http.Request) error {
+	for _, ref := range authorization.HeadersFrom {
+		secret := &corev1.Secret{}
+
+		err := cl.Get(ctx, client.ObjectKey{Namespace: cr.GetNamespace(), Name: ref.Name}, secret)
+		if err != nil {
+			return fmt.Errorf("getting headers from secret %s/%s: %w", cr.GetNamespace(), ref.Name, err)
+		}
+
+		for name, value := range secret.Data {
+			request.Header.Set(name, string(value))
+		}
+	}
+
+	if authorization.BasicAuth != nil {
+		username, err := grafanaclient.GetValueFromSecretKey(ctx, cl, cr.GetNamespace(), authorization.BasicAuth.Username)
+		if err != nil {
+			return err
+		}
+
+		password, err := grafanaclient.GetValueFromSecretKey(ctx, cl, cr.GetNamespace(), authorization.BasicAuth.Password)
+		if err != nil {
+			return err
+		}
+
+		if username == nil || password == nil {
+			return fmt.Errorf("basic auth username and/or password are missing for dashboard %s/%s", cr.GetNamespace(), cr.GetName())
+		}
+
+		request.SetBasicAuth(string(username), string(password))
+	}
+
+	if authorization.BearerToken != nil {
+		token, err := grafanaclient.GetValueFromSecretKey(ctx, cl, cr.GetNamespace(), authorization.BearerToken)
+		if err != nil {
+			return err
+		}
+
+		request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	return nil
+}
+
+// buildURLTLSConfig returns the tls.Config verifying the server against the CA bundle and presenting the client certificate
+func buildURLTLSConfig(ctx context.Context, cl client.Client, namespace string, spec *v1beta1.GrafanaContentURLTLS) (*tls.Config, error) {
+	if spec.InsecureSkipVerify {
+		tlsConfig := grafanaclient.InsecureTLSConfiguration.Clone()
+
+		err := setURLClientCertificate(ctx, cl, namespace, spec, tlsConfig)
 		if err != nil {
 			return nil, err
 		}
 
-		password, err := grafanaclient.GetValueFromSecretKey(ctx, cl, cr.GetNamespace(), spec.URLAuthorization.BasicAuth.Password)
+		return tlsConfig, nil
+	}
+
+	tlsConfig := grafanaclient.DefaultTLSConfiguration.Clone()
+
+	if spec.CA != nil {
+		ca, err := grafanaclient.GetValueFromSecretKey(ctx, cl, namespace, spec.CA)
 		if err != nil {
 			return nil, err
 		}
 
-		if username != nil && password != nil {
-			request.SetBasicAuth(string(username), string(password))
-		} else {
-			return nil, fmt.Errorf("basic auth username and/or password are missing for dashboard %s/%s", cr.GetNamespace(), cr.GetName())
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s/%s key %s", namespace, spec.CA.Name, spec.CA.Key)
 		}
+
+		tlsConfig.RootCAs = pool
 	}
 
-	response, err := httpClient.RoundTrip(request)
+	err := setURLClientCertificate(ctx, cl, namespace, spec, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code from dashboard url request, get %v for dashboard %v", response.StatusCode, cr.GetName())
+	return tlsConfig, nil
+}
+
+func setURLClientCertificate(ctx context.Context, cl client.Client, namespace string, spec *v1beta1.GrafanaContentURLTLS, tlsConfig *tls.Config) error {
+	if spec.Cert == nil || spec.Key == nil {
+		return nil
 	}
 
-	content, err := io.ReadAll(response.Body)
+	cert, err := grafanaclient.GetValueFromSecretKey(ctx, cl, namespace, spec.Cert)
+	if err != nil {
+		return err
+	}
+
+	key, err := grafanaclient.GetValueFromSecretKey(ctx, cl, namespace, spec.Key)
 	if err != nil {
-		return []byte{}, err
+		return err
 	}
 
-	return content, nil
+	pair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return fmt.Errorf("parsing client certificate: %w", err)
+	}
+
+	tlsConfig.Certificates = []tls.Certificate{pair}
+
+	return nil
 }
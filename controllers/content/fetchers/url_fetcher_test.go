@@ -2,17 +2,33 @@ package fetchers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/controllers/content/cache"
+	"github.com/grafana/grafana-operator/v5/controllers/metrics"
 	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
 )
 
@@ -92,7 +108,7 @@ func TestFetchFromURL(t *testing.T) {
 			Status: v1beta1.GrafanaDashboardStatus{},
 		}
 
-		got, err := FetchFromURL(context.Background(), dashboard, cl, nil)
+		got, _, err := FetchFromURL(context.Background(), dashboard, cl, nil)
 		require.NoError(t, err)
 
 		assert.Equal(t, want, got)
@@ -120,9 +136,298 @@ func TestFetchFromURL(t *testing.T) {
 		err := cl.Create(context.Background(), credentialsSecret)
 		require.NoError(t, err)
 
-		got, err := FetchFromURL(context.Background(), dashboard, cl, nil)
+		got, _, err := FetchFromURL(context.Background(), dashboard, cl, nil)
 		require.NoError(t, err)
 
 		assert.Equal(t, want, got)
 	})
 }
+
+func TestFetchFromURLConditional(t *testing.T) {
+	const (
+		etag         = `"v1"`
+		lastModified = "Sun, 18 Oct 2026 10:00:00 GMT"
+	)
+
+	want := []byte(`{"title":"conditional"}`)
+
+	var requests []*http.Request
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req)
+
+		if req.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(want))
+	}))
+
+	t.Cleanup(ts.Close)
+
+	dashboard := &v1beta1.GrafanaDashboard{
+		TypeMeta: metav1.TypeMeta{Kind: "GrafanaDashboard"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "conditional",
+			Namespace: "default",
+		},
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				URL:                  ts.URL,
+				ContentCacheDuration: metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+
+	cl := tk8s.GetFakeClient(t)
+
+	got, validators, err := FetchFromURL(context.Background(), dashboard, cl, nil)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, URLValidators{ETag: etag, LastModified: lastModified}, validators)
+	assert.Empty(t, requests[0].Header.Get("If-None-Match"))
+
+	// the resolver stores the validators along with the cached model
+	model := map[string]any{"title": "conditional"}
+
+	err = cache.SetContentCache(dashboard, model)
+	require.NoError(t, err)
+
+	dashboard.Status.ContentETag = validators.ETag
+	dashboard.Status.ContentLastModified = validators.LastModified
+
+	t.Run("cache is used while valid", func(t *testing.T) {
+		_, _, err := FetchFromURL(context.Background(), dashboard, cl, nil)
+		require.NoError(t, err)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("expired cache is revalidated", func(t *testing.T) {
+		dashboard.Status.ContentTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+
+		got, validators, err := FetchFromURL(context.Background(), dashboard, cl, nil)
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+		assert.Equal(t, etag, requests[1].Header.Get("If-None-Match"))
+		assert.Equal(t, lastModified, requests[1].Header.Get("If-Modified-Since"))
+		assert.JSONEq(t, string(want), string(got))
+		assert.Equal(t, URLValidators{ETag: etag, LastModified: lastModified}, validators)
+
+		counter, err := metrics.ContentURLRequests.GetMetricWithLabelValues("GrafanaDashboard", "default/conditional", http.MethodGet, "304")
+		require.NoError(t, err)
+
+		m := &dto.Metric{}
+
+		err = counter.Write(m)
+		require.NoError(t, err)
+		assert.InDelta(t, 1, m.GetCounter().GetValue(), 0)
+	})
+
+	t.Run("no conditional request without cached content", func(t *testing.T) {
+		dashboard.Status.ContentDigest = ""
+
+		got, _, err := FetchFromURL(context.Background(), dashboard, cl, nil)
+		require.NoError(t, err)
+		require.Len(t, requests, 3)
+		assert.Empty(t, requests[2].Header.Get("If-None-Match"))
+		assert.Equal(t, want, got)
+	})
+}
+
+func urlDashboard(url string, authorization *v1beta1.GrafanaContentURLAuthorization) *v1beta1.GrafanaDashboard {
+	return &v1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "url-authorization",
+			Namespace: "default",
+		},
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				URL:              url,
+				URLAuthorization: authorization,
+			},
+		},
+	}
+}
+
+func TestFetchFromURLAuthorization(t *testing.T) {
+	want := []byte(`{"title":"authorized"}`)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret-token" || req.Header.Get("X-Scope-OrgID") != "team-a" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprint(w, string(want))
+	}))
+
+	t.Cleanup(ts.Close)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("secret-token\n")},
+	}
+
+	headers := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "headers", Namespace: "default"},
+		Data:       map[string][]byte{"X-Scope-OrgID": []byte("team-a")},
+	}
+
+	cl := tk8s.GetFakeClient(t, secret, headers)
+
+	t.Run("bearer token and headers", func(t *testing.T) {
+		cr := urlDashboard(ts.URL, &v1beta1.GrafanaContentURLAuthorization{
+			BearerToken: tk8s.GetSecretKeySelector(t, "token", "token"),
+			HeadersFrom: []corev1.LocalObjectReference{{Name: "headers"}},
+		})
+
+		got, _, err := FetchFromURL(context.Background(), cr, cl, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		cr := urlDashboard(ts.URL, &v1beta1.GrafanaContentURLAuthorization{
+			BearerToken: tk8s.GetSecretKeySelector(t, "token", "token"),
+		})
+
+		_, _, err := FetchFromURL(context.Background(), cr, cl, nil)
+		require.ErrorContains(t, err, "unexpected status code")
+	})
+
+	t.Run("missing headers secret", func(t *testing.T) {
+		cr := urlDashboard(ts.URL, &v1beta1.GrafanaContentURLAuthorization{
+			HeadersFrom: []corev1.LocalObjectReference{{Name: "missing"}},
+		})
+
+		_, _, err := FetchFromURL(context.Background(), cr, cl, nil)
+		require.ErrorContains(t, err, "getting headers from secret default/missing")
+	})
+}
+
+// newTestCertificate returns a PEM encoded certificate and key signed by parent, self-signed without parent
+func newTestCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "grafana-operator-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return cert, key,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestFetchFromURLTLS(t *testing.T) {
+	want := []byte(`{"title":"tls"}`)
+
+	ca, caKey, caPEM, _ := newTestCertificate(t, nil, nil, true)
+	_, _, serverPEM, serverKeyPEM := newTestCertificate(t, ca, caKey, false)
+	_, _, clientPEM, clientKeyPEM := newTestCertificate(t, ca, caKey, false)
+
+	serverCert, err := tls.X509KeyPair(serverPEM, serverKeyPEM)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, string(want))
+	}))
+	ts.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	// rejected handshakes are expected
+	ts.Config.ErrorLog = log.New(io.Discard, "", 0)
+	ts.StartTLS()
+
+	t.Cleanup(ts.Close)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default"},
+		Data: map[string][]byte{
+			"ca.crt":  caPEM,
+			"tls.crt": clientPEM,
+			"tls.key": clientKeyPEM,
+		},
+	}
+
+	cl := tk8s.GetFakeClient(t, secret)
+
+	t.Run("server certificate is verified by default", func(t *testing.T) {
+		cr := urlDashboard(ts.URL, nil)
+
+		_, _, err := FetchFromURL(context.Background(), cr, cl, grafanaclient.DefaultTLSConfiguration)
+		require.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("client certificate is required", func(t *testing.T) {
+		cr := urlDashboard(ts.URL, &v1beta1.GrafanaContentURLAuthorization{
+			TLS: &v1beta1.GrafanaContentURLTLS{
+				CA: tk8s.GetSecretKeySelector(t, "tls", "ca.crt"),
+			},
+		})
+
+		_, _, err := FetchFromURL(context.Background(), cr, cl, grafanaclient.DefaultTLSConfiguration)
+		require.Error(t, err)
+	})
+
+	t.Run("ca bundle and client certificate", func(t *testing.T) {
+		cr := urlDashboard(ts.URL, &v1beta1.GrafanaContentURLAuthorization{
+			TLS: &v1beta1.GrafanaContentURLTLS{
+				CA:   tk8s.GetSecretKeySelector(t, "tls", "ca.crt"),
+				Cert: tk8s.GetSecretKeySelector(t, "tls", "tls.crt"),
+				Key:  tk8s.GetSecretKeySelector(t, "tls", "tls.key"),
+			},
+		})
+
+		got, _, err := FetchFromURL(context.Background(), cr, cl, grafanaclient.DefaultTLSConfiguration)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		cr := urlDashboard(ts.URL, &v1beta1.GrafanaContentURLAuthorization{
+			TLS: &v1beta1.GrafanaContentURLTLS{
+				InsecureSkipVerify: true,
+				Cert:               tk8s.GetSecretKeySelector(t, "tls", "tls.crt"),
+				Key:                tk8s.GetSecretKeySelector(t, "tls", "tls.key"),
+			},
+		})
+
+		got, _, err := FetchFromURL(context.Background(), cr, cl, grafanaclient.DefaultTLSConfiguration)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"sync"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/grafana/grafana-operator/v5/embeds"
 	"github.com/itchyny/gojq"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -24,8 +26,19 @@ type Resolver struct {
 	Client          client.Client
 	resource        v1beta1.GrafanaContentResource
 	disabledSources []SourceType
+	gitCommit       string
+	ociRevision     fetchers.OCIRevision
+	urlValidators   fetchers.URLValidators
+
+	// fetched content and envs of templates, kept to render them for each Grafana instance
+	template    []byte
+	templateEnv map[string]string
+	uid         any
 }
 
+// ErrSignatureVerification is returned by Resolve when the content does not satisfy the signature verification policy
+var ErrSignatureVerification = fetchers.ErrSignatureVerification
+
 type Option func(r *Resolver)
 
 func WithDisabledSources(disabledSources []SourceType) Option {
@@ -53,11 +66,98 @@ func (h *Resolver) Resolve(ctx context.Context) (map[string]any, string, error)
 		return nil, "", fmt.Errorf("failed to fetch contents: %w", err)
 	}
 
+	if templating := h.resource.GrafanaContentSpec().Templating; templating != nil {
+		h.template = j
+
+		h.templateEnv, err = h.getContentEnvs(ctx)
+		if err != nil {
+			return nil, "", fmt.Errorf("something went wrong while collecting envs, error: %w", err)
+		}
+
+		if len(templating.GrafanaRefs) > 0 {
+			return h.resolveTemplate(templating)
+		}
+
+		j, err = renderTemplate(h.resource.GetName(), j, templating, templateValues{Env: h.templateEnv, Grafana: map[string]string{}})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to render template: %w", err)
+		}
+	}
+
+	model, hash, err := h.getContentModel(j)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to extract model: %w", err)
+	}
+
+	h.uid = model["uid"]
+
+	return model, hash, nil
+}
+
+// resolveTemplate defers rendering content using values of Grafana instances to ResolveForInstance, the returned model
+// only holds the uid. The uid is taken from a render without instance values, templates failing to render or
+// producing invalid JSON without them use spec.uid or the uid of the resource instead
+func (h *Resolver) resolveTemplate(templating *v1beta1.GrafanaContentTemplating) (map[string]any, string, error) {
+	_, err := parseTemplate(h.resource.GetName(), h.template, templating)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	contentUID := ""
+
+	j, err := renderTemplate(h.resource.GetName(), h.template, templating, templateValues{Env: h.templateEnv, Grafana: map[string]string{}})
+	if err == nil {
+		model := struct {
+			UID string `json:"uid"`
+		}{}
+
+		if json.Unmarshal(j, &model) == nil {
+			contentUID = model.UID
+		}
+	}
+
+	h.uid = GetGrafanaUID(h.resource, contentUID)
+
+	hash := sha256.New()
+	hash.Write(h.template)
+
+	for _, name := range slices.Sorted(maps.Keys(h.templateEnv)) {
+		hash.Write([]byte(name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(h.templateEnv[name]))
+		hash.Write([]byte{0})
+	}
+
+	for _, v := range h.contentVariables() {
+		hash.Write([]byte(v.Name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(v.Value))
+		hash.Write([]byte{0})
+	}
+
+	return map[string]any{"uid": h.uid}, fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// ResolveForInstance renders templated content with the values of a Grafana instance, Resolve must be called first.
+// The uid is the one returned by Resolve, so that the content is tracked the same way in all instances
+func (h *Resolver) ResolveForInstance(grafanaValues map[string]string) (map[string]any, string, error) {
+	templating := h.resource.GrafanaContentSpec().Templating
+	if templating == nil || h.template == nil {
+		return nil, "", errors.New("content is not templated or not resolved yet")
+	}
+
+	j, err := renderTemplate(h.resource.GetName(), h.template, templating, templateValues{Env: h.templateEnv, Grafana: grafanaValues})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to render template: %w", err)
+	}
+
 	model, hash, err := h.getContentModel(j)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to extract model: %w", err)
 	}
 
+	model["uid"] = h.uid
+
 	return model, hash, nil
 }
 
@@ -83,19 +183,12 @@ func (h *Resolver) resolveDatasources(contentJSON []byte) ([]byte, error) {
 // fetchContentJSON delegates obtaining the json definition to one of the known fetchers, for example
 // from embedded raw json or from a url
 func (h *Resolver) fetchContentJSON(ctx context.Context) ([]byte, error) {
-	sourceTypes := GetSourceTypes(h.resource)
-
-	if len(sourceTypes) == 0 {
-		return nil, fmt.Errorf("no source type provided for content resource %v", h.resource.GetName())
-	}
-
-	if len(sourceTypes) > 1 {
-		return nil, fmt.Errorf("more than one source types found for content resource %v", h.resource.GetName())
+	err := ValidateSourceTypes(h.resource, h.disabledSources...)
+	if err != nil {
+		return nil, err
 	}
 
-	if slices.Contains(h.disabledSources, sourceTypes[0]) {
-		return nil, fmt.Errorf("source type %v is disabled for content resource %v", sourceTypes[0], h.resource.GetName())
-	}
+	sourceTypes := GetSourceTypes(h.resource)
 
 	spec := h.resource.GrafanaContentSpec()
 
@@ -105,7 +198,14 @@ func (h *Resolver) fetchContentJSON(ctx context.Context) ([]byte, error) {
 	case SourceTypeGzipJSON:
 		return cache.Gunzip(spec.GzipJSON)
 	case SourceTypeURL:
-		return fetchers.FetchFromURL(ctx, h.resource, h.Client, grafanaclient.InsecureTLSConfiguration)
+		j, validators, err := fetchers.FetchFromURL(ctx, h.resource, h.Client, grafanaclient.DefaultTLSConfiguration)
+		if err != nil {
+			return nil, err
+		}
+
+		h.urlValidators = validators
+
+		return j, nil
 	case SourceTypeJsonnet:
 		envs, err := h.getContentEnvs(ctx)
 		if err != nil {
@@ -121,11 +221,34 @@ func (h *Resolver) fetchContentJSON(ctx context.Context) ([]byte, error) {
 
 		return fetchers.BuildProjectAndFetchJsonnetFrom(ctx, h.resource, envs)
 	case SourceTypeGrafanaCom:
-		return fetchers.FetchFromGrafanaCom(ctx, h.resource, h.Client)
+		j, validators, err := fetchers.FetchFromGrafanaCom(ctx, h.resource, h.Client)
+		if err != nil {
+			return nil, err
+		}
+
+		h.urlValidators = validators
+
+		return j, nil
 	case SourceConfigMap:
 		return fetchers.FetchDashboardFromConfigMap(ctx, h.resource, h.Client)
 	case SourceOCI:
-		return fetchers.FetchFromOCI(ctx, h.resource, h.Client)
+		j, revision, err := fetchers.FetchFromOCI(ctx, h.resource, h.Client)
+		if err != nil {
+			return nil, err
+		}
+
+		h.ociRevision = revision
+
+		return j, nil
+	case SourceGit:
+		j, commit, err := fetchers.FetchFromGit(ctx, h.resource, h.Client)
+		if err != nil {
+			return nil, err
+		}
+
+		h.gitCommit = commit
+
+		return j, nil
 	default:
 		return nil, fmt.Errorf("unknown source type %v found in content resource %v", sourceTypes[0], h.resource.GetName())
 	}
@@ -335,10 +458,30 @@ func (h *Resolver) UpdateCache(model map[string]any) error {
 	// GetSourceTypes needs to be of length 1 for this function to even be called
 	sourceType := GetSourceTypes(h.resource)[0]
 
+	// validators, commit and digests are stored along with the cached content they belong to
+	status := h.resource.GrafanaContentStatus()
+	status.GitCommit = h.gitCommit
+	status.OCIDigest = h.ociRevision.Digest
+	status.OCITag = h.ociRevision.Tag
+	status.OCIResolvedTimestamp = metav1.NewTime(h.ociRevision.ResolvedAt)
+
+	status.VerifiedDigest = ""
+	if h.ociRevision.Verified {
+		status.VerifiedDigest = h.ociRevision.Digest
+	}
+
+	status.ContentETag = h.urlValidators.ETag
+	status.ContentLastModified = h.urlValidators.LastModified
+
 	// only cache remote resources
-	if sourceType != SourceTypeURL && sourceType != SourceTypeGrafanaCom && sourceType != SourceOCI {
+	if sourceType != SourceTypeURL && sourceType != SourceTypeGrafanaCom && sourceType != SourceOCI && sourceType != SourceGit {
 		return nil
 	}
 
+	// templates are cached before rendering, they are rendered again for every Grafana instance
+	if h.template != nil {
+		return cache.SetRawContentCache(h.resource, h.template)
+	}
+
 	return cache.SetContentCache(h.resource, model)
 }
@@ -1,6 +1,11 @@
 package content
 
-import "github.com/grafana/grafana-operator/v5/api/v1beta1"
+import (
+	"fmt"
+	"slices"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+)
 
 type SourceType string
 
@@ -13,6 +18,7 @@ const (
 	SourceTypeGrafanaCom SourceType = "grafana"
 	SourceConfigMap      SourceType = "configmap"
 	SourceOCI            SourceType = "oci"
+	SourceGit            SourceType = "git"
 )
 
 func GetSourceTypes(cr v1beta1.GrafanaContentResource) []SourceType {
@@ -52,5 +58,28 @@ func GetSourceTypes(cr v1beta1.GrafanaContentResource) []SourceType {
 		sourceTypes = append(sourceTypes, SourceOCI)
 	}
 
+	if spec.Git != nil {
+		sourceTypes = append(sourceTypes, SourceGit)
+	}
+
 	return sourceTypes
 }
+
+// ValidateSourceTypes ensures exactly one source type is set and that it is not one of the disabled sources
+func ValidateSourceTypes(cr v1beta1.GrafanaContentResource, disabledSources ...SourceType) error {
+	sourceTypes := GetSourceTypes(cr)
+
+	if len(sourceTypes) == 0 {
+		return fmt.Errorf("no source type provided for content resource %v", cr.GetName())
+	}
+
+	if len(sourceTypes) > 1 {
+		return fmt.Errorf("more than one source types found for content resource %v", cr.GetName())
+	}
+
+	if slices.Contains(disabledSources, sourceTypes[0]) {
+		return fmt.Errorf("source type %v is disabled for content resource %v", sourceTypes[0], cr.GetName())
+	}
+
+	return nil
+}
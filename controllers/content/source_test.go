@@ -0,0 +1,71 @@
+package content
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateSourceTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     v1beta1.GrafanaContentSpec
+		disabled []SourceType
+		wantErr  string
+	}{
+		{
+			name:    "no source",
+			spec:    v1beta1.GrafanaContentSpec{},
+			wantErr: "no source type provided",
+		},
+		{
+			name: "single source",
+			spec: v1beta1.GrafanaContentSpec{JSON: "{}"},
+		},
+		{
+			name: "multiple sources",
+			spec: v1beta1.GrafanaContentSpec{
+				JSON: "{}",
+				URL:  "https://example.com/dashboard.json",
+			},
+			wantErr: "more than one source types found",
+		},
+		{
+			name: "git source",
+			spec: v1beta1.GrafanaContentSpec{Git: &v1beta1.GrafanaContentGit{URL: "https://github.com/team/dashboards.git", Path: "board.json"}},
+		},
+		{
+			name: "git and url",
+			spec: v1beta1.GrafanaContentSpec{
+				URL: "https://example.com/dashboard.json",
+				Git: &v1beta1.GrafanaContentGit{URL: "https://github.com/team/dashboards.git", Path: "board.json"},
+			},
+			wantErr: "more than one source types found",
+		},
+		{
+			name:     "disabled source",
+			spec:     v1beta1.GrafanaContentSpec{GrafanaCom: &v1beta1.GrafanaComContentReference{ID: 1860}},
+			disabled: []SourceType{SourceTypeGrafanaCom},
+			wantErr:  "source type grafana is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1beta1.GrafanaDashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard"},
+				Spec:       v1beta1.GrafanaDashboardSpec{GrafanaContentSpec: tt.spec},
+			}
+
+			err := ValidateSourceTypes(cr, tt.disabled...)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
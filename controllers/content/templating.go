@@ -0,0 +1,164 @@
+package content
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+)
+
+// templateValues are available to templated content as .Env and .Grafana
+type templateValues struct {
+	Env     map[string]string
+	Grafana map[string]string
+}
+
+// templateFuncs is the subset of the sprig functions commonly used in Helm charts, arguments are in the same order
+var templateFuncs = template.FuncMap{
+	"default":    templateDefault,
+	"empty":      templateEmpty,
+	"coalesce":   templateCoalesce,
+	"required":   templateRequired,
+	"ternary":    templateTernary,
+	"quote":      func(s any) string { return fmt.Sprintf("%q", fmt.Sprint(s)) },
+	"squote":     func(s any) string { return "'" + fmt.Sprint(s) + "'" },
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"splitList":  func(sep, s string) []string { return strings.Split(s, sep) },
+	"join":       templateJoin,
+	"list":       func(items ...any) []any { return items },
+	"indent":     templateIndent,
+	"nindent":    func(spaces int, s string) string { return "\n" + templateIndent(spaces, s) },
+	"toJson":     templateToJSON,
+	"b64enc":     func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"b64dec":     templateB64Dec,
+}
+
+func parseTemplate(name string, content []byte, templating *v1beta1.GrafanaContentTemplating) (*template.Template, error) {
+	left, right := templating.LeftDelimiter, templating.RightDelimiter
+
+	tmpl, err := template.New(name).Delims(left, right).Funcs(templateFuncs).Option("missingkey=zero").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// renderTemplate executes content as template, missing keys of .Env and .Grafana render as empty strings
+func renderTemplate(name string, content []byte, templating *v1beta1.GrafanaContentTemplating, values templateValues) ([]byte, error) {
+	tmpl, err := parseTemplate(name, content, templating)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, values)
+	if err != nil {
+		return nil, fmt.Errorf("rendering template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func templateEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+func templateDefault(fallback any, value ...any) any {
+	if len(value) == 0 || templateEmpty(value[0]) {
+		return fallback
+	}
+
+	return value[0]
+}
+
+func templateCoalesce(values ...any) any {
+	for _, v := range values {
+		if !templateEmpty(v) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func templateRequired(message string, value any) (any, error) {
+	if templateEmpty(value) {
+		return nil, errors.New(message)
+	}
+
+	return value, nil
+}
+
+func templateTernary(whenTrue, whenFalse any, condition bool) any {
+	if condition {
+		return whenTrue
+	}
+
+	return whenFalse
+}
+
+func templateJoin(sep string, items any) string {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(items)
+	}
+
+	parts := make([]string, v.Len())
+	for i := range v.Len() {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+
+	return strings.Join(parts, sep)
+}
+
+func templateIndent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+func templateToJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func templateB64Dec(s string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,178 @@
+package content
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	values := templateValues{
+		Env:     map[string]string{"CLUSTER": "prod", "EMPTY": ""},
+		Grafana: map[string]string{"url": "https://grafana.example.com"},
+	}
+
+	tests := []struct {
+		name       string
+		templating v1beta1.GrafanaContentTemplating
+		content    string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:    "env and grafana values",
+			content: `{{ .Env.CLUSTER }} {{ index .Grafana "url" }}`,
+			want:    "prod https://grafana.example.com",
+		},
+		{
+			name:    "missing keys render empty",
+			content: `[{{ .Env.MISSING }}]`,
+			want:    "[]",
+		},
+		{
+			name:    "functions",
+			content: `{{ .Env.EMPTY | default "dev" | upper }} {{ .Env.CLUSTER | quote }} {{ list "a" "b" | join "," }} {{ .Env.CLUSTER | b64enc | b64dec }}`,
+			want:    `DEV "prod" a,b prod`,
+		},
+		{
+			name:    "toJson",
+			content: `{{ splitList "," "a,b" | toJson }}`,
+			want:    `["a","b"]`,
+		},
+		{
+			name:    "ternary and coalesce",
+			content: `{{ ternary "yes" "no" (eq .Env.CLUSTER "prod") }} {{ coalesce .Env.EMPTY .Env.CLUSTER }}`,
+			want:    "yes prod",
+		},
+		{
+			name:    "required",
+			content: `{{ required "EMPTY must be set" .Env.EMPTY }}`,
+			wantErr: "EMPTY must be set",
+		},
+		{
+			name:       "custom delimiters leave grafana templates alone",
+			templating: v1beta1.GrafanaContentTemplating{LeftDelimiter: "[[", RightDelimiter: "]]"},
+			content:    `{"title": "{{ instance }} [[ .Env.CLUSTER ]]"}`,
+			want:       `{"title": "{{ instance }} prod"}`,
+		},
+		{
+			name:    "parse error",
+			content: `{{ .Env.CLUSTER `,
+			wantErr: "parsing template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderTemplate("test", []byte(tt.content), &tt.templating, values)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestResolveForInstance(t *testing.T) {
+	dashboard := &v1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-dashboard", Namespace: "default", UID: "cr-uid"},
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				JSON: `{"title": "[[ .Env.CLUSTER ]] on [[ .Grafana.version | default "unknown" ]]"}`,
+				Envs: []v1beta1.GrafanaContentEnv{
+					{Name: "CLUSTER", Value: "prod"},
+				},
+				Templating: &v1beta1.GrafanaContentTemplating{
+					LeftDelimiter:  "[[",
+					RightDelimiter: "]]",
+				},
+			},
+		},
+	}
+
+	resolver := NewResolver(dashboard, tk8s.GetFakeClient(t))
+
+	_, _, err := resolver.ResolveForInstance(nil)
+	require.Error(t, err, "Resolve must be called first")
+
+	model, hash, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "prod on unknown", model["title"])
+
+	instanceModel, instanceHash, err := resolver.ResolveForInstance(map[string]string{"version": "12.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "prod on 12.0.0", instanceModel["title"])
+	assert.Equal(t, model["uid"], instanceModel["uid"], "uid must not depend on the instance")
+	assert.NotEqual(t, hash, instanceHash)
+}
+
+func TestResolveTemplateWithGrafanaRefs(t *testing.T) {
+	dashboardWithJSON := func(json string) *v1beta1.GrafanaDashboard {
+		return &v1beta1.GrafanaDashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-dashboard", Namespace: "default", UID: "cr-uid"},
+			Spec: v1beta1.GrafanaDashboardSpec{
+				GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+					JSON: json,
+					Templating: &v1beta1.GrafanaContentTemplating{
+						GrafanaRefs: []v1beta1.GrafanaContentTemplatingRef{
+							{Name: "replicas", GrafanaRef: corev1.ObjectFieldSelector{FieldPath: "spec.deployment.spec.replicas"}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("values required from the instance", func(t *testing.T) {
+		resolver := NewResolver(dashboardWithJSON(`{"uid": "static", "title": "{{ required "replicas" .Grafana.replicas }} replicas"}`), tk8s.GetFakeClient(t))
+
+		model, _, err := resolver.Resolve(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "cr-uid", model["uid"], "the uid cannot be rendered without instance values")
+
+		instanceModel, _, err := resolver.ResolveForInstance(map[string]string{"replicas": "2"})
+		require.NoError(t, err)
+		assert.Equal(t, "2 replicas", instanceModel["title"])
+		assert.Equal(t, "cr-uid", instanceModel["uid"])
+
+		_, _, err = resolver.ResolveForInstance(map[string]string{})
+		require.ErrorContains(t, err, "replicas")
+	})
+
+	t.Run("non-string values", func(t *testing.T) {
+		resolver := NewResolver(dashboardWithJSON(`{"uid": "static", "replicas": {{ .Grafana.replicas }}}`), tk8s.GetFakeClient(t))
+
+		model, hash, err := resolver.Resolve(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"uid": "cr-uid"}, model)
+		assert.NotEmpty(t, hash)
+
+		instanceModel, _, err := resolver.ResolveForInstance(map[string]string{"replicas": "2"})
+		require.NoError(t, err)
+		assert.InDelta(t, 2, instanceModel["replicas"], 0)
+	})
+
+	t.Run("uid rendered without instance values", func(t *testing.T) {
+		resolver := NewResolver(dashboardWithJSON(`{"uid": "static", "title": "{{ .Grafana.replicas | default "1" }} replicas"}`), tk8s.GetFakeClient(t))
+
+		model, _, err := resolver.Resolve(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "static", model["uid"])
+	})
+
+	t.Run("invalid templates are rejected early", func(t *testing.T) {
+		resolver := NewResolver(dashboardWithJSON(`{"title": "{{ .Grafana.replicas "}`), tk8s.GetFakeClient(t))
+
+		_, _, err := resolver.Resolve(context.Background())
+		require.ErrorContains(t, err, "parsing template")
+	})
+}
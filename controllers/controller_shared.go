@@ -7,10 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/controllers/content"
 	"github.com/grafana/grafana-operator/v5/controllers/resources"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -83,6 +87,10 @@ type GrafanaCommonReconciler interface {
 
 type Config struct {
 	ResyncPeriod time.Duration
+	DriftPolicy  v1beta1.DriftPolicy
+
+	// Namespaces token secrets of service accounts may be copied to, "*" allows any namespace
+	SecretTargetNamespaces []string
 }
 
 func (c *Config) requeueAfter(d metav1.Duration) time.Duration {
@@ -97,6 +105,28 @@ func (c *Config) requeueAfter(d metav1.Duration) time.Duration {
 	return c.ResyncPeriod
 }
 
+// driftPolicy returns the policy of the resource, falling back to the operator wide default
+func (c *Config) driftPolicy(spec v1beta1.GrafanaCommonSpec) v1beta1.DriftPolicy {
+	if spec.DriftPolicy != "" {
+		return spec.DriftPolicy
+	}
+
+	if c == nil || c.DriftPolicy == "" {
+		return v1beta1.DriftPolicyEnforce
+	}
+
+	return c.DriftPolicy
+}
+
+// secretTargetAllowed reports whether token secrets of service accounts may be copied to the namespace
+func (c *Config) secretTargetAllowed(namespace string) bool {
+	if c == nil {
+		return false
+	}
+
+	return slices.Contains(c.SecretTargetNamespaces, "*") || slices.Contains(c.SecretTargetNamespaces, namespace)
+}
+
 // Allow slower initial retry on any failure
 // Significantly slower compared to the default exponential backoff
 func defaultRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
@@ -205,6 +235,63 @@ func getFolderUID(ctx context.Context, cl client.Client, ref v1beta1.FolderRefer
 	return folder.GetGrafanaUID(), nil
 }
 
+// orgNotSynchronizedError is returned for an orgRef missing from the status of the instance,
+// the GrafanaOrganization was either deleted or has not been synchronized yet
+type orgNotSynchronizedError struct {
+	namespace, orgRef string
+	instance          *v1beta1.Grafana
+}
+
+func (e *orgNotSynchronizedError) Error() string {
+	return fmt.Sprintf("organization %s/%s has not been synchronized with instance %s/%s yet", e.namespace, e.orgRef, e.instance.Namespace, e.instance.Name)
+}
+
+// isOrgRemoved reports whether err was caused by a missing organization. Finalizers treat the resources
+// of such organizations as removed, deleting an organization deletes its resources in Grafana
+func isOrgRemoved(err error) bool {
+	return IsErrorType[*orgNotSynchronizedError](err)
+}
+
+// getOrgID resolves orgRef to the id of the GrafanaOrganization within the instance
+// Returns 0 when orgRef is empty, leaving the organization up to the credentials of the instance
+func getOrgID(instance *v1beta1.Grafana, namespace, orgRef string) (int64, error) {
+	if orgRef == "" {
+		return 0, nil
+	}
+
+	found, orgID := instance.Status.Organizations.Find(namespace, orgRef)
+	if !found {
+		return 0, &orgNotSynchronizedError{namespace: namespace, orgRef: orgRef, instance: instance}
+	}
+
+	id, err := strconv.ParseInt(*orgID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing id of organization %s/%s: %w", namespace, orgRef, err)
+	}
+
+	return id, nil
+}
+
+// newOrgScopedClient builds a Grafana client scoped to the organization referenced by orgRef
+func newOrgScopedClient(ctx context.Context, cl client.Client, instance *v1beta1.Grafana, namespace, orgRef string) (*genapi.GrafanaHTTPAPI, error) {
+	orgID, err := getOrgID(instance, namespace, orgRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return grafanaclient.NewGeneratedGrafanaClientForOrg(ctx, cl, instance, orgID)
+}
+
+// newOrgScopedDynamicClient builds a dynamic client defaulting to the namespace of the organization referenced by orgRef
+func newOrgScopedDynamicClient(ctx context.Context, cl client.Client, instance *v1beta1.Grafana, namespace, orgRef string) (*grafanaclient.DynamicClient, error) {
+	orgID, err := getOrgID(instance, namespace, orgRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return grafanaclient.NewDynamicClientForOrg(ctx, cl, instance, orgID)
+}
+
 func labelsSatisfyMatchExpressions(labels map[string]string, matchExpressions []metav1.LabelSelectorRequirement) bool {
 	for _, matchExpression := range matchExpressions {
 		selected := false
@@ -262,17 +349,35 @@ func updatePluginConfigMap(cm *corev1.ConfigMap, value []byte, key, deprecatedKe
 	return isUpdated
 }
 
+// ReconcilePlugins stores the plugins requested by a resource in namespace in the plugins ConfigMap of the instance.
+// Plugins not allowed by the plugin policy of the instance are left out, the reasons of all rejected plugins are returned.
+// Sources referencing objects are resolved in the namespace of the instance, these are only accepted from resources in it
 // TODO Refactor to use scheme from cl.Scheme() as it's the same anyways
-func ReconcilePlugins(ctx context.Context, cl client.Client, scheme *runtime.Scheme, grafana *v1beta1.Grafana, plugins v1beta1.PluginList, cmKey, cmDeprecatedKey string) error {
+func ReconcilePlugins(ctx context.Context, cl client.Client, scheme *runtime.Scheme, grafana *v1beta1.Grafana, namespace string, plugins v1beta1.PluginList, cmKey, cmDeprecatedKey string) ([]string, error) {
+	// Just in case we have some broken plugins, better to assess length of the sanitized list, not the original one
+	accepted, rejected := rejectForeignPluginSources(grafana, namespace, plugins.Sanitize())
+
+	sanitized, rejectedByPolicy, err := checkPluginPolicy(ctx, grafana, accepted)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected = append(rejected, rejectedByPolicy...)
+
 	cm := resources.GetPluginsConfigMap(grafana, scheme)
 	selector := client.ObjectKey{
 		Namespace: cm.Namespace,
 		Name:      cm.Name,
 	}
 
-	err := cl.Get(ctx, selector, cm)
+	err = cl.Get(ctx, selector, cm)
 	if err != nil {
-		return err
+		// Nothing to remove, e.g. external instances reconciled before they supported plugins
+		if apierrors.IsNotFound(err) && len(sanitized) == 0 {
+			return rejected, nil
+		}
+
+		return rejected, err
 	}
 
 	// Even though model.GetPluginsConfigMap already sets an owner reference, it gets overwritten
@@ -281,22 +386,20 @@ func ReconcilePlugins(ctx context.Context, cl client.Client, scheme *runtime.Sch
 
 	val := []byte{}
 
-	// Just in case we have some broken plugins, better to assess length of the sanitized list, not the original one
-	sanitized := plugins.Sanitize()
 	if len(sanitized) > 0 {
 		val, err = json.Marshal(sanitized)
 		if err != nil {
-			return err
+			return rejected, err
 		}
 	}
 
 	isUpdated := updatePluginConfigMap(cm, val, cmKey, cmDeprecatedKey)
 
 	if isUpdated {
-		return cl.Update(ctx, cm)
+		return rejected, cl.Update(ctx, cm)
 	}
 
-	return nil
+	return rejected, nil
 }
 
 // Correctly determine cause of no matching instance from error
@@ -501,6 +604,51 @@ func getGrafanaRefValue(instance *v1beta1.Grafana, fieldSelector *corev1.ObjectF
 	return val, nil
 }
 
+// resolveContentForInstance renders templated content with the grafanaRefs of an instance,
+// model and hash are returned as is when the content does not reference the instance
+func resolveContentForInstance(resolver *content.Resolver, cr v1beta1.GrafanaContentResource, instance *v1beta1.Grafana, model map[string]any, hash string) (map[string]any, string, error) {
+	templating := cr.GrafanaContentSpec().Templating
+	if templating == nil || len(templating.GrafanaRefs) == 0 {
+		return model, hash, nil
+	}
+
+	values := make(map[string]string, len(templating.GrafanaRefs))
+
+	for _, ref := range templating.GrafanaRefs {
+		value, err := getGrafanaRefValue(instance, &ref.GrafanaRef)
+		if err != nil {
+			return nil, "", fmt.Errorf("getting grafana field value for %s: %w", ref.Name, err)
+		}
+
+		values[ref.Name] = value
+	}
+
+	return resolver.ResolveForInstance(values)
+}
+
+// contentRequeueAfter shortens the resync period of content resources polling a registry for new versions
+func contentRequeueAfter(resyncPeriod time.Duration, cr v1beta1.GrafanaContentResource) time.Duration {
+	oci := cr.GrafanaContentSpec().OCI
+	if oci == nil || oci.PollInterval == nil || oci.PollInterval.Duration <= 0 {
+		return resyncPeriod
+	}
+
+	if resyncPeriod <= 0 {
+		return oci.PollInterval.Duration
+	}
+
+	return min(resyncPeriod, oci.PollInterval.Duration)
+}
+
+// modelResolutionReason returns the reason of the InvalidSpec condition of content resources that could not be resolved
+func modelResolutionReason(err error) string {
+	if errors.Is(err, content.ErrSignatureVerification) {
+		return conditionReasonSignatureVerificationFailed
+	}
+
+	return conditionReasonInvalidModelResolution
+}
+
 func getReferencedValue(ctx context.Context, cl client.Client, namespace string, source v1beta1.ValueFromSource) (string, string, error) {
 	if source.SecretKeyRef != nil {
 		return getSecretValue(ctx, cl, namespace, source.SecretKeyRef)
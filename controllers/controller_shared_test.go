@@ -19,9 +19,12 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/content"
 	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/stretchr/testify/assert"
@@ -585,6 +588,46 @@ func TestGetGrafanaRefValue(t *testing.T) {
 	}
 }
 
+func TestResolveContentForInstance(t *testing.T) {
+	dashboard := &v1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: "templated", Namespace: "default"},
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				JSON: `{"title": "{{ .Grafana.version }}"}`,
+				Templating: &v1beta1.GrafanaContentTemplating{
+					GrafanaRefs: []v1beta1.GrafanaContentTemplatingRef{
+						{Name: "version", GrafanaRef: corev1.ObjectFieldSelector{FieldPath: "spec.version"}},
+					},
+				},
+			},
+		},
+	}
+
+	resolver := content.NewResolver(dashboard, tk8s.GetFakeClient(t))
+
+	model, hash, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	instance := &v1beta1.Grafana{Spec: v1beta1.GrafanaSpec{Version: "12.0.0"}}
+
+	got, gotHash, err := resolveContentForInstance(resolver, dashboard, instance, model, hash)
+	require.NoError(t, err)
+	assert.Equal(t, "12.0.0", got["title"])
+	assert.NotEqual(t, hash, gotHash)
+
+	dashboard.Spec.Templating.GrafanaRefs[0].GrafanaRef.FieldPath = "spec.missing"
+
+	_, _, err = resolveContentForInstance(resolver, dashboard, instance, model, hash)
+	require.ErrorContains(t, err, "getting grafana field value for version")
+
+	dashboard.Spec.Templating = nil
+
+	got, gotHash, err = resolveContentForInstance(resolver, dashboard, instance, model, hash)
+	require.NoError(t, err)
+	assert.Equal(t, model, got)
+	assert.Equal(t, hash, gotHash)
+}
+
 var _ = Describe("GetMatchingInstances functions", Ordered, func() {
 	t := GinkgoT()
 
@@ -687,6 +730,40 @@ func (e errTypeB) Error() string {
 	return "B"
 }
 
+func TestGetOrgID(t *testing.T) {
+	instance := &v1beta1.Grafana{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "grafana",
+		},
+		Status: v1beta1.GrafanaStatus{
+			Organizations: v1beta1.NamespacedResourceList{
+				"default/platform/2",
+				"other/platform/3",
+			},
+		},
+	}
+
+	t.Run("empty orgRef keeps the organization of the credentials", func(t *testing.T) {
+		got, err := getOrgID(instance, "default", "")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), got)
+	})
+
+	t.Run("orgRef resolves to the organization id of the same namespace", func(t *testing.T) {
+		got, err := getOrgID(instance, "other", "platform")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), got)
+	})
+
+	t.Run("unsynchronized organization", func(t *testing.T) {
+		_, err := getOrgID(instance, "default", "missing")
+		require.ErrorContains(t, err, "has not been synchronized")
+		assert.True(t, isOrgRemoved(fmt.Errorf("building grafana client: %w", err)))
+		assert.False(t, isOrgRemoved(errors.New("connection refused")))
+	})
+}
+
 func TestIsErrorType(t *testing.T) {
 	var (
 		errA errTypeA
@@ -765,3 +842,29 @@ func TestRemoveFinalizer(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestModelResolutionReason(t *testing.T) {
+	err := fmt.Errorf("failed to fetch contents: verify ghcr.io/team/dashboards:v1: %w", content.ErrSignatureVerification)
+
+	assert.Equal(t, conditionReasonSignatureVerificationFailed, modelResolutionReason(err))
+	assert.Equal(t, conditionReasonInvalidModelResolution, modelResolutionReason(errors.New("file not found")))
+}
+
+func TestContentRequeueAfter(t *testing.T) {
+	cr := &v1beta1.GrafanaDashboard{
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				OCI: &v1beta1.GrafanaContentOCI{Reference: "ghcr.io/team/dashboards", SemverConstraint: "~1.4"},
+			},
+		},
+	}
+
+	assert.Equal(t, 10*time.Minute, contentRequeueAfter(10*time.Minute, cr))
+
+	cr.Spec.OCI.PollInterval = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, time.Minute, contentRequeueAfter(10*time.Minute, cr))
+	assert.Equal(t, time.Minute, contentRequeueAfter(0, cr))
+
+	cr.Spec.OCI.PollInterval = &metav1.Duration{Duration: time.Hour}
+	assert.Equal(t, 10*time.Minute, contentRequeueAfter(10*time.Minute, cr))
+}
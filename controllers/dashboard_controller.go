@@ -31,7 +31,6 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/client/search"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 	"github.com/grafana/grafana-operator/v5/controllers/content"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -49,8 +48,9 @@ import (
 )
 
 const (
-	conditionDashboardSynchronized        = "DashboardSynchronized"
-	conditionReasonInvalidModelResolution = "InvalidModelResolution"
+	conditionDashboardSynchronized             = "DashboardSynchronized"
+	conditionReasonInvalidModelResolution      = "InvalidModelResolution"
+	conditionReasonSignatureVerificationFailed = "SignatureVerificationFailed"
 
 	LogMsgResolvingDashboardContents      = "error resolving dashboard contents"
 	LogMsgGettingPublicSharing            = "failed to get existing public dashboard share configuration from Grafana"
@@ -144,7 +144,7 @@ func (r *GrafanaDashboardReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		// Resolve has a lot of failure cases.
 		// fetch content errors could be a temporary network issue but would result in an InvalidSpec condition
-		setInvalidSpec(&cr.Status.Conditions, cr.Generation, conditionReasonInvalidModelResolution, err.Error())
+		setInvalidSpec(&cr.Status.Conditions, cr.Generation, modelResolutionReason(err), err.Error())
 		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionDashboardSynchronized)
 		log.Error(err, LogMsgResolvingDashboardContents)
 
@@ -186,26 +186,46 @@ func (r *GrafanaDashboardReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgResolvingFolderUID, err)
 	}
 
+	if len(cr.Spec.ACL) == 0 {
+		cr.Status.ACL = nil
+	}
+
+	pruneEffectiveACL(&cr.Status.ACL, instances)
+
 	applyHomeErrors := make(map[string]string)
 	publicShareErrors := make(map[string]string)
 	pluginErrors := make(map[string]string)
+	rejectedPlugins := pluginRejections{}
+	permissionErrors := make(map[string]string)
 	applyErrors := make(map[string]string)
 
+	drift := newDriftTracker(r.Cfg, "GrafanaDashboard", cr, cr.Spec.GrafanaCommonSpec)
+
 	for _, grafana := range instances {
-		if grafana.IsInternal() {
-			// first reconcile the plugins
-			// append the requested dashboards to a configmap from where the
-			// grafana reconciler will pick them up
-			err = ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, cr.Spec.Plugins, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
-			if err != nil {
-				pluginErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
-			}
+		// first reconcile the plugins
+		// append the requested dashboards to a configmap from where the
+		// grafana reconciler will pick them up
+		rejected, err := ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, cr.Namespace, cr.Spec.Plugins, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
+		rejectedPlugins.add(&grafana, rejected)
+
+		if err != nil {
+			pluginErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 		}
 
 		// then import the dashboard into the matching grafana instances
-		err = r.reconcileWithInstance(ctx, &grafana, cr, dashboardModel, folderUID)
+		instanceModel, _, err := resolveContentForInstance(resolver, cr, &grafana, dashboardModel, hash)
+		if err == nil {
+			err = r.reconcileWithInstance(ctx, &grafana, cr, instanceModel, folderUID, drift)
+		}
+
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
+		} else {
+			// permissions can only be applied once the dashboard exists
+			err = r.reconcilePermissions(ctx, &grafana, cr, uid)
+			if err != nil {
+				permissionErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
+			}
 		}
 
 		// then reconcile the public share config
@@ -237,10 +257,17 @@ func (r *GrafanaDashboardReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		log.Error(err, "failed to apply home dashboards to all instances")
 	}
 
-	allApplyErrors := mergeReconcileErrors(applyErrors, pluginErrors, publicShareErrors, applyHomeErrors)
+	if len(permissionErrors) > 0 {
+		err := fmt.Errorf(FmtStrApplyErrors, permissionErrors)
+		log.Error(err, "failed to apply dashboard permissions to all instances")
+	}
+
+	allApplyErrors := mergeReconcileErrors(applyErrors, pluginErrors, publicShareErrors, applyHomeErrors, permissionErrors)
 
 	condition := buildSynchronizedCondition("Dashboard", conditionDashboardSynchronized, cr.Generation, allApplyErrors, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	drift.finish(&cr.Status.Conditions, cr.Generation, len(instances))
+	rejectedPlugins.finish(&cr.Status.Conditions, cr.Generation)
 
 	if len(allApplyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, allApplyErrors)
@@ -270,7 +297,7 @@ func (r *GrafanaDashboardReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		log.Error(err, LogMsgUpdateCache)
 	}
 
-	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
+	return ctrl.Result{RequeueAfter: contentRequeueAfter(r.Cfg.requeueAfter(cr.Spec.ResyncPeriod), cr)}, nil
 }
 
 func (r *GrafanaDashboardReconciler) finalize(ctx context.Context, cr *v1beta1.GrafanaDashboard) error {
@@ -286,82 +313,102 @@ func (r *GrafanaDashboardReconciler) finalize(ctx context.Context, cr *v1beta1.G
 	}
 
 	for _, grafana := range instances {
-		gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, &grafana)
-		if err != nil {
+		gClient, err := newOrgScopedClient(ctx, r.Client, &grafana, cr.Namespace, cr.Spec.OrgRef)
+		if err != nil && !isOrgRemoved(err) {
 			return fmt.Errorf("creating grafana http client: %w", err)
 		}
 
-		isCleanupInGrafanaRequired := true
+		// Skip cleanup in instances, the dashboards of removed organizations are gone with them
+		if err == nil {
+			err = r.removeFromInstance(ctx, gClient, cr, uid)
+			if err != nil {
+				return err
+			}
+		}
 
-		resp, err := gClient.Dashboards.GetDashboardByUID(uid)
+		_, err = ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, cr.Namespace, nil, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
 		if err != nil {
-			if IsNotErrorType[*dashboards.GetDashboardByUIDNotFound](err) {
-				return fmt.Errorf("fetching dashboard from instance: %w", err)
-			}
+			return fmt.Errorf("reconciling plugins: %w", err)
+		}
 
-			isCleanupInGrafanaRequired = false
+		// Update grafana instance Status
+		err = grafana.RemoveNamespacedResource(ctx, r.Client, cr)
+		if err != nil {
+			return fmt.Errorf("removing dashboard from grafana cr: %w", err)
 		}
+	}
 
-		if isCleanupInGrafanaRequired {
-			var dash *models.DashboardFullWithMeta
-			if resp != nil {
-				dash = resp.GetPayload()
-			}
+	clearDriftMetrics("GrafanaDashboard", driftResource(cr))
 
-			_, err = gClient.Dashboards.DeleteDashboardByUID(uid) //nolint:errcheck
-			if err != nil {
-				if IsNotErrorType[*dashboards.DeleteDashboardByUIDNotFound](err) {
-					return fmt.Errorf("deleting dashboard from instance: %w", err)
-				}
-			}
+	return nil
+}
 
-			if dash != nil && dash.Meta != nil && dash.Meta.FolderUID != "" && cr.Spec.FolderRef == "" && cr.Spec.FolderUID == "" {
-				log.V(1).Info("Folder qualifies for deletion, checking if empty")
+// removeFromInstance deletes the dashboard and the folders created for it from the instance
+func (r *GrafanaDashboardReconciler) removeFromInstance(ctx context.Context, gClient *genapi.GrafanaHTTPAPI, cr *v1beta1.GrafanaDashboard, uid string) error {
+	log := logf.FromContext(ctx)
 
-				resp, err := r.DeleteFolderIfEmpty(gClient, dash.Meta.FolderUID)
-				if err != nil {
-					return fmt.Errorf("deleting empty parent folder from instance: %w", err)
-				}
+	isCleanupInGrafanaRequired := true
+
+	resp, err := gClient.Dashboards.GetDashboardByUID(uid)
+	if err != nil {
+		if IsNotErrorType[*dashboards.GetDashboardByUIDNotFound](err) {
+			return fmt.Errorf("fetching dashboard from instance: %w", err)
+		}
+
+		isCleanupInGrafanaRequired = false
+	}
 
-				switch resp.StatusCode {
-				case http.StatusOK:
-					log.Info("unused folder successfully removed")
-				case 432:
-					log.Info("folder still in use by other dashboards, libraryPanels, or alertrules")
-				}
+	if isCleanupInGrafanaRequired {
+		var dash *models.DashboardFullWithMeta
+		if resp != nil {
+			dash = resp.GetPayload()
+		}
+
+		_, err = gClient.Dashboards.DeleteDashboardByUID(uid) //nolint:errcheck
+		if err != nil {
+			if IsNotErrorType[*dashboards.DeleteDashboardByUIDNotFound](err) {
+				return fmt.Errorf("deleting dashboard from instance: %w", err)
 			}
 		}
 
-		if grafana.IsInternal() {
-			err = ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, nil, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
+		if dash != nil && dash.Meta != nil && dash.Meta.FolderUID != "" && cr.Spec.FolderRef == "" && cr.Spec.FolderUID == "" && cr.Spec.FolderPath == "" {
+			log.V(1).Info("Folder qualifies for deletion, checking if empty")
+
+			resp, err := r.DeleteFolderIfEmpty(gClient, dash.Meta.FolderUID)
 			if err != nil {
-				return fmt.Errorf("reconciling plugins: %w", err)
+				return fmt.Errorf("deleting empty parent folder from instance: %w", err)
 			}
-		}
 
-		// Update grafana instance Status
-		err = grafana.RemoveNamespacedResource(ctx, r.Client, cr)
-		if err != nil {
-			return fmt.Errorf("removing dashboard from grafana cr: %w", err)
+			switch resp.StatusCode {
+			case http.StatusOK:
+				log.Info("unused folder successfully removed")
+			case 432:
+				log.Info("folder still in use by other dashboards, libraryPanels, or alertrules")
+			}
 		}
 	}
 
+	deleteManagedFolders(ctx, gClient, cr)
+
 	return nil
 }
 
-func (r *GrafanaDashboardReconciler) reconcileWithInstance(ctx context.Context, grafana *v1beta1.Grafana, cr *v1beta1.GrafanaDashboard, dashboardModel map[string]any, folderUID string) error {
+func (r *GrafanaDashboardReconciler) reconcileWithInstance(ctx context.Context, grafana *v1beta1.Grafana, cr *v1beta1.GrafanaDashboard, dashboardModel map[string]any, folderUID string, drift *driftTracker) error {
 	log := logf.FromContext(ctx)
 
-	if grafana.IsExternal() && cr.Spec.Plugins != nil {
-		return fmt.Errorf("external grafana instances don't support plugins, please remove spec.plugins from your dashboard cr")
-	}
-
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, grafana)
+	gClient, err := newOrgScopedClient(ctx, r.Client, grafana, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return fmt.Errorf("creating grafana http client: %w", err)
 	}
 
-	if folderUID == "" {
+	switch {
+	case folderUID != "":
+	case cr.Spec.FolderPath != "":
+		folderUID, err = getOrCreateFolderPath(ctx, r.Client, gClient, cr, cr.Spec.FolderPath)
+		if err != nil {
+			return err
+		}
+	default:
 		folderUID, err = r.GetOrCreateFolder(gClient, cr)
 		if err != nil {
 			return err
@@ -389,6 +436,20 @@ func (r *GrafanaDashboardReconciler) reconcileWithInstance(ctx context.Context,
 	}
 
 	exists := dashWithMeta != nil
+	if exists && remoteUID == uid {
+		skip, err := drift.skipsUpdate(grafana, func() ([]string, error) {
+			return dashboardDiff(dashboardModel, dashWithMeta.Payload, folderUID)
+		})
+		if err != nil {
+			return err
+		}
+
+		if skip {
+			log.V(1).Info("dashboard exists, skipping update due to drift policy", "driftPolicy", drift.policy)
+			return grafana.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(uid))
+		}
+	}
+
 	if exists && (remoteUID != uid || dashWithMeta.Payload.Meta.FolderUID != folderUID) {
 		// If there's already a dashboard with the same title in the same folder, grafana preserves that dashboard's uid, so we should remove it first
 		log.Info("found dashboard with the same title (in the same folder) but different uid, removing the dashboard before recreating it with a new uid")
@@ -435,10 +496,30 @@ func (r *GrafanaDashboardReconciler) reconcileWithInstance(ctx context.Context,
 	return grafana.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(uid))
 }
 
+func (r *GrafanaDashboardReconciler) reconcilePermissions(ctx context.Context, grafana *v1beta1.Grafana, cr *v1beta1.GrafanaDashboard, uid string) error {
+	if len(cr.Spec.ACL) == 0 {
+		return nil
+	}
+
+	gClient, err := newOrgScopedClient(ctx, r.Client, grafana, cr.Namespace, cr.Spec.OrgRef)
+	if err != nil {
+		return fmt.Errorf("creating grafana http client: %w", err)
+	}
+
+	items, err := reconcileACL(ctx, r.Client, gClient, grafana, cr.Namespace, cr.Spec.OrgRef, aclResourceDashboards, uid, cr.Spec.ACL)
+	if err != nil {
+		return fmt.Errorf("failed to update dashboard permissions: %w", err)
+	}
+
+	setEffectiveACL(&cr.Status.ACL, grafana, items)
+
+	return nil
+}
+
 func (r *GrafanaDashboardReconciler) reconcilePublicSharing(ctx context.Context, grafana *v1beta1.Grafana, cr *v1beta1.GrafanaDashboard, dto *models.PublicDashboardDTO, dashUID string) error {
 	log := logf.FromContext(ctx).WithName("PublicSharing")
 
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, grafana)
+	gClient, err := newOrgScopedClient(ctx, r.Client, grafana, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return err
 	}
@@ -617,11 +698,37 @@ func (r *GrafanaDashboardReconciler) matchesStateInGrafana(exists bool, model ma
 		return false, fmt.Errorf("remote dashboard is not a valid object")
 	}
 
+	return len(dashboardModelDiff(model, remoteModel)) == 0, nil
+}
+
+// dashboardDiff lists the fields of the model and the folder differing from the dashboard in Grafana
+func dashboardDiff(model map[string]any, remoteDashboard *models.DashboardFullWithMeta, folderUID string) ([]string, error) {
+	remoteModel, ok := remoteDashboard.Dashboard.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("remote dashboard is not a valid object")
+	}
+
+	diff := dashboardModelDiff(model, remoteModel)
+
+	if remoteDashboard.Meta != nil && remoteDashboard.Meta.FolderUID != folderUID {
+		diff = append(diff, "folder")
+	}
+
+	return diff, nil
+}
+
+// dashboardModelDiff returns the sorted keys of the model differing from the remote model.
+// Keys only present in the remote model are added by Grafana and are not compared
+func dashboardModelDiff(model, remoteModel map[string]any) []string {
 	keys := make([]string, 0, len(model))
 	for key := range model {
 		keys = append(keys, key)
 	}
 
+	slices.Sort(keys)
+
+	var diff []string
+
 	skipKeys := []string{"id", "version"}
 	for _, key := range keys {
 		// we do not keep track of those keys in the custom resource
@@ -633,11 +740,11 @@ func (r *GrafanaDashboardReconciler) matchesStateInGrafana(exists bool, model ma
 
 		remoteValue := remoteModel[key]
 		if !reflect.DeepEqual(localValue, remoteValue) {
-			return false, nil
+			diff = append(diff, key)
 		}
 	}
 
-	return true, nil
+	return diff
 }
 
 // publicSharingMatchesStateInGrafana checks whether a public dashboard share exists in Grafana and its contents matches the model defined in the custom resources
@@ -739,37 +846,7 @@ func (r *GrafanaDashboardReconciler) GetFolderUID(
 }
 
 func (r *GrafanaDashboardReconciler) DeleteFolderIfEmpty(gClient *genapi.GrafanaHTTPAPI, folderUID string) (http.Response, error) {
-	params := search.NewSearchParams().WithFolderUIDs([]string{folderUID})
-
-	results, err := gClient.Search.Search(params)
-	if err != nil {
-		return http.Response{
-			Status:     "internal grafana client error getting dashboards",
-			StatusCode: http.StatusInternalServerError,
-		}, err
-	}
-
-	if len(results.GetPayload()) > 0 {
-		return http.Response{
-			Status:     "resource is still in use",
-			StatusCode: http.StatusLocked,
-		}, err
-	}
-
-	deleteParams := folders.NewDeleteFolderParams().WithFolderUID(folderUID)
-	if _, err = gClient.Folders.DeleteFolder(deleteParams); err != nil { //nolint:errcheck
-		if IsNotErrorType[*folders.DeleteFolderNotFound](err) {
-			return http.Response{
-				Status:     "internal grafana client error deleting grafana folder",
-				StatusCode: http.StatusInternalServerError,
-			}, err
-		}
-	}
-
-	return http.Response{
-		Status:     "grafana folder deleted",
-		StatusCode: http.StatusOK,
-	}, nil
+	return deleteFolderIfEmpty(gClient, folderUID)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -834,7 +911,7 @@ func (r *GrafanaDashboardReconciler) requestsForChangeByField(indexKey string) h
 func (r *GrafanaDashboardReconciler) UpdateHomeDashboard(ctx context.Context, grafana *v1beta1.Grafana, uid string, dashboard *v1beta1.GrafanaDashboard) error {
 	log := logf.FromContext(ctx)
 
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, grafana)
+	gClient, err := newOrgScopedClient(ctx, r.Client, grafana, dashboard.Namespace, dashboard.Spec.OrgRef)
 	if err != nil {
 		return err
 	}
@@ -21,6 +21,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/grafana/grafana-openapi-client-go/client/datasources"
@@ -28,7 +29,6 @@ import (
 	"github.com/spyzhov/ajson"
 
 	genapi "github.com/grafana/grafana-openapi-client-go/client"
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -157,21 +157,24 @@ func (r *GrafanaDatasourceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	removeInvalidSpec(&cr.Status.Conditions)
 
 	pluginErrors := make(map[string]string)
+	rejectedPlugins := pluginRejections{}
 	applyErrors := make(map[string]string)
 
+	drift := newDriftTracker(r.Cfg, "GrafanaDatasource", cr, cr.Spec.GrafanaCommonSpec)
+
 	for _, grafana := range instances {
-		if grafana.IsInternal() {
-			// first reconcile the plugins
-			// append the requested datasources to a configmap from where the
-			// grafana reconciler will pick them up
-			err = ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, cr.Spec.Plugins, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
-			if err != nil {
-				pluginErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
-			}
+		// first reconcile the plugins
+		// append the requested datasources to a configmap from where the
+		// grafana reconciler will pick them up
+		rejected, err := ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, cr.Namespace, cr.Spec.Plugins, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
+		rejectedPlugins.add(&grafana, rejected)
+
+		if err != nil {
+			pluginErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 		}
 
 		// then import the datasource into the matching grafana instances
-		err = r.onDatasourceCreated(ctx, &grafana, cr, datasource, hash)
+		err = r.onDatasourceCreated(ctx, &grafana, cr, datasource, hash, drift)
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 		}
@@ -186,6 +189,8 @@ func (r *GrafanaDatasourceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	condition := buildSynchronizedCondition("Datasource", conditionDatasourceSynchronized, cr.Generation, allApplyErrors, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	drift.finish(&cr.Status.Conditions, cr.Generation, len(instances))
+	rejectedPlugins.finish(&cr.Status.Conditions, cr.Generation)
 
 	if len(allApplyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, allApplyErrors)
@@ -217,7 +222,7 @@ func (r *GrafanaDatasourceReconciler) deleteOldDatasource(ctx context.Context, c
 			continue
 		}
 
-		gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, &grafana)
+		gClient, err := newOrgScopedClient(ctx, r.Client, &grafana, cr.Namespace, cr.Spec.OrgRef)
 		if err != nil {
 			return err
 		}
@@ -245,23 +250,24 @@ func (r *GrafanaDatasourceReconciler) finalize(ctx context.Context, cr *v1beta1.
 	uid := cr.GetGrafanaUID()
 
 	for _, grafana := range instances {
-		gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, &grafana)
-		if err != nil {
+		gClient, err := newOrgScopedClient(ctx, r.Client, &grafana, cr.Namespace, cr.Spec.OrgRef)
+		if err != nil && !isOrgRemoved(err) {
 			return err
 		}
 
-		_, err = gClient.Datasources.DeleteDataSourceByUID(uid) //nolint:errcheck
-		if err != nil {
-			if IsNotErrorType[*datasources.DeleteDataSourceByUIDNotFound](err) {
-				return fmt.Errorf("deleting datasource %s: %w", uid, err)
+		// Skip cleanup in instances, the datasources of removed organizations are gone with them
+		if err == nil {
+			_, err = gClient.Datasources.DeleteDataSourceByUID(uid) //nolint:errcheck
+			if err != nil {
+				if IsNotErrorType[*datasources.DeleteDataSourceByUIDNotFound](err) {
+					return fmt.Errorf("deleting datasource %s: %w", uid, err)
+				}
 			}
 		}
 
-		if grafana.IsInternal() {
-			err = ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, nil, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
-			if err != nil {
-				return fmt.Errorf("reconciling plugins: %w", err)
-			}
+		_, err = ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, cr.Namespace, nil, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
+		if err != nil {
+			return fmt.Errorf("reconciling plugins: %w", err)
 		}
 
 		// Update grafana instance Status
@@ -271,19 +277,17 @@ func (r *GrafanaDatasourceReconciler) finalize(ctx context.Context, cr *v1beta1.
 		}
 	}
 
+	clearDriftMetrics("GrafanaDatasource", driftResource(cr))
+
 	return nil
 }
 
-func (r *GrafanaDatasourceReconciler) onDatasourceCreated(ctx context.Context, grafana *v1beta1.Grafana, cr *v1beta1.GrafanaDatasource, datasource *models.UpdateDataSourceCommand, hash string) error {
-	if grafana.IsExternal() && cr.Spec.Plugins != nil {
-		return fmt.Errorf("external grafana instances don't support plugins, please remove spec.plugins from your datasource cr")
-	}
-
+func (r *GrafanaDatasourceReconciler) onDatasourceCreated(ctx context.Context, grafana *v1beta1.Grafana, cr *v1beta1.GrafanaDatasource, datasource *models.UpdateDataSourceCommand, hash string, drift *driftTracker) error {
 	if cr.Spec.Datasource == nil {
 		return nil
 	}
 
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, grafana)
+	gClient, err := newOrgScopedClient(ctx, r.Client, grafana, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return err
 	}
@@ -293,6 +297,24 @@ func (r *GrafanaDatasourceReconciler) onDatasourceCreated(ctx context.Context, g
 		return err
 	}
 
+	if exists {
+		skip, err := drift.skipsUpdate(grafana, func() ([]string, error) {
+			remote, err := gClient.Datasources.GetDataSourceByUID(uid)
+			if err != nil {
+				return nil, fmt.Errorf("fetching datasource %s: %w", uid, err)
+			}
+
+			return datasourceDiff(datasource, remote.Payload)
+		})
+		if err != nil {
+			return err
+		}
+
+		if skip {
+			return grafana.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource())
+		}
+	}
+
 	if exists && cr.Unchanged(hash) {
 		return nil
 	}
@@ -343,6 +365,45 @@ func (r *GrafanaDatasourceReconciler) Exists(gClient *genapi.GrafanaHTTPAPI, uid
 	return false, "", nil
 }
 
+// datasourceDiff lists the fields of the datasource model differing from the datasource in Grafana.
+// Secure values cannot be compared as Grafana only returns whether they are set
+func datasourceDiff(datasource *models.UpdateDataSourceCommand, remote *models.DataSource) ([]string, error) {
+	desired := *datasource
+
+	// Grafana defaults both fields, only report a difference when they are set explicitly
+	if desired.JSONData == nil {
+		desired.JSONData = map[string]any{}
+	}
+
+	if desired.Access == "" {
+		desired.Access = "proxy"
+	}
+
+	diff, err := diffFields(desired, remote,
+		"accessControl", "id", "orgId", "readOnly", "secureJsonData", "secureJsonFields", "typeLogoUrl", "uid", "version")
+	if err != nil {
+		return nil, err
+	}
+
+	var secureDiff []string
+
+	for field := range desired.SecureJSONData {
+		if !remote.SecureJSONFields[field] {
+			secureDiff = append(secureDiff, "secureJsonData."+field)
+		}
+	}
+
+	for field, set := range remote.SecureJSONFields {
+		if _, ok := desired.SecureJSONData[field]; set && !ok {
+			secureDiff = append(secureDiff, "secureJsonData."+field)
+		}
+	}
+
+	slices.Sort(secureDiff)
+
+	return append(diff, secureDiff...), nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GrafanaDatasourceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	const (
@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	conditionDrifted = "Drifted"
+
+	conditionReasonDriftDetected = "DriftDetected"
+	conditionReasonNoDrift       = "NoDrift"
+
+	// Fields listed per instance in the Drifted condition, the remainder is only counted
+	maxDriftSummaryFields = 5
+)
+
+// driftTracker applies the drift policy of a resource to every matching instance
+// and collects the differences detected with the detect policy
+type driftTracker struct {
+	policy   v1beta1.DriftPolicy
+	kind     string
+	resource string
+	drifts   map[string]string
+}
+
+func newDriftTracker(cfg *Config, kind string, cr client.Object, spec v1beta1.GrafanaCommonSpec) *driftTracker {
+	return &driftTracker{
+		policy:   cfg.driftPolicy(spec),
+		kind:     kind,
+		resource: driftResource(cr),
+		drifts:   map[string]string{},
+	}
+}
+
+// enforced reports whether existing resources are always updated
+func (d *driftTracker) enforced() bool {
+	return d.policy != v1beta1.DriftPolicyDetect && d.policy != v1beta1.DriftPolicyIgnore
+}
+
+// skipsUpdate reports whether a resource that already exists in the instance must be left untouched.
+// diff is only called with the detect policy and returns the fields differing between the spec and the instance
+func (d *driftTracker) skipsUpdate(instance *v1beta1.Grafana, diff func() ([]string, error)) (bool, error) {
+	if d.enforced() {
+		return false, nil
+	}
+
+	if d.policy == v1beta1.DriftPolicyIgnore {
+		return true, nil
+	}
+
+	fields, err := diff()
+	if err != nil {
+		return false, fmt.Errorf("detecting drift: %w", err)
+	}
+
+	gauge := metrics.DriftedResources.WithLabelValues(instance.Namespace, instance.Name, d.kind, d.resource)
+
+	if len(fields) == 0 {
+		gauge.Set(0)
+		return true, nil
+	}
+
+	d.drifts[fmt.Sprintf("%s/%s", instance.Namespace, instance.Name)] = summarizeDiff(fields)
+
+	gauge.Set(1)
+
+	return true, nil
+}
+
+// finish updates the Drifted condition, which is only present with the detect policy
+func (d *driftTracker) finish(conditions *[]metav1.Condition, generation int64, total int) {
+	if d.policy != v1beta1.DriftPolicyDetect {
+		meta.RemoveStatusCondition(conditions, conditionDrifted)
+		clearDriftMetrics(d.kind, d.resource)
+
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               conditionDrifted,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Time{
+			Time: time.Now(),
+		},
+	}
+
+	if len(d.drifts) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditionReasonNoDrift
+		condition.Message = fmt.Sprintf("No drift detected on %d instances", total)
+	} else {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = conditionReasonDriftDetected
+
+		var sb strings.Builder
+		for _, instance := range slices.Sorted(maps.Keys(d.drifts)) {
+			fmt.Fprintf(&sb, "\n- %s: %s", instance, d.drifts[instance])
+		}
+
+		condition.Message = fmt.Sprintf("Drift detected on %d out of %d instances:%s", len(d.drifts), total, sb.String())
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// clearDriftMetrics removes the drift metrics of a resource across all instances
+func clearDriftMetrics(kind, resource string) {
+	metrics.DriftedResources.DeletePartialMatch(prometheus.Labels{"kind": kind, "resource": resource})
+}
+
+func driftResource(cr client.Object) string {
+	return fmt.Sprintf("%s/%s", cr.GetNamespace(), cr.GetName())
+}
+
+// summarizeDiff lists the first differing fields, e.g. "title, panels (+2 more)"
+func summarizeDiff(fields []string) string {
+	if len(fields) <= maxDriftSummaryFields {
+		return strings.Join(fields, ", ")
+	}
+
+	return fmt.Sprintf("%s (+%d more)", strings.Join(fields[:maxDriftSummaryFields], ", "), len(fields)-maxDriftSummaryFields)
+}
+
+// diffFields compares the top level fields of both objects after normalizing them through json
+// and returns the sorted names of differing fields. Fields in skip are not compared
+func diffFields(desired, remote any, skip ...string) ([]string, error) {
+	desiredFields, err := toJSONObject(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteFields, err := toJSONObject(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := slices.Collect(maps.Keys(desiredFields))
+	for key := range remoteFields {
+		if _, ok := desiredFields[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	var diff []string
+
+	for _, key := range keys {
+		if slices.Contains(skip, key) {
+			continue
+		}
+
+		if !reflect.DeepEqual(desiredFields[key], remoteFields[key]) {
+			diff = append(diff, key)
+		}
+	}
+
+	return diff, nil
+}
+
+func toJSONObject(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding object: %w", err)
+	}
+
+	obj := map[string]any{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, fmt.Errorf("decoding object: %w", err)
+	}
+
+	return obj, nil
+}
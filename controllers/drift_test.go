@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigDriftPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		spec v1beta1.DriftPolicy
+		want v1beta1.DriftPolicy
+	}{
+		{
+			name: "nil config defaults to enforce",
+			cfg:  nil,
+			want: v1beta1.DriftPolicyEnforce,
+		},
+		{
+			name: "unset config defaults to enforce",
+			cfg:  &Config{},
+			want: v1beta1.DriftPolicyEnforce,
+		},
+		{
+			name: "operator wide default",
+			cfg:  &Config{DriftPolicy: v1beta1.DriftPolicyDetect},
+			want: v1beta1.DriftPolicyDetect,
+		},
+		{
+			name: "spec takes precedence",
+			cfg:  &Config{DriftPolicy: v1beta1.DriftPolicyDetect},
+			spec: v1beta1.DriftPolicyIgnore,
+			want: v1beta1.DriftPolicyIgnore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cfg.driftPolicy(v1beta1.GrafanaCommonSpec{DriftPolicy: tt.spec})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDriftTracker(t *testing.T) {
+	cr := &v1beta1.GrafanaFolder{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "drift"},
+	}
+
+	instance := &v1beta1.Grafana{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "grafana"},
+	}
+
+	drifted := func() ([]string, error) {
+		return []string{"title"}, nil
+	}
+
+	t.Run("enforce updates without comparing", func(t *testing.T) {
+		drift := newDriftTracker(&Config{}, "GrafanaFolder", cr, v1beta1.GrafanaCommonSpec{})
+
+		skip, err := drift.skipsUpdate(instance, func() ([]string, error) {
+			t.Fatal("diff must not be called")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.False(t, skip)
+
+		conditions := []metav1.Condition{{Type: conditionDrifted}}
+		drift.finish(&conditions, 1, 1)
+		assert.Nil(t, meta.FindStatusCondition(conditions, conditionDrifted))
+	})
+
+	t.Run("ignore skips without comparing", func(t *testing.T) {
+		drift := newDriftTracker(&Config{}, "GrafanaFolder", cr, v1beta1.GrafanaCommonSpec{DriftPolicy: v1beta1.DriftPolicyIgnore})
+
+		skip, err := drift.skipsUpdate(instance, func() ([]string, error) {
+			t.Fatal("diff must not be called")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.True(t, skip)
+	})
+
+	t.Run("detect reports drift", func(t *testing.T) {
+		drift := newDriftTracker(&Config{DriftPolicy: v1beta1.DriftPolicyDetect}, "GrafanaFolder", cr, v1beta1.GrafanaCommonSpec{})
+
+		skip, err := drift.skipsUpdate(instance, drifted)
+		require.NoError(t, err)
+		assert.True(t, skip)
+
+		gauge := &dto.Metric{}
+		require.NoError(t, metrics.DriftedResources.WithLabelValues("monitoring", "grafana", "GrafanaFolder", "default/drift").Write(gauge))
+		assert.InDelta(t, 1, gauge.GetGauge().GetValue(), 0)
+
+		var conditions []metav1.Condition
+
+		drift.finish(&conditions, 2, 1)
+
+		condition := meta.FindStatusCondition(conditions, conditionDrifted)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, conditionReasonDriftDetected, condition.Reason)
+		assert.Equal(t, int64(2), condition.ObservedGeneration)
+		assert.Equal(t, "Drift detected on 1 out of 1 instances:\n- monitoring/grafana: title", condition.Message)
+
+		clearDriftMetrics("GrafanaFolder", "default/drift")
+		assert.Equal(t, 0, countDriftMetrics())
+	})
+
+	t.Run("detect without drift", func(t *testing.T) {
+		drift := newDriftTracker(&Config{}, "GrafanaFolder", cr, v1beta1.GrafanaCommonSpec{DriftPolicy: v1beta1.DriftPolicyDetect})
+
+		skip, err := drift.skipsUpdate(instance, func() ([]string, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.True(t, skip)
+
+		var conditions []metav1.Condition
+
+		drift.finish(&conditions, 1, 1)
+
+		condition := meta.FindStatusCondition(conditions, conditionDrifted)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, conditionReasonNoDrift, condition.Reason)
+
+		// Switching to another policy removes the condition and metrics
+		drift.policy = v1beta1.DriftPolicyEnforce
+		drift.finish(&conditions, 1, 1)
+		assert.Nil(t, meta.FindStatusCondition(conditions, conditionDrifted))
+		assert.Equal(t, 0, countDriftMetrics())
+	})
+}
+
+func countDriftMetrics() int {
+	ch := make(chan prometheus.Metric, 10)
+	metrics.DriftedResources.Collect(ch)
+	close(ch)
+
+	return len(ch)
+}
+
+func TestSummarizeDiff(t *testing.T) {
+	assert.Equal(t, "title", summarizeDiff([]string{"title"}))
+	assert.Equal(t, "a, b, c, d, e", summarizeDiff([]string{"a", "b", "c", "d", "e"}))
+	assert.Equal(t, "a, b, c, d, e (+2 more)", summarizeDiff([]string{"a", "b", "c", "d", "e", "f", "g"}))
+}
+
+func TestDiffFields(t *testing.T) {
+	desired := map[string]any{"title": "a", "tags": []string{"x"}, "version": 1}
+	remote := map[string]any{"title": "b", "tags": []any{"x"}, "version": 2, "extra": true}
+
+	diff, err := diffFields(desired, remote, "version")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"extra", "title"}, diff)
+}
+
+func TestDashboardDiff(t *testing.T) {
+	model := map[string]any{"uid": "dash", "title": "Dashboard", "id": float64(1), "panels": []any{}}
+	remote := &models.DashboardFullWithMeta{
+		Dashboard: map[string]any{"uid": "dash", "title": "Changed", "id": float64(3), "version": float64(4), "panels": []any{}},
+		Meta:      &models.DashboardMeta{FolderUID: "other"},
+	}
+
+	diff, err := dashboardDiff(model, remote, "folder")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"title", "folder"}, diff)
+}
+
+func TestDatasourceDiff(t *testing.T) {
+	desired := &models.UpdateDataSourceCommand{
+		Name:           "prometheus",
+		Type:           "prometheus",
+		URL:            "http://prometheus:9090",
+		SecureJSONData: map[string]string{"httpHeaderValue1": "secret"},
+	}
+
+	t.Run("matching datasource", func(t *testing.T) {
+		remote := &models.DataSource{
+			ID:               1,
+			UID:              "uid",
+			Name:             "prometheus",
+			Type:             "prometheus",
+			URL:              "http://prometheus:9090",
+			Access:           "proxy",
+			JSONData:         map[string]any{},
+			SecureJSONFields: map[string]bool{"httpHeaderValue1": true},
+		}
+
+		diff, err := datasourceDiff(desired, remote)
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("modified datasource", func(t *testing.T) {
+		remote := &models.DataSource{
+			Name:             "prometheus",
+			Type:             "prometheus",
+			URL:              "http://other:9090",
+			Access:           "proxy",
+			JSONData:         map[string]any{"timeInterval": "30s"},
+			SecureJSONFields: map[string]bool{"basicAuthPassword": true},
+		}
+
+		diff, err := datasourceDiff(desired, remote)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"jsonData", "url", "secureJsonData.basicAuthPassword", "secureJsonData.httpHeaderValue1"}, diff)
+	})
+}
+
+func TestAlertRuleGroupDiff(t *testing.T) {
+	title := "rule"
+	changed := "changed"
+
+	model := &models.AlertRuleGroup{
+		Interval: 60,
+		Rules: []*models.ProvisionedAlertRule{
+			{UID: "a", Title: &title},
+			{UID: "b", Title: &title},
+		},
+	}
+
+	remote := &models.AlertRuleGroup{
+		Interval: 120,
+		Rules: []*models.ProvisionedAlertRule{
+			{UID: "a", Title: &changed, ID: 1, OrgID: new(int64(1)), Provenance: "api"},
+			{UID: "c", Title: &title},
+		},
+	}
+
+	diff, err := alertRuleGroupDiff(model, remote)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"interval", "rules[a].title", "rules[b]", "rules[c]"}, diff)
+}
+
+func TestContactPointDiff(t *testing.T) {
+	cr := &v1beta1.GrafanaContactPoint{
+		ObjectMeta: metav1.ObjectMeta{UID: "cp"},
+		Spec: v1beta1.GrafanaContactPointSpec{
+			Receivers: []v1beta1.ContactPointReceiver{
+				{Type: "email", Settings: &apiextensionsv1.JSON{Raw: []byte(`{}`)}},
+				{CustomUID: "webhook", Type: "webhook", Settings: &apiextensionsv1.JSON{Raw: []byte(`{}`)}},
+			},
+		},
+	}
+
+	settings := []models.JSON{
+		map[string]any{"addresses": "a@example.com"},
+		map[string]any{"url": "https://example.com", "password": "secret"},
+	}
+
+	emailType := "email"
+	slackType := "slack"
+
+	remote := []*models.EmbeddedContactPoint{
+		{UID: "cp_0", Type: &emailType, Settings: map[string]any{"addresses": "b@example.com", "singleEmail": true}},
+		{UID: "slack", Type: &slackType, Settings: map[string]any{}},
+	}
+
+	diff, err := contactPointDiff(cr, settings, remote)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"receivers[cp_0].settings.addresses",
+		"receivers[cp_0].settings.singleEmail",
+		"receivers[webhook]",
+		"receivers[slack]",
+	}, diff)
+
+	// Redacted secure settings are not compared
+	webhookType := "webhook"
+	remote = []*models.EmbeddedContactPoint{
+		{UID: "cp_0", Type: &emailType, Settings: map[string]any{"addresses": "a@example.com"}},
+		{UID: "webhook", Type: &webhookType, Settings: map[string]any{"url": "https://example.com", "password": redactedContactPointSetting}},
+	}
+
+	diff, err = contactPointDiff(cr, settings, remote)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
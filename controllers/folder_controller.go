@@ -18,7 +18,6 @@ package controllers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -26,7 +25,6 @@ import (
 
 	"github.com/grafana/grafana-openapi-client-go/client/folders"
 	"github.com/grafana/grafana-openapi-client-go/models"
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 	folderv1 "github.com/grafana/grafana/apps/folder/pkg/apis/folder/v1"
 	apiutils "github.com/grafana/grafana/pkg/apimachinery/utils"
 
@@ -145,11 +143,19 @@ func (r *GrafanaFolderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgResolvingFolderUID, err)
 	}
 
+	if len(cr.Spec.ACL) == 0 {
+		cr.Status.ACL = nil
+	}
+
+	pruneEffectiveACL(&cr.Status.ACL, instances)
+
 	applyErrors := make(map[string]string)
 	uidMismatches := make(map[string]string)
 
+	drift := newDriftTracker(r.Cfg, "GrafanaFolder", cr, cr.Spec.GrafanaCommonSpec)
+
 	for _, grafana := range instances {
-		trackedUID, err := r.onFolderCreated(ctx, &grafana, cr, parentFolderUID)
+		trackedUID, err := r.onFolderCreated(ctx, &grafana, cr, parentFolderUID, drift)
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 			continue
@@ -164,6 +170,7 @@ func (r *GrafanaFolderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	meta.SetStatusCondition(&cr.Status.Conditions, synchronizedCondition)
 	mismatchCondition := buildUIDMismatchCondition(cr.Generation, uidMismatches, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, mismatchCondition)
+	drift.finish(&cr.Status.Conditions, cr.Generation, len(instances))
 
 	if len(applyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
@@ -192,15 +199,18 @@ func (r *GrafanaFolderReconciler) finalize(ctx context.Context, cr *v1beta1.Graf
 	params := folders.NewDeleteFolderParams().WithForceDeleteRules(new(true))
 
 	for _, grafana := range instances {
-		gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, &grafana)
-		if err != nil {
+		gClient, err := newOrgScopedClient(ctx, r.Client, &grafana, cr.Namespace, cr.Spec.OrgRef)
+		if err != nil && !isOrgRemoved(err) {
 			return err
 		}
 
-		_, err = gClient.Folders.DeleteFolder(params.WithFolderUID(uid)) //nolint
-		if err != nil {
-			if IsNotErrorType[*folders.DeleteFolderNotFound](err) {
-				return err
+		// Skip cleanup in instances, the folders of removed organizations are gone with them
+		if err == nil {
+			_, err = gClient.Folders.DeleteFolder(params.WithFolderUID(uid)) //nolint
+			if err != nil {
+				if IsNotErrorType[*folders.DeleteFolderNotFound](err) {
+					return err
+				}
 			}
 		}
 
@@ -211,16 +221,18 @@ func (r *GrafanaFolderReconciler) finalize(ctx context.Context, cr *v1beta1.Graf
 		}
 	}
 
+	clearDriftMetrics("GrafanaFolder", driftResource(cr))
+
 	return nil
 }
 
-func (r *GrafanaFolderReconciler) onFolderCreated(ctx context.Context, grafana *v1beta1.Grafana, cr *v1beta1.GrafanaFolder, parentFolderUID string) (string, error) {
+func (r *GrafanaFolderReconciler) onFolderCreated(ctx context.Context, grafana *v1beta1.Grafana, cr *v1beta1.GrafanaFolder, parentFolderUID string, drift *driftTracker) (string, error) {
 	log := logf.FromContext(ctx)
 
 	title := cr.GetTitle()
 	uid := cr.GetGrafanaUID()
 
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, grafana)
+	gClient, err := newOrgScopedClient(ctx, r.Client, grafana, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return "", err
 	}
@@ -230,10 +242,39 @@ func (r *GrafanaFolderReconciler) onFolderCreated(ctx context.Context, grafana *
 		return "", err
 	}
 
+	if exists {
+		skip, err := drift.skipsUpdate(grafana, func() ([]string, error) {
+			remote, err := gClient.Folders.GetFolderByUID(remoteUID)
+			if err != nil {
+				return nil, fmt.Errorf("fetching folder %s: %w", remoteUID, err)
+			}
+
+			var diff []string
+			if remote.Payload.Title != title {
+				diff = append(diff, "title")
+			}
+
+			if remoteParent != parentFolderUID {
+				diff = append(diff, "parentFolder")
+			}
+
+			return diff, nil
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if skip {
+			log.V(1).Info("folder exists, skipping update due to drift policy", "driftPolicy", drift.policy)
+			return remoteUID, grafana.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(remoteUID))
+		}
+	}
+
 	// Update when missing, the CR is updated or parentFolder has changed.
 	if exists && cr.Unchanged() && parentFolderUID == remoteParent {
 		log.V(1).Info("folder unchanged. skipping remaining requests")
-		return remoteUID, nil
+		// the acl is always compared to pick up changes made in Grafana
+		return remoteUID, reconcileFolderACL(ctx, r.Client, gClient, grafana, cr, remoteUID)
 	}
 
 	if exists {
@@ -273,19 +314,22 @@ func (r *GrafanaFolderReconciler) onFolderCreated(ctx context.Context, grafana *
 
 	// NOTE: it's up to a user to reset permissions with correct json
 	if cr.Spec.Permissions != "" {
-		permissions := models.UpdateDashboardACLCommand{}
-
-		err = json.Unmarshal([]byte(cr.Spec.Permissions), &permissions)
+		permissions, err := ParsePermissions(grafana, cr.Namespace, cr.Spec.OrgRef, cr.Spec.Permissions)
 		if err != nil {
-			return "", fmt.Errorf("failed to unmarshal spec.permissions: %w", err)
+			return "", fmt.Errorf("failed to parse spec.permissions: %w", err)
 		}
 
-		_, err = gClient.Folders.UpdateFolderPermissions(uid, &permissions) //nolint:errcheck
+		_, err = gClient.Folders.UpdateFolderPermissions(uid, permissions) //nolint:errcheck
 		if err != nil {
 			return "", fmt.Errorf("failed to update folder permissions: %w", err)
 		}
 	}
 
+	err = reconcileFolderACL(ctx, r.Client, gClient, grafana, cr, uid)
+	if err != nil {
+		return "", err
+	}
+
 	// Update grafana instance Status
 	return uid, grafana.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(uid))
 }
@@ -405,22 +449,31 @@ func NewGenericFolderReconciler(cl client.Client, cfg *Config) *GenericReconcile
 			return nil
 		},
 		PostApplyHook: func(ctx context.Context, cl client.Client, instance *v1beta1.Grafana, cr *v1beta1.GrafanaFolder) error {
+			if len(cr.Spec.ACL) > 0 {
+				gClient, err := newOrgScopedClient(ctx, cl, instance, cr.Namespace, cr.Spec.OrgRef)
+				if err != nil {
+					return fmt.Errorf("building grafana client: %w", err)
+				}
+
+				return reconcileFolderACL(ctx, cl, gClient, instance, cr, cr.GetGrafanaUID())
+			}
+
+			cr.Status.ACL = nil
+
 			if cr.Spec.Permissions != "" {
-				gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, cl, instance)
+				gClient, err := newOrgScopedClient(ctx, cl, instance, cr.Namespace, cr.Spec.OrgRef)
 				if err != nil {
 					return fmt.Errorf("building grafana client: %w", err)
 				}
 
 				uid := cr.GetGrafanaUID()
 				// NOTE: it's up to a user to reset permissions with correct json
-				permissions := models.UpdateDashboardACLCommand{}
-
-				err = json.Unmarshal([]byte(cr.Spec.Permissions), &permissions)
+				permissions, err := ParsePermissions(instance, cr.Namespace, cr.Spec.OrgRef, cr.Spec.Permissions)
 				if err != nil {
-					return fmt.Errorf("failed to unmarshal spec.permissions: %w", err)
+					return fmt.Errorf("failed to parse spec.permissions: %w", err)
 				}
 
-				_, err = gClient.Folders.UpdateFolderPermissions(uid, &permissions) //nolint:errcheck
+				_, err = gClient.Folders.UpdateFolderPermissions(uid, permissions) //nolint:errcheck
 				if err != nil {
 					return fmt.Errorf("failed to update folder permissions: %w", err)
 				}
@@ -433,3 +486,19 @@ func NewGenericFolderReconciler(cl client.Client, cfg *Config) *GenericReconcile
 		SynchronizedCondition: conditionFolderSynchronized,
 	}
 }
+
+// reconcileFolderACL applies spec.acl and records the resulting permissions in the status
+func reconcileFolderACL(ctx context.Context, cl client.Client, gClient *genapi.GrafanaHTTPAPI, instance *v1beta1.Grafana, cr *v1beta1.GrafanaFolder, uid string) error {
+	if len(cr.Spec.ACL) == 0 {
+		return nil
+	}
+
+	items, err := reconcileACL(ctx, cl, gClient, instance, cr.Namespace, cr.Spec.OrgRef, aclResourceFolders, uid, cr.Spec.ACL)
+	if err != nil {
+		return fmt.Errorf("failed to update folder permissions: %w", err)
+	}
+
+	setEffectiveACL(&cr.Status.ACL, instance, items)
+
+	return nil
+}
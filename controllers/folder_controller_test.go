@@ -242,3 +242,75 @@ var _ = Describe("Folder reconciler", func() {
 		assert.IsType(t, &folders.GetFolderByUIDNotFound{}, err) //nolint:testifylint
 	})
 })
+
+var _ = Describe("Folder reconciler: ACL", func() {
+	It("applies acl differences and records the effective permissions", func() {
+		t := GinkgoT()
+
+		folder := &v1beta1.GrafanaFolder{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "acl-folder",
+			},
+			Spec: v1beta1.GrafanaFolderSpec{
+				GrafanaCommonSpec: commonSpecSynchronized,
+				CustomUID:         "acl-folder",
+				ACL: []v1beta1.GrafanaPermission{
+					{Role: "Viewer", Permission: "Edit"},
+					{User: "admin", Permission: "Admin"},
+				},
+			},
+		}
+
+		req := tk8s.GetRequest(t, folder)
+		r := &GrafanaFolderReconciler{Client: cl, Scheme: cl.Scheme()}
+
+		err := cl.Create(testCtx, folder)
+		require.NoError(t, err)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		err = cl.Get(testCtx, req.NamespacedName, folder)
+		require.NoError(t, err)
+
+		want := []v1beta1.GrafanaEffectivePermission{
+			{Role: "Viewer", Permission: "Edit"},
+			{User: "admin", Permission: "Admin"},
+		}
+
+		require.Len(t, folder.Status.ACL, 1)
+		assert.Equal(t, want, managedPermissions(folder.Status.ACL[0].Items))
+
+		By("Granting a permission outside of the operator")
+
+		gClient, err := grafanaclient.NewGeneratedGrafanaClient(testCtx, cl, externalGrafanaCr)
+		require.NoError(t, err)
+
+		err = setACLEntry(gClient, aclResourceFolders, "acl-folder", aclChange{
+			key:        aclKey{kind: aclKindBuiltInRole, role: "Editor"},
+			permission: "Admin",
+		})
+		require.NoError(t, err)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		err = cl.Get(testCtx, req.NamespacedName, folder)
+		require.NoError(t, err)
+		assert.Equal(t, want, managedPermissions(folder.Status.ACL[0].Items))
+	})
+})
+
+// managedPermissions drops permissions granted to the basic Admin role, which Grafana may report without them being managed
+func managedPermissions(items []v1beta1.GrafanaEffectivePermission) []v1beta1.GrafanaEffectivePermission {
+	var result []v1beta1.GrafanaEffectivePermission
+
+	for _, item := range items {
+		if item.Role != "Admin" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
+	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// annotationManagedFolders lists the uids of the folders created for spec.folderPath, outermost folders first
+const annotationManagedFolders = "operator.grafana.com/managed-folders"
+
+// folderPathUID derives the uid of a folder created for a path, so that it is the same in every instance
+// and folders created by the operator can be told apart from folders created by users.
+// Titles are matched case-insensitively, so is the uid
+func folderPathUID(segments []string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.Join(segments, "/"))))
+
+	// uids are limited to 40 characters
+	return hex.EncodeToString(sum[:])[:40]
+}
+
+// folderPathUIDs returns the uids of the folders along path, outermost folders first
+func folderPathUIDs(path string) []string {
+	segments := strings.Split(path, "/")
+	uids := make([]string, 0, len(segments))
+
+	for i := range segments {
+		uids = append(uids, folderPathUID(segments[:i+1]))
+	}
+
+	return uids
+}
+
+// findChildFolder returns the uid of the folder with title within the parent folder, an empty parentUID looks up root folders
+func findChildFolder(gClient *genapi.GrafanaHTTPAPI, parentUID, title string) (string, bool, error) {
+	page := int64(1)
+	limit := int64(1000)
+
+	for {
+		params := folders.NewGetFoldersParams().WithPage(&page).WithLimit(&limit)
+		if parentUID != "" {
+			params.SetParentUID(&parentUID)
+		}
+
+		resp, err := gClient.Folders.GetFolders(params)
+		if err != nil {
+			return "", false, err
+		}
+
+		items := resp.GetPayload()
+
+		for _, folder := range items {
+			if strings.EqualFold(folder.Title, title) {
+				return folder.UID, true, nil
+			}
+		}
+
+		if len(items) < int(limit) {
+			return "", false, nil
+		}
+
+		page++
+	}
+}
+
+// walkFolderPath returns the uid of the innermost folder of path and the uids of the folders along it created by the operator.
+// Missing folders are created if create is set, otherwise an empty uid is returned
+func walkFolderPath(gClient *genapi.GrafanaHTTPAPI, path string, create bool) (string, []string, error) {
+	segments := strings.Split(path, "/")
+	parentUID := ""
+	managed := make([]string, 0, len(segments))
+
+	for i, title := range segments {
+		uid := folderPathUID(segments[:i+1])
+
+		found, exists, err := findChildFolder(gClient, parentUID, title)
+		if err != nil {
+			return "", nil, fmt.Errorf("looking up folder %s: %w", strings.Join(segments[:i+1], "/"), err)
+		}
+
+		if exists {
+			if found == uid {
+				managed = append(managed, uid)
+			}
+
+			parentUID = found
+
+			continue
+		}
+
+		if !create {
+			return "", managed, nil
+		}
+
+		_, err = gClient.Folders.CreateFolder(&models.CreateFolderCommand{ //nolint:errcheck
+			Title:     title,
+			UID:       uid,
+			ParentUID: parentUID,
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("creating folder %s: %w", strings.Join(segments[:i+1], "/"), err)
+		}
+
+		managed = append(managed, uid)
+		parentUID = uid
+	}
+
+	return parentUID, managed, nil
+}
+
+// getOrCreateFolderPath returns the uid of the innermost folder of path, creating missing folders.
+// Created folders are tracked in an annotation of cr to remove them once cr is deleted
+func getOrCreateFolderPath(ctx context.Context, cl client.Client, gClient *genapi.GrafanaHTTPAPI, cr client.Object, path string) (string, error) {
+	folderUID, managed, err := walkFolderPath(gClient, path, true)
+	if err != nil {
+		return "", err
+	}
+
+	// Folders of previous paths are no longer tracked and are left in place, they may hold resources of others.
+	// Folders along the path are kept as other instances may have created different ones
+	pathUIDs := folderPathUIDs(path)
+	uids := slices.DeleteFunc(managedFolders(cr), func(uid string) bool {
+		return !slices.Contains(pathUIDs, uid)
+	})
+
+	// parents are always listed before their children
+	for _, uid := range managed {
+		if !slices.Contains(uids, uid) {
+			uids = append(uids, uid)
+		}
+	}
+
+	err = addAnnotation(ctx, cl, cr, annotationManagedFolders, strings.Join(uids, ","))
+	if err != nil {
+		return "", fmt.Errorf("tracking created folders: %w", err)
+	}
+
+	return folderUID, nil
+}
+
+func managedFolders(cr client.Object) []string {
+	value := cr.GetAnnotations()[annotationManagedFolders]
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+// deleteManagedFolders removes the empty folders created for cr, innermost folders first.
+// Failures are only logged, folders still in use must not block deleting cr
+func deleteManagedFolders(ctx context.Context, gClient *genapi.GrafanaHTTPAPI, cr client.Object) {
+	log := logf.FromContext(ctx)
+
+	uids := managedFolders(cr)
+
+	for _, uid := range slices.Backward(uids) {
+		resp, err := deleteFolderIfEmpty(gClient, uid)
+		if err != nil {
+			log.Error(err, "deleting empty folder", "folderUID", uid)
+			continue
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			log.Info("unused folder successfully removed", "folderUID", uid)
+		case http.StatusLocked:
+			log.V(1).Info("folder still in use", "folderUID", uid)
+		}
+	}
+}
+
+// isFolderInUse reports whether the folder holds dashboards, folders, library panels or alert rules. Deleting a folder
+// removes all of them
+func isFolderInUse(gClient *genapi.GrafanaHTTPAPI, folderUID string) (bool, error) {
+	results, err := gClient.Search.Search(search.NewSearchParams().WithFolderUIDs([]string{folderUID}))
+	if err != nil {
+		return false, fmt.Errorf("searching folder contents: %w", err)
+	}
+
+	if len(results.GetPayload()) > 0 {
+		return true, nil
+	}
+
+	perPage := int64(1)
+
+	elements, err := gClient.LibraryElements.GetLibraryElements(
+		library_elements.NewGetLibraryElementsParams().WithFolderFilterUIDs(&folderUID).WithPerPage(&perPage))
+	if err != nil {
+		return false, fmt.Errorf("listing library elements: %w", err)
+	}
+
+	if result := elements.GetPayload().Result; result != nil && (result.TotalCount > 0 || len(result.Elements) > 0) {
+		return true, nil
+	}
+
+	rules, err := gClient.Provisioning.GetAlertRules()
+	if err != nil {
+		return false, fmt.Errorf("listing alert rules: %w", err)
+	}
+
+	return slices.ContainsFunc(rules.GetPayload(), func(rule *models.ProvisionedAlertRule) bool {
+		return rule.FolderUID != nil && *rule.FolderUID == folderUID
+	}), nil
+}
+
+func deleteFolderIfEmpty(gClient *genapi.GrafanaHTTPAPI, folderUID string) (http.Response, error) {
+	inUse, err := isFolderInUse(gClient, folderUID)
+	if err != nil {
+		return http.Response{
+			Status:     "internal grafana client error getting folder contents",
+			StatusCode: http.StatusInternalServerError,
+		}, err
+	}
+
+	if inUse {
+		return http.Response{
+			Status:     "resource is still in use",
+			StatusCode: http.StatusLocked,
+		}, nil
+	}
+
+	deleteParams := folders.NewDeleteFolderParams().WithFolderUID(folderUID)
+	if _, err = gClient.Folders.DeleteFolder(deleteParams); err != nil { //nolint:errcheck
+		if IsNotErrorType[*folders.DeleteFolderNotFound](err) {
+			return http.Response{
+				Status:     "internal grafana client error deleting grafana folder",
+				StatusCode: http.StatusInternalServerError,
+			}, err
+		}
+	}
+
+	return http.Response{
+		Status:     "grafana folder deleted",
+		StatusCode: http.StatusOK,
+	}, nil
+}
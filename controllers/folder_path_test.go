@@ -0,0 +1,263 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeFolderAPI serves the folder, search, library element and alert rule endpoints of Grafana from memory
+type fakeFolderAPI struct {
+	mu      sync.Mutex
+	folders map[string]models.FolderSearchHit
+	// folder uids of library panels and alert rules by uid
+	libraryPanels map[string]string
+	alertRules    map[string]string
+}
+
+func newFakeFolderAPI(t *testing.T, existing ...models.FolderSearchHit) (*fakeFolderAPI, *genapi.GrafanaHTTPAPI) {
+	t.Helper()
+
+	api := &fakeFolderAPI{
+		folders:       map[string]models.FolderSearchHit{},
+		libraryPanels: map[string]string{},
+		alertRules:    map[string]string{},
+	}
+	for _, f := range existing {
+		api.folders[f.UID] = f
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/folders", api.list)
+	mux.HandleFunc("POST /api/folders", api.create)
+	mux.HandleFunc("DELETE /api/folders/{uid}", api.delete)
+	mux.HandleFunc("GET /api/search", api.search)
+	mux.HandleFunc("GET /api/library-elements", api.listLibraryElements)
+	mux.HandleFunc("GET /api/v1/provisioning/alert-rules", api.listAlertRules)
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	return api, genapi.NewHTTPClientWithConfig(nil, &genapi.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	})
+}
+
+func (f *fakeFolderAPI) children(parentUID string) []models.FolderSearchHit {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := []models.FolderSearchHit{}
+
+	for _, folder := range f.folders {
+		if folder.ParentUID == parentUID {
+			out = append(out, folder)
+		}
+	}
+
+	return out
+}
+
+func (f *fakeFolderAPI) list(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, f.children(r.URL.Query().Get("parentUid")))
+}
+
+func (f *fakeFolderAPI) create(w http.ResponseWriter, r *http.Request) {
+	var cmd models.CreateFolderCommand
+
+	err := json.NewDecoder(r.Body).Decode(&cmd)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	f.folders[cmd.UID] = models.FolderSearchHit{UID: cmd.UID, Title: cmd.Title, ParentUID: cmd.ParentUID}
+	f.mu.Unlock()
+
+	writeJSON(w, models.Folder{UID: cmd.UID, Title: cmd.Title, ParentUID: cmd.ParentUID})
+}
+
+func (f *fakeFolderAPI) delete(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	delete(f.folders, r.PathValue("uid"))
+	f.mu.Unlock()
+
+	writeJSON(w, map[string]string{"message": "Folder deleted"})
+}
+
+func (f *fakeFolderAPI) search(w http.ResponseWriter, r *http.Request) {
+	hits := models.HitList{}
+	for _, folder := range f.children(r.URL.Query().Get("folderUIDs")) {
+		hits = append(hits, &models.Hit{UID: folder.UID, Title: folder.Title, Type: "dash-folder"})
+	}
+
+	writeJSON(w, hits)
+}
+
+func (f *fakeFolderAPI) listLibraryElements(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := &models.LibraryElementSearchResult{Elements: []*models.LibraryElementDTO{}}
+
+	for uid, folderUID := range f.libraryPanels {
+		if folderUID == r.URL.Query().Get("folderFilterUIDs") {
+			result.Elements = append(result.Elements, &models.LibraryElementDTO{UID: uid, FolderUID: folderUID})
+			result.TotalCount++
+		}
+	}
+
+	writeJSON(w, models.LibraryElementSearchResponse{Result: result})
+}
+
+func (f *fakeFolderAPI) listAlertRules(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rules := models.ProvisionedAlertRules{}
+
+	for uid, folderUID := range f.alertRules {
+		rules = append(rules, &models.ProvisionedAlertRule{UID: uid, FolderUID: &folderUID, Title: &uid})
+	}
+
+	writeJSON(w, rules)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+func (f *fakeFolderAPI) exists(uid string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.folders[uid]
+
+	return ok
+}
+
+func TestFolderPathUID(t *testing.T) {
+	uid := folderPathUID([]string{"Platform", "Kubernetes"})
+
+	assert.Len(t, uid, 40)
+	assert.Equal(t, uid, folderPathUID([]string{"platform", "KUBERNETES"}), "titles are matched case-insensitively")
+	assert.NotEqual(t, uid, folderPathUID([]string{"Platform"}))
+	assert.NotEqual(t, uid, folderPathUID([]string{"Kubernetes", "Platform"}))
+}
+
+func TestFolderPath(t *testing.T) {
+	ctx := context.Background()
+
+	// created by a user, must not be tracked or removed
+	platform := models.FolderSearchHit{UID: "platform", Title: "Platform"}
+
+	api, gClient := newFakeFolderAPI(t, platform)
+
+	dashboard := &v1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nodes"},
+	}
+
+	s := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(dashboard).Build()
+
+	kubernetesUID := folderPathUID([]string{"Platform", "Kubernetes"})
+	nodesUID := folderPathUID([]string{"Platform", "Kubernetes", "Nodes"})
+
+	t.Run("lookup does not create folders", func(t *testing.T) {
+		uid, _, err := walkFolderPath(gClient, "Platform/Kubernetes/Nodes", false)
+		require.NoError(t, err)
+		assert.Empty(t, uid)
+		assert.False(t, api.exists(kubernetesUID))
+	})
+
+	t.Run("missing folders are created and tracked", func(t *testing.T) {
+		uid, err := getOrCreateFolderPath(ctx, cl, gClient, dashboard, "Platform/Kubernetes/Nodes")
+		require.NoError(t, err)
+		assert.Equal(t, nodesUID, uid)
+		assert.True(t, api.exists(kubernetesUID))
+		assert.Equal(t, []string{kubernetesUID, nodesUID}, managedFolders(dashboard))
+
+		// existing folders are reused, regardless of the case of their titles
+		uid, err = getOrCreateFolderPath(ctx, cl, gClient, dashboard, "platform/kubernetes/nodes")
+		require.NoError(t, err)
+		assert.Equal(t, nodesUID, uid)
+		assert.Len(t, api.children(kubernetesUID), 1)
+	})
+
+	t.Run("folders of previous paths are no longer tracked", func(t *testing.T) {
+		podsUID, err := getOrCreateFolderPath(ctx, cl, gClient, dashboard, "Platform/Kubernetes/Pods")
+		require.NoError(t, err)
+		assert.Equal(t, []string{kubernetesUID, podsUID}, managedFolders(dashboard))
+		assert.True(t, api.exists(nodesUID), "folders of previous paths are left in place")
+	})
+
+	t.Run("only empty folders created by the operator are removed", func(t *testing.T) {
+		podsUID := folderPathUID([]string{"Platform", "Kubernetes", "Pods"})
+
+		// folders of other resources are not empty
+		api.folders["other"] = models.FolderSearchHit{UID: "other", Title: "Other", ParentUID: kubernetesUID}
+
+		deleteManagedFolders(ctx, gClient, dashboard)
+
+		assert.False(t, api.exists(podsUID))
+		assert.True(t, api.exists(kubernetesUID))
+
+		delete(api.folders, "other")
+
+		deleteManagedFolders(ctx, gClient, dashboard)
+
+		// the folder of the previous path is not tracked any more
+		assert.True(t, api.exists(kubernetesUID))
+
+		delete(api.folders, nodesUID)
+
+		deleteManagedFolders(ctx, gClient, dashboard)
+
+		assert.False(t, api.exists(kubernetesUID))
+		assert.True(t, api.exists(platform.UID))
+		assert.Len(t, strings.Split(dashboard.Annotations[annotationManagedFolders], ","), 2)
+	})
+
+	t.Run("folders holding library panels or alert rules are kept", func(t *testing.T) {
+		uid, err := getOrCreateFolderPath(ctx, cl, gClient, dashboard, "Platform/Kubernetes/Pods")
+		require.NoError(t, err)
+
+		api.libraryPanels["panel"] = uid
+		api.alertRules["rule"] = kubernetesUID
+
+		deleteManagedFolders(ctx, gClient, dashboard)
+
+		assert.True(t, api.exists(uid))
+		assert.True(t, api.exists(kubernetesUID))
+
+		delete(api.libraryPanels, "panel")
+
+		deleteManagedFolders(ctx, gClient, dashboard)
+
+		assert.False(t, api.exists(uid))
+		assert.True(t, api.exists(kubernetesUID))
+	})
+}
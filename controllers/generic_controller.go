@@ -19,11 +19,13 @@ package controllers
 import (
 	"context"
 	"fmt"
-
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"maps"
+	"reflect"
+	"slices"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -33,6 +35,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 )
 
 type ValidationError struct {
@@ -151,22 +154,18 @@ func (r *GenericReconciler[T, PT]) Reconcile(ctx context.Context, req ctrl.Reque
 
 	applyErrors := make(map[string]string)
 
+	drift := newDriftTracker(r.Cfg, r.kind(), cr, cr.CommonSpec())
+
 	for _, grafana := range instances {
-		err := r.reconcileWithInstance(ctx, &grafana, cr, resource)
+		err := r.reconcileWithInstance(ctx, &grafana, cr, resource, drift)
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
-			continue
-		}
-
-		if r.PostApplyHook != nil {
-			if err := r.PostApplyHook(ctx, r.Client, &grafana, cr); err != nil {
-				applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
-			}
 		}
 	}
 
 	condition := buildSynchronizedCondition(r.ResourceName, r.SynchronizedCondition, cr.GetGeneration(), applyErrors, len(instances))
 	meta.SetStatusCondition(cr.Conditions(), condition)
+	drift.finish(cr.Conditions(), cr.GetGeneration(), len(instances))
 
 	if len(applyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
@@ -178,14 +177,45 @@ func (r *GenericReconciler[T, PT]) Reconcile(ctx context.Context, req ctrl.Reque
 	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.CommonSpec().ResyncPeriod)}, nil
 }
 
-func (r *GenericReconciler[T, PT]) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr PT, resource runtime.Object) error {
-	dc, err := grafanaclient.NewDynamicClient(ctx, r.Client, instance)
+func (r *GenericReconciler[T, PT]) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr PT, resource runtime.Object, drift *driftTracker) error {
+	dc, err := newOrgScopedDynamicClient(ctx, r.Client, instance, cr.GetNamespace(), cr.CommonSpec().OrgRef)
 	if err != nil {
 		return fmt.Errorf("building grafana client: %w", err)
 	}
 
-	if err := dc.ApplyObject(ctx, resource); err != nil {
-		return fmt.Errorf("applying folder resource: %w", err)
+	obj := grafanaclient.ToUnstructured(resource)
+
+	existing, err := dc.Get(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	skip := false
+
+	if existing != nil {
+		skip, err = drift.skipsUpdate(instance, func() ([]string, error) {
+			return objectDiff(obj, existing), nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if skip {
+			logf.FromContext(ctx).V(1).Info("resource exists, skipping update due to drift policy", "driftPolicy", drift.policy)
+		}
+	}
+
+	if !skip {
+		if err := dc.Apply(ctx, obj); err != nil {
+			return fmt.Errorf("applying folder resource: %w", err)
+		}
+	}
+
+	// the hook applies settings not covered by the drift policy, e.g. permissions
+	if r.PostApplyHook != nil {
+		if err := r.PostApplyHook(ctx, r.Client, instance, cr); err != nil {
+			return err
+		}
 	}
 
 	return instance.AddNamespacedResource(ctx, r.Client, cr, v1beta1.NewNamespacedResource(cr.GetNamespace(), cr.GetName(), string(cr.GetUID())))
@@ -203,14 +233,17 @@ func (r *GenericReconciler[T, PT]) finalize(ctx context.Context, cr PT) error {
 	}
 
 	for _, grafana := range instances {
-		gClient, err := grafanaclient.NewDynamicClient(ctx, r.Client, &grafana)
-		if err != nil {
+		gClient, err := newOrgScopedDynamicClient(ctx, r.Client, &grafana, cr.GetNamespace(), cr.CommonSpec().OrgRef)
+		if err != nil && !isOrgRemoved(err) {
 			return err
 		}
 
-		err = gClient.DeleteInDefaultNamespace(ctx, r.GVR, string(uid))
-		if err != nil {
-			return err
+		// Skip cleanup in instances, the resources of removed organizations are gone with them
+		if err == nil {
+			err = gClient.DeleteInDefaultNamespace(ctx, r.GVR, string(uid))
+			if err != nil {
+				return err
+			}
 		}
 
 		// Update grafana instance Status
@@ -220,9 +253,40 @@ func (r *GenericReconciler[T, PT]) finalize(ctx context.Context, cr PT) error {
 		}
 	}
 
+	clearDriftMetrics(r.kind(), driftResource(cr))
+
 	return nil
 }
 
+// kind is the kind of the custom resource, e.g. GrafanaFolder
+func (r *GenericReconciler[T, PT]) kind() string {
+	return "Grafana" + r.ResourceName
+}
+
+// objectDiff lists the fields of the spec and the annotations of the desired object differing from the existing object.
+// Fields only present on the existing object are defaulted by the api server and are not compared
+func objectDiff(desired, existing *unstructured.Unstructured) []string {
+	var diff []string
+
+	desiredSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+
+	for _, key := range slices.Sorted(maps.Keys(desiredSpec)) {
+		if !reflect.DeepEqual(desiredSpec[key], existingSpec[key]) {
+			diff = append(diff, "spec."+key)
+		}
+	}
+
+	existingAnnotations := existing.GetAnnotations()
+	for _, key := range slices.Sorted(maps.Keys(desired.GetAnnotations())) {
+		if desired.GetAnnotations()[key] != existingAnnotations[key] {
+			diff = append(diff, "metadata.annotations."+key)
+		}
+	}
+
+	return diff
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GenericReconciler[T, PT]) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -32,6 +32,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,6 +66,9 @@ type GrafanaReconciler struct {
 	IsOpenShift     bool
 	HasHTTPRouteCRD bool
 	ClusterDomain   string
+
+	// Image of the init container installing plugins from archives
+	PluginInstallerImage string
 }
 
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;create;update;delete;watch
@@ -73,6 +77,7 @@ type GrafanaReconciler struct {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=configmaps;secrets;serviceaccounts;services;persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 
 func (r *GrafanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -109,8 +114,8 @@ func (r *GrafanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	var stages []v1beta1.OperatorStageName
 	if cr.IsExternal() {
-		// Only reconcile the Completion stage for external instances
-		stages = []v1beta1.OperatorStageName{v1beta1.OperatorStageComplete}
+		// Only reconcile the Plugins and Completion stages for external instances
+		stages = []v1beta1.OperatorStageName{v1beta1.OperatorStagePlugins, v1beta1.OperatorStageComplete}
 		// AdminURL is normally set during ingress/route stage.
 		// External instances only use the complete stage
 		cr.Status.AdminURL = cr.Spec.External.URL
@@ -129,6 +134,13 @@ func (r *GrafanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 	}
 
+	// Plugins stored before the plugin policy was added or tightened are removed before the plugins stage. Failed
+	// lookups of plugin signatures are retried without holding back the stages
+	policyErr := applyPluginPolicy(ctx, r.Client, cr)
+	if policyErr != nil {
+		log.Error(policyErr, "applying the plugin policy to the requested plugins")
+	}
+
 	vars := &v1beta1.OperatorReconcileVars{}
 
 	for _, stage := range stages {
@@ -167,6 +179,10 @@ func (r *GrafanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		LastTransitionTime: metav1.Time{Time: time.Now()},
 	})
 
+	if policyErr != nil {
+		return ctrl.Result{}, fmt.Errorf("applying the plugin policy: %w", policyErr)
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -277,6 +293,34 @@ func (r *GrafanaReconciler) syncStatuses(ctx context.Context) error {
 		return err
 	}
 
+	organizations := &v1beta1.GrafanaOrganizationList{}
+
+	err = r.List(ctx, organizations)
+	if err != nil {
+		return err
+	}
+
+	silences := &v1beta1.GrafanaSilenceList{}
+
+	err = r.List(ctx, silences)
+	if err != nil {
+		return err
+	}
+
+	teams := &v1beta1.GrafanaTeamList{}
+
+	err = r.List(ctx, teams)
+	if err != nil {
+		return err
+	}
+
+	users := &v1beta1.GrafanaUserList{}
+
+	err = r.List(ctx, users)
+	if err != nil {
+		return err
+	}
+
 	manifests := &v1beta1.GrafanaManifestList{}
 
 	err = r.List(ctx, manifests)
@@ -298,6 +342,10 @@ func (r *GrafanaReconciler) syncStatuses(ctx context.Context) error {
 		removeMissingCRs(&grafana.Status.LibraryPanels, libraryPanels, &updateStatus)
 		removeMissingCRs(&grafana.Status.MuteTimings, muteTimings, &updateStatus)
 		removeMissingCRs(&grafana.Status.NotificationTemplates, notificationTemplates, &updateStatus)
+		removeMissingCRs(&grafana.Status.Organizations, organizations, &updateStatus)
+		removeMissingCRs(&grafana.Status.Silences, silences, &updateStatus)
+		removeMissingCRs(&grafana.Status.Teams, teams, &updateStatus)
+		removeMissingCRs(&grafana.Status.Users, users, &updateStatus)
 		removeMissingCRs(&grafana.Status.Manifests, manifests, &updateStatus)
 
 		if updateStatus {
@@ -343,6 +391,7 @@ func (r *GrafanaReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&corev1.Service{}, builder.WithPredicates(ignoreStatusUpdates())).
 		Owns(&networkingv1.Ingress{}, builder.WithPredicates(ignoreStatusUpdates())).
+		Owns(&policyv1.PodDisruptionBudget{}, builder.WithPredicates(ignoreStatusUpdates())).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeByField(secretIndexKey)),
@@ -411,6 +460,7 @@ func getInstallationStages() []v1beta1.OperatorStageName {
 		v1beta1.OperatorStageService,
 		v1beta1.OperatorStageIngress,
 		v1beta1.OperatorStagePlugins,
+		v1beta1.OperatorStageRenderer,
 		v1beta1.OperatorStageDeployment,
 		v1beta1.OperatorStageComplete,
 	}
@@ -432,8 +482,10 @@ func (r *GrafanaReconciler) getReconcilerForStage(stage v1beta1.OperatorStageNam
 		return grafana.NewIngressReconciler(r.Client, r.IsOpenShift, r.HasHTTPRouteCRD)
 	case v1beta1.OperatorStagePlugins:
 		return grafana.NewPluginsReconciler(r.Client)
+	case v1beta1.OperatorStageRenderer:
+		return grafana.NewRendererReconciler(r.Client, r.IsOpenShift)
 	case v1beta1.OperatorStageDeployment:
-		return grafana.NewDeploymentReconciler(r.Client, r.IsOpenShift)
+		return grafana.NewDeploymentReconciler(r.Client, r.IsOpenShift, r.PluginInstallerImage)
 	case v1beta1.OperatorStageComplete:
 		return grafana.NewCompleteReconciler(r.Client)
 	default:
@@ -21,10 +21,10 @@ import (
 	"errors"
 	"fmt"
 
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/library_elements"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 	"github.com/grafana/grafana-operator/v5/controllers/content"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -134,7 +134,7 @@ func (r *GrafanaLibraryPanelReconciler) Reconcile(ctx context.Context, req ctrl.
 	// Retrieving the model before the loop ensures to exit early in case of failure and not fail once per matching instance
 	contentModel, hash, err := resolver.Resolve(ctx)
 	if err != nil {
-		setInvalidSpec(&cr.Status.Conditions, cr.Generation, "InvalidModelResolution", err.Error())
+		setInvalidSpec(&cr.Status.Conditions, cr.Generation, modelResolutionReason(err), err.Error())
 		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionLibraryPanelSynchronized)
 		log.Error(err, LogMsgResolvingPanelContents)
 
@@ -162,9 +162,14 @@ func (r *GrafanaLibraryPanelReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	applyErrors := make(map[string]string)
+	rejectedPlugins := pluginRejections{}
 
 	for _, grafana := range instances {
-		err := r.reconcileWithInstance(ctx, &grafana, cr, contentModel, hash, folderUID)
+		instanceModel, _, err := resolveContentForInstance(resolver, cr, &grafana, contentModel, hash)
+		if err == nil {
+			err = r.reconcileWithInstance(ctx, &grafana, cr, instanceModel, hash, folderUID, rejectedPlugins)
+		}
+
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 		}
@@ -172,6 +177,7 @@ func (r *GrafanaLibraryPanelReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	condition := buildSynchronizedCondition("Library panel", conditionLibraryPanelSynchronized, cr.Generation, applyErrors, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	rejectedPlugins.finish(&cr.Status.Conditions, cr.Generation)
 
 	if len(applyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
@@ -187,24 +193,29 @@ func (r *GrafanaLibraryPanelReconciler) Reconcile(ctx context.Context, req ctrl.
 		log.Error(err, LogMsgUpdateCache)
 	}
 
-	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
+	return ctrl.Result{RequeueAfter: contentRequeueAfter(r.Cfg.requeueAfter(cr.Spec.ResyncPeriod), cr)}, nil
 }
 
-func (r *GrafanaLibraryPanelReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaLibraryPanel, model map[string]any, hash, folderUID string) error {
-	if instance.IsInternal() {
-		err := ReconcilePlugins(ctx, r.Client, r.Scheme, instance, cr.Spec.Plugins, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
-		if err != nil {
-			return err
-		}
-	} else if instance.IsExternal() && cr.Spec.Plugins != nil {
-		return fmt.Errorf("external grafana instances don't support plugins, please remove spec.plugins from your library panel cr")
+func (r *GrafanaLibraryPanelReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaLibraryPanel, model map[string]any, hash, folderUID string, rejectedPlugins pluginRejections) error {
+	rejected, err := ReconcilePlugins(ctx, r.Client, r.Scheme, instance, cr.Namespace, cr.Spec.Plugins, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
+	rejectedPlugins.add(instance, rejected)
+
+	if err != nil {
+		return err
 	}
 
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return err
 	}
 
+	if cr.Spec.FolderPath != "" {
+		folderUID, err = getOrCreateFolderPath(ctx, r.Client, gClient, cr, cr.Spec.FolderPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	uid := content.GetGrafanaUID(cr, fmt.Sprintf("%s", model["uid"]))
 	name := fmt.Sprintf("%s", model["name"])
 
@@ -230,8 +241,8 @@ func (r *GrafanaLibraryPanelReconciler) reconcileWithInstance(ctx context.Contex
 		return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(uid))
 	}
 
-	// handle content caching
-	if content.HasChanged(cr, hash) {
+	// handle content caching, content templated with grafanaRefs is compared with the library panel in Grafana
+	if content.HasChanged(cr, hash) || !libraryPanelMatchesStateInGrafana(model, name, resp.Payload.Result) {
 		_, err = gClient.LibraryElements.UpdateLibraryElement(uid, &models.PatchLibraryElementCommand{ //nolint:errcheck
 			FolderUID: folderUID,
 			Kind:      int64(libraryElementTypePanel),
@@ -248,6 +259,20 @@ func (r *GrafanaLibraryPanelReconciler) reconcileWithInstance(ctx context.Contex
 	return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(uid))
 }
 
+// libraryPanelMatchesStateInGrafana checks whether the name and model of the library panel in Grafana match the rendered content
+func libraryPanelMatchesStateInGrafana(model map[string]any, name string, remote *models.LibraryElementDTO) bool {
+	if remote == nil || remote.Name != name {
+		return false
+	}
+
+	remoteModel, ok := remote.Model.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	return len(dashboardModelDiff(model, remoteModel)) == 0
+}
+
 func (r *GrafanaLibraryPanelReconciler) finalize(ctx context.Context, cr *v1beta1.GrafanaLibraryPanel) error {
 	log := logf.FromContext(ctx)
 	log.Info("Finalizing GrafanaLibraryPanel")
@@ -261,50 +286,63 @@ func (r *GrafanaLibraryPanelReconciler) finalize(ctx context.Context, cr *v1beta
 	}
 
 	for _, grafana := range instances {
-		gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, &grafana)
-		if err != nil {
+		gClient, err := newOrgScopedClient(ctx, r.Client, &grafana, cr.Namespace, cr.Spec.OrgRef)
+		if err != nil && !isOrgRemoved(err) {
 			return err
 		}
 
-		isCleanupInGrafanaRequired := true
+		// Skip cleanup in instances, the library panels of removed organizations are gone with them
+		if err == nil {
+			err = r.removeFromInstance(ctx, gClient, &grafana, cr, uid)
+			if err != nil {
+				return err
+			}
+		}
 
-		resp, err := gClient.LibraryElements.GetLibraryElementByUID(uid)
+		_, err = ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, cr.Namespace, nil, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
 		if err != nil {
-			if IsNotErrorType[*library_elements.GetLibraryElementByUIDNotFound](err) {
-				return fmt.Errorf("fetching library panel from instance %s/%s: %w", grafana.Namespace, grafana.Name, err)
-			}
+			return fmt.Errorf("reconciling plugins: %w", err)
+		}
 
-			isCleanupInGrafanaRequired = false
+		// Update grafana instance Status
+		err = grafana.RemoveNamespacedResource(ctx, r.Client, cr)
+		if err != nil {
+			return fmt.Errorf("removing Folder from Grafana cr: %w", err)
 		}
+	}
 
-		// Skip cleanup in instances
-		if isCleanupInGrafanaRequired {
-			if resp.Payload.Result.Meta.ConnectedDashboards > 0 {
-				return fmt.Errorf("library panel %s/%s/%s on instance %s/%s has existing connections", cr.Namespace, cr.Name, uid, grafana.Namespace, grafana.Name)
-			}
+	return nil
+}
 
-			_, err = gClient.LibraryElements.DeleteLibraryElementByUID(uid) //nolint:errcheck
-			if err != nil {
-				if IsNotErrorType[*library_elements.DeleteLibraryElementByUIDNotFound](err) {
-					return err
-				}
-			}
+// removeFromInstance deletes the library panel and the folders created for it from the instance
+func (r *GrafanaLibraryPanelReconciler) removeFromInstance(ctx context.Context, gClient *genapi.GrafanaHTTPAPI, instance *v1beta1.Grafana, cr *v1beta1.GrafanaLibraryPanel, uid string) error {
+	isCleanupInGrafanaRequired := true
+
+	resp, err := gClient.LibraryElements.GetLibraryElementByUID(uid)
+	if err != nil {
+		if IsNotErrorType[*library_elements.GetLibraryElementByUIDNotFound](err) {
+			return fmt.Errorf("fetching library panel from instance %s/%s: %w", instance.Namespace, instance.Name, err)
 		}
 
-		if grafana.IsInternal() {
-			err = ReconcilePlugins(ctx, r.Client, r.Scheme, &grafana, nil, cr.GetPluginConfigMapKey(), cr.GetPluginConfigMapDeprecatedKey())
-			if err != nil {
-				return fmt.Errorf("reconciling plugins: %w", err)
-			}
+		isCleanupInGrafanaRequired = false
+	}
+
+	// Skip cleanup in instances
+	if isCleanupInGrafanaRequired {
+		if resp.Payload.Result.Meta.ConnectedDashboards > 0 {
+			return fmt.Errorf("library panel %s/%s/%s on instance %s/%s has existing connections", cr.Namespace, cr.Name, uid, instance.Namespace, instance.Name)
 		}
 
-		// Update grafana instance Status
-		err = grafana.RemoveNamespacedResource(ctx, r.Client, cr)
+		_, err = gClient.LibraryElements.DeleteLibraryElementByUID(uid) //nolint:errcheck
 		if err != nil {
-			return fmt.Errorf("removing Folder from Grafana cr: %w", err)
+			if IsNotErrorType[*library_elements.DeleteLibraryElementByUIDNotFound](err) {
+				return err
+			}
 		}
 	}
 
+	deleteManagedFolders(ctx, gClient, cr)
+
 	return nil
 }
 
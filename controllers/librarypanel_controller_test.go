@@ -1,16 +1,26 @@
 package controllers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
+	"sync"
+	"testing"
 
+	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
 	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	. "github.com/onsi/ginkgo/v2"
 )
@@ -191,3 +201,160 @@ var _ = Describe("LibraryPanel Reconciler", Ordered, func() {
 		require.NoError(t, err)
 	})
 })
+
+// fakeLibraryElementAPI serves the library element endpoints of Grafana from memory
+type fakeLibraryElementAPI struct {
+	mu       sync.Mutex
+	elements map[string]*models.LibraryElementDTO
+	updates  int
+}
+
+func newFakeLibraryElementAPI(t *testing.T) (*fakeLibraryElementAPI, string) {
+	t.Helper()
+
+	api := &fakeLibraryElementAPI{elements: map[string]*models.LibraryElementDTO{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/library-elements/{uid}", api.get)
+	mux.HandleFunc("POST /api/library-elements", api.create)
+	mux.HandleFunc("PATCH /api/library-elements/{uid}", api.update)
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return api, ts.URL
+}
+
+func (f *fakeLibraryElementAPI) get(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	element, ok := f.elements[r.PathValue("uid")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, models.LibraryElementResponse{Result: element})
+}
+
+func (f *fakeLibraryElementAPI) create(w http.ResponseWriter, r *http.Request) {
+	var cmd models.CreateLibraryElementCommand
+
+	err := json.NewDecoder(r.Body).Decode(&cmd)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	element := &models.LibraryElementDTO{UID: cmd.UID, Name: cmd.Name, FolderUID: cmd.FolderUID, Kind: cmd.Kind, Model: cmd.Model, Version: 1}
+
+	f.mu.Lock()
+	f.elements[cmd.UID] = element
+	f.mu.Unlock()
+
+	writeJSON(w, models.LibraryElementResponse{Result: element})
+}
+
+func (f *fakeLibraryElementAPI) update(w http.ResponseWriter, r *http.Request) {
+	var cmd models.PatchLibraryElementCommand
+
+	err := json.NewDecoder(r.Body).Decode(&cmd)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	element, ok := f.elements[r.PathValue("uid")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	element.Name = cmd.Name
+	element.FolderUID = cmd.FolderUID
+	element.Model = cmd.Model
+	element.Version++
+	f.updates++
+
+	writeJSON(w, models.LibraryElementResponse{Result: element})
+}
+
+func TestLibraryPanelTemplatedWithGrafanaRefs(t *testing.T) {
+	ctx := context.Background()
+	api, url := newFakeLibraryElementAPI(t)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	apiKey := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "grafana-api-key"},
+		Data:       map[string][]byte{"key": []byte("key")},
+	}
+
+	grafana := &v1beta1.Grafana{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "external", Labels: map[string]string{"dashboards": "external"}},
+		Spec: v1beta1.GrafanaSpec{
+			Version: "12.0.0",
+			External: &v1beta1.External{
+				URL: url,
+				APIKey: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: apiKey.Name},
+					Key:                  "key",
+				},
+			},
+		},
+		Status: v1beta1.GrafanaStatus{
+			Stage:       v1beta1.OperatorStageComplete,
+			StageStatus: v1beta1.OperatorStageResultSuccess,
+			AdminURL:    url,
+		},
+	}
+
+	cr := &v1beta1.GrafanaLibraryPanel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "templated"},
+		Spec: v1beta1.GrafanaLibraryPanelSpec{
+			GrafanaCommonSpec: v1beta1.GrafanaCommonSpec{
+				InstanceSelector: &metav1.LabelSelector{MatchLabels: grafana.Labels},
+			},
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				JSON: `{"uid": "templated", "name": "Version", "type": "text", "model": {"content": "{{ .Grafana.version }}"}}`,
+				Templating: &v1beta1.GrafanaContentTemplating{
+					GrafanaRefs: []v1beta1.GrafanaContentTemplatingRef{
+						{Name: "version", GrafanaRef: corev1.ObjectFieldSelector{FieldPath: "spec.version"}},
+					},
+				},
+			},
+		},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(apiKey, grafana, cr).
+		WithStatusSubresource(&v1beta1.Grafana{}, &v1beta1.GrafanaLibraryPanel{}).
+		Build()
+	r := &GrafanaLibraryPanelReconciler{Client: cl, Scheme: scheme}
+	req := tk8s.GetRequest(t, cr)
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Contains(t, api.elements, "templated")
+	assert.Equal(t, map[string]any{"content": "12.0.0"}, api.elements["templated"].Model.(map[string]any)["model"])
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Zero(t, api.updates, "unchanged library panels are not updated")
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(grafana), grafana))
+	grafana.Spec.Version = "12.1.0"
+	require.NoError(t, cl.Update(ctx, grafana))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, api.updates, "library panels are updated when the referenced instance changes")
+	assert.Equal(t, map[string]any{"content": "12.1.0"}, api.elements["templated"].Model.(map[string]any)["model"])
+}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 	"github.com/itchyny/gojq"
 
 	corev1 "k8s.io/api/core/v1"
@@ -169,14 +168,17 @@ func (r *GrafanaManifestReconciler) finalize(ctx context.Context, cr *v1beta1.Gr
 	}
 
 	for _, instance := range instances {
-		cl, err := grafanaclient.NewDynamicClient(ctx, r.Client, &instance)
-		if err != nil {
+		cl, err := newOrgScopedDynamicClient(ctx, r.Client, &instance, cr.Namespace, cr.Spec.OrgRef)
+		if err != nil && !isOrgRemoved(err) {
 			return fmt.Errorf("building grafana api client: %w", err)
 		}
 
-		err = cl.DeleteObj(ctx, cr.Spec.Template.ToUnstructured())
-		if err != nil {
-			return fmt.Errorf(" resource: %w", err)
+		// Skip cleanup in instances, the resources of removed organizations are gone with them
+		if err == nil {
+			err = cl.DeleteObj(ctx, cr.Spec.Template.ToUnstructured())
+			if err != nil {
+				return fmt.Errorf(" resource: %w", err)
+			}
 		}
 
 		if err := instance.RemoveNamespacedResource(ctx, r.Client, cr); err != nil {
@@ -190,7 +192,7 @@ func (r *GrafanaManifestReconciler) finalize(ctx context.Context, cr *v1beta1.Gr
 func (r *GrafanaManifestReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaManifest, patches []*gojq.Query, env []patchEnvResolver) error {
 	log := logf.FromContext(ctx)
 
-	cl, err := grafanaclient.NewDynamicClient(ctx, r.Client, instance)
+	cl, err := newOrgScopedDynamicClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return fmt.Errorf("building grafana api client: %w", err)
 	}
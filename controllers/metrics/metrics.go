@@ -54,12 +54,33 @@ var (
 		Help:      "requests to fetch model contents from urls",
 	}, []string{"kind", "resource", method, status})
 
+	ContentCacheDeduplicated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "content",
+		Name:      "cache_deduplications",
+		Help:      "model contents cached for a resource that were already cached for another resource",
+	})
+
 	GrafanaComAPIRevisionRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "revision_requests",
 		Help:      "requests to list content revisions on grafana.com",
 	}, []string{"kind", "resource", method, status})
 
+	DriftedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "drift",
+		Name:      "detected",
+		Help:      "resources whose state in grafana differs from the spec, only reported with the detect drift policy",
+	}, []string{instanceNamespace, instanceName, "kind", "resource"})
+
+	ServiceAccountTokenRotations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "serviceaccounts",
+		Name:      "token_rotations",
+		Help:      "service account tokens renewed by their rotation policy",
+	}, []string{instanceNamespace, instanceName, "resource", "token"})
+
 	InitialStatusSyncDuration = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Subsystem: subsystemReconciler,
@@ -75,5 +96,8 @@ func init() {
 	metrics.Registry.MustRegister(GrafanaComAPIRevisionRequests)
 	metrics.Registry.MustRegister(DashboardURLRequests)
 	metrics.Registry.MustRegister(ContentURLRequests)
+	metrics.Registry.MustRegister(ContentCacheDeduplicated)
+	metrics.Registry.MustRegister(DriftedResources)
+	metrics.Registry.MustRegister(ServiceAccountTokenRotations)
 	metrics.Registry.MustRegister(InitialStatusSyncDuration)
 }
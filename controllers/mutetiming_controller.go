@@ -32,7 +32,6 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 )
 
 const (
@@ -111,8 +110,10 @@ func (r *GrafanaMuteTimingReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	applyErrors := make(map[string]string)
 
+	drift := newDriftTracker(r.Cfg, "GrafanaMuteTiming", cr, cr.Spec.GrafanaCommonSpec)
+
 	for _, grafana := range instances {
-		err := r.reconcileWithInstance(ctx, &grafana, cr)
+		err := r.reconcileWithInstance(ctx, &grafana, cr, drift)
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 		}
@@ -120,6 +121,7 @@ func (r *GrafanaMuteTimingReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	condition := buildSynchronizedCondition("Mute timing", conditionMuteTimingSynchronized, cr.Generation, applyErrors, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	drift.finish(&cr.Status.Conditions, cr.Generation, len(instances))
 
 	if len(applyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
@@ -131,13 +133,13 @@ func (r *GrafanaMuteTimingReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
 }
 
-func (r *GrafanaMuteTimingReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaMuteTiming) error {
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+func (r *GrafanaMuteTimingReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaMuteTiming, drift *driftTracker) error {
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return fmt.Errorf("building grafana client: %w", err)
 	}
 
-	_, err = r.getMuteTimingByName(ctx, cr.Spec.Name, instance)
+	remote, err := r.getMuteTimingByName(ctx, cr, instance)
 
 	shouldCreate := false
 	if errors.Is(err, provisioning.NewGetMuteTimingNotFound()) {
@@ -170,6 +172,20 @@ func (r *GrafanaMuteTimingReconciler) reconcileWithInstance(ctx context.Context,
 		})
 	}
 
+	if !shouldCreate {
+		skip, err := drift.skipsUpdate(instance, func() ([]string, error) {
+			return diffFields(&payload, remote, "name", "provenance", "version")
+		})
+		if err != nil {
+			return err
+		}
+
+		if skip {
+			logf.FromContext(ctx).V(1).Info("mute timing exists, skipping update due to drift policy", "driftPolicy", drift.policy)
+			return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource())
+		}
+	}
+
 	if shouldCreate {
 		params := provisioning.NewPostMuteTimingParams().WithBody(&payload)
 		if cr.Spec.Editable {
@@ -196,13 +212,13 @@ func (r *GrafanaMuteTimingReconciler) reconcileWithInstance(ctx context.Context,
 	return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource())
 }
 
-func (r *GrafanaMuteTimingReconciler) getMuteTimingByName(ctx context.Context, name string, instance *v1beta1.Grafana) (*models.MuteTimeInterval, error) {
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+func (r *GrafanaMuteTimingReconciler) getMuteTimingByName(ctx context.Context, cr *v1beta1.GrafanaMuteTiming, instance *v1beta1.Grafana) (*models.MuteTimeInterval, error) {
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return nil, fmt.Errorf("building grafana client: %w", err)
 	}
 
-	remoteMuteTiming, err := gClient.Provisioning.GetMuteTiming(name)
+	remoteMuteTiming, err := gClient.Provisioning.GetMuteTiming(cr.Spec.Name)
 	if err != nil {
 		return nil, fmt.Errorf("getting mute timing: %w", err)
 	}
@@ -232,11 +248,17 @@ func (r *GrafanaMuteTimingReconciler) finalize(ctx context.Context, cr *v1beta1.
 		}
 	}
 
+	clearDriftMetrics("GrafanaMuteTiming", driftResource(cr))
+
 	return nil
 }
 
 func (r *GrafanaMuteTimingReconciler) removeFromInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaMuteTiming) error {
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
+	if isOrgRemoved(err) {
+		return nil
+	}
+
 	if err != nil {
 		return fmt.Errorf("building grafana client: %w", err)
 	}
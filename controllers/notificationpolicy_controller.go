@@ -38,7 +38,6 @@ import (
 
 	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 )
 
 var (
@@ -286,7 +285,7 @@ func assembleNotificationPolicyRoutes(ctx context.Context, cl client.Client, cr
 }
 
 func (r *GrafanaNotificationPolicyReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaNotificationPolicy) error {
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return fmt.Errorf("building grafana client: %w", err)
 	}
@@ -330,13 +329,16 @@ func (r *GrafanaNotificationPolicyReconciler) finalize(ctx context.Context, cr *
 			continue
 		}
 
-		gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, &grafana)
-		if err != nil {
+		gClient, err := newOrgScopedClient(ctx, r.Client, &grafana, cr.Namespace, cr.Spec.OrgRef)
+		if err != nil && !isOrgRemoved(err) {
 			return fmt.Errorf("building grafana client: %w", err)
 		}
 
-		if _, err := gClient.Provisioning.ResetPolicyTree(); err != nil { //nolint:errcheck
-			return fmt.Errorf("resetting policy tree")
+		// Skip cleanup in instances, the policy tree of removed organizations is gone with them
+		if err == nil {
+			if _, err := gClient.Provisioning.ResetPolicyTree(); err != nil { //nolint:errcheck
+				return fmt.Errorf("resetting policy tree")
+			}
 		}
 
 		err = removeAnnotation(ctx, r.Client, &grafana, annotationAppliedNotificationPolicy)
@@ -31,7 +31,6 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
-	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 )
 
 const (
@@ -110,8 +109,10 @@ func (r *GrafanaNotificationTemplateReconciler) Reconcile(ctx context.Context, r
 
 	applyErrors := make(map[string]string)
 
+	drift := newDriftTracker(r.Cfg, "GrafanaNotificationTemplate", cr, cr.Spec.GrafanaCommonSpec)
+
 	for _, grafana := range instances {
-		err := r.reconcileWithInstance(ctx, &grafana, cr)
+		err := r.reconcileWithInstance(ctx, &grafana, cr, drift)
 		if err != nil {
 			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
 		}
@@ -119,6 +120,7 @@ func (r *GrafanaNotificationTemplateReconciler) Reconcile(ctx context.Context, r
 
 	condition := buildSynchronizedCondition("Notification template", conditionNotificationTemplateSynchronized, cr.Generation, applyErrors, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	drift.finish(&cr.Status.Conditions, cr.Generation, len(instances))
 
 	if len(applyErrors) > 0 {
 		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
@@ -130,12 +132,38 @@ func (r *GrafanaNotificationTemplateReconciler) Reconcile(ctx context.Context, r
 	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
 }
 
-func (r *GrafanaNotificationTemplateReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaNotificationTemplate) error {
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+func (r *GrafanaNotificationTemplateReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaNotificationTemplate, drift *driftTracker) error {
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
 	if err != nil {
 		return fmt.Errorf("building grafana client: %w", err)
 	}
 
+	// Templates are created and updated through the same request, only check for existing templates when required by the drift policy
+	if !drift.enforced() {
+		remote, err := gClient.Provisioning.GetTemplate(cr.Spec.Name)
+		if err != nil && !IsErrorType[*provisioning.GetTemplateNotFound](err) {
+			return fmt.Errorf("fetching notification template: %w", err)
+		}
+
+		if err == nil {
+			skip, err := drift.skipsUpdate(instance, func() ([]string, error) {
+				if remote.Payload.Template != cr.Spec.Template {
+					return []string{"template"}, nil
+				}
+
+				return nil, nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if skip {
+				logf.FromContext(ctx).V(1).Info("notification template exists, skipping update due to drift policy", "driftPolicy", drift.policy)
+				return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource())
+			}
+		}
+	}
+
 	editable := true //nolint:staticcheck
 	if cr.Spec.Editable != nil && !*cr.Spec.Editable {
 		editable = false
@@ -181,11 +209,17 @@ func (r *GrafanaNotificationTemplateReconciler) finalize(ctx context.Context, cr
 		}
 	}
 
+	clearDriftMetrics("GrafanaNotificationTemplate", driftResource(cr))
+
 	return nil
 }
 
 func (r *GrafanaNotificationTemplateReconciler) removeFromInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaNotificationTemplate) error {
-	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
+	if isOrgRemoved(err) {
+		return nil
+	}
+
 	if err != nil {
 		return fmt.Errorf("building grafana client: %w", err)
 	}
@@ -0,0 +1,360 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/orgs"
+	"github.com/grafana/grafana-openapi-client-go/client/users"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+)
+
+const (
+	conditionOrganizationSynchronized = "OrganizationSynchronized"
+
+	// defaultOrgID is the organization every Grafana instance is created with, it cannot be deleted
+	defaultOrgID int64 = 1
+)
+
+// GrafanaOrganizationReconciler reconciles a GrafanaOrganization object
+type GrafanaOrganizationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Cfg    *Config
+}
+
+func (r *GrafanaOrganizationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx).WithName("GrafanaOrganizationReconciler")
+	ctx = logf.IntoContext(ctx, log)
+
+	cr := &v1beta1.GrafanaOrganization{}
+
+	err := r.Get(ctx, req.NamespacedName, cr)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, LogMsgGettingCR)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingCR, err)
+	}
+
+	if cr.GetDeletionTimestamp() != nil {
+		// Check if resource needs clean up
+		if controllerutil.ContainsFinalizer(cr, grafanaFinalizer) {
+			if err := r.finalize(ctx, cr); err != nil {
+				log.Error(err, LogMsgRunningFinalizer)
+				return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgRunningFinalizer, err)
+			}
+
+			if err := removeFinalizer(ctx, r.Client, cr); err != nil {
+				log.Error(err, LogMsgRemoveFinalizer)
+				return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgRemoveFinalizer, err)
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	defer UpdateStatus(ctx, r.Client, cr)
+
+	if cr.Spec.Suspend {
+		setSuspended(&cr.Status.Conditions, cr.Generation, conditionReasonApplySuspended)
+		return ctrl.Result{}, nil
+	}
+
+	removeSuspended(&cr.Status.Conditions)
+
+	instances, err := GetScopedMatchingInstances(ctx, r.Client, cr)
+	if err != nil {
+		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionOrganizationSynchronized)
+		cr.Status.OrgID = 0
+		log.Error(err, LogMsgGettingInstances)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
+	}
+
+	if len(instances) == 0 {
+		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionOrganizationSynchronized)
+		cr.Status.OrgID = 0
+		log.Error(ErrNoMatchingInstances, LogMsgNoMatchingInstances)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgNoMatchingInstances, ErrNoMatchingInstances)
+	}
+
+	removeNoMatchingInstance(&cr.Status.Conditions)
+	log.V(1).Info(DbgMsgFoundMatchingInstances, "count", len(instances))
+
+	applyErrors := make(map[string]string)
+	orgIDs := make(map[int64]struct{})
+
+	for _, grafana := range instances {
+		orgID, err := r.reconcileWithInstance(ctx, &grafana, cr)
+		if err != nil {
+			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
+			continue
+		}
+
+		orgIDs[orgID] = struct{}{}
+	}
+
+	// Organization IDs are allocated per instance, only surface the ID when it is unambiguous
+	cr.Status.OrgID = 0
+
+	if len(orgIDs) == 1 && len(applyErrors) == 0 {
+		for id := range orgIDs {
+			cr.Status.OrgID = id
+		}
+	}
+
+	condition := buildSynchronizedCondition("Organization", conditionOrganizationSynchronized, cr.Generation, applyErrors, len(instances))
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+
+	if len(applyErrors) > 0 {
+		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
+		log.Error(err, LogMsgApplyErrors)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgApplyErrors, err)
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
+}
+
+func (r *GrafanaOrganizationReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaOrganization) (int64, error) {
+	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	if err != nil {
+		return 0, fmt.Errorf("building grafana client: %w", err)
+	}
+
+	org, err := getOrgByName(gClient, cr.GetGrafanaName())
+	if err != nil {
+		return 0, err
+	}
+
+	var orgID int64
+
+	if org == nil {
+		resp, err := gClient.Orgs.CreateOrg(&models.CreateOrgCommand{
+			Name: cr.GetGrafanaName(),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("creating organization: %w", err)
+		}
+
+		if resp.Payload.OrgID == nil {
+			return 0, fmt.Errorf("creating organization: no id returned")
+		}
+
+		orgID = *resp.Payload.OrgID
+	} else {
+		orgID = org.ID
+	}
+
+	err = r.syncUsers(gClient, orgID, cr.Spec.Users)
+	if err != nil {
+		return 0, err
+	}
+
+	if cr.Spec.Preferences != nil {
+		orgClient, err := grafanaclient.NewGeneratedGrafanaClientForOrg(ctx, r.Client, instance, orgID)
+		if err != nil {
+			return 0, fmt.Errorf("building organization scoped grafana client: %w", err)
+		}
+
+		_, err = orgClient.Org.UpdateOrgPreferences(&models.UpdatePrefsCmd{ //nolint:errcheck
+			HomeDashboardUID: cr.Spec.Preferences.HomeDashboardUID,
+			Theme:            cr.Spec.Preferences.Theme,
+			Timezone:         cr.Spec.Preferences.Timezone,
+			WeekStart:        cr.Spec.Preferences.WeekStart,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("updating organization preferences: %w", err)
+		}
+	}
+
+	// Update grafana instance Status
+	err = instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(strconv.FormatInt(orgID, 10)))
+	if err != nil {
+		return 0, err
+	}
+
+	return orgID, nil
+}
+
+// syncUsers makes the users of the organization in Grafana match the desired users.
+// The user the operator authenticates as is never removed, it would lose access to the organization otherwise
+func (r *GrafanaOrganizationReconciler) syncUsers(gClient *genapi.GrafanaHTTPAPI, orgID int64, orgUsers []v1beta1.GrafanaOrganizationUser) error {
+	desired := make(map[int64]v1beta1.GrafanaOrganizationUser, len(orgUsers))
+
+	for _, user := range orgUsers {
+		resp, err := gClient.Users.GetUserByLoginOrEmail(user.Identifier())
+		if err != nil {
+			if IsErrorType[*users.GetUserByLoginOrEmailNotFound](err) {
+				return fmt.Errorf("user %q does not exist", user.Identifier())
+			}
+
+			return fmt.Errorf("getting user %q: %w", user.Identifier(), err)
+		}
+
+		desired[resp.Payload.ID] = user
+	}
+
+	self, err := gClient.SignedInUser.GetSignedInUser()
+	if err != nil {
+		return fmt.Errorf("getting signed in user: %w", err)
+	}
+
+	current, err := gClient.Orgs.GetOrgUsers(orgID)
+	if err != nil {
+		return fmt.Errorf("getting organization users: %w", err)
+	}
+
+	existing := make(map[int64]string, len(current.Payload))
+
+	for _, user := range current.Payload {
+		existing[user.UserID] = user.Role
+
+		if _, ok := desired[user.UserID]; ok || user.UserID == self.Payload.ID {
+			continue
+		}
+
+		_, err = gClient.Orgs.RemoveOrgUser(user.UserID, orgID) //nolint:errcheck
+		if err != nil {
+			return fmt.Errorf("removing organization user %q: %w", user.Login, err)
+		}
+	}
+
+	for userID, user := range desired {
+		role, ok := existing[userID]
+		if !ok {
+			_, err = gClient.Orgs.AddOrgUser(orgID, &models.AddOrgUserCommand{ //nolint:errcheck
+				LoginOrEmail: user.Identifier(),
+				Role:         user.Role,
+			})
+			if err != nil {
+				return fmt.Errorf("adding organization user %q: %w", user.Identifier(), err)
+			}
+
+			continue
+		}
+
+		if role == user.Role {
+			continue
+		}
+
+		params := orgs.NewUpdateOrgUserParams().
+			WithOrgID(orgID).
+			WithUserID(userID).
+			WithBody(&models.UpdateOrgUserCommand{Role: user.Role})
+
+		_, err = gClient.Orgs.UpdateOrgUser(params) //nolint:errcheck
+		if err != nil {
+			return fmt.Errorf("updating role of organization user %q: %w", user.Identifier(), err)
+		}
+	}
+
+	return nil
+}
+
+// getOrgByName returns the organization with an exact name match, or nil if it does not exist
+func getOrgByName(gClient *genapi.GrafanaHTTPAPI, name string) (*models.OrgDTO, error) {
+	resp, err := gClient.Orgs.SearchOrgs(orgs.NewSearchOrgsParams().WithName(&name))
+	if err != nil {
+		return nil, fmt.Errorf("searching organizations: %w", err)
+	}
+
+	for _, org := range resp.Payload {
+		if org != nil && org.Name == name {
+			return org, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *GrafanaOrganizationReconciler) finalize(ctx context.Context, cr *v1beta1.GrafanaOrganization) error {
+	log := logf.FromContext(ctx)
+	log.Info("Finalizing GrafanaOrganization")
+
+	instances, err := GetScopedMatchingInstances(ctx, r.Client, cr)
+	if err != nil {
+		log.Error(err, LogMsgGettingInstances)
+		return fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
+	}
+
+	for _, instance := range instances {
+		if err := r.removeFromInstance(ctx, &instance, cr); err != nil {
+			return fmt.Errorf("removing organization from instance: %w", err)
+		}
+
+		// Update grafana instance Status
+		err = instance.RemoveNamespacedResource(ctx, r.Client, cr)
+		if err != nil {
+			return fmt.Errorf("removing organization from Grafana cr: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *GrafanaOrganizationReconciler) removeFromInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaOrganization) error {
+	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	if err != nil {
+		return fmt.Errorf("building grafana client: %w", err)
+	}
+
+	org, err := getOrgByName(gClient, cr.GetGrafanaName())
+	if err != nil {
+		return err
+	}
+
+	if org == nil || org.ID == defaultOrgID {
+		return nil
+	}
+
+	_, err = gClient.Orgs.DeleteOrgByID(org.ID) //nolint:errcheck
+	if err != nil && IsNotErrorType[*orgs.DeleteOrgByIDNotFound](err) {
+		return fmt.Errorf("deleting organization: %w", err)
+	}
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GrafanaOrganizationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.GrafanaOrganization{}).
+		WithEventFilter(ignoreStatusUpdates()).
+		Complete(r)
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("Organization Reconciler: Provoke Conditions", func() {
+	tests := []struct {
+		name    string
+		meta    metav1.ObjectMeta
+		spec    v1beta1.GrafanaOrganizationSpec
+		want    metav1.Condition
+		wantErr string
+	}{
+		{
+			name: ".spec.suspend=true",
+			meta: objectMetaSuspended,
+			spec: v1beta1.GrafanaOrganizationSpec{
+				GrafanaCommonSpec: commonSpecSuspended,
+			},
+			want: metav1.Condition{
+				Type:   conditionSuspended,
+				Reason: conditionReasonApplySuspended,
+			},
+		},
+		{
+			name: "GetScopedMatchingInstances returns empty list",
+			meta: objectMetaNoMatchingInstances,
+			spec: v1beta1.GrafanaOrganizationSpec{
+				GrafanaCommonSpec: commonSpecNoMatchingInstances,
+			},
+			want: metav1.Condition{
+				Type:   conditionNoMatchingInstance,
+				Reason: conditionReasonEmptyAPIReply,
+			},
+			wantErr: ErrNoMatchingInstances.Error(),
+		},
+		{
+			name: "Failed to apply to instance",
+			meta: objectMetaApplyFailed,
+			spec: v1beta1.GrafanaOrganizationSpec{
+				GrafanaCommonSpec: commonSpecApplyFailed,
+			},
+			want: metav1.Condition{
+				Type:   conditionOrganizationSynchronized,
+				Reason: conditionReasonApplyFailed,
+			},
+			wantErr: LogMsgApplyErrors,
+		},
+		{
+			name: "Successfully applied resource to instance",
+			meta: objectMetaSynchronized,
+			spec: v1beta1.GrafanaOrganizationSpec{
+				GrafanaCommonSpec: commonSpecSynchronized,
+				Preferences: &v1beta1.GrafanaOrganizationPreferences{
+					Timezone: "utc",
+				},
+				Users: []v1beta1.GrafanaOrganizationUser{
+					{Login: "admin", Role: "Admin"},
+				},
+			},
+			want: metav1.Condition{
+				Type:   conditionOrganizationSynchronized,
+				Reason: conditionReasonApplySuccessful,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		It(tt.name, func() {
+			cr := &v1beta1.GrafanaOrganization{
+				ObjectMeta: tt.meta,
+				Spec:       tt.spec,
+			}
+
+			r := &GrafanaOrganizationReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			reconcileAndValidateCondition(r, cr, tt.want, tt.wantErr)
+		})
+	}
+})
+
+var _ = Describe("Organization Reconciler: Lifecycle", func() {
+	It("Creates the organization, records its id and removes it on deletion", func() {
+		t := GinkgoT()
+
+		cr := &v1beta1.GrafanaOrganization{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "organization-lifecycle",
+			},
+			Spec: v1beta1.GrafanaOrganizationSpec{
+				GrafanaCommonSpec: commonSpecSynchronized,
+			},
+		}
+
+		r := &GrafanaOrganizationReconciler{Client: cl, Scheme: cl.Scheme()}
+
+		err := cl.Create(testCtx, cr)
+		require.NoError(t, err)
+
+		req := tk8s.GetRequest(t, cr)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		err = r.Get(testCtx, req.NamespacedName, cr)
+		require.NoError(t, err)
+		require.Greater(t, cr.Status.OrgID, defaultOrgID)
+
+		gClient, err := grafanaclient.NewGeneratedGrafanaClient(testCtx, cl, externalGrafanaCr)
+		require.NoError(t, err)
+
+		org, err := getOrgByName(gClient, cr.GetGrafanaName())
+		require.NoError(t, err)
+		require.NotNil(t, org)
+		require.Equal(t, cr.Status.OrgID, org.ID)
+
+		err = cl.Delete(testCtx, cr)
+		require.NoError(t, err)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		org, err = getOrgByName(gClient, cr.GetGrafanaName())
+		require.NoError(t, err)
+		require.Nil(t, org)
+	})
+})
@@ -0,0 +1,394 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/access_control"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	aclResourceFolders    = "folders"
+	aclResourceDashboards = "dashboards"
+
+	aclKindUser        = "user"
+	aclKindTeam        = "team"
+	aclKindBuiltInRole = "builtInRole"
+)
+
+// permissionItem extends the Grafana ACL item with a reference to a GrafanaTeam CR
+type permissionItem struct {
+	models.DashboardACLUpdateItem `json:",inline"`
+
+	// Name of a GrafanaTeam in the same namespace, resolved to teamId per instance
+	TeamRef string `json:"teamRef,omitempty"`
+}
+
+type permissionList struct {
+	Items []*permissionItem `json:"items"`
+}
+
+// ParsePermissions unmarshals raw permissions json and resolves teamRef entries
+// to the team IDs recorded in the status of the Grafana instance
+func ParsePermissions(instance *v1beta1.Grafana, namespace, orgRef, raw string) (*models.UpdateDashboardACLCommand, error) {
+	list := permissionList{}
+
+	err := json.Unmarshal([]byte(raw), &list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal permissions: %w", err)
+	}
+
+	permissions := &models.UpdateDashboardACLCommand{}
+
+	for _, item := range list.Items {
+		if item == nil {
+			continue
+		}
+
+		if item.TeamRef != "" {
+			item.TeamID, err = getTeamID(instance, namespace, orgRef, item.TeamRef)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		permissions.Items = append(permissions.Items, &item.DashboardACLUpdateItem)
+	}
+
+	return permissions, nil
+}
+
+// getTeamID resolves teamRef to the team id recorded in the status of the Grafana instance.
+// The team has to belong to the organization referenced by orgRef
+func getTeamID(instance *v1beta1.Grafana, namespace, orgRef, teamRef string) (int64, error) {
+	orgID, err := getOrgID(instance, namespace, orgRef)
+	if err != nil {
+		return 0, err
+	}
+
+	found, identifier := instance.Status.Teams.Find(namespace, teamRef)
+	if !found {
+		return 0, fmt.Errorf("team %s/%s has not been synchronized with instance %s/%s yet", namespace, teamRef, instance.Namespace, instance.Name)
+	}
+
+	// Entries recorded before teams were keyed by organization lack the organization id
+	teamOrgID, teamID, ok := strings.Cut(*identifier, ":")
+	if !ok {
+		return 0, fmt.Errorf("team %s/%s has not been synchronized with instance %s/%s yet", namespace, teamRef, instance.Namespace, instance.Name)
+	}
+
+	if teamOrgID != strconv.FormatInt(orgID, 10) {
+		return 0, fmt.Errorf("team %s/%s belongs to a different organization of instance %s/%s", namespace, teamRef, instance.Namespace, instance.Name)
+	}
+
+	id, err := strconv.ParseInt(teamID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing id of team %s/%s: %w", namespace, teamRef, err)
+	}
+
+	return id, nil
+}
+
+// aclKey identifies the assignee of a permission in Grafana
+type aclKey struct {
+	kind string
+	id   int64
+	role string
+}
+
+func (k aclKey) String() string {
+	if k.kind == aclKindBuiltInRole {
+		return fmt.Sprintf("role %s", k.role)
+	}
+
+	return fmt.Sprintf("%s %d", k.kind, k.id)
+}
+
+// aclChange sets the permission of an assignee, an empty permission removes it
+type aclChange struct {
+	key        aclKey
+	permission string
+}
+
+// reconcileACL applies the differences between the typed permissions and the permissions of a folder or dashboard.
+// Permissions not listed are removed, inherited permissions are left untouched. Returns the resulting permissions
+func reconcileACL(ctx context.Context, cl client.Client, gClient *genapi.GrafanaHTTPAPI, instance *v1beta1.Grafana, namespace, orgRef, resource, uid string, acl []v1beta1.GrafanaPermission) ([]v1beta1.GrafanaEffectivePermission, error) {
+	desired, err := resolveACL(ctx, cl, gClient, instance, namespace, orgRef, acl)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := gClient.AccessControl.GetResourcePermissions(uid, resource)
+	if err != nil {
+		return nil, fmt.Errorf("fetching permissions of %s %s: %w", resource, uid, err)
+	}
+
+	changes := aclChanges(desired, managedACL(resp.Payload))
+	if len(changes) == 0 {
+		return effectiveACL(resp.Payload), nil
+	}
+
+	for _, change := range changes {
+		err = setACLEntry(gClient, resource, uid, change)
+		if err != nil {
+			return nil, fmt.Errorf("setting permission of %s on %s %s: %w", change.key, resource, uid, err)
+		}
+	}
+
+	resp, err = gClient.AccessControl.GetResourcePermissions(uid, resource)
+	if err != nil {
+		return nil, fmt.Errorf("fetching permissions of %s %s: %w", resource, uid, err)
+	}
+
+	return effectiveACL(resp.Payload), nil
+}
+
+// resolveACL translates the assignees of the typed permissions to their IDs on the instance
+func resolveACL(ctx context.Context, cl client.Client, gClient *genapi.GrafanaHTTPAPI, instance *v1beta1.Grafana, namespace, orgRef string, acl []v1beta1.GrafanaPermission) (map[aclKey]string, error) {
+	desired := make(map[aclKey]string, len(acl))
+
+	for _, item := range acl {
+		var key aclKey
+
+		switch {
+		case item.Role != "":
+			key = aclKey{kind: aclKindBuiltInRole, role: item.Role}
+		case item.Team != "":
+			team, err := getTeamByName(gClient, item.Team)
+			if err != nil {
+				return nil, err
+			}
+
+			if team == nil {
+				return nil, fmt.Errorf("team %q does not exist in instance %s/%s", item.Team, instance.Namespace, instance.Name)
+			}
+
+			key = aclKey{kind: aclKindTeam, id: *team.ID}
+		case item.TeamRef != "":
+			id, err := getTeamID(instance, namespace, orgRef, item.TeamRef)
+			if err != nil {
+				return nil, err
+			}
+
+			key = aclKey{kind: aclKindTeam, id: id}
+		case item.User != "":
+			user, err := getUserByLogin(gClient, item.User)
+			if err != nil {
+				return nil, err
+			}
+
+			if user == nil {
+				return nil, fmt.Errorf("user %q does not exist in instance %s/%s", item.User, instance.Namespace, instance.Name)
+			}
+
+			key = aclKey{kind: aclKindUser, id: user.ID}
+		case item.ServiceAccountRef != "":
+			id, err := getServiceAccountID(ctx, cl, instance, namespace, item.ServiceAccountRef)
+			if err != nil {
+				return nil, err
+			}
+
+			key = aclKey{kind: aclKindUser, id: id}
+		default:
+			return nil, fmt.Errorf("permission %q has no team, teamRef, user, role or serviceAccountRef", item.Permission)
+		}
+
+		if _, ok := desired[key]; ok {
+			return nil, fmt.Errorf("multiple permissions for %s", key)
+		}
+
+		desired[key] = item.Permission
+	}
+
+	return desired, nil
+}
+
+// getServiceAccountID returns the ID of a GrafanaServiceAccount, which is only known for the instances it targets
+func getServiceAccountID(ctx context.Context, cl client.Client, instance *v1beta1.Grafana, namespace, name string) (int64, error) {
+	sa := &v1beta1.GrafanaServiceAccount{}
+
+	err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, sa)
+	if err != nil {
+		return 0, fmt.Errorf("getting service account %s/%s: %w", namespace, name, err)
+	}
+
+	if sa.Namespace != instance.Namespace || (sa.Spec.InstanceName != "" && sa.Spec.InstanceName != instance.Name) {
+		return 0, fmt.Errorf("service account %s/%s does not target instance %s/%s", namespace, name, instance.Namespace, instance.Name)
+	}
+
+	account := sa.InstanceAccount(instance.Name)
+	if account == nil || account.ID == 0 {
+		return 0, fmt.Errorf("service account %s/%s has not been synchronized with instance %s/%s yet", namespace, name, instance.Namespace, instance.Name)
+	}
+
+	return account.ID, nil
+}
+
+// managedACL returns the permissions that can be changed through the API, skipping inherited ones
+func managedACL(payload []*models.ResourcePermissionDTO) map[aclKey]string {
+	current := make(map[aclKey]string, len(payload))
+
+	for _, p := range payload {
+		if p == nil || p.IsInherited || !p.IsManaged {
+			continue
+		}
+
+		switch {
+		case p.UserID != 0:
+			current[aclKey{kind: aclKindUser, id: p.UserID}] = p.Permission
+		case p.TeamID != 0:
+			current[aclKey{kind: aclKindTeam, id: p.TeamID}] = p.Permission
+		case p.BuiltInRole != "":
+			current[aclKey{kind: aclKindBuiltInRole, role: p.BuiltInRole}] = p.Permission
+		}
+	}
+
+	return current
+}
+
+// aclChanges returns the changes turning current into desired, sorted for a stable order of requests
+func aclChanges(desired, current map[aclKey]string) []aclChange {
+	changes := make([]aclChange, 0)
+
+	for key, permission := range desired {
+		if current[key] != permission {
+			changes = append(changes, aclChange{key: key, permission: permission})
+		}
+	}
+
+	for key := range current {
+		if _, ok := desired[key]; !ok {
+			changes = append(changes, aclChange{key: key})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b aclChange) int {
+		return cmp.Or(cmp.Compare(a.key.kind, b.key.kind), cmp.Compare(a.key.id, b.key.id), cmp.Compare(a.key.role, b.key.role))
+	})
+
+	return changes
+}
+
+func setACLEntry(gClient *genapi.GrafanaHTTPAPI, resource, uid string, change aclChange) error {
+	body := &models.SetPermissionCommand{Permission: change.permission}
+
+	var err error
+
+	switch change.key.kind {
+	case aclKindUser:
+		params := access_control.NewSetResourcePermissionsForUserParams().
+			WithResource(resource).
+			WithResourceID(uid).
+			WithUserID(change.key.id).
+			WithBody(body)
+		_, err = gClient.AccessControl.SetResourcePermissionsForUser(params) //nolint:errcheck
+	case aclKindTeam:
+		params := access_control.NewSetResourcePermissionsForTeamParams().
+			WithResource(resource).
+			WithResourceID(uid).
+			WithTeamID(change.key.id).
+			WithBody(body)
+		_, err = gClient.AccessControl.SetResourcePermissionsForTeam(params) //nolint:errcheck
+	case aclKindBuiltInRole:
+		params := access_control.NewSetResourcePermissionsForBuiltInRoleParams().
+			WithResource(resource).
+			WithResourceID(uid).
+			WithBuiltInRole(change.key.role).
+			WithBody(body)
+		_, err = gClient.AccessControl.SetResourcePermissionsForBuiltInRole(params) //nolint:errcheck
+	}
+
+	return err
+}
+
+// effectiveACL converts the permissions reported by Grafana for the status, sorted to avoid needless status updates
+func effectiveACL(payload []*models.ResourcePermissionDTO) []v1beta1.GrafanaEffectivePermission {
+	items := make([]v1beta1.GrafanaEffectivePermission, 0, len(payload))
+
+	for _, p := range payload {
+		if p == nil {
+			continue
+		}
+
+		item := v1beta1.GrafanaEffectivePermission{
+			Permission: p.Permission,
+			Inherited:  p.IsInherited,
+		}
+
+		switch {
+		case p.UserID != 0 && p.IsServiceAccount:
+			item.ServiceAccount = p.UserLogin
+		case p.UserID != 0:
+			item.User = p.UserLogin
+		case p.TeamID != 0:
+			item.Team = p.Team
+		case p.BuiltInRole != "":
+			item.Role = p.BuiltInRole
+		default:
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	// roles first, followed by teams, users and service accounts
+	rank := func(p v1beta1.GrafanaEffectivePermission) int {
+		switch {
+		case p.Role != "":
+			return 0
+		case p.Team != "":
+			return 1
+		case p.User != "":
+			return 2
+		default:
+			return 3
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b v1beta1.GrafanaEffectivePermission) int {
+		return cmp.Or(
+			cmp.Compare(rank(a), rank(b)),
+			cmp.Compare(a.Role+a.Team+a.User+a.ServiceAccount, b.Role+b.Team+b.User+b.ServiceAccount),
+		)
+	})
+
+	return items
+}
+
+// setEffectiveACL records the permissions reported by an instance, keeping the list sorted by instance
+func setEffectiveACL(list *[]v1beta1.GrafanaEffectiveACL, instance *v1beta1.Grafana, items []v1beta1.GrafanaEffectivePermission) {
+	key := fmt.Sprintf("%s/%s", instance.Namespace, instance.Name)
+
+	idx := slices.IndexFunc(*list, func(acl v1beta1.GrafanaEffectiveACL) bool {
+		return acl.Instance == key
+	})
+	if idx >= 0 {
+		(*list)[idx].Items = items
+		return
+	}
+
+	*list = append(*list, v1beta1.GrafanaEffectiveACL{Instance: key, Items: items})
+
+	slices.SortFunc(*list, func(a, b v1beta1.GrafanaEffectiveACL) int {
+		return cmp.Compare(a.Instance, b.Instance)
+	})
+}
+
+// pruneEffectiveACL removes the permissions of instances no longer matching the resource
+func pruneEffectiveACL(list *[]v1beta1.GrafanaEffectiveACL, instances []v1beta1.Grafana) {
+	*list = slices.DeleteFunc(*list, func(acl v1beta1.GrafanaEffectiveACL) bool {
+		return !slices.ContainsFunc(instances, func(instance v1beta1.Grafana) bool {
+			return acl.Instance == fmt.Sprintf("%s/%s", instance.Namespace, instance.Name)
+		})
+	})
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParsePermissions(t *testing.T) {
+	instance := &v1beta1.Grafana{
+		Status: v1beta1.GrafanaStatus{
+			Organizations: v1beta1.NamespacedResourceList{"default/sales/2"},
+			Teams: v1beta1.NamespacedResourceList{
+				"default/platform/0:7",
+				"default/sales-team/2:8",
+				"other/platform/0:9",
+				"other/legacy/5",
+			},
+		},
+	}
+
+	t.Run("raw permissions are passed through", func(t *testing.T) {
+		got, err := ParsePermissions(instance, "default", "", `{"items":[{"role":"Viewer","permission":1},{"teamId":3,"permission":2}]}`)
+		require.NoError(t, err)
+		require.Len(t, got.Items, 2)
+
+		assert.Equal(t, "Viewer", got.Items[0].Role)
+		assert.Equal(t, int64(3), got.Items[1].TeamID)
+	})
+
+	t.Run("teamRef resolves to the team id of the same namespace", func(t *testing.T) {
+		got, err := ParsePermissions(instance, "default", "", `{"items":[{"teamRef":"platform","permission":4}]}`)
+		require.NoError(t, err)
+		require.Len(t, got.Items, 1)
+
+		assert.Equal(t, int64(7), got.Items[0].TeamID)
+		assert.EqualValues(t, 4, got.Items[0].Permission)
+	})
+
+	t.Run("unsynchronized teamRef returns an error", func(t *testing.T) {
+		_, err := ParsePermissions(instance, "default", "", `{"items":[{"teamRef":"missing","permission":1}]}`)
+		require.Error(t, err)
+
+		_, err = ParsePermissions(instance, "other", "", `{"items":[{"teamRef":"legacy","permission":1}]}`)
+		require.ErrorContains(t, err, "has not been synchronized")
+	})
+
+	t.Run("teamRef resolves within the organization of the resource", func(t *testing.T) {
+		got, err := ParsePermissions(instance, "default", "sales", `{"items":[{"teamRef":"sales-team","permission":1}]}`)
+		require.NoError(t, err)
+		require.Len(t, got.Items, 1)
+		assert.Equal(t, int64(8), got.Items[0].TeamID)
+
+		_, err = ParsePermissions(instance, "default", "sales", `{"items":[{"teamRef":"platform","permission":1}]}`)
+		require.ErrorContains(t, err, "belongs to a different organization")
+
+		_, err = ParsePermissions(instance, "default", "", `{"items":[{"teamRef":"sales-team","permission":1}]}`)
+		require.ErrorContains(t, err, "belongs to a different organization")
+	})
+
+	t.Run("invalid json returns an error", func(t *testing.T) {
+		_, err := ParsePermissions(instance, "default", "", `{"items":`)
+		require.Error(t, err)
+	})
+
+	t.Run("empty items resets permissions", func(t *testing.T) {
+		got, err := ParsePermissions(instance, "default", "", `{"items":[]}`)
+		require.NoError(t, err)
+		assert.Empty(t, got.Items)
+	})
+}
+
+func TestResolveACL(t *testing.T) {
+	instance := &v1beta1.Grafana{
+		Status: v1beta1.GrafanaStatus{
+			Teams: v1beta1.NamespacedResourceList{"default/platform/0:7"},
+		},
+	}
+
+	t.Run("roles and teamRefs resolve without requests", func(t *testing.T) {
+		got, err := resolveACL(t.Context(), nil, nil, instance, "default", "", []v1beta1.GrafanaPermission{
+			{Role: "Viewer", Permission: "View"},
+			{TeamRef: "platform", Permission: "Admin"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[aclKey]string{
+			{kind: aclKindBuiltInRole, role: "Viewer"}: "View",
+			{kind: aclKindTeam, id: 7}:                 "Admin",
+		}, got)
+	})
+
+	t.Run("duplicate assignees return an error", func(t *testing.T) {
+		_, err := resolveACL(t.Context(), nil, nil, instance, "default", "", []v1beta1.GrafanaPermission{
+			{Role: "Viewer", Permission: "View"},
+			{Role: "Viewer", Permission: "Edit"},
+		})
+		require.ErrorContains(t, err, "multiple permissions for role Viewer")
+	})
+
+	t.Run("unsynchronized teamRef returns an error", func(t *testing.T) {
+		_, err := resolveACL(t.Context(), nil, nil, instance, "other", "", []v1beta1.GrafanaPermission{
+			{TeamRef: "platform", Permission: "View"},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestACLChanges(t *testing.T) {
+	viewer := aclKey{kind: aclKindBuiltInRole, role: "Viewer"}
+	editor := aclKey{kind: aclKindBuiltInRole, role: "Editor"}
+	team := aclKey{kind: aclKindTeam, id: 3}
+	user := aclKey{kind: aclKindUser, id: 1}
+
+	current := managedACL([]*models.ResourcePermissionDTO{
+		{BuiltInRole: "Viewer", Permission: "View", IsManaged: true},
+		{BuiltInRole: "Editor", Permission: "Edit", IsManaged: true},
+		{UserID: 1, UserLogin: "admin", Permission: "Admin", IsManaged: true},
+		{TeamID: 3, Team: "platform", Permission: "View", IsManaged: true, IsInherited: true},
+		{BuiltInRole: "Admin", Permission: "Admin"},
+	})
+	require.Equal(t, map[aclKey]string{viewer: "View", editor: "Edit", user: "Admin"}, current)
+
+	t.Run("unchanged permissions need no requests", func(t *testing.T) {
+		assert.Empty(t, aclChanges(map[aclKey]string{viewer: "View", editor: "Edit", user: "Admin"}, current))
+	})
+
+	t.Run("only differences are applied", func(t *testing.T) {
+		got := aclChanges(map[aclKey]string{viewer: "Edit", team: "Admin", user: "Admin"}, current)
+
+		assert.Equal(t, []aclChange{
+			{key: editor},
+			{key: viewer, permission: "Edit"},
+			{key: team, permission: "Admin"},
+		}, got)
+	})
+}
+
+func TestEffectiveACL(t *testing.T) {
+	got := effectiveACL([]*models.ResourcePermissionDTO{
+		{UserID: 2, UserLogin: "sa-ci", IsServiceAccount: true, Permission: "View"},
+		{UserID: 1, UserLogin: "admin", Permission: "Admin"},
+		{TeamID: 3, Team: "platform", Permission: "Edit", IsInherited: true},
+		{BuiltInRole: "Viewer", Permission: "View"},
+		{RoleName: "fixed:folders:reader", Permission: "View"},
+	})
+
+	assert.Equal(t, []v1beta1.GrafanaEffectivePermission{
+		{Role: "Viewer", Permission: "View"},
+		{Team: "platform", Permission: "Edit", Inherited: true},
+		{User: "admin", Permission: "Admin"},
+		{ServiceAccount: "sa-ci", Permission: "View"},
+	}, got)
+}
+
+func TestSetEffectiveACL(t *testing.T) {
+	instance := func(name string) v1beta1.Grafana {
+		return v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+
+	a, b := instance("a"), instance("b")
+	items := []v1beta1.GrafanaEffectivePermission{{Role: "Viewer", Permission: "View"}}
+
+	var list []v1beta1.GrafanaEffectiveACL
+
+	setEffectiveACL(&list, &b, nil)
+	setEffectiveACL(&list, &a, nil)
+	setEffectiveACL(&list, &b, items)
+
+	assert.Equal(t, []v1beta1.GrafanaEffectiveACL{
+		{Instance: "default/a"},
+		{Instance: "default/b", Items: items},
+	}, list)
+
+	pruneEffectiveACL(&list, []v1beta1.Grafana{b})
+
+	assert.Equal(t, []v1beta1.GrafanaEffectiveACL{{Instance: "default/b", Items: items}}, list)
+}
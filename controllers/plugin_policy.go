@@ -0,0 +1,340 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/controllers/resources"
+	"github.com/grafana/grafana-operator/v5/pkg/oci"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	conditionPluginsRejected = "PluginsRejected"
+
+	conditionReasonPluginPolicy = "PluginPolicy"
+
+	// pluginCatalogCacheTTL is how long signatures looked up in the plugin catalog are reused
+	pluginCatalogCacheTTL = time.Hour
+)
+
+// pluginCatalogURL is the plugin catalog of grafana.com, signatures of plugins are looked up there before installation
+var pluginCatalogURL = "https://grafana.com/api/plugins"
+
+var pluginSignatures = &pluginSignatureCache{entries: map[string]pluginSignatureEntry{}}
+
+// checkPluginPolicy returns the plugins to store in the plugins ConfigMap of the instance and the reasons of the
+// rejected ones, e.g. "grafana-clock-panel 2.1.8: not allowed". While unsigned plugins are rejected, the signature of
+// plugins is looked up in the plugin catalog before they are stored. Plugins the instance reported as unsigned are
+// stored regardless, the instance never installs them but keeps them rejected while requested
+func checkPluginPolicy(ctx context.Context, grafana *v1beta1.Grafana, plugins v1beta1.PluginList) (v1beta1.PluginList, []string, error) {
+	policy := grafana.Spec.PluginPolicy
+	if policy == nil {
+		return plugins, nil, nil
+	}
+
+	stored := make(v1beta1.PluginList, 0, len(plugins))
+	rejected := []string{}
+
+	for _, plugin := range plugins {
+		reason := pluginRejection(policy, plugin)
+		if reason == "" && policy.RejectUnsigned {
+			var err error
+
+			reason, err = pluginSignatureRejection(ctx, plugin)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if reason != "" {
+			rejected = append(rejected, fmt.Sprintf("%s: %s", plugin.String(), reason))
+			continue
+		}
+
+		if policy.RejectUnsigned && slices.ContainsFunc(grafana.Status.UnsignedPlugins, func(p v1beta1.GrafanaPlugin) bool {
+			return p.Name == plugin.Name && p.Version == plugin.Version
+		}) {
+			rejected = append(rejected, fmt.Sprintf("%s: unsigned", plugin.String()))
+		}
+
+		stored = append(stored, plugin)
+	}
+
+	return stored, rejected, nil
+}
+
+// pluginSignatureRejection returns why the plugin is rejected as unsigned before its installation, empty if the
+// plugin catalog lists a signature for the requested version. The signature of plugins with a source is only known
+// once installed, these are rejected
+func pluginSignatureRejection(ctx context.Context, plugin v1beta1.GrafanaPlugin) (string, error) {
+	if plugin.Source != nil {
+		return "the signature of plugins with a source cannot be checked before installation", nil
+	}
+
+	signatureType, found, err := pluginSignatures.lookup(ctx, plugin.Name, plugin.Version)
+	if err != nil {
+		return "", fmt.Errorf("looking up the signature of plugin %s: %w", plugin.String(), err)
+	}
+
+	switch {
+	case !found:
+		return "not found in the plugin catalog", nil
+	case signatureType == "":
+		return "unsigned", nil
+	}
+
+	return "", nil
+}
+
+type pluginSignatureEntry struct {
+	signatureType string
+	found         bool
+	expires       time.Time
+}
+
+// pluginSignatureCache caches the signature types of plugin versions in the plugin catalog
+type pluginSignatureCache struct {
+	mu      sync.Mutex
+	entries map[string]pluginSignatureEntry
+}
+
+// lookup returns the signature type of a plugin version in the plugin catalog, empty if it is unsigned
+func (c *pluginSignatureCache) lookup(ctx context.Context, name, version string) (string, bool, error) {
+	key := fmt.Sprintf("%s %s", name, version)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.signatureType, entry.found, nil
+	}
+
+	httpClient := &http.Client{
+		Transport: grafanaclient.NewInstrumentedRoundTripper(true, grafanaclient.DefaultTLSConfiguration),
+		Timeout:   10 * time.Second,
+	}
+
+	pluginVersion := struct {
+		Version       string `json:"version"`
+		SignatureType string `json:"signatureType"`
+	}{Version: version}
+
+	// the plugin lists its latest version
+	if version == v1beta1.PluginVersionLatest {
+		found, err := getPluginCatalog(ctx, httpClient, url.PathEscape(name), &pluginVersion)
+		if err != nil || !found {
+			return "", found, err
+		}
+	}
+
+	found, err := getPluginCatalog(ctx, httpClient, fmt.Sprintf("%s/versions/%s", url.PathEscape(name), url.PathEscape(pluginVersion.Version)), &pluginVersion)
+	if err != nil {
+		return "", false, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = pluginSignatureEntry{signatureType: pluginVersion.SignatureType, found: found, expires: time.Now().Add(pluginCatalogCacheTTL)}
+	c.mu.Unlock()
+
+	return pluginVersion.SignatureType, found, nil
+}
+
+// getPluginCatalog decodes a response of the plugin catalog into v, found is false if the catalog does not list it
+func getPluginCatalog(ctx context.Context, httpClient *http.Client, path string, v any) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s", pluginCatalogURL, path), http.NoBody)
+	if err != nil {
+		return false, err
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code %d from the plugin catalog", response.StatusCode)
+	}
+
+	return true, json.NewDecoder(response.Body).Decode(v)
+}
+
+// applyPluginPolicy removes the plugins rejected by the plugin policy of the instance from its plugins ConfigMap, so
+// that plugins stored before the policy was added or tightened are not installed. The requesting resources report
+// them as rejected once reconciled again
+func applyPluginPolicy(ctx context.Context, cl client.Client, grafana *v1beta1.Grafana) error {
+	log := logf.FromContext(ctx)
+
+	if grafana.Spec.PluginPolicy == nil {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{}
+
+	err := cl.Get(ctx, client.ObjectKeyFromObject(resources.GetPluginsConfigMap(grafana, cl.Scheme())), cm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	isUpdated := false
+
+	for _, key := range slices.Sorted(maps.Keys(cm.BinaryData)) {
+		var plugins v1beta1.PluginList
+
+		err = json.Unmarshal(cm.BinaryData[key], &plugins)
+		if err != nil {
+			return fmt.Errorf("parsing plugins of %s: %w", key, err)
+		}
+
+		stored, rejected, err := checkPluginPolicy(ctx, grafana, plugins)
+		if err != nil {
+			return err
+		}
+
+		if len(stored) == len(plugins) {
+			continue
+		}
+
+		log.Info("removing plugins rejected by the plugin policy", "key", key, "rejected", rejected)
+
+		val := []byte{}
+
+		if len(stored) > 0 {
+			val, err = json.Marshal(stored)
+			if err != nil {
+				return err
+			}
+		}
+
+		isUpdated = updatePluginConfigMap(cm, val, key, "") || isUpdated
+	}
+
+	if isUpdated {
+		return cl.Update(ctx, cm)
+	}
+
+	return nil
+}
+
+// rejectForeignPluginSources rejects plugins with a source referencing Secrets, ConfigMaps or PersistentVolumeClaims
+// requested by resources outside the namespace of the instance, these would be resolved in the namespace of the instance
+func rejectForeignPluginSources(grafana *v1beta1.Grafana, namespace string, plugins v1beta1.PluginList) (v1beta1.PluginList, []string) {
+	if namespace == grafana.Namespace {
+		return plugins, nil
+	}
+
+	accepted := make(v1beta1.PluginList, 0, len(plugins))
+	rejected := []string{}
+
+	for _, plugin := range plugins {
+		if plugin.Source != nil && plugin.Source.ReferencesObjects() {
+			rejected = append(rejected, fmt.Sprintf("%s: sources referencing objects are only accepted from resources in namespace %s", plugin.String(), grafana.Namespace))
+			continue
+		}
+
+		accepted = append(accepted, plugin)
+	}
+
+	return accepted, rejected
+}
+
+// pluginRejection returns why the allow list of the policy rejects the plugin, empty if it is allowed
+func pluginRejection(policy *v1beta1.GrafanaPluginPolicy, plugin v1beta1.GrafanaPlugin) string {
+	if len(policy.Allowed) == 0 {
+		return ""
+	}
+
+	idx := slices.IndexFunc(policy.Allowed, func(rule v1beta1.GrafanaPluginPolicyRule) bool {
+		return rule.Name == plugin.Name
+	})
+	if idx == -1 {
+		return "not allowed"
+	}
+
+	rule := policy.Allowed[idx]
+	if rule.Versions == "" {
+		return ""
+	}
+
+	if plugin.Version == v1beta1.PluginVersionLatest {
+		return fmt.Sprintf("a version matching %q is required", rule.Versions)
+	}
+
+	inRange, err := oci.ParseSemverConstraint(rule.Versions)
+	if err != nil {
+		return fmt.Sprintf("plugin policy: %s", err.Error())
+	}
+
+	// plugin versions are not always full semver, e.g. "1.2"
+	version, err := semver.ParseTolerant(plugin.Version)
+	if err != nil {
+		return fmt.Sprintf("version is not a semantic version, required by %q", rule.Versions)
+	}
+
+	if !inRange(version) {
+		return fmt.Sprintf("version does not match %q", rule.Versions)
+	}
+
+	return ""
+}
+
+// pluginRejections collects the plugins rejected by the plugin policies of the matching instances
+type pluginRejections map[string][]string
+
+func (p pluginRejections) add(grafana *v1beta1.Grafana, rejected []string) {
+	if len(rejected) == 0 {
+		return
+	}
+
+	p[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = rejected
+}
+
+// finish updates the PluginsRejected condition, which is only present while plugins are rejected
+func (p pluginRejections) finish(conditions *[]metav1.Condition, generation int64) {
+	if len(p) == 0 {
+		meta.RemoveStatusCondition(conditions, conditionPluginsRejected)
+		return
+	}
+
+	var sb strings.Builder
+	for _, instance := range slices.Sorted(maps.Keys(p)) {
+		fmt.Fprintf(&sb, "\n- %s: %s", instance, strings.Join(p[instance], ", "))
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionPluginsRejected,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             conditionReasonPluginPolicy,
+		Message:            fmt.Sprintf("Plugins rejected by the plugin policy of %d instances:%s", len(p), sb.String()),
+		LastTransitionTime: metav1.Time{
+			Time: time.Now(),
+		},
+	})
+}
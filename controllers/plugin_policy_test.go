@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newFakePluginCatalog serves the plugin catalog of grafana.com with the signature types of plugin versions, the
+// latest version of a plugin is its last listed one
+func newFakePluginCatalog(t *testing.T, versions map[string][][2]string) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{name}", func(w http.ResponseWriter, r *http.Request) {
+		list, ok := versions[r.PathValue("name")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		writeJSON(w, map[string]string{"slug": r.PathValue("name"), "version": list[len(list)-1][0]})
+	})
+	mux.HandleFunc("GET /{name}/versions/{version}", func(w http.ResponseWriter, r *http.Request) {
+		for _, v := range versions[r.PathValue("name")] {
+			if v[0] == r.PathValue("version") {
+				writeJSON(w, map[string]string{"version": v[0], "signatureType": v[1]})
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ts := httptest.NewServer(mux)
+
+	catalogURL := pluginCatalogURL
+	pluginCatalogURL = ts.URL
+	pluginSignatures = &pluginSignatureCache{entries: map[string]pluginSignatureEntry{}}
+
+	t.Cleanup(func() {
+		ts.Close()
+
+		pluginCatalogURL = catalogURL
+	})
+}
+
+func TestCheckPluginPolicy(t *testing.T) {
+	newFakePluginCatalog(t, map[string][][2]string{
+		"grafana-clock-panel":    {{"2.1.8", "grafana"}},
+		"grafana-piechart-panel": {{"1.6.2", "grafana"}, {"1.6.4", "community"}},
+		"internal-panel":         {{"0.9.0", "private"}, {"1.0.0", ""}},
+	})
+
+	plugins := v1beta1.PluginList{
+		{Name: "grafana-clock-panel", Version: "2.1.8"},
+		{Name: "grafana-piechart-panel", Version: "latest"},
+		{Name: "internal-panel", Version: "1.0.0"},
+	}
+
+	tests := []struct {
+		name         string
+		policy       *v1beta1.GrafanaPluginPolicy
+		plugins      v1beta1.PluginList
+		unsigned     v1beta1.PluginList
+		wantStored   v1beta1.PluginList
+		wantRejected []string
+	}{
+		{
+			name:       "no policy",
+			wantStored: plugins,
+		},
+		{
+			name:       "empty allow list accepts all plugins",
+			policy:     &v1beta1.GrafanaPluginPolicy{},
+			wantStored: plugins,
+		},
+		{
+			name: "allow list and version ranges",
+			policy: &v1beta1.GrafanaPluginPolicy{
+				Allowed: []v1beta1.GrafanaPluginPolicyRule{
+					{Name: "grafana-clock-panel", Versions: ">=2.0.0 <3.0.0"},
+					{Name: "grafana-piechart-panel", Versions: "^1.6.0"},
+				},
+			},
+			wantStored: v1beta1.PluginList{{Name: "grafana-clock-panel", Version: "2.1.8"}},
+			wantRejected: []string{
+				`grafana-piechart-panel: a version matching "^1.6.0" is required`,
+				"internal-panel 1.0.0: not allowed",
+			},
+		},
+		{
+			name: "version out of range",
+			policy: &v1beta1.GrafanaPluginPolicy{
+				Allowed: []v1beta1.GrafanaPluginPolicyRule{
+					{Name: "grafana-clock-panel", Versions: "<2.0.0"},
+					{Name: "grafana-piechart-panel"},
+					{Name: "internal-panel"},
+				},
+			},
+			wantStored: plugins[1:],
+			wantRejected: []string{
+				`grafana-clock-panel 2.1.8: version does not match "<2.0.0"`,
+			},
+		},
+		{
+			name: "versions which are not full semver",
+			policy: &v1beta1.GrafanaPluginPolicy{
+				Allowed: []v1beta1.GrafanaPluginPolicyRule{
+					{Name: "grafana-clock-panel", Versions: ">=1.2.0 <2.0.0"},
+					{Name: "internal-panel", Versions: ">=1.0.0"},
+				},
+			},
+			plugins: v1beta1.PluginList{
+				{Name: "grafana-clock-panel", Version: "1.2"},
+				{Name: "internal-panel", Version: "nightly"},
+			},
+			wantStored: v1beta1.PluginList{{Name: "grafana-clock-panel", Version: "1.2"}},
+			wantRejected: []string{
+				`internal-panel nightly: version is not a semantic version, required by ">=1.0.0"`,
+			},
+		},
+		{
+			name:   "unsigned plugins in the catalog",
+			policy: &v1beta1.GrafanaPluginPolicy{RejectUnsigned: true},
+			plugins: v1beta1.PluginList{
+				{Name: "grafana-clock-panel", Version: "2.1.8"},
+				{Name: "grafana-piechart-panel", Version: "latest"},
+				{Name: "internal-panel", Version: "1.0.0"},
+				{Name: "internal-app", Version: "1.0.0"},
+				{Name: "private-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{URL: "https://plugins.local/private-panel.zip"}},
+			},
+			wantStored: plugins[:2],
+			wantRejected: []string{
+				"internal-panel 1.0.0: unsigned",
+				"internal-app 1.0.0: not found in the plugin catalog",
+				"private-panel 1.0.0: the signature of plugins with a source cannot be checked before installation",
+			},
+		},
+		{
+			name:   "plugins reported as unsigned by the instance",
+			policy: &v1beta1.GrafanaPluginPolicy{RejectUnsigned: true},
+			plugins: v1beta1.PluginList{
+				{Name: "grafana-clock-panel", Version: "2.1.8"},
+				{Name: "internal-panel", Version: "0.9.0"},
+			},
+			unsigned: v1beta1.PluginList{{Name: "internal-panel", Version: "0.9.0"}, {Name: "grafana-clock-panel", Version: "2.0.0"}},
+			// stored to keep them rejected while requested, the instance never installs them
+			wantStored: v1beta1.PluginList{
+				{Name: "grafana-clock-panel", Version: "2.1.8"},
+				{Name: "internal-panel", Version: "0.9.0"},
+			},
+			wantRejected: []string{"internal-panel 0.9.0: unsigned"},
+		},
+		{
+			name:       "unsigned plugins are accepted without rejectUnsigned",
+			policy:     &v1beta1.GrafanaPluginPolicy{},
+			unsigned:   v1beta1.PluginList{{Name: "internal-panel", Version: "1.0.0"}},
+			wantStored: plugins,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grafana := &v1beta1.Grafana{
+				Spec:   v1beta1.GrafanaSpec{PluginPolicy: tt.policy},
+				Status: v1beta1.GrafanaStatus{UnsignedPlugins: tt.unsigned},
+			}
+
+			requested := plugins
+			if tt.plugins != nil {
+				requested = tt.plugins
+			}
+
+			stored, rejected, err := checkPluginPolicy(context.Background(), grafana, requested)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStored, stored)
+			assert.ElementsMatch(t, tt.wantRejected, rejected)
+		})
+	}
+}
+
+func TestRejectForeignPluginSources(t *testing.T) {
+	grafana := &v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{Namespace: "grafana", Name: "shared"}}
+
+	plugins := v1beta1.PluginList{
+		{Name: "grafana-clock-panel", Version: "2.1.8"},
+		{Name: "url-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{URL: "https://plugins.local/url-panel.zip"}},
+		{Name: "public-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{OCI: &v1beta1.GrafanaPluginOCISource{Reference: "registry.local/public-panel:1.0.0"}}},
+		{Name: "private-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{OCI: &v1beta1.GrafanaPluginOCISource{
+			Reference:     "registry.local/private-panel:1.0.0",
+			PullSecretRef: &corev1.LocalObjectReference{Name: "registry-credentials"},
+		}}},
+		{Name: "configmap-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{ConfigMap: &corev1.ConfigMapKeySelector{Key: "configmap-panel.zip"}}},
+	}
+
+	accepted, rejected := rejectForeignPluginSources(grafana, "grafana", plugins)
+	assert.Equal(t, plugins, accepted)
+	assert.Empty(t, rejected)
+
+	accepted, rejected = rejectForeignPluginSources(grafana, "team-a", plugins)
+	assert.Equal(t, plugins[:3], accepted)
+	assert.Equal(t, []string{
+		"private-panel 1.0.0: sources referencing objects are only accepted from resources in namespace grafana",
+		"configmap-panel 1.0.0: sources referencing objects are only accepted from resources in namespace grafana",
+	}, rejected)
+}
+
+func TestPluginRejections(t *testing.T) {
+	conditions := []metav1.Condition{}
+
+	rejections := pluginRejections{}
+	rejections.add(&v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "allowed"}}, nil)
+	rejections.add(&v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shared"}}, []string{"internal-panel 1.0.0: not allowed"})
+	rejections.finish(&conditions, 2)
+
+	condition := meta.FindStatusCondition(conditions, conditionPluginsRejected)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, conditionReasonPluginPolicy, condition.Reason)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+	assert.Equal(t, "Plugins rejected by the plugin policy of 1 instances:\n- default/shared: internal-panel 1.0.0: not allowed", condition.Message)
+
+	pluginRejections{}.finish(&conditions, 3)
+	assert.Nil(t, meta.FindStatusCondition(conditions, conditionPluginsRejected))
+}
+
+func TestApplyPluginPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	newFakePluginCatalog(t, map[string][][2]string{
+		"grafana-clock-panel": {{"2.1.8", "grafana"}},
+		"internal-panel":      {{"1.0.0", ""}},
+	})
+
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, v1beta1.AddToScheme(s))
+
+	grafana := &v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "default"}}
+
+	marshal := func(plugins v1beta1.PluginList) []byte {
+		b, err := json.Marshal(plugins)
+		require.NoError(t, err)
+
+		return b
+	}
+
+	cm := resources.GetPluginsConfigMap(grafana, s)
+	cm.BinaryData = map[string][]byte{
+		"dashboard":  marshal(v1beta1.PluginList{{Name: "grafana-clock-panel", Version: "2.1.8"}, {Name: "internal-panel", Version: "1.0.0"}}),
+		"datasource": marshal(v1beta1.PluginList{{Name: "internal-panel", Version: "1.0.0"}}),
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(cm).Build()
+
+	require.NoError(t, applyPluginPolicy(ctx, cl, grafana), "instances without a policy keep all plugins")
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(cm), cm))
+	assert.Len(t, cm.BinaryData, 2)
+
+	grafana.Spec.PluginPolicy = &v1beta1.GrafanaPluginPolicy{RejectUnsigned: true}
+
+	require.NoError(t, applyPluginPolicy(ctx, cl, grafana))
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(cm), cm))
+	assert.Equal(t, map[string][]byte{
+		"dashboard": marshal(v1beta1.PluginList{{Name: "grafana-clock-panel", Version: "2.1.8"}}),
+	}, cm.BinaryData)
+}
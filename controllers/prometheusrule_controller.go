@@ -0,0 +1,541 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/pkg/gtime"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=grafana.integreatly.org,resources=grafanaalertrulegroups,verbs=create;update;delete
+
+const (
+	// Overrides of the operator wide conversion settings on PrometheusRules
+	annotationPrometheusRuleDatasourceUID = "operator.grafana.com/datasource-uid"
+	annotationPrometheusRuleFolderUID     = "operator.grafana.com/folder-uid"
+	annotationPrometheusRuleFolderRef     = "operator.grafana.com/folder-ref"
+
+	// Evaluation interval of groups without an interval, matching the Prometheus default
+	defaultPrometheusRuleInterval = time.Minute
+
+	prometheusRuleQueryRefID     = "A"
+	prometheusRuleConditionRefID = "B"
+
+	// Fires for every series returned by the query, like Prometheus does
+	prometheusRuleConditionExpression = "is_number($A) || is_nan($A) || is_inf($A)"
+
+	expressionDatasourceUID = "__expr__"
+
+	maxAlertRuleUIDLength = 40
+)
+
+var (
+	PrometheusRuleGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+	invalidResourceNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// PrometheusRuleConversion configures how PrometheusRules are converted into GrafanaAlertRuleGroups
+type PrometheusRuleConversion struct {
+	// UID of the datasource evaluating the rule expressions
+	DatasourceUID string
+	// UID of the folder storing the generated rule groups
+	FolderUID string
+	// InstanceSelector set on the generated rule groups
+	InstanceSelector *metav1.LabelSelector
+	// AllowCrossNamespaceImport set on the generated rule groups
+	AllowCrossNamespaceImport bool
+}
+
+// PrometheusRuleReconciler converts PrometheusRules into GrafanaAlertRuleGroups, one per rule group
+type PrometheusRuleReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Recorder   events.EventRecorder
+	Selector   labels.Selector
+	Conversion PrometheusRuleConversion
+}
+
+// prometheusRuleSpec is the subset of the monitoring.coreos.com/v1 PrometheusRule spec used for the conversion
+type prometheusRuleSpec struct {
+	Groups []prometheusRuleGroup `json:"groups"`
+}
+
+type prometheusRuleGroup struct {
+	Name     string            `json:"name"`
+	Interval string            `json:"interval,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Rules    []prometheusRule  `json:"rules"`
+}
+
+type prometheusRule struct {
+	Record        string             `json:"record,omitempty"`
+	Alert         string             `json:"alert,omitempty"`
+	Expr          intstr.IntOrString `json:"expr"`
+	For           string             `json:"for,omitempty"`
+	KeepFiringFor string             `json:"keep_firing_for,omitempty"` //nolint:tagliatelle
+	Labels        map[string]string  `json:"labels,omitempty"`
+	Annotations   map[string]string  `json:"annotations,omitempty"`
+}
+
+func newPrometheusRule() *unstructured.Unstructured {
+	cr := &unstructured.Unstructured{}
+	cr.SetGroupVersionKind(PrometheusRuleGVK)
+
+	return cr
+}
+
+func (r *PrometheusRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx).WithName("PrometheusRuleReconciler")
+	ctx = logf.IntoContext(ctx, log)
+
+	cr := newPrometheusRule()
+
+	err := r.Get(ctx, req.NamespacedName, cr)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Generated rule groups are garbage collected through their owner reference
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, LogMsgGettingCR)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingCR, err)
+	}
+
+	var desired []v1beta1.GrafanaAlertRuleGroup
+
+	// PrometheusRules no longer matching the selector have their rule groups removed
+	if cr.GetDeletionTimestamp() == nil && r.Selector.Matches(labels.Set(cr.GetLabels())) {
+		desired, err = r.Conversion.convert(cr)
+		if err != nil {
+			// Retrying does not help until the PrometheusRule is updated
+			log.Error(err, "failed to convert PrometheusRule")
+			r.Recorder.Eventf(cr, nil, corev1.EventTypeWarning, "ConversionFailed", "ConvertPrometheusRule", "%v", err)
+
+			return ctrl.Result{}, nil
+		}
+	}
+
+	var errs []error
+
+	names := make(map[string]struct{}, len(desired))
+
+	for i := range desired {
+		group := &desired[i]
+		names[group.Name] = struct{}{}
+
+		if err := r.applyRuleGroup(ctx, cr, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := r.pruneRuleGroups(ctx, cr, names); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		log.Error(err, "failed to apply rule groups")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *PrometheusRuleReconciler) applyRuleGroup(ctx context.Context, cr *unstructured.Unstructured, desired *v1beta1.GrafanaAlertRuleGroup) error {
+	if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+		return fmt.Errorf("setting owner reference of rule group %s: %w", desired.Name, err)
+	}
+
+	existing := &v1beta1.GrafanaAlertRuleGroup{}
+
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("creating rule group %s: %w", desired.Name, err)
+		}
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("fetching rule group %s: %w", desired.Name, err)
+	}
+
+	if !metav1.IsControlledBy(existing, cr) {
+		r.Recorder.Eventf(cr, existing, corev1.EventTypeWarning, "RuleGroupConflict", "ApplyRuleGroup", "GrafanaAlertRuleGroup %s already exists and is not generated from this PrometheusRule", existing.Name)
+		return fmt.Errorf("rule group %s is not generated from this PrometheusRule", existing.Name)
+	}
+
+	// Still being finalized, the deletion event triggers the creation
+	if existing.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	// The folder is immutable, a moved group is deleted and created again once it is finalized
+	if existing.Spec.FolderUID != desired.Spec.FolderUID || existing.Spec.FolderRef != desired.Spec.FolderRef {
+		if err := r.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting rule group %s: %w", existing.Name, err)
+		}
+
+		return nil
+	}
+
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("updating rule group %s: %w", existing.Name, err)
+	}
+
+	return nil
+}
+
+// pruneRuleGroups removes rule groups generated from cr that are not part of keep
+func (r *PrometheusRuleReconciler) pruneRuleGroups(ctx context.Context, cr *unstructured.Unstructured, keep map[string]struct{}) error {
+	var list v1beta1.GrafanaAlertRuleGroupList
+
+	if err := r.List(ctx, &list, client.InNamespace(cr.GetNamespace())); err != nil {
+		return fmt.Errorf("listing rule groups: %w", err)
+	}
+
+	for i := range list.Items {
+		group := &list.Items[i]
+
+		if _, ok := keep[group.Name]; ok || !metav1.IsControlledBy(group, cr) {
+			continue
+		}
+
+		if err := r.Delete(ctx, group); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting rule group %s: %w", group.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// convert builds one GrafanaAlertRuleGroup per rule group of the PrometheusRule
+func (c *PrometheusRuleConversion) convert(cr *unstructured.Unstructured) ([]v1beta1.GrafanaAlertRuleGroup, error) {
+	annotations := cr.GetAnnotations()
+
+	datasourceUID := c.DatasourceUID
+	if uid := annotations[annotationPrometheusRuleDatasourceUID]; uid != "" {
+		datasourceUID = uid
+	}
+
+	if datasourceUID == "" {
+		return nil, fmt.Errorf("no datasource configured, set the %s annotation or --prometheus-rule-datasource-uid", annotationPrometheusRuleDatasourceUID)
+	}
+
+	folderUID, folderRef := c.FolderUID, ""
+
+	switch {
+	case annotations[annotationPrometheusRuleFolderRef] != "":
+		folderUID, folderRef = "", annotations[annotationPrometheusRuleFolderRef]
+	case annotations[annotationPrometheusRuleFolderUID] != "":
+		folderUID = annotations[annotationPrometheusRuleFolderUID]
+	}
+
+	if folderUID == "" && folderRef == "" {
+		return nil, fmt.Errorf("no folder configured, set the %s or %s annotation or --prometheus-rule-folder-uid", annotationPrometheusRuleFolderRef, annotationPrometheusRuleFolderUID)
+	}
+
+	spec, err := decodePrometheusRuleSpec(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceSelector := c.InstanceSelector
+	if instanceSelector == nil {
+		instanceSelector = &metav1.LabelSelector{}
+	}
+
+	groups := make([]v1beta1.GrafanaAlertRuleGroup, 0, len(spec.Groups))
+	names := make(map[string]struct{}, len(spec.Groups))
+
+	for _, group := range spec.Groups {
+		interval := defaultPrometheusRuleInterval
+
+		if group.Interval != "" {
+			interval, err = gtime.ParseDuration(group.Interval)
+			if err != nil {
+				return nil, fmt.Errorf("invalid interval of group %s: %w", group.Name, err)
+			}
+		}
+
+		rules, err := convertPrometheusRuleGroup(cr, group, datasourceUID)
+		if err != nil {
+			return nil, err
+		}
+
+		name := prometheusRuleGroupResourceName(cr.GetName(), group.Name)
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("group %s results in the duplicate resource name %s", group.Name, name)
+		}
+
+		names[name] = struct{}{}
+
+		groups = append(groups, v1beta1.GrafanaAlertRuleGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cr.GetNamespace(),
+				// Inherit labels so the rule groups stay within the same shard
+				Labels: maps.Clone(cr.GetLabels()),
+			},
+			Spec: v1beta1.GrafanaAlertRuleGroupSpec{
+				GrafanaCommonSpec: v1beta1.GrafanaCommonSpec{
+					InstanceSelector:          instanceSelector.DeepCopy(),
+					AllowCrossNamespaceImport: c.AllowCrossNamespaceImport,
+				},
+				Name:      prometheusRuleGroupName(cr, group.Name),
+				FolderUID: folderUID,
+				FolderRef: folderRef,
+				Rules:     rules,
+				Interval:  metav1.Duration{Duration: interval},
+			},
+		})
+	}
+
+	return groups, nil
+}
+
+func decodePrometheusRuleSpec(cr *unstructured.Unstructured) (*prometheusRuleSpec, error) {
+	raw, err := json.Marshal(cr.Object["spec"])
+	if err != nil {
+		return nil, fmt.Errorf("encoding PrometheusRule spec: %w", err)
+	}
+
+	spec := &prometheusRuleSpec{}
+	if err := json.Unmarshal(raw, spec); err != nil {
+		return nil, fmt.Errorf("decoding PrometheusRule spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+func convertPrometheusRuleGroup(cr *unstructured.Unstructured, group prometheusRuleGroup, datasourceUID string) ([]v1beta1.AlertRule, error) {
+	if len(group.Rules) == 0 {
+		return nil, fmt.Errorf("group %s has no rules", group.Name)
+	}
+
+	rules := make([]v1beta1.AlertRule, 0, len(group.Rules))
+
+	// Rules sharing a name are told apart by their position among those rules,
+	// which keeps UIDs stable when unrelated rules are added or removed
+	occurrences := map[string]int{}
+
+	for _, rule := range group.Rules {
+		name := rule.Alert
+		if rule.Record != "" {
+			name = rule.Record
+		}
+
+		key := fmt.Sprintf("%s/%s/%s/%s/%d", cr.GetNamespace(), cr.GetName(), group.Name, name, occurrences[name])
+		occurrences[name]++
+
+		converted, err := convertPrometheusRule(rule, group.Labels, datasourceUID, alertRuleUID(key))
+		if err != nil {
+			return nil, fmt.Errorf("converting rule %s of group %s: %w", name, group.Name, err)
+		}
+
+		rules = append(rules, converted)
+	}
+
+	return rules, nil
+}
+
+// convertPrometheusRule maps alerting rules to Grafana-managed alert rules and recording rules to Grafana recording rules
+func convertPrometheusRule(rule prometheusRule, groupLabels map[string]string, datasourceUID, uid string) (v1beta1.AlertRule, error) {
+	if (rule.Alert == "") == (rule.Record == "") {
+		return v1beta1.AlertRule{}, errors.New("exactly one of alert or record must be set")
+	}
+
+	expr := rule.Expr.String()
+	if expr == "" {
+		return v1beta1.AlertRule{}, errors.New("expr must not be empty")
+	}
+
+	query, err := prometheusRuleQuery(datasourceUID, expr)
+	if err != nil {
+		return v1beta1.AlertRule{}, err
+	}
+
+	ruleLabels := maps.Clone(groupLabels)
+	if ruleLabels == nil {
+		ruleLabels = map[string]string{}
+	}
+
+	maps.Copy(ruleLabels, rule.Labels)
+
+	if len(ruleLabels) == 0 {
+		ruleLabels = nil
+	}
+
+	converted := v1beta1.AlertRule{
+		UID:          uid,
+		Labels:       ruleLabels,
+		ExecErrState: "Error",
+		NoDataState:  new("OK"),
+		For:          new("0s"),
+	}
+
+	if rule.Record != "" {
+		converted.Title = rule.Record
+		converted.Condition = prometheusRuleQueryRefID
+		converted.Data = []*v1beta1.AlertQuery{query}
+		converted.Record = &v1beta1.Record{
+			From:   prometheusRuleQueryRefID,
+			Metric: rule.Record,
+		}
+
+		return converted, nil
+	}
+
+	converted.Title = rule.Alert
+	converted.Annotations = rule.Annotations
+	converted.Condition = prometheusRuleConditionRefID
+	converted.Data = []*v1beta1.AlertQuery{query, prometheusRuleCondition()}
+
+	if rule.For != "" {
+		duration, err := gtime.ParseDuration(rule.For)
+		if err != nil {
+			return v1beta1.AlertRule{}, fmt.Errorf("invalid 'for' duration %s: %w", rule.For, err)
+		}
+
+		converted.For = new(duration.Round(time.Second).String())
+	}
+
+	if rule.KeepFiringFor != "" {
+		duration, err := gtime.ParseDuration(rule.KeepFiringFor)
+		if err != nil {
+			return v1beta1.AlertRule{}, fmt.Errorf("invalid 'keep_firing_for' duration %s: %w", rule.KeepFiringFor, err)
+		}
+
+		converted.KeepFiringFor = &metav1.Duration{Duration: duration}
+	}
+
+	return converted, nil
+}
+
+// prometheusRuleQuery evaluates the rule expression as an instant query
+func prometheusRuleQuery(datasourceUID, expr string) (*v1beta1.AlertQuery, error) {
+	model, err := json.Marshal(map[string]any{
+		"refId":   prometheusRuleQueryRefID,
+		"expr":    expr,
+		"instant": true,
+		"range":   false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding query model: %w", err)
+	}
+
+	return &v1beta1.AlertQuery{
+		RefID:         prometheusRuleQueryRefID,
+		DatasourceUID: datasourceUID,
+		Model:         &apiextensionsv1.JSON{Raw: model},
+		RelativeTimeRange: &models.RelativeTimeRange{
+			From: models.Duration(10 * time.Minute / time.Second),
+		},
+	}, nil
+}
+
+// prometheusRuleCondition is a math expression that is true for every series returned by the query
+func prometheusRuleCondition() *v1beta1.AlertQuery {
+	model := fmt.Sprintf(`{"refId":%q,"type":"math","expression":%q,"datasource":{"type":%q,"uid":%q}}`,
+		prometheusRuleConditionRefID, prometheusRuleConditionExpression, expressionDatasourceUID, expressionDatasourceUID)
+
+	return &v1beta1.AlertQuery{
+		RefID:         prometheusRuleConditionRefID,
+		DatasourceUID: expressionDatasourceUID,
+		Model:         &apiextensionsv1.JSON{Raw: []byte(model)},
+	}
+}
+
+// alertRuleUID derives a stable UID within the length limit of Grafana
+func alertRuleUID(key string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))[:maxAlertRuleUIDLength]
+}
+
+// prometheusRuleGroupResourceName derives a valid resource name from the PrometheusRule and group names
+func prometheusRuleGroupResourceName(ruleName, groupName string) string {
+	name := strings.Trim(invalidResourceNameChars.ReplaceAllString(strings.ToLower(ruleName+"-"+groupName), "-"), "-")
+
+	// Leave room for a hash suffix within the 253 characters allowed for resource names
+	const maxLength = 240
+	if len(name) > maxLength {
+		hash := fmt.Sprintf("%x", sha256.Sum256([]byte(ruleName+"/"+groupName)))
+		name = strings.TrimRight(name[:maxLength], "-") + "-" + hash[:8]
+	}
+
+	return name
+}
+
+// prometheusRuleGroupName prefixes the group name with the namespace and name of the
+// PrometheusRule, groups of different PrometheusRules share the folder in Grafana.
+// Namespaces and resource names cannot contain underscores, so the name is unambiguous.
+func prometheusRuleGroupName(cr *unstructured.Unstructured, groupName string) string {
+	name := fmt.Sprintf("%s_%s_%s", cr.GetNamespace(), cr.GetName(), groupName)
+
+	// Grafana limits rule group names to 190 characters
+	const maxLength = 180
+	if len(name) > maxLength {
+		hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))
+		name = name[:maxLength] + "-" + hash[:8]
+	}
+
+	return name
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PrometheusRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("prometheusrule").
+		// Label changes decide whether a PrometheusRule matches the selector
+		For(newPrometheusRule(), builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Owns(&v1beta1.GrafanaAlertRuleGroup{}, builder.WithPredicates(ignoreStatusUpdates())).
+		Complete(r)
+}
@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newTestPrometheusRule(annotations map[string]string, groups ...any) *unstructured.Unstructured {
+	cr := newPrometheusRule()
+	cr.SetNamespace("monitoring")
+	cr.SetName("node")
+	cr.SetLabels(map[string]string{"grafana": "managed"})
+	cr.SetAnnotations(annotations)
+	cr.Object["spec"] = map[string]any{"groups": groups}
+
+	return cr
+}
+
+func TestPrometheusRuleConversion(t *testing.T) {
+	conversion := PrometheusRuleConversion{
+		DatasourceUID:    "prometheus",
+		FolderUID:        "alerts",
+		InstanceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}},
+	}
+
+	cr := newTestPrometheusRule(nil, map[string]any{
+		"name":     "Node.Rules",
+		"interval": "30s",
+		"labels":   map[string]any{"team": "infra", "severity": "info"},
+		"rules": []any{
+			map[string]any{
+				"record": "instance:node_cpu:ratio",
+				"expr":   "avg by (instance) (rate(node_cpu_seconds_total[5m]))",
+			},
+			map[string]any{
+				"alert":           "NodeDown",
+				"expr":            "up == 0",
+				"for":             "1d",
+				"keep_firing_for": "5m",
+				"labels":          map[string]any{"severity": "critical"},
+				"annotations":     map[string]any{"summary": "{{ $labels.instance }} is down"},
+			},
+		},
+	})
+
+	groups, err := conversion.convert(cr)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+
+	group := groups[0]
+	assert.Equal(t, "node-node-rules", group.Name)
+	assert.Equal(t, "monitoring", group.Namespace)
+	assert.Equal(t, map[string]string{"grafana": "managed"}, group.Labels)
+	assert.Equal(t, "monitoring_node_Node.Rules", group.Spec.Name)
+	assert.Equal(t, "alerts", group.Spec.FolderUID)
+	assert.Empty(t, group.Spec.FolderRef)
+	assert.Equal(t, 30*time.Second, group.Spec.Interval.Duration)
+	assert.Equal(t, conversion.InstanceSelector, group.Spec.InstanceSelector)
+	require.Len(t, group.Spec.Rules, 2)
+
+	record := group.Spec.Rules[0]
+	assert.Equal(t, "instance:node_cpu:ratio", record.Title)
+	assert.Equal(t, &v1beta1.Record{From: "A", Metric: "instance:node_cpu:ratio"}, record.Record)
+	assert.Equal(t, "A", record.Condition)
+	assert.Equal(t, map[string]string{"team": "infra", "severity": "info"}, record.Labels)
+	require.Len(t, record.Data, 1)
+	assert.Equal(t, "prometheus", record.Data[0].DatasourceUID)
+	assert.JSONEq(t, `{"refId":"A","expr":"avg by (instance) (rate(node_cpu_seconds_total[5m]))","instant":true,"range":false}`, string(record.Data[0].Model.Raw))
+
+	alert := group.Spec.Rules[1]
+	assert.Equal(t, "NodeDown", alert.Title)
+	assert.Nil(t, alert.Record)
+	assert.Equal(t, "B", alert.Condition)
+	assert.Equal(t, "24h0m0s", *alert.For)
+	assert.Equal(t, 5*time.Minute, alert.KeepFiringFor.Duration)
+	assert.Equal(t, "OK", *alert.NoDataState)
+	assert.Equal(t, "Error", alert.ExecErrState)
+	assert.Equal(t, map[string]string{"team": "infra", "severity": "critical"}, alert.Labels)
+	assert.Equal(t, map[string]string{"summary": "{{ $labels.instance }} is down"}, alert.Annotations)
+	require.Len(t, alert.Data, 2)
+	assert.JSONEq(t, `{"refId":"A","expr":"up == 0","instant":true,"range":false}`, string(alert.Data[0].Model.Raw))
+	assert.Equal(t, "__expr__", alert.Data[1].DatasourceUID)
+	assert.Equal(t, "B", alert.Data[1].RefID)
+	assert.Contains(t, string(alert.Data[1].Model.Raw), `"type":"math"`)
+
+	assert.Len(t, alert.UID, maxAlertRuleUIDLength)
+	assert.NotEqual(t, record.UID, alert.UID)
+
+	// UIDs are stable across conversions
+	again, err := conversion.convert(cr)
+	require.NoError(t, err)
+	assert.Equal(t, alert.UID, again[0].Spec.Rules[1].UID)
+}
+
+func TestPrometheusRuleConversionDefaults(t *testing.T) {
+	conversion := PrometheusRuleConversion{DatasourceUID: "prometheus", FolderUID: "alerts"}
+
+	cr := newTestPrometheusRule(map[string]string{
+		annotationPrometheusRuleDatasourceUID: "mimir",
+		annotationPrometheusRuleFolderRef:     "node-alerts",
+	}, map[string]any{
+		"name": "node",
+		"rules": []any{
+			map[string]any{"alert": "NodeDown", "expr": "up == 0"},
+			map[string]any{"alert": "NodeDown", "expr": "absent(up)"},
+		},
+	})
+
+	groups, err := conversion.convert(cr)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+
+	group := groups[0]
+	assert.Equal(t, time.Minute, group.Spec.Interval.Duration)
+	assert.Empty(t, group.Spec.FolderUID)
+	assert.Equal(t, "node-alerts", group.Spec.FolderRef)
+	assert.Equal(t, &metav1.LabelSelector{}, group.Spec.InstanceSelector)
+
+	rules := group.Spec.Rules
+	assert.Equal(t, "mimir", rules[0].Data[0].DatasourceUID)
+	assert.Equal(t, "0s", *rules[0].For)
+	assert.Nil(t, rules[0].KeepFiringFor)
+	assert.Nil(t, rules[0].Labels)
+	assert.NotEqual(t, rules[0].UID, rules[1].UID, "rules sharing a name must have distinct UIDs")
+}
+
+func TestPrometheusRuleConversionErrors(t *testing.T) {
+	group := func(rules ...any) map[string]any {
+		return map[string]any{"name": "node", "rules": rules}
+	}
+
+	tests := []struct {
+		name       string
+		conversion PrometheusRuleConversion
+		group      map[string]any
+		wantErr    string
+	}{
+		{
+			name:       "missing datasource",
+			conversion: PrometheusRuleConversion{FolderUID: "alerts"},
+			group:      group(map[string]any{"alert": "a", "expr": "up"}),
+			wantErr:    "no datasource configured",
+		},
+		{
+			name:       "missing folder",
+			conversion: PrometheusRuleConversion{DatasourceUID: "prometheus"},
+			group:      group(map[string]any{"alert": "a", "expr": "up"}),
+			wantErr:    "no folder configured",
+		},
+		{
+			name:       "alert and record",
+			conversion: PrometheusRuleConversion{DatasourceUID: "prometheus", FolderUID: "alerts"},
+			group:      group(map[string]any{"alert": "a", "record": "b", "expr": "up"}),
+			wantErr:    "exactly one of alert or record must be set",
+		},
+		{
+			name:       "invalid for",
+			conversion: PrometheusRuleConversion{DatasourceUID: "prometheus", FolderUID: "alerts"},
+			group:      group(map[string]any{"alert": "a", "expr": "up", "for": "soon"}),
+			wantErr:    "invalid 'for' duration",
+		},
+		{
+			name:       "empty group",
+			conversion: PrometheusRuleConversion{DatasourceUID: "prometheus", FolderUID: "alerts"},
+			group:      group(),
+			wantErr:    "group node has no rules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.conversion.convert(newTestPrometheusRule(nil, tt.group))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPrometheusRuleGroupResourceName(t *testing.T) {
+	assert.Equal(t, "node-node-exporter-rules", prometheusRuleGroupResourceName("node", "node_exporter.rules"))
+	assert.Equal(t, "kube-apiserver-slos", prometheusRuleGroupResourceName("kube", "apiserver SLOs"))
+
+	long := prometheusRuleGroupResourceName("node", strings.Repeat("a", 300))
+	assert.Len(t, long, 249)
+	assert.NotEqual(t, long, prometheusRuleGroupResourceName("node", strings.Repeat("a", 301)))
+}
+
+func TestPrometheusRuleGroupName(t *testing.T) {
+	cr := newTestPrometheusRule(nil)
+	assert.Equal(t, "monitoring_node_node.rules", prometheusRuleGroupName(cr, "node.rules"))
+
+	long := prometheusRuleGroupName(cr, strings.Repeat("a", 300))
+	assert.Len(t, long, 189)
+	assert.NotEqual(t, long, prometheusRuleGroupName(cr, strings.Repeat("a", 301)))
+}
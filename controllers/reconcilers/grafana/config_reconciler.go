@@ -2,6 +2,8 @@ package grafana
 
 import (
 	"context"
+	"fmt"
+	"maps"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
 	"github.com/grafana/grafana-operator/v5/controllers/config"
@@ -26,7 +28,16 @@ func NewConfigReconciler(cl client.Client) reconcilers.OperatorGrafanaReconciler
 func (r *ConfigReconciler) Reconcile(ctx context.Context, cr *v1beta1.Grafana, vars *v1beta1.OperatorReconcileVars, scheme *runtime.Scheme) (v1beta1.OperatorStageStatus, error) {
 	_ = logf.FromContext(ctx)
 
-	cfg := config.WriteIni(config.SetDefaults(cr.Spec.Config, cr.Spec.Version))
+	settings := setHighAvailabilityConfig(cr, config.SetDefaults(cr.Spec.Config, cr.Spec.Version))
+
+	if cr.Spec.Renderer != nil {
+		serverURL, callbackURL := getRendererURLs(cr)
+		settings = config.SetRendering(settings, serverURL, callbackURL)
+	}
+
+	settings = setPluginPolicyConfig(cr, settings)
+
+	cfg := config.WriteIni(settings)
 	vars.ConfigHash = config.GetHash(cfg)
 
 	configMap := resources.GetGrafanaConfigMap(cr, scheme)
@@ -55,3 +66,77 @@ func (r *ConfigReconciler) Reconcile(ctx context.Context, cr *v1beta1.Grafana, v
 
 	return v1beta1.OperatorStageResultSuccess, nil
 }
+
+// setPluginPolicyConfig drops plugins.allow_loading_unsigned_plugins while spec.pluginPolicy.rejectUnsigned is set,
+// Grafana refuses to load unsigned plugins unless they are explicitly allowed
+func setPluginPolicyConfig(cr *v1beta1.Grafana, cfg map[string]map[string]string) map[string]map[string]string {
+	if cr.Spec.PluginPolicy == nil || !cr.Spec.PluginPolicy.RejectUnsigned {
+		return cfg
+	}
+
+	if _, ok := cfg["plugins"]["allow_loading_unsigned_plugins"]; !ok {
+		return cfg
+	}
+
+	// the settings are shared with spec.config
+	plugins := maps.Clone(cfg["plugins"])
+	delete(plugins, "allow_loading_unsigned_plugins")
+
+	cfg = maps.Clone(cfg)
+	cfg["plugins"] = plugins
+
+	return cfg
+}
+
+// setHighAvailabilityConfig adds the database and alerting gossip settings of spec.highAvailability.
+// Values set in spec.config take precedence. Database credentials are passed through environment variables
+func setHighAvailabilityConfig(cr *v1beta1.Grafana, cfg map[string]map[string]string) map[string]map[string]string {
+	ha := cr.Spec.HighAvailability
+	if ha == nil {
+		return cfg
+	}
+
+	port := getAlertingPort(cr)
+
+	settings := map[string]map[string]string{
+		"database": {
+			"type": ha.Database.Type,
+			"host": ha.Database.Host,
+			"name": ha.Database.Name,
+		},
+		"unified_alerting": {
+			// POD_IP is set on the grafana container
+			"ha_listen_address":    fmt.Sprintf("${POD_IP}:%d", port),
+			"ha_advertise_address": fmt.Sprintf("${POD_IP}:%d", port),
+			"ha_peers":             fmt.Sprintf("%s:%d", resources.GetGrafanaHeadlessService(cr, nil).Name, port),
+		},
+	}
+
+	if ha.Database.SSLMode != "" {
+		settings["database"]["ssl_mode"] = ha.Database.SSLMode
+	}
+
+	if alerting := ha.Alerting; alerting != nil {
+		if alerting.GossipInterval != nil {
+			settings["unified_alerting"]["ha_gossip_interval"] = alerting.GossipInterval.Duration.String()
+		}
+
+		if alerting.PushPullInterval != nil {
+			settings["unified_alerting"]["ha_push_pull_interval"] = alerting.PushPullInterval.Duration.String()
+		}
+	}
+
+	for section, values := range settings {
+		if cfg[section] == nil {
+			cfg[section] = make(map[string]string)
+		}
+
+		for key, value := range values {
+			if cfg[section][key] == "" {
+				cfg[section][key] = value
+			}
+		}
+	}
+
+	return cfg
+}
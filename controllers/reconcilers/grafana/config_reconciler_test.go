@@ -0,0 +1,100 @@
+package grafana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetHighAvailabilityConfig(t *testing.T) {
+	t.Run("no changes without high availability", func(t *testing.T) {
+		cr := &v1beta1.Grafana{}
+
+		got := setHighAvailabilityConfig(cr, map[string]map[string]string{})
+
+		assert.Empty(t, got)
+	})
+
+	t.Run("database and gossip settings", func(t *testing.T) {
+		cr := &v1beta1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "grafana"},
+			Spec: v1beta1.GrafanaSpec{
+				HighAvailability: &v1beta1.GrafanaHighAvailability{
+					Database: v1beta1.GrafanaDatabase{
+						Type:    "postgres",
+						Host:    "postgres:5432",
+						Name:    "grafana",
+						SSLMode: "require",
+					},
+					Alerting: &v1beta1.GrafanaAlertingHA{
+						Port:           9095,
+						GossipInterval: &metav1.Duration{Duration: 200 * time.Millisecond},
+					},
+				},
+			},
+		}
+
+		got := setHighAvailabilityConfig(cr, map[string]map[string]string{})
+
+		assert.Equal(t, map[string]map[string]string{
+			"database": {
+				"type":     "postgres",
+				"host":     "postgres:5432",
+				"name":     "grafana",
+				"ssl_mode": "require",
+			},
+			"unified_alerting": {
+				"ha_listen_address":    "${POD_IP}:9095",
+				"ha_advertise_address": "${POD_IP}:9095",
+				"ha_peers":             "grafana-alerting:9095",
+				"ha_gossip_interval":   "200ms",
+			},
+		}, got)
+	})
+
+	t.Run("config values take precedence", func(t *testing.T) {
+		cr := &v1beta1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "grafana"},
+			Spec: v1beta1.GrafanaSpec{
+				HighAvailability: &v1beta1.GrafanaHighAvailability{
+					Database: v1beta1.GrafanaDatabase{Type: "mysql", Host: "mysql:3306", Name: "grafana"},
+				},
+			},
+		}
+
+		got := setHighAvailabilityConfig(cr, map[string]map[string]string{
+			"unified_alerting": {"ha_peers": "peer-0:9094,peer-1:9094"},
+		})
+
+		assert.Equal(t, "peer-0:9094,peer-1:9094", got["unified_alerting"]["ha_peers"])
+		assert.Equal(t, "${POD_IP}:9094", got["unified_alerting"]["ha_listen_address"])
+		assert.Equal(t, "mysql", got["database"]["type"])
+	})
+}
+
+func TestSetPluginPolicyConfig(t *testing.T) {
+	cfg := map[string]map[string]string{
+		"plugins": {
+			"allow_loading_unsigned_plugins": "internal-panel",
+			"enable_alpha":                   "true",
+		},
+	}
+
+	t.Run("no changes without rejectUnsigned", func(t *testing.T) {
+		cr := &v1beta1.Grafana{Spec: v1beta1.GrafanaSpec{PluginPolicy: &v1beta1.GrafanaPluginPolicy{}}}
+
+		assert.Equal(t, cfg, setPluginPolicyConfig(cr, cfg))
+	})
+
+	t.Run("unsigned plugins are no longer allowed", func(t *testing.T) {
+		cr := &v1beta1.Grafana{Spec: v1beta1.GrafanaSpec{PluginPolicy: &v1beta1.GrafanaPluginPolicy{RejectUnsigned: true}}}
+
+		got := setPluginPolicyConfig(cr, cfg)
+
+		assert.Equal(t, map[string]map[string]string{"plugins": {"enable_alpha": "true"}}, got)
+		assert.Contains(t, cfg["plugins"], "allow_loading_unsigned_plugins", "spec.config is left untouched")
+	})
+}
@@ -3,8 +3,11 @@ package grafana
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"path"
 	"sort"
 	"strings"
 
@@ -12,8 +15,10 @@ import (
 	"github.com/grafana/grafana-operator/v5/controllers/config"
 	"github.com/grafana/grafana-operator/v5/controllers/reconcilers"
 	"github.com/grafana/grafana-operator/v5/controllers/resources"
+	"github.com/grafana/grafana-operator/v5/pkg/plugininstaller"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,14 +43,16 @@ const (
 )
 
 type DeploymentReconciler struct {
-	client      client.Client
-	isOpenShift bool
+	client               client.Client
+	isOpenShift          bool
+	pluginInstallerImage string
 }
 
-func NewDeploymentReconciler(cl client.Client, isOpenShift bool) reconcilers.OperatorGrafanaReconciler {
+func NewDeploymentReconciler(cl client.Client, isOpenShift bool, pluginInstallerImage string) reconcilers.OperatorGrafanaReconciler {
 	return &DeploymentReconciler{
-		client:      cl,
-		isOpenShift: isOpenShift,
+		client:               cl,
+		isOpenShift:          isOpenShift,
+		pluginInstallerImage: pluginInstallerImage,
 	}
 }
 
@@ -66,9 +73,14 @@ func (r *DeploymentReconciler) Reconcile(ctx context.Context, cr *v1beta1.Grafan
 	deployment := resources.GetGrafanaDeployment(cr, scheme)
 
 	_, err = controllerutil.CreateOrUpdate(ctx, r.client, deployment, func() error {
-		deployment.Spec = getDeploymentSpec(cr, deployment.Name, scheme, vars, openshiftPlatform)
+		spec, err := getDeploymentSpec(cr, deployment.Name, scheme, vars, openshiftPlatform, r.pluginInstallerImage)
+		if err != nil {
+			return err
+		}
 
-		err := v1beta1.Merge(deployment, cr.Spec.Deployment)
+		deployment.Spec = spec
+
+		err = v1beta1.Merge(deployment, cr.Spec.Deployment)
 		if err != nil {
 			setInvalidMergeCondition(cr, "Deployment", err)
 			return err
@@ -100,6 +112,11 @@ func (r *DeploymentReconciler) Reconcile(ctx context.Context, cr *v1beta1.Grafan
 		return v1beta1.OperatorStageResultFailed, err
 	}
 
+	err = r.reconcilePodDisruptionBudget(ctx, cr, scheme)
+	if err != nil {
+		return v1beta1.OperatorStageResultFailed, err
+	}
+
 	selector := labels.SelectorFromValidatedSet(getSelectorLabels(cr))
 
 	cr.Status.Replicas = deployment.Status.Replicas
@@ -108,6 +125,51 @@ func (r *DeploymentReconciler) Reconcile(ctx context.Context, cr *v1beta1.Grafan
 	return v1beta1.OperatorStageResultSuccess, nil
 }
 
+// reconcilePodDisruptionBudget keeps a PodDisruptionBudget while high availability is enabled
+func (r *DeploymentReconciler) reconcilePodDisruptionBudget(ctx context.Context, cr *v1beta1.Grafana, scheme *runtime.Scheme) error {
+	pdb := resources.GetGrafanaPodDisruptionBudget(cr, scheme)
+
+	if cr.Spec.HighAvailability == nil {
+		err := r.client.Delete(ctx, pdb)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting pod disruption budget: %w", err)
+		}
+
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.client, pdb, func() error {
+		pdb.Spec = policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: getSelectorLabels(cr),
+			},
+		}
+
+		if budget := cr.Spec.HighAvailability.PodDisruptionBudget; budget != nil && (budget.MinAvailable != nil || budget.MaxUnavailable != nil) {
+			pdb.Spec.MinAvailable = budget.MinAvailable
+			pdb.Spec.MaxUnavailable = budget.MaxUnavailable
+		} else {
+			pdb.Spec.MaxUnavailable = new(intstr.FromInt32(1))
+		}
+
+		if scheme != nil {
+			err := controllerutil.SetControllerReference(cr, pdb, scheme)
+			if err != nil {
+				return err
+			}
+		}
+
+		resources.SetInheritedLabels(pdb, cr.Labels)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("reconciling pod disruption budget: %w", err)
+	}
+
+	return nil
+}
+
 func getSelectorLabels(cr *v1beta1.Grafana) map[string]string {
 	return map[string]string{
 		appLabel: cr.Name,
@@ -241,7 +303,7 @@ func getContainers(cr *v1beta1.Grafana, scheme *runtime.Scheme, vars *v1beta1.Op
 			},
 			{
 				Name:          config.GrafanaAlertPortName,
-				ContainerPort: int32(config.GrafanaAlertPort),
+				ContainerPort: getAlertingPort(cr),
 				Protocol:      protocolTCP,
 			},
 		},
@@ -288,8 +350,39 @@ func getContainers(cr *v1beta1.Grafana, scheme *runtime.Scheme, vars *v1beta1.Op
 		container.Env = append(container.Env, envCredentials...)
 	}
 
+	if ha := cr.Spec.HighAvailability; ha != nil {
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name:      config.GrafanaDatabaseUserEnvVar,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &ha.Database.User},
+			},
+			corev1.EnvVar{
+				Name:      config.GrafanaDatabasePasswordEnvVar,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &ha.Database.Password},
+			},
+		)
+	}
+
+	if cr.Spec.Renderer != nil {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: config.GrafanaRendererTokenEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: resources.GetGrafanaRendererSecret(cr, scheme).Name,
+					},
+					Key: config.RendererTokenSecretKey,
+				},
+			},
+		})
+	}
+
 	containers := []corev1.Container{container}
 
+	if cr.Spec.Renderer != nil && !cr.Spec.Renderer.IsDeployment() {
+		containers = append(containers, getRendererContainer(cr, scheme, openshiftPlatform))
+	}
+
 	return containers
 }
 
@@ -354,10 +447,29 @@ func getDefaultPodSecurityContext(disableSecurityContext string) *corev1.PodSecu
 	}
 }
 
-func getDeploymentSpec(cr *v1beta1.Grafana, deploymentName string, scheme *runtime.Scheme, vars *v1beta1.OperatorReconcileVars, openshiftPlatform bool) appsv1.DeploymentSpec {
+func getDeploymentSpec(cr *v1beta1.Grafana, deploymentName string, scheme *runtime.Scheme, vars *v1beta1.OperatorReconcileVars, openshiftPlatform bool, pluginInstallerImage string) (appsv1.DeploymentSpec, error) {
 	sa := resources.GetGrafanaServiceAccount(cr, scheme)
 
+	volumes := getVolumes(cr, scheme)
+
+	var initContainers []corev1.Container
+
+	if len(vars.PluginArchives) > 0 {
+		if pluginInstallerImage == "" {
+			return appsv1.DeploymentSpec{}, errors.New("installing plugins from archives requires the plugin installer image, set --plugin-installer-image (PLUGIN_INSTALLER_IMAGE) when running a development build of the operator")
+		}
+
+		installer, archiveVolumes, err := getPluginInstallerContainer(cr, vars.PluginArchives, pluginInstallerImage, openshiftPlatform)
+		if err != nil {
+			return appsv1.DeploymentSpec{}, err
+		}
+
+		initContainers = append(initContainers, installer)
+		volumes = append(volumes, archiveVolumes...)
+	}
+
 	return appsv1.DeploymentSpec{
+		Replicas: cr.GetReplicas(),
 		Selector: &metav1.LabelSelector{
 			MatchLabels: getSelectorLabels(cr),
 		},
@@ -369,13 +481,106 @@ func getDeploymentSpec(cr *v1beta1.Grafana, deploymentName string, scheme *runti
 				},
 			},
 			Spec: corev1.PodSpec{
-				Volumes:            getVolumes(cr, scheme),
+				Volumes:            volumes,
+				InitContainers:     initContainers,
 				Containers:         getContainers(cr, scheme, vars, openshiftPlatform),
 				SecurityContext:    getDefaultPodSecurityContext(cr.Spec.DisableDefaultSecurityContext),
 				ServiceAccountName: sa.Name,
 			},
 		},
+	}, nil
+}
+
+// getPluginInstallerContainer returns the init container unpacking plugin archives into the plugins directory and the
+// volumes of archives from ConfigMaps and PersistentVolumeClaims and of registry credentials
+func getPluginInstallerContainer(cr *v1beta1.Grafana, plugins v1beta1.PluginList, image string, openshiftPlatform bool) (corev1.Container, []corev1.Volume, error) {
+	archives := make([]plugininstaller.Archive, 0, len(plugins))
+	volumes := []corev1.Volume{}
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      config.GrafanaDataVolumeName,
+			MountPath: config.GrafanaDataPath,
+		},
+	}
+
+	addVolume := func(source corev1.VolumeSource) string {
+		name := fmt.Sprintf("%s-%d", config.GrafanaPluginArchiveVolumePrefix, len(volumes))
+		mountPath := path.Join(config.GrafanaPluginArchivesPath, name)
+
+		volumes = append(volumes, corev1.Volume{Name: name, VolumeSource: source})
+		mounts = append(mounts, corev1.VolumeMount{Name: name, MountPath: mountPath, ReadOnly: true})
+
+		return mountPath
+	}
+
+	for _, plugin := range plugins {
+		source := plugin.Source
+		archive := plugininstaller.Archive{
+			Name:    plugin.Name,
+			Version: plugin.Version,
+			URL:     source.URL,
+			SHA256:  source.SHA256,
+		}
+
+		switch {
+		case source.OCI != nil:
+			archive.OCI = source.OCI.Reference
+			archive.InsecurePlainHTTP = source.OCI.InsecurePlainHTTP
+
+			if source.OCI.PullSecretRef != nil {
+				mountPath := addVolume(corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: source.OCI.PullSecretRef.Name,
+						Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: "config.json"}},
+					},
+				})
+				archive.DockerConfig = path.Join(mountPath, "config.json")
+			}
+		case source.ConfigMap != nil:
+			mountPath := addVolume(corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: source.ConfigMap.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: source.ConfigMap.Key, Path: "archive.zip"}},
+				},
+			})
+			archive.File = path.Join(mountPath, "archive.zip")
+		case source.PersistentVolumeClaim != nil:
+			mountPath := addVolume(corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: source.PersistentVolumeClaim.ClaimName,
+					ReadOnly:  true,
+				},
+			})
+			archive.File = path.Join(mountPath, source.PersistentVolumeClaim.Path)
+		}
+
+		archives = append(archives, archive)
+	}
+
+	spec, err := json.Marshal(archives)
+	if err != nil {
+		return corev1.Container{}, nil, fmt.Errorf("encoding plugin archives: %w", err)
+	}
+
+	container := corev1.Container{
+		Name:  config.GrafanaPluginInstallerContainerName,
+		Image: image,
+		Args:  []string{plugininstaller.Command, config.GrafanaPluginsPath},
+		Env: []corev1.EnvVar{
+			{
+				Name:  plugininstaller.ArchivesEnvVar,
+				Value: string(spec),
+			},
+		},
+		Resources:                getResources(),
+		VolumeMounts:             mounts,
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: "File",
+		ImagePullPolicy:          "IfNotPresent",
+		SecurityContext:          getDefaultContainerSecurityContext(cr.Spec.DisableDefaultSecurityContext, openshiftPlatform),
 	}
+
+	return container, volumes, nil
 }
 
 // computeSecretsHash returns a SHA-256 hash of the ResourceVersions of all Secrets and ConfigMaps
@@ -2,17 +2,22 @@ package grafana
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
 	"github.com/grafana/grafana-operator/v5/controllers/config"
+	"github.com/grafana/grafana-operator/v5/pkg/plugininstaller"
 	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -95,6 +100,82 @@ func TestHashResourceVersions(t *testing.T) {
 	})
 }
 
+func TestGetPluginInstallerContainer(t *testing.T) {
+	cr := &v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "default"}}
+	checksum := strings.Repeat("a", 64)
+
+	t.Run("no archives, no init container", func(t *testing.T) {
+		spec, err := getDeploymentSpec(cr, "grafana", scheme.Scheme, &v1beta1.OperatorReconcileVars{}, false, "installer:v5")
+		require.NoError(t, err)
+		assert.Empty(t, spec.Template.Spec.InitContainers)
+	})
+
+	t.Run("archives are mounted and passed to the installer", func(t *testing.T) {
+		vars := &v1beta1.OperatorReconcileVars{
+			Plugins: "grafana-piechart-panel",
+			PluginArchives: v1beta1.PluginList{
+				{Name: "oci-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{
+					OCI: &v1beta1.GrafanaPluginOCISource{
+						Reference:     "registry.local/plugins/oci-panel@sha256:" + checksum,
+						PullSecretRef: &corev1.LocalObjectReference{Name: "regcred"},
+					},
+					SHA256: checksum,
+				}},
+				{Name: "url-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{URL: "https://plugins.local/url-panel.zip", SHA256: checksum}},
+				{Name: "cm-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{
+					ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "plugins"}, Key: "cm-panel.zip"},
+				}},
+				{Name: "pvc-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{
+					PersistentVolumeClaim: &v1beta1.GrafanaPluginVolumeSource{ClaimName: "plugins", Path: "archives/pvc-panel.zip"},
+				}},
+			},
+		}
+
+		spec, err := getDeploymentSpec(cr, "grafana", scheme.Scheme, vars, false, "installer:v5")
+		require.NoError(t, err)
+		require.Len(t, spec.Template.Spec.InitContainers, 1)
+
+		installer := spec.Template.Spec.InitContainers[0]
+		assert.Equal(t, "installer:v5", installer.Image)
+		assert.Equal(t, []string{plugininstaller.Command, config.GrafanaPluginsPath}, installer.Args)
+
+		archives := []plugininstaller.Archive{}
+		require.NoError(t, json.Unmarshal([]byte(installer.Env[0].Value), &archives))
+
+		want := []plugininstaller.Archive{
+			{Name: "oci-panel", Version: "1.0.0", OCI: "registry.local/plugins/oci-panel@sha256:" + checksum, DockerConfig: "/etc/grafana-plugin-archives/grafana-plugin-archive-0/config.json", SHA256: checksum},
+			{Name: "url-panel", Version: "1.0.0", URL: "https://plugins.local/url-panel.zip", SHA256: checksum},
+			{Name: "cm-panel", Version: "1.0.0", File: "/etc/grafana-plugin-archives/grafana-plugin-archive-1/archive.zip"},
+			{Name: "pvc-panel", Version: "1.0.0", File: "/etc/grafana-plugin-archives/grafana-plugin-archive-2/archives/pvc-panel.zip"},
+		}
+		assert.Equal(t, want, archives)
+
+		volumes := map[string]corev1.VolumeSource{}
+		for _, v := range spec.Template.Spec.Volumes {
+			volumes[v.Name] = v.VolumeSource
+		}
+
+		assert.Equal(t, "regcred", volumes["grafana-plugin-archive-0"].Secret.SecretName)
+		assert.Equal(t, "plugins", volumes["grafana-plugin-archive-1"].ConfigMap.Name)
+		assert.True(t, volumes["grafana-plugin-archive-2"].PersistentVolumeClaim.ReadOnly)
+		assert.Len(t, installer.VolumeMounts, 4, "the data volume and one volume per mounted source")
+
+		grafana := spec.Template.Spec.Containers[0]
+		assert.Contains(t, grafana.Env, corev1.EnvVar{Name: config.GrafanaPluginsEnvVar, Value: "grafana-piechart-panel"}, "archives are not installed from the plugin catalog")
+	})
+
+	t.Run("archives without an installer image", func(t *testing.T) {
+		vars := &v1beta1.OperatorReconcileVars{
+			PluginArchives: v1beta1.PluginList{
+				{Name: "url-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{URL: "https://plugins.local/url-panel.zip", SHA256: checksum}},
+			},
+		}
+
+		_, err := getDeploymentSpec(cr, "grafana", scheme.Scheme, vars, false, "")
+		require.ErrorContains(t, err, "--plugin-installer-image")
+	})
+}
+
 var _ = Describe("Deployment reconciler secrets hash", func() {
 	t := GinkgoT()
 
@@ -135,7 +216,7 @@ var _ = Describe("Deployment reconciler secrets hash", func() {
 		err = cl.Create(ctx, cr)
 		require.NoError(t, err)
 
-		r := NewDeploymentReconciler(cl, false)
+		r := NewDeploymentReconciler(cl, false, "")
 		vars := &v1beta1.OperatorReconcileVars{}
 
 		status, err := r.Reconcile(context.Background(), cr, vars, scheme.Scheme)
@@ -159,7 +240,7 @@ var _ = Describe("Deployment reconciler secrets hash", func() {
 		err := cl.Create(ctx, cr)
 		require.NoError(t, err)
 
-		r := NewDeploymentReconciler(cl, false)
+		r := NewDeploymentReconciler(cl, false, "")
 		vars := &v1beta1.OperatorReconcileVars{}
 
 		status, err := r.Reconcile(context.Background(), cr, vars, scheme.Scheme)
@@ -206,7 +287,7 @@ var _ = Describe("Deployment reconciler secrets hash", func() {
 		err = cl.Create(ctx, cr)
 		require.NoError(t, err)
 
-		r := NewDeploymentReconciler(cl, false)
+		r := NewDeploymentReconciler(cl, false, "")
 
 		vars1 := &v1beta1.OperatorReconcileVars{}
 		status, err := r.Reconcile(context.Background(), cr, vars1, scheme.Scheme)
@@ -254,7 +335,7 @@ var _ = Describe("Deployment reconciler secrets hash", func() {
 		err := cl.Create(ctx, cr)
 		require.NoError(t, err)
 
-		r := NewDeploymentReconciler(cl, false)
+		r := NewDeploymentReconciler(cl, false, "")
 		vars := &v1beta1.OperatorReconcileVars{}
 
 		status, err := r.Reconcile(context.Background(), cr, vars, scheme.Scheme)
@@ -280,7 +361,7 @@ var _ = Describe("Deployment reconciler scale subresource", func() {
 		err := cl.Create(ctx, cr)
 		require.NoError(t, err)
 
-		r := NewDeploymentReconciler(cl, false)
+		r := NewDeploymentReconciler(cl, false, "")
 		vars := &v1beta1.OperatorReconcileVars{}
 
 		status, err := r.Reconcile(context.Background(), cr, vars, scheme.Scheme)
@@ -303,7 +384,7 @@ var _ = Describe("Deployment reconciler scale subresource", func() {
 		err := cl.Create(ctx, cr)
 		require.NoError(t, err)
 
-		r := NewDeploymentReconciler(cl, false)
+		r := NewDeploymentReconciler(cl, false, "")
 		vars := &v1beta1.OperatorReconcileVars{}
 
 		status, err := r.Reconcile(context.Background(), cr, vars, scheme.Scheme)
@@ -339,7 +420,7 @@ var _ = Describe("Deployment reconciler scale subresource", func() {
 		err := cl.Create(ctx, cr)
 		require.NoError(t, err)
 
-		r := NewDeploymentReconciler(cl, false)
+		r := NewDeploymentReconciler(cl, false, "")
 		vars := &v1beta1.OperatorReconcileVars{}
 
 		status, err := r.Reconcile(context.Background(), cr, vars, scheme.Scheme)
@@ -354,3 +435,68 @@ var _ = Describe("Deployment reconciler scale subresource", func() {
 		assert.Equal(t, replicas, *deployment.Spec.Replicas)
 	})
 })
+
+var _ = Describe("Deployment reconciler high availability", func() {
+	t := GinkgoT()
+
+	It("sets replicas, database credentials and a PodDisruptionBudget", func() {
+		ctx := context.Background()
+
+		cr := &v1beta1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "deploy-high-availability",
+			},
+			Spec: v1beta1.GrafanaSpec{
+				HighAvailability: &v1beta1.GrafanaHighAvailability{
+					Replicas: new(int32(3)),
+					Database: v1beta1.GrafanaDatabase{
+						Type:     "postgres",
+						Host:     "postgres:5432",
+						Name:     "grafana",
+						User:     corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "user"},
+						Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password"},
+					},
+				},
+			},
+		}
+
+		err := cl.Create(ctx, cr)
+		require.NoError(t, err)
+
+		r := NewDeploymentReconciler(cl, false, "")
+
+		status, err := r.Reconcile(ctx, cr, &v1beta1.OperatorReconcileVars{}, scheme.Scheme)
+		require.NoError(t, err)
+		assert.Equal(t, v1beta1.OperatorStageResultSuccess, status)
+
+		deployment := &appsv1.Deployment{}
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-deployment"}, deployment)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+
+		env := deployment.Spec.Template.Spec.Containers[0].Env
+		assert.Contains(t, env, corev1.EnvVar{
+			Name:      config.GrafanaDatabasePasswordEnvVar,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &cr.Spec.HighAvailability.Database.Password},
+		})
+
+		pdb := &policyv1.PodDisruptionBudget{}
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-pdb"}, pdb)
+		require.NoError(t, err)
+		assert.Equal(t, 1, pdb.Spec.MaxUnavailable.IntValue())
+		assert.Equal(t, map[string]string{"app": cr.Name}, pdb.Spec.Selector.MatchLabels)
+
+		By("disabling high availability")
+
+		cr.Spec.HighAvailability = nil
+
+		_, err = r.Reconcile(ctx, cr, &v1beta1.OperatorReconcileVars{}, scheme.Scheme)
+		require.NoError(t, err)
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-pdb"}, pdb)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+})
@@ -3,16 +3,33 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
 	"github.com/grafana/grafana-operator/v5/controllers/reconcilers"
 	"github.com/grafana/grafana-operator/v5/controllers/resources"
+	"github.com/grafana/grafana-operator/v5/pkg/oci"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// conditionPluginsSynchronized reports whether the plugins of external instances were installed through the plugin admin API
+const conditionPluginsSynchronized = "PluginsSynchronized"
+
+// unsignedPluginSignatures are the signature states of installed plugins rejected by spec.pluginPolicy.rejectUnsigned
+var unsignedPluginSignatures = []string{"unsigned", "invalid", "modified"}
+
 type PluginsReconciler struct {
 	client client.Client
 }
@@ -47,27 +64,350 @@ func (r *PluginsReconciler) Reconcile(ctx context.Context, cr *v1beta1.Grafana,
 		return v1beta1.OperatorStageResultFailed, err
 	}
 
-	// plugins config map found, but may be empty
-	if len(cm.BinaryData) == 0 {
-		vars.Plugins = ""
-		return v1beta1.OperatorStageResultSuccess, nil
-	}
-
-	pm := v1beta1.NewPluginMap()
+	// Plugins of all resources, including those rejected as unsigned which are never installed
+	requested := v1beta1.NewPluginMap()
+	lists := []v1beta1.PluginList{}
 
-	for k, v := range cm.BinaryData {
+	// Sorted keys keep the chosen source stable when plugins of the same version have different sources
+	for _, k := range slices.Sorted(maps.Keys(cm.BinaryData)) {
 		var plugins v1beta1.PluginList
 
-		err = json.Unmarshal(v, &plugins)
+		err = json.Unmarshal(cm.BinaryData[k], &plugins)
 		if err != nil {
 			log.Error(err, "error consolidating plugins from ConfigMap", "name", cm.Name, "namespace", cm.Namespace, "key", k)
 			return v1beta1.OperatorStageResultFailed, err
 		}
 
-		pm.Merge(plugins)
+		requested.Merge(plugins)
+		lists = append(lists, plugins)
+	}
+
+	// External instances install plugins through the plugin admin API instead of GF_INSTALL_PLUGINS. Errors are only
+	// reported in the PluginsSynchronized condition, the instance stays usable for other resources meanwhile
+	if cr.IsExternal() {
+		err = r.syncExternalInstance(ctx, cr, requested, lists)
+		if err != nil {
+			log.Error(err, "failed to synchronize plugins of external instance")
+		}
+
+		setPluginsSynchronizedCondition(cr, err)
+
+		return v1beta1.OperatorStageResultSuccess, nil
+	}
+
+	meta.RemoveStatusCondition(&cr.Status.Conditions, conditionPluginsSynchronized)
+
+	cr.Status.Plugins = nil
+
+	// Plugins can only be inspected once the instance is running. Grafana refuses to load unsigned plugins meanwhile,
+	// signature errors are attributed to the requested versions
+	if cr.Status.Version != "" {
+		gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.client, cr)
+		if err == nil {
+			err = recordUnsignedPlugins(ctx, gClient, cr, requested.GetPluginList(), mergeSignedPlugins(cr, lists))
+		}
+
+		if err != nil {
+			log.Error(err, "failed to look up unsigned plugins")
+		}
+	}
+
+	catalog, archives := mergeSignedPlugins(cr, lists).Split()
+
+	resolved, err := r.resolvePluginArchives(ctx, cr, archives)
+	if err != nil {
+		return v1beta1.OperatorStageResultFailed, err
 	}
 
-	vars.Plugins = pm.GetPluginList().String()
+	vars.Plugins = catalog.String()
+	vars.PluginArchives = resolved
 
 	return v1beta1.OperatorStageResultSuccess, nil
 }
+
+// syncExternalInstance records the unsigned plugins of an external instance and installs the requested plugins
+func (r *PluginsReconciler) syncExternalInstance(ctx context.Context, cr *v1beta1.Grafana, requested v1beta1.PluginMap, lists []v1beta1.PluginList) error {
+	log := logf.FromContext(ctx)
+
+	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.client, cr)
+	if err != nil {
+		return fmt.Errorf("creating Grafana client: %w", err)
+	}
+
+	// Signature errors are attributed to the versions installed by the operator
+	err = recordUnsignedPlugins(ctx, gClient, cr, requested.GetPluginList(), cr.Status.Plugins)
+	if err != nil {
+		return err
+	}
+
+	catalog, archives := mergeSignedPlugins(cr, lists).Split()
+	if len(archives) > 0 {
+		log.Info("ignoring plugins with a source, external instances only install plugins from the plugin catalog", "plugins", archives.String())
+	}
+
+	// Unsigned plugins installed by the operator are uninstalled again
+	return syncExternalPlugins(ctx, gClient, cr, catalog)
+}
+
+func setPluginsSynchronizedCondition(cr *v1beta1.Grafana, err error) {
+	condition := metav1.Condition{
+		Type:               conditionPluginsSynchronized,
+		Reason:             "ApplySuccessful",
+		Message:            "Plugins were successfully synchronized",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		LastTransitionTime: metav1.Time{Time: time.Now()},
+	}
+
+	if err != nil {
+		condition.Reason = "ApplyFailed"
+		condition.Message = err.Error()
+		condition.Status = metav1.ConditionFalse
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+}
+
+// resolvePluginArchives pins the OCI sources of plugins to the digest of their manifests, so that all pods install
+// the same archive and tags moving to a new digest roll out the deployment
+func (r *PluginsReconciler) resolvePluginArchives(ctx context.Context, cr *v1beta1.Grafana, archives v1beta1.PluginList) (v1beta1.PluginList, error) {
+	resolved := make(v1beta1.PluginList, 0, len(archives))
+
+	for _, plugin := range archives {
+		if plugin.Source.OCI != nil {
+			reference, checksum, err := oci.ResolvePluginArchive(ctx, r.client, cr.Namespace, plugin.Source.OCI)
+			if err != nil {
+				return nil, fmt.Errorf("resolving archive of plugin %s: %w", plugin.Name, err)
+			}
+
+			if plugin.Source.SHA256 != "" && plugin.Source.SHA256 != checksum {
+				return nil, fmt.Errorf("checksum of plugin %s does not match: expected sha256 %s, got %s", plugin.Name, plugin.Source.SHA256, checksum)
+			}
+
+			plugin.Source = plugin.Source.DeepCopy()
+			plugin.Source.OCI.Reference = reference
+			plugin.Source.SHA256 = checksum
+		}
+
+		resolved = append(resolved, plugin)
+	}
+
+	return resolved, nil
+}
+
+// syncExternalPlugins installs, upgrades and uninstalls plugins through the plugin admin API and records the installed versions.
+// Plugins requested with the latest version are only installed if missing. Only plugins recorded in the status,
+// i.e. installed by the operator, are uninstalled once no longer requested
+func syncExternalPlugins(ctx context.Context, gClient *genapi.GrafanaHTTPAPI, cr *v1beta1.Grafana, desired v1beta1.PluginList) error {
+	log := logf.FromContext(ctx)
+
+	if len(desired) == 0 && len(cr.Status.Plugins) == 0 {
+		return nil
+	}
+
+	installed, err := installedPluginVersions(gClient)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	errs := []error{}
+
+	// Only plugins installed or upgraded by the operator are managed, plugins installed otherwise are never uninstalled
+	installedByOperator := map[string]bool{}
+	for _, plugin := range cr.Status.Plugins {
+		installedByOperator[plugin.Name] = true
+	}
+
+	for _, plugin := range desired {
+		current, ok := installed[plugin.Name]
+		if ok && (plugin.Version == v1beta1.PluginVersionLatest || plugin.Version == current) {
+			continue
+		}
+
+		version := plugin.Version
+		if version == v1beta1.PluginVersionLatest {
+			version = ""
+		}
+
+		err := grafanaclient.InstallPlugin(gClient, plugin.Name, version)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		log.Info("installed plugin", "plugin", plugin.Name, "version", plugin.Version, "previousVersion", current)
+
+		installedByOperator[plugin.Name] = true
+		changed = true
+	}
+
+	// Plugins which failed to uninstall stay in the status to be retried
+	managed := v1beta1.PluginList{}
+
+	for _, plugin := range cr.Status.Plugins {
+		if slices.ContainsFunc(desired, func(p v1beta1.GrafanaPlugin) bool { return p.Name == plugin.Name }) {
+			continue
+		}
+
+		if _, ok := installed[plugin.Name]; !ok {
+			continue
+		}
+
+		err := grafanaclient.UninstallPlugin(gClient, plugin.Name)
+		if err != nil {
+			errs = append(errs, err)
+			managed = append(managed, plugin)
+
+			continue
+		}
+
+		log.Info("uninstalled plugin", "plugin", plugin.Name)
+
+		changed = true
+	}
+
+	if changed {
+		installed, err = installedPluginVersions(gClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, plugin := range desired {
+		if !installedByOperator[plugin.Name] {
+			continue
+		}
+
+		if version, ok := installed[plugin.Name]; ok {
+			managed = append(managed, v1beta1.GrafanaPlugin{Name: plugin.Name, Version: version})
+		}
+	}
+
+	slices.SortFunc(managed, func(a, b v1beta1.GrafanaPlugin) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	cr.Status.Plugins = managed
+
+	return errors.Join(errs...)
+}
+
+// recordUnsignedPlugins adds the requested plugins the instance reports as unsigned to the status while the plugin
+// policy rejects unsigned plugins, ReconcilePlugins rejects them on the requesting resources from then on.
+// Signatures are only known once plugins are installed, loaded lists the versions the instance was asked to load.
+// Entries of plugins no longer requested, or since reported as signed, are removed again
+func recordUnsignedPlugins(ctx context.Context, gClient *genapi.GrafanaHTTPAPI, cr *v1beta1.Grafana, requested, loaded v1beta1.PluginList) error {
+	log := logf.FromContext(ctx)
+
+	if cr.Spec.PluginPolicy == nil || !cr.Spec.PluginPolicy.RejectUnsigned || len(requested) == 0 {
+		cr.Status.UnsignedPlugins = nil
+		return nil
+	}
+
+	unsigned, signed, err := pluginSignatures(gClient, loaded)
+	if err != nil {
+		return err
+	}
+
+	cr.Status.UnsignedPlugins = slices.DeleteFunc(cr.Status.UnsignedPlugins, func(p v1beta1.GrafanaPlugin) bool {
+		return !slices.ContainsFunc(requested, func(r v1beta1.GrafanaPlugin) bool { return samePluginVersion(p, r) }) ||
+			signed[p.Name] == p.Version
+	})
+
+	for _, plugin := range requested {
+		version, ok := unsigned[plugin.Name]
+		if !ok || (plugin.Version != version && plugin.Version != v1beta1.PluginVersionLatest) || isUnsignedPlugin(cr, plugin) {
+			continue
+		}
+
+		log.Info("rejecting unsigned plugin", "plugin", plugin.Name, "version", plugin.Version, "installedVersion", version)
+
+		cr.Status.UnsignedPlugins = append(cr.Status.UnsignedPlugins, v1beta1.GrafanaPlugin{Name: plugin.Name, Version: plugin.Version})
+	}
+
+	slices.SortFunc(cr.Status.UnsignedPlugins, func(a, b v1beta1.GrafanaPlugin) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return nil
+}
+
+// pluginSignatures returns the versions of the installed plugins without and with a valid signature. Plugins Grafana
+// refused to load due to their signature are not listed as installed, their version is looked up in loaded
+func pluginSignatures(gClient *genapi.GrafanaHTTPAPI, loaded v1beta1.PluginList) (map[string]string, map[string]string, error) {
+	installed, err := grafanaclient.ListInstalledPlugins(gClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pluginErrors, err := grafanaclient.ListPluginErrors(gClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unsigned := map[string]string{}
+	signed := map[string]string{}
+
+	for _, plugin := range installed {
+		if slices.Contains(unsignedPluginSignatures, plugin.Signature) {
+			unsigned[plugin.ID] = plugin.Info.Version
+		} else {
+			signed[plugin.ID] = plugin.Info.Version
+		}
+	}
+
+	for _, pluginError := range pluginErrors {
+		if !strings.HasPrefix(pluginError.ErrorCode, "signature") {
+			continue
+		}
+
+		idx := slices.IndexFunc(loaded, func(p v1beta1.GrafanaPlugin) bool { return p.Name == pluginError.PluginID })
+		if idx != -1 {
+			unsigned[pluginError.PluginID] = loaded[idx].Version
+		}
+	}
+
+	return unsigned, signed, nil
+}
+
+func samePluginVersion(a, b v1beta1.GrafanaPlugin) bool {
+	return a.Name == b.Name && a.Version == b.Version
+}
+
+func isUnsignedPlugin(cr *v1beta1.Grafana, plugin v1beta1.GrafanaPlugin) bool {
+	return slices.ContainsFunc(cr.Status.UnsignedPlugins, func(p v1beta1.GrafanaPlugin) bool {
+		return samePluginVersion(p, plugin)
+	})
+}
+
+// mergeSignedPlugins merges the plugins requested by resources, leaving out plugins rejected as unsigned. These stay
+// in the plugins ConfigMap to keep them rejected while requested, other resources may request signed versions
+func mergeSignedPlugins(cr *v1beta1.Grafana, lists []v1beta1.PluginList) v1beta1.PluginList {
+	pm := v1beta1.NewPluginMap()
+
+	for _, plugins := range lists {
+		if cr.Spec.PluginPolicy != nil && cr.Spec.PluginPolicy.RejectUnsigned {
+			plugins = slices.DeleteFunc(slices.Clone(plugins), func(p v1beta1.GrafanaPlugin) bool {
+				return isUnsignedPlugin(cr, p)
+			})
+		}
+
+		pm.Merge(plugins)
+	}
+
+	return pm.GetPluginList()
+}
+
+func installedPluginVersions(gClient *genapi.GrafanaHTTPAPI) (map[string]string, error) {
+	plugins, err := grafanaclient.ListInstalledPlugins(gClient)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string, len(plugins))
+	for _, plugin := range plugins {
+		versions[plugin.ID] = plugin.Info.Version
+	}
+
+	return versions, nil
+}
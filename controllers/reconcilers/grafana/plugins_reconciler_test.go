@@ -0,0 +1,356 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakePluginAPI serves the plugin admin API of Grafana from memory, installing the latest version resolves to 9.9.9
+type fakePluginAPI struct {
+	url     string
+	mu      sync.Mutex
+	plugins map[string]string
+	// signatures of installed plugins, valid unless set
+	signatures map[string]string
+	// errors lists the plugins failing to load and their error code
+	errors map[string]string
+}
+
+func newFakePluginAPI(t *testing.T, installed map[string]string) (*fakePluginAPI, *genapi.GrafanaHTTPAPI) {
+	t.Helper()
+
+	api := &fakePluginAPI{plugins: installed, signatures: map[string]string{}, errors: map[string]string{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/plugins", func(w http.ResponseWriter, _ *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		list := []map[string]any{}
+
+		for id, version := range api.plugins {
+			signature := api.signatures[id]
+			if signature == "" {
+				signature = "valid"
+			}
+
+			list = append(list, map[string]any{"id": id, "info": map[string]string{"version": version}, "signature": signature})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /api/plugins/errors", func(w http.ResponseWriter, _ *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		list := []map[string]string{}
+		for id, code := range api.errors {
+			list = append(list, map[string]string{"pluginId": id, "errorCode": code})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list) //nolint:errcheck
+	})
+	mux.HandleFunc("POST /api/plugins/{id}/install", func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Version string `json:"version"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+
+		if r.PathValue("id") == "missing-panel" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Plugin not found"}`)) //nolint:errcheck
+
+			return
+		}
+
+		if body.Version == "" {
+			body.Version = "9.9.9"
+		}
+
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		api.plugins[r.PathValue("id")] = body.Version
+	})
+	mux.HandleFunc("POST /api/plugins/{id}/uninstall", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		if _, ok := api.plugins[r.PathValue("id")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		delete(api.plugins, r.PathValue("id"))
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	api.url = ts.URL
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	return api, genapi.NewHTTPClientWithConfig(nil, &genapi.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	})
+}
+
+func TestSyncExternalPlugins(t *testing.T) {
+	ctx := context.Background()
+
+	api, gClient := newFakePluginAPI(t, map[string]string{
+		"grafana-clock-panel": "2.1.0",
+		"preinstalled-app":    "1.0.0",
+	})
+
+	cr := &v1beta1.Grafana{}
+
+	t.Run("missing plugins are installed and outdated ones upgraded", func(t *testing.T) {
+		desired := v1beta1.NewPluginMapFromList(v1beta1.PluginList{
+			{Name: "grafana-clock-panel", Version: "2.1.0"},
+			{Name: "grafana-clock-panel", Version: "2.1.8"},
+			{Name: "grafana-piechart-panel", Version: "latest"},
+		})
+
+		require.NoError(t, syncExternalPlugins(ctx, gClient, cr, desired.GetPluginList()))
+
+		assert.Equal(t, v1beta1.PluginList{
+			{Name: "grafana-clock-panel", Version: "2.1.8"},
+			{Name: "grafana-piechart-panel", Version: "9.9.9"},
+		}, cr.Status.Plugins)
+	})
+
+	t.Run("plugins installed with the latest version are not upgraded", func(t *testing.T) {
+		api.plugins["grafana-piechart-panel"] = "9.0.0"
+
+		require.NoError(t, syncExternalPlugins(ctx, gClient, cr, v1beta1.PluginList{
+			{Name: "grafana-clock-panel", Version: "2.1.8"},
+			{Name: "grafana-piechart-panel", Version: "latest"},
+		}))
+
+		assert.Equal(t, "9.0.0", api.plugins["grafana-piechart-panel"])
+	})
+
+	t.Run("failed installations are reported", func(t *testing.T) {
+		err := syncExternalPlugins(ctx, gClient, cr, v1beta1.PluginList{
+			{Name: "grafana-clock-panel", Version: "2.1.8"},
+			{Name: "grafana-piechart-panel", Version: "latest"},
+			{Name: "missing-panel", Version: "1.0.0"},
+		})
+		require.ErrorContains(t, err, "installing plugin missing-panel failed with status 404: Plugin not found")
+
+		assert.Len(t, cr.Status.Plugins, 2)
+	})
+
+	t.Run("requested plugins installed otherwise are not managed", func(t *testing.T) {
+		require.NoError(t, syncExternalPlugins(ctx, gClient, cr, v1beta1.PluginList{
+			{Name: "grafana-clock-panel", Version: "2.1.8"},
+			{Name: "grafana-piechart-panel", Version: "latest"},
+			{Name: "preinstalled-app", Version: "latest"},
+		}))
+
+		assert.NotContains(t, cr.Status.Plugins, v1beta1.GrafanaPlugin{Name: "preinstalled-app", Version: "1.0.0"})
+	})
+
+	t.Run("plugins no longer requested are uninstalled", func(t *testing.T) {
+		require.NoError(t, syncExternalPlugins(ctx, gClient, cr, v1beta1.PluginList{
+			{Name: "grafana-clock-panel", Version: "2.1.8"},
+		}))
+
+		assert.NotContains(t, api.plugins, "grafana-piechart-panel")
+		assert.Contains(t, api.plugins, "preinstalled-app", "plugins not installed by the operator are kept")
+		assert.Equal(t, v1beta1.PluginList{{Name: "grafana-clock-panel", Version: "2.1.8"}}, cr.Status.Plugins)
+	})
+}
+
+func TestRecordUnsignedPlugins(t *testing.T) {
+	ctx := context.Background()
+
+	api, gClient := newFakePluginAPI(t, map[string]string{
+		"grafana-clock-panel": "2.1.8",
+		"unsigned-panel":      "1.0.0",
+		"unsigned-app":        "1.0.0",
+	})
+	api.signatures["unsigned-panel"] = "unsigned"
+	api.errors["broken-panel"] = "signatureModified"
+
+	requested := v1beta1.PluginList{
+		{Name: "grafana-clock-panel", Version: "2.1.8"},
+		{Name: "unsigned-panel", Version: "1.0.0"},
+		{Name: "broken-panel", Version: "2.0.0"},
+	}
+	rejectUnsigned := v1beta1.GrafanaSpec{PluginPolicy: &v1beta1.GrafanaPluginPolicy{RejectUnsigned: true}}
+
+	t.Run("without a policy nothing is recorded", func(t *testing.T) {
+		cr := &v1beta1.Grafana{Status: v1beta1.GrafanaStatus{UnsignedPlugins: v1beta1.PluginList{{Name: "stale-panel", Version: "1.0.0"}}}}
+
+		require.NoError(t, recordUnsignedPlugins(ctx, gClient, cr, requested, requested))
+		assert.Nil(t, cr.Status.UnsignedPlugins)
+		assert.ElementsMatch(t, requested, mergeSignedPlugins(cr, []v1beta1.PluginList{requested}))
+	})
+
+	t.Run("requested unsigned versions are recorded", func(t *testing.T) {
+		cr := &v1beta1.Grafana{Spec: rejectUnsigned}
+
+		require.NoError(t, recordUnsignedPlugins(ctx, gClient, cr, requested, requested))
+		require.NoError(t, recordUnsignedPlugins(ctx, gClient, cr, requested, requested))
+
+		expected := v1beta1.PluginList{
+			{Name: "broken-panel", Version: "2.0.0"},
+			{Name: "unsigned-panel", Version: "1.0.0"},
+		}
+		assert.Equal(t, expected, cr.Status.UnsignedPlugins, "plugins not requested are ignored, recorded plugins are not duplicated")
+		assert.Equal(t, v1beta1.PluginList{{Name: "grafana-clock-panel", Version: "2.1.8"}}, mergeSignedPlugins(cr, []v1beta1.PluginList{requested}))
+	})
+
+	t.Run("other versions of unsigned plugins are not rejected", func(t *testing.T) {
+		cr := &v1beta1.Grafana{Spec: rejectUnsigned}
+
+		upgrade := v1beta1.PluginList{{Name: "unsigned-panel", Version: "1.1.0"}}
+
+		require.NoError(t, recordUnsignedPlugins(ctx, gClient, cr, upgrade, upgrade))
+		assert.Empty(t, cr.Status.UnsignedPlugins)
+		assert.Equal(t, upgrade, mergeSignedPlugins(cr, []v1beta1.PluginList{upgrade}))
+	})
+
+	t.Run("signed versions of other resources are installed", func(t *testing.T) {
+		cr := &v1beta1.Grafana{Spec: rejectUnsigned, Status: v1beta1.GrafanaStatus{UnsignedPlugins: v1beta1.PluginList{{Name: "unsigned-panel", Version: "1.2.0"}}}}
+
+		lists := []v1beta1.PluginList{
+			{{Name: "unsigned-panel", Version: "1.2.0"}},
+			{{Name: "unsigned-panel", Version: "1.1.0"}},
+		}
+		assert.Equal(t, v1beta1.PluginList{{Name: "unsigned-panel", Version: "1.1.0"}}, mergeSignedPlugins(cr, lists))
+	})
+
+	t.Run("entries no longer requested or since signed are removed", func(t *testing.T) {
+		cr := &v1beta1.Grafana{Spec: rejectUnsigned, Status: v1beta1.GrafanaStatus{UnsignedPlugins: v1beta1.PluginList{
+			{Name: "grafana-clock-panel", Version: "2.1.8"},
+			{Name: "removed-panel", Version: "1.0.0"},
+			{Name: "unsigned-panel", Version: "1.0.0"},
+		}}}
+
+		require.NoError(t, recordUnsignedPlugins(ctx, gClient, cr, requested[:2], requested[:2]))
+		assert.Equal(t, v1beta1.PluginList{{Name: "unsigned-panel", Version: "1.0.0"}}, cr.Status.UnsignedPlugins)
+
+		require.NoError(t, recordUnsignedPlugins(ctx, gClient, cr, nil, nil))
+		assert.Nil(t, cr.Status.UnsignedPlugins)
+	})
+
+	t.Run("operator installed unsigned plugins are uninstalled", func(t *testing.T) {
+		cr := &v1beta1.Grafana{
+			Spec:   rejectUnsigned,
+			Status: v1beta1.GrafanaStatus{Plugins: v1beta1.PluginList{{Name: "unsigned-panel", Version: "1.0.0"}}},
+		}
+
+		require.NoError(t, recordUnsignedPlugins(ctx, gClient, cr, requested, cr.Status.Plugins))
+		assert.Equal(t, v1beta1.PluginList{{Name: "unsigned-panel", Version: "1.0.0"}}, cr.Status.UnsignedPlugins,
+			"signature errors of plugins not installed by the operator cannot be attributed to a version")
+
+		require.NoError(t, syncExternalPlugins(ctx, gClient, cr, mergeSignedPlugins(cr, []v1beta1.PluginList{requested[:2]})))
+
+		assert.NotContains(t, api.plugins, "unsigned-panel")
+		assert.Contains(t, api.plugins, "unsigned-app", "plugins not installed by the operator are kept")
+	})
+}
+
+func TestPluginsReconcilerSplitsArchives(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, v1beta1.AddToScheme(s))
+
+	cr := &v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "default"}}
+	checksum := strings.Repeat("a", 64)
+
+	dashboardPlugins, err := json.Marshal(v1beta1.PluginList{
+		{Name: "grafana-clock-panel", Version: "2.1.8"},
+		{Name: "internal-panel", Version: "1.0.0", Source: &v1beta1.GrafanaPluginSource{URL: "https://plugins.local/internal-panel.zip", SHA256: checksum}},
+	})
+	require.NoError(t, err)
+
+	cm := resources.GetPluginsConfigMap(cr, s)
+	cm.BinaryData = map[string][]byte{"dashboard": dashboardPlugins}
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(cm).Build()
+	vars := &v1beta1.OperatorReconcileVars{}
+
+	status, err := NewPluginsReconciler(cl).Reconcile(context.Background(), cr, vars, s)
+	require.NoError(t, err)
+	assert.Equal(t, v1beta1.OperatorStageResultSuccess, status)
+
+	assert.Equal(t, "grafana-clock-panel 2.1.8", vars.Plugins)
+	require.Len(t, vars.PluginArchives, 1)
+	assert.Equal(t, "internal-panel", vars.PluginArchives[0].Name)
+}
+
+func TestPluginsReconcilerExternalErrors(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, v1beta1.AddToScheme(s))
+
+	api, _ := newFakePluginAPI(t, map[string]string{})
+
+	apiKey := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "grafana-api-key", Namespace: "default"},
+		Data:       map[string][]byte{"key": []byte("key")},
+	}
+
+	cr := &v1beta1.Grafana{
+		ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "default"},
+		Spec: v1beta1.GrafanaSpec{
+			External: &v1beta1.External{
+				URL: api.url,
+				APIKey: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: apiKey.Name},
+					Key:                  "key",
+				},
+			},
+		},
+		Status: v1beta1.GrafanaStatus{AdminURL: api.url},
+	}
+
+	dashboardPlugins, err := json.Marshal(v1beta1.PluginList{
+		{Name: "grafana-clock-panel", Version: "2.1.8"},
+		{Name: "missing-panel", Version: "1.0.0"},
+	})
+	require.NoError(t, err)
+
+	cm := resources.GetPluginsConfigMap(cr, s)
+	cm.BinaryData = map[string][]byte{"dashboard": dashboardPlugins}
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(apiKey, cm).Build()
+
+	status, err := NewPluginsReconciler(cl).Reconcile(context.Background(), cr, &v1beta1.OperatorReconcileVars{}, s)
+	require.NoError(t, err, "plugin errors of external instances do not fail the stage")
+	assert.Equal(t, v1beta1.OperatorStageResultSuccess, status)
+
+	assert.Equal(t, v1beta1.PluginList{{Name: "grafana-clock-panel", Version: "2.1.8"}}, cr.Status.Plugins)
+
+	condition := meta.FindStatusCondition(cr.Status.Conditions, conditionPluginsSynchronized)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, "installing plugin missing-panel failed")
+}
@@ -0,0 +1,242 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/config"
+	"github.com/grafana/grafana-operator/v5/controllers/reconcilers"
+	"github.com/grafana/grafana-operator/v5/controllers/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type RendererReconciler struct {
+	client      client.Client
+	isOpenShift bool
+}
+
+func NewRendererReconciler(cl client.Client, isOpenShift bool) reconcilers.OperatorGrafanaReconciler {
+	return &RendererReconciler{
+		client:      cl,
+		isOpenShift: isOpenShift,
+	}
+}
+
+func (r *RendererReconciler) Reconcile(ctx context.Context, cr *v1beta1.Grafana, _ *v1beta1.OperatorReconcileVars, scheme *runtime.Scheme) (v1beta1.OperatorStageStatus, error) {
+	log := logf.FromContext(ctx).WithName("RendererReconciler")
+
+	deployment := resources.GetGrafanaRendererDeployment(cr, scheme)
+	service := resources.GetGrafanaRendererService(cr, scheme)
+	secret := resources.GetGrafanaRendererSecret(cr, scheme)
+
+	if cr.Spec.Renderer == nil {
+		err := r.delete(ctx, deployment, service, secret)
+		if err != nil {
+			return v1beta1.OperatorStageResultFailed, err
+		}
+
+		return v1beta1.OperatorStageResultSuccess, nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.client, secret, func() error {
+		// keep the token across reconciliations, changing it requires restarting Grafana and the renderer
+		if len(secret.Data[config.RendererTokenSecretKey]) == 0 {
+			secret.Data = map[string][]byte{
+				config.RendererTokenSecretKey: []byte(randStringRunes(32)),
+			}
+		}
+
+		if scheme != nil {
+			err := controllerutil.SetControllerReference(cr, secret, scheme)
+			if err != nil {
+				return err
+			}
+		}
+
+		resources.SetInheritedLabels(secret, cr.Labels)
+
+		return nil
+	})
+	if err != nil {
+		return v1beta1.OperatorStageResultFailed, err
+	}
+
+	if !cr.Spec.Renderer.IsDeployment() {
+		log.V(1).Info("renderer runs as sidecar")
+
+		err = r.delete(ctx, deployment, service)
+		if err != nil {
+			return v1beta1.OperatorStageResultFailed, err
+		}
+
+		return v1beta1.OperatorStageResultSuccess, nil
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.client, deployment, func() error {
+		deployment.Spec = appsv1.DeploymentSpec{
+			Replicas: cr.Spec.Renderer.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: getRendererSelectorLabels(cr),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: getRendererSelectorLabels(cr),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{getRendererContainer(cr, scheme, r.isOpenShift)},
+					Volumes: []corev1.Volume{
+						{
+							Name: config.GrafanaTmpVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					SecurityContext: getDefaultPodSecurityContext(cr.Spec.DisableDefaultSecurityContext),
+				},
+			},
+		}
+
+		if scheme != nil {
+			err := controllerutil.SetControllerReference(cr, deployment, scheme)
+			if err != nil {
+				return err
+			}
+		}
+
+		resources.SetInheritedLabels(deployment, cr.Labels)
+
+		return nil
+	})
+	if err != nil {
+		return v1beta1.OperatorStageResultFailed, err
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.client, service, func() error {
+		service.Spec = corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       config.GrafanaRendererPortName,
+					Protocol:   protocolTCP,
+					Port:       int32(config.GrafanaRendererPort),
+					TargetPort: intstr.FromString(config.GrafanaRendererPortName),
+				},
+			},
+			Selector: getRendererSelectorLabels(cr),
+			Type:     corev1.ServiceTypeClusterIP,
+		}
+
+		if scheme != nil {
+			err := controllerutil.SetControllerReference(cr, service, scheme)
+			if err != nil {
+				return err
+			}
+		}
+
+		resources.SetInheritedLabels(service, cr.Labels)
+
+		return nil
+	})
+	if err != nil {
+		return v1beta1.OperatorStageResultFailed, err
+	}
+
+	return v1beta1.OperatorStageResultSuccess, nil
+}
+
+func (r *RendererReconciler) delete(ctx context.Context, objects ...client.Object) error {
+	for _, obj := range objects {
+		err := r.client.Delete(ctx, obj)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting renderer %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func getRendererSelectorLabels(cr *v1beta1.Grafana) map[string]string {
+	return map[string]string{
+		appLabel: fmt.Sprintf("%s-renderer", cr.Name),
+	}
+}
+
+// getRendererContainer returns the renderer container, used both as sidecar and in the renderer Deployment
+func getRendererContainer(cr *v1beta1.Grafana, scheme *runtime.Scheme, openshiftPlatform bool) corev1.Container {
+	secret := resources.GetGrafanaRendererSecret(cr, scheme)
+
+	image := cr.Spec.Renderer.Image
+	if image == "" {
+		image = fmt.Sprintf("%s:%s", config.GrafanaRendererImage, config.GrafanaRendererVersion)
+	}
+
+	container := corev1.Container{
+		Name:  "grafana-image-renderer",
+		Image: image,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          config.GrafanaRendererPortName,
+				ContainerPort: int32(config.GrafanaRendererPort),
+				Protocol:      protocolTCP,
+			},
+		},
+		Env: []corev1.EnvVar{
+			{
+				Name:  "HTTP_PORT",
+				Value: fmt.Sprint(config.GrafanaRendererPort),
+			},
+			{
+				Name: config.RendererAuthTokenEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: config.RendererTokenSecretKey,
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				// chromium needs a writable /tmp
+				Name:      config.GrafanaTmpVolumeName,
+				MountPath: config.GrafanaTmpPath,
+			},
+		},
+		ImagePullPolicy: "IfNotPresent",
+		SecurityContext: getDefaultContainerSecurityContext(cr.Spec.DisableDefaultSecurityContext, openshiftPlatform),
+	}
+
+	if cr.Spec.Renderer.Resources != nil {
+		container.Resources = *cr.Spec.Renderer.Resources
+	}
+
+	return container
+}
+
+// getRendererURLs returns the URL Grafana sends render requests to and the URL the renderer loads Grafana from
+func getRendererURLs(cr *v1beta1.Grafana) (string, string) {
+	protocol := getGrafanaServerProtocol(cr)
+	port := GetGrafanaPort(cr)
+
+	if cr.Spec.Renderer.IsDeployment() {
+		renderer := resources.GetGrafanaRendererService(cr, nil)
+		grafana := resources.GetGrafanaService(cr, nil)
+
+		return fmt.Sprintf("http://%s.%s:%d/render", renderer.Name, cr.Namespace, config.GrafanaRendererPort),
+			fmt.Sprintf("%s://%s.%s:%d/", protocol, grafana.Name, cr.Namespace, port)
+	}
+
+	return fmt.Sprintf("http://localhost:%d/render", config.GrafanaRendererPort),
+		fmt.Sprintf("%s://localhost:%d/", protocol, port)
+}
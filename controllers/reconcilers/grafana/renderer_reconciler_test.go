@@ -0,0 +1,133 @@
+package grafana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/config"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestGetRendererURLs(t *testing.T) {
+	tests := []struct {
+		name            string
+		renderer        v1beta1.GrafanaRenderer
+		wantServerURL   string
+		wantCallbackURL string
+	}{
+		{
+			name:            "sidecar",
+			renderer:        v1beta1.GrafanaRenderer{Mode: v1beta1.RendererModeSidecar},
+			wantServerURL:   "http://localhost:8081/render",
+			wantCallbackURL: "http://localhost:3000/",
+		},
+		{
+			name:            "deployment",
+			renderer:        v1beta1.GrafanaRenderer{Mode: v1beta1.RendererModeDeployment},
+			wantServerURL:   "http://grafana-renderer.monitoring:8081/render",
+			wantCallbackURL: "http://grafana-service.monitoring:3000/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1beta1.Grafana{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "grafana"},
+				Spec:       v1beta1.GrafanaSpec{Renderer: &tt.renderer},
+			}
+
+			serverURL, callbackURL := getRendererURLs(cr)
+
+			assert.Equal(t, tt.wantServerURL, serverURL)
+			assert.Equal(t, tt.wantCallbackURL, callbackURL)
+		})
+	}
+}
+
+var _ = Describe("Renderer reconciler", func() {
+	t := GinkgoT()
+
+	It("deploys the renderer as sidecar or separate Deployment", func() {
+		ctx := context.Background()
+
+		cr := &v1beta1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "renderer",
+			},
+			Spec: v1beta1.GrafanaSpec{
+				Renderer: &v1beta1.GrafanaRenderer{
+					Mode:     v1beta1.RendererModeDeployment,
+					Replicas: new(int32(2)),
+				},
+			},
+		}
+
+		err := cl.Create(ctx, cr)
+		require.NoError(t, err)
+
+		r := NewRendererReconciler(cl, false)
+
+		status, err := r.Reconcile(ctx, cr, &v1beta1.OperatorReconcileVars{}, scheme.Scheme)
+		require.NoError(t, err)
+		assert.Equal(t, v1beta1.OperatorStageResultSuccess, status)
+
+		secret := &corev1.Secret{}
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-renderer-token"}, secret)
+		require.NoError(t, err)
+		require.NotEmpty(t, secret.Data[config.RendererTokenSecretKey])
+
+		token := secret.Data[config.RendererTokenSecretKey]
+
+		deployment := &appsv1.Deployment{}
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-renderer"}, deployment)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+		assert.Equal(t, config.GrafanaRendererImage+":"+config.GrafanaRendererVersion, deployment.Spec.Template.Spec.Containers[0].Image)
+
+		service := &corev1.Service{}
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-renderer"}, service)
+		require.NoError(t, err)
+		assert.Equal(t, int32(config.GrafanaRendererPort), service.Spec.Ports[0].Port)
+
+		By("switching to sidecar mode")
+
+		cr.Spec.Renderer.Mode = v1beta1.RendererModeSidecar
+
+		_, err = r.Reconcile(ctx, cr, &v1beta1.OperatorReconcileVars{}, scheme.Scheme)
+		require.NoError(t, err)
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-renderer"}, deployment)
+		assert.True(t, apierrors.IsNotFound(err))
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-renderer-token"}, secret)
+		require.NoError(t, err)
+		assert.Equal(t, token, secret.Data[config.RendererTokenSecretKey], "token must be kept")
+
+		containers := getContainers(cr, scheme.Scheme, &v1beta1.OperatorReconcileVars{}, false)
+		require.Len(t, containers, 2)
+		assert.Equal(t, "grafana-image-renderer", containers[1].Name)
+
+		By("removing the renderer")
+
+		cr.Spec.Renderer = nil
+
+		_, err = r.Reconcile(ctx, cr, &v1beta1.OperatorReconcileVars{}, scheme.Scheme)
+		require.NoError(t, err)
+
+		err = cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-renderer-token"}, secret)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+})
@@ -90,6 +90,8 @@ func (r *ServiceReconciler) Reconcile(ctx context.Context, cr *v1beta1.Grafana,
 				appLabel: cr.Name,
 			},
 			Type: corev1.ServiceTypeClusterIP,
+			// replicas need to find their peers before they become ready
+			PublishNotReadyAddresses: cr.Spec.HighAvailability != nil,
 		}
 
 		return nil
@@ -136,8 +138,8 @@ func getServicePorts(cr *v1beta1.Grafana) []corev1.ServicePort {
 	return defaultPorts
 }
 
-func getHeadlessServicePorts(_ *v1beta1.Grafana) []corev1.ServicePort {
-	intPort := int32(config.GrafanaAlertPort)
+func getHeadlessServicePorts(cr *v1beta1.Grafana) []corev1.ServicePort {
+	intPort := getAlertingPort(cr)
 
 	defaultPorts := []corev1.ServicePort{
 		{
@@ -150,3 +152,12 @@ func getHeadlessServicePorts(_ *v1beta1.Grafana) []corev1.ServicePort {
 
 	return defaultPorts
 }
+
+// getAlertingPort returns the port used for unified alerting gossip
+func getAlertingPort(cr *v1beta1.Grafana) int32 {
+	if ha := cr.Spec.HighAvailability; ha != nil && ha.Alerting != nil && ha.Alerting.Port != 0 {
+		return ha.Alerting.Port
+	}
+
+	return int32(config.GrafanaAlertPort)
+}
@@ -103,3 +103,28 @@ func TestGetGrafanaPort(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHeadlessServicePorts(t *testing.T) {
+	t.Run("default alerting port", func(t *testing.T) {
+		got := getHeadlessServicePorts(&v1beta1.Grafana{})
+
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(config.GrafanaAlertPort), got[0].Port)
+	})
+
+	t.Run("high availability gossip port", func(t *testing.T) {
+		cr := &v1beta1.Grafana{
+			Spec: v1beta1.GrafanaSpec{
+				HighAvailability: &v1beta1.GrafanaHighAvailability{
+					Alerting: &v1beta1.GrafanaAlertingHA{Port: 9095},
+				},
+			},
+		}
+
+		got := getHeadlessServicePorts(cr)
+
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(9095), got[0].Port)
+		assert.Equal(t, int32(9095), got[0].TargetPort.IntVal)
+	})
+}
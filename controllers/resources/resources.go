@@ -8,6 +8,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -91,7 +92,10 @@ func GetGrafanaService(cr *v1beta1.Grafana, scheme *runtime.Scheme) *corev1.Serv
 			Labels:    GetCommonLabels(),
 		},
 	}
-	controllerutil.SetControllerReference(cr, service, scheme) //nolint:errcheck
+
+	if scheme != nil {
+		controllerutil.SetControllerReference(cr, service, scheme) //nolint:errcheck
+	}
 
 	return service
 }
@@ -104,11 +108,30 @@ func GetGrafanaHeadlessService(cr *v1beta1.Grafana, scheme *runtime.Scheme) *cor
 			Labels:    GetCommonLabels(),
 		},
 	}
-	controllerutil.SetControllerReference(cr, service, scheme) //nolint:errcheck
+
+	if scheme != nil {
+		controllerutil.SetControllerReference(cr, service, scheme) //nolint:errcheck
+	}
 
 	return service
 }
 
+func GetGrafanaPodDisruptionBudget(cr *v1beta1.Grafana, scheme *runtime.Scheme) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-pdb", cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    GetCommonLabels(),
+		},
+	}
+
+	if scheme != nil {
+		controllerutil.SetControllerReference(cr, pdb, scheme) //nolint:errcheck
+	}
+
+	return pdb
+}
+
 func GetGrafanaIngress(cr *v1beta1.Grafana, scheme *runtime.Scheme) *networkingv1.Ingress {
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
@@ -162,3 +185,48 @@ func GetGrafanaDeployment(cr *v1beta1.Grafana, scheme *runtime.Scheme) *appsv1.D
 
 	return deployment
 }
+
+func GetGrafanaRendererDeployment(cr *v1beta1.Grafana, scheme *runtime.Scheme) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-renderer", cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    GetCommonLabels(),
+		},
+	}
+	if scheme != nil {
+		controllerutil.SetControllerReference(cr, deployment, scheme) //nolint:errcheck
+	}
+
+	return deployment
+}
+
+func GetGrafanaRendererService(cr *v1beta1.Grafana, scheme *runtime.Scheme) *corev1.Service {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-renderer", cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    GetCommonLabels(),
+		},
+	}
+	if scheme != nil {
+		controllerutil.SetControllerReference(cr, service, scheme) //nolint:errcheck
+	}
+
+	return service
+}
+
+func GetGrafanaRendererSecret(cr *v1beta1.Grafana, scheme *runtime.Scheme) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-renderer-token", cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    GetCommonLabels(),
+		},
+	}
+	if scheme != nil {
+		controllerutil.SetControllerReference(cr, secret, scheme) //nolint:errcheck
+	}
+
+	return secret
+}
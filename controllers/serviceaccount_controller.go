@@ -23,23 +23,30 @@ package controllers
 // GrafanaServiceAccount custom resources. It handles:
 //   - Service account creation, updates, and deletion
 //   - Token lifecycle management with automatic recreation on expiration changes
+//   - Rotation of tokens before they expire, keeping the replaced token valid for a grace period
 //   - Secure token storage in Kubernetes Secrets
+//   - Service accounts in each instance selected by spec.instanceSelector, with their own token secrets
+//   - Copies of token secrets in the allowed namespaces of spec.secretTargets
 //   - Cleanup of orphaned resources
 //
 // Key architectural decisions:
 //   - Tokens are immutable in Grafana - any change requires recreation
+//   - Token names are unique in Grafana, so rotated tokens carry a suffix; the Secret references the current token
 //   - Token names must be unique within a service account (enforced by CRD validation)
 //   - Secrets use annotations to link them with their corresponding tokens
 //   - The controller follows eventual consistency model, handling external modifications gracefully
-//   - All resources are created in the same namespace as the CR for security
+//   - All resources are created in the same namespace as the CR for security, copies only in namespaces allowed by the operator
+//   - Copies carry the uid of the CR in their labels, secrets created by others are never overwritten or deleted
 //
 // The reconciliation process is idempotent and can recover from partial failures or
 // external modifications to either Grafana or Kubernetes resources.
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"sort"
 	"strconv"
@@ -47,11 +54,14 @@ import (
 	"time"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -64,18 +74,34 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
 	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/controllers/metrics"
 	"github.com/grafana/grafana-operator/v5/controllers/resources"
 )
 
 const (
 	conditionServiceAccountSynchronized = "ServiceAccountSynchronized"
+	conditionTokenSecretsCopied         = "TokenSecretsCopied"
+
+	conditionReasonSecretTargetNotAllowed = "SecretTargetNotAllowed"
+
+	// annotationTokenRotated records when the token in a Secret was last replaced by a rotation
+	annotationTokenRotated = "operator.grafana.com/service-account-token-rotated"
+
+	// annotationServiceAccountNamespace records the namespace of the GrafanaServiceAccount on copies of its token secrets
+	annotationServiceAccountNamespace = "operator.grafana.com/service-account-namespace"
+
+	// rotatedTokenInfix separates the name of a token from the time it was rotated at in Grafana token names
+	rotatedTokenInfix = "-rotation-"
+
+	defaultTokenGracePeriod = 5 * time.Minute
 )
 
 // GrafanaServiceAccountReconciler reconciles a GrafanaServiceAccount object.
 type GrafanaServiceAccountReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Cfg    *Config
+	Scheme   *runtime.Scheme
+	Cfg      *Config
+	Recorder events.EventRecorder
 }
 
 // Reconcile synchronizes the actual state (Grafana service accounts and Kubernetes secrets)
@@ -86,10 +112,11 @@ type GrafanaServiceAccountReconciler struct {
 // 1. Fetches the GrafanaServiceAccount resource from Kubernetes
 // 2. Handles resource deletion (removes service account from Grafana and cleans up secrets)
 // 3. Sets up status update handling (deferred)
-// 4. Establishes connection to the target Grafana instance
+// 4. Establishes connection to the target Grafana instances, removing the service accounts of instances no longer selected
 // 5. For active resources - reconciles the actual state with the desired state (creates, updates, removes as needed)
-// 6. Updates the resource status with current state and conditions
-// 7. Schedules periodic reconciliation based on ResyncPeriod
+// 6. Copies the token secrets to the namespaces of spec.secretTargets
+// 7. Updates the resource status with current state and conditions
+// 8. Schedules periodic reconciliation based on ResyncPeriod
 func (r *GrafanaServiceAccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx).WithName("GrafanaServiceAccountReconciler")
 	ctx = logf.IntoContext(ctx, log)
@@ -137,11 +164,11 @@ func (r *GrafanaServiceAccountReconciler) Reconcile(ctx context.Context, req ctr
 
 	removeSuspended(&cr.Status.Conditions)
 
-	// 4. Establish connection to the target Grafana instance
-	// First, get the Grafana CR
+	// 4. Establish connection to the target Grafana instances
+	// First, get the Grafana CRs
 	meta.RemoveStatusCondition(&cr.Status.Conditions, conditionServiceAccountSynchronized)
 
-	grafana, err := r.lookupGrafana(ctx, cr)
+	instances, err := r.lookupGrafanas(ctx, cr)
 	if err != nil {
 		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
 		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionContactPointSynchronized)
@@ -150,7 +177,14 @@ func (r *GrafanaServiceAccountReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
 	}
 
-	if grafana == nil {
+	// Runs before the instances are checked, so that accounts are removed when no instance is selected anymore
+	err = r.removeUnselectedInstances(ctx, cr, instances)
+	if err != nil {
+		log.Error(err, "failed to remove service accounts of unselected instances")
+		return ctrl.Result{}, fmt.Errorf("removing service accounts of unselected instances: %w", err)
+	}
+
+	if len(instances) == 0 {
 		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
 		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionContactPointSynchronized)
 		log.Error(ErrNoMatchingInstances, LogMsgNoMatchingInstances)
@@ -159,18 +193,23 @@ func (r *GrafanaServiceAccountReconciler) Reconcile(ctx context.Context, req ctr
 	}
 
 	removeNoMatchingInstance(&cr.Status.Conditions)
-	log.V(1).Info(DbgMsgFoundMatchingInstances, "count", 1)
+	log.V(1).Info(DbgMsgFoundMatchingInstances, "count", len(instances))
 
 	// 5. For active resources - reconcile the actual state with the desired state (creates, updates, removes as needed)
-	err = r.reconcileWithInstance(ctx, cr, grafana)
-
-	// 6. Update the resource status with current state and conditions
 	applyErrors := map[string]string{}
-	if err != nil {
-		applyErrors[grafana.Name] = err.Error()
+
+	for _, grafana := range instances {
+		err = r.reconcileWithInstance(ctx, cr, &grafana)
+		if err != nil {
+			applyErrors[grafana.Name] = err.Error()
+		}
 	}
 
-	condition := buildSynchronizedCondition("Service Account", conditionServiceAccountSynchronized, cr.Generation, applyErrors, 1)
+	// 6. Copy the token secrets to the namespaces of spec.secretTargets
+	copyErr := r.syncSecretCopies(ctx, cr)
+
+	// 7. Update the resource status with current state and conditions
+	condition := buildSynchronizedCondition("Service Account", conditionServiceAccountSynchronized, cr.Generation, applyErrors, len(instances))
 	meta.SetStatusCondition(&cr.Status.Conditions, condition)
 
 	if len(applyErrors) > 0 {
@@ -180,27 +219,59 @@ func (r *GrafanaServiceAccountReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgApplyErrors, err)
 	}
 
-	// 7. Schedule periodic reconciliation based on ResyncPeriod
-	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
+	if copyErr != nil {
+		log.Error(copyErr, "failed to copy token secrets")
+		return ctrl.Result{}, fmt.Errorf("copying token secrets: %w", copyErr)
+	}
+
+	// 8. Schedule periodic reconciliation based on ResyncPeriod, or earlier if a token is due for rotation
+	return ctrl.Result{RequeueAfter: nextTokenRotation(r.Cfg.requeueAfter(cr.Spec.ResyncPeriod), cr, time.Now())}, nil
 }
 
 // finalize handles the cleanup logic when a GrafanaServiceAccount resource is being deleted.
-// It attempts to remove the service account from Grafana and clean up associated secrets.
+// It attempts to remove the service accounts from Grafana and clean up the copies of the token secrets,
+// the token secrets themselves are garbage collected through their owner reference.
 func (r *GrafanaServiceAccountReconciler) finalize(ctx context.Context, cr *v1beta1.GrafanaServiceAccount) error {
-	if cr.Status.Account == nil {
-		return nil
-	}
+	for instance, account := range cr.InstanceAccounts() {
+		// Get the Grafana CR for deletion
+		grafana, err := r.lookupGrafana(ctx, cr, instance)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
 
-	// Get the Grafana CR for deletion
-	grafana, err := r.lookupGrafana(ctx, cr)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil
+			return err
 		}
 
-		return err
+		if grafana == nil {
+			continue
+		}
+
+		err = r.deleteAccount(ctx, cr, grafana, account)
+		if err != nil {
+			return err
+		}
 	}
 
+	for _, secretCopy := range cr.Status.SecretCopies {
+		err := r.deleteSecretCopy(ctx, cr, secretCopy)
+		if err != nil {
+			return err
+		}
+	}
+
+	clearTokenRotationMetrics(cr)
+
+	return nil
+}
+
+// deleteAccount removes the service account from a Grafana instance
+func (r *GrafanaServiceAccountReconciler) deleteAccount(
+	ctx context.Context,
+	cr *v1beta1.GrafanaServiceAccount,
+	grafana *v1beta1.Grafana,
+	account *v1beta1.GrafanaServiceAccountInfo,
+) error {
 	gClient, err := grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, grafana)
 	if err != nil {
 		return fmt.Errorf("creating Grafana client: %w", err)
@@ -209,7 +280,7 @@ func (r *GrafanaServiceAccountReconciler) finalize(ctx context.Context, cr *v1be
 	_, err = gClient.ServiceAccounts.DeleteServiceAccountWithParams( //nolint:errcheck
 		service_accounts.
 			NewDeleteServiceAccountParamsWithContext(ctx).
-			WithServiceAccountID(cr.Status.Account.ID),
+			WithServiceAccountID(account.ID),
 	)
 	if err != nil {
 		// ATM, service_accounts.DeleteServiceAccountNotFound doesn't have Is, Unwrap, Unwrap.
@@ -217,8 +288,9 @@ func (r *GrafanaServiceAccountReconciler) finalize(ctx context.Context, cr *v1be
 		_, ok := err.(*service_accounts.DeleteServiceAccountNotFound) //nolint:errorlint
 		if ok || errors.Is(err, service_accounts.NewDeleteServiceAccountNotFound()) {
 			logf.FromContext(ctx).Info("service account not found, skipping removal",
-				"serviceAccountID", cr.Status.Account.ID,
+				"serviceAccountID", account.ID,
 				"serviceAccountName", cr.GetGrafanaName(),
+				"instance", grafana.Name,
 			)
 
 			return nil
@@ -234,8 +306,9 @@ func (r *GrafanaServiceAccountReconciler) finalize(ctx context.Context, cr *v1be
 		//
 		// Until then, we treat any non-nil error from the delete call as "already removed" and just log it for visibility.
 		logf.FromContext(ctx).Error(err, "failed to delete service account (may already be deleted)",
-			"serviceAccountID", cr.Status.Account.ID,
+			"serviceAccountID", account.ID,
 			"serviceAccountName", cr.GetGrafanaName(),
+			"instance", grafana.Name,
 		)
 		// return fmt.Errorf("deleting service account %q: %w", status.SpecID, err)
 	}
@@ -243,17 +316,36 @@ func (r *GrafanaServiceAccountReconciler) finalize(ctx context.Context, cr *v1be
 	return nil
 }
 
-// lookupGrafana retrieves the Grafana instance referenced by the GrafanaServiceAccount
+// lookupGrafanas returns the Grafana instances targeted by the GrafanaServiceAccount which are ready for API requests,
+// either the instance referenced by spec.instanceName or the instances in its namespace matching spec.instanceSelector.
+func (r *GrafanaServiceAccountReconciler) lookupGrafanas(
+	ctx context.Context,
+	cr *v1beta1.GrafanaServiceAccount,
+) ([]v1beta1.Grafana, error) {
+	if cr.Spec.InstanceName == "" {
+		return GetScopedMatchingInstances(ctx, r.Client, cr)
+	}
+
+	grafana, err := r.lookupGrafana(ctx, cr, cr.Spec.InstanceName)
+	if err != nil || grafana == nil {
+		return nil, err
+	}
+
+	return []v1beta1.Grafana{*grafana}, nil
+}
+
+// lookupGrafana retrieves a Grafana instance in the namespace of the GrafanaServiceAccount
 // and validates that it's in a ready state for accepting API requests.
 func (r *GrafanaServiceAccountReconciler) lookupGrafana(
 	ctx context.Context,
 	cr *v1beta1.GrafanaServiceAccount,
+	name string,
 ) (*v1beta1.Grafana, error) {
 	var grafana v1beta1.Grafana
 
 	err := r.Get(ctx, client.ObjectKey{
 		Namespace: cr.Namespace,
-		Name:      cr.Spec.InstanceName,
+		Name:      name,
 	}, &grafana)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -265,18 +357,93 @@ func (r *GrafanaServiceAccountReconciler) lookupGrafana(
 
 	// Check if Grafana instance is ready
 	if grafana.Status.Stage != v1beta1.OperatorStageComplete || grafana.Status.StageStatus != v1beta1.OperatorStageResultSuccess {
-		return nil, fmt.Errorf("Grafana instance %q is not ready (stage: %q, status: %q)", cr.Spec.InstanceName, grafana.Status.Stage, grafana.Status.StageStatus) //nolint:staticcheck
+		return nil, fmt.Errorf("Grafana instance %q is not ready (stage: %q, status: %q)", name, grafana.Status.Stage, grafana.Status.StageStatus) //nolint:staticcheck
 	}
 
 	return &grafana, nil
 }
 
+// removeUnselectedInstances deletes the service accounts and token secrets of instances no longer matching spec.instanceSelector.
+// Accounts of instances which still exist but are not ready are kept until they can be removed through the API
+func (r *GrafanaServiceAccountReconciler) removeUnselectedInstances(
+	ctx context.Context,
+	cr *v1beta1.GrafanaServiceAccount,
+	instances []v1beta1.Grafana,
+) error {
+	if cr.Spec.InstanceName != "" {
+		return nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(cr.Spec.InstanceSelector)
+	if err != nil {
+		return fmt.Errorf("parsing instance selector: %w", err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cr.InstanceAccounts())) {
+		if slices.ContainsFunc(instances, func(g v1beta1.Grafana) bool { return g.Name == name }) {
+			continue
+		}
+
+		grafana := &v1beta1.Grafana{}
+
+		err := r.Get(ctx, client.ObjectKey{Namespace: cr.Namespace, Name: name}, grafana)
+		switch {
+		case apierrors.IsNotFound(err):
+			// The service account was removed along with the instance
+		case err != nil:
+			return fmt.Errorf("getting Grafana instance %q: %w", name, err)
+		case selector.Matches(labels.Set(grafana.Labels)),
+			grafana.Status.Stage != v1beta1.OperatorStageComplete || grafana.Status.StageStatus != v1beta1.OperatorStageResultSuccess:
+			continue
+		default:
+			err = r.deleteAccount(ctx, cr, grafana, cr.InstanceAccount(name))
+			if err != nil {
+				return err
+			}
+		}
+
+		err = r.deleteTokenSecrets(ctx, cr, name)
+		if err != nil {
+			return err
+		}
+
+		logf.FromContext(ctx).Info("removed service account of unselected instance", "instance", name)
+		cr.SetInstanceAccount(name, nil)
+	}
+
+	return nil
+}
+
+// deleteTokenSecrets removes the token secrets of the service account in an instance
+func (r *GrafanaServiceAccountReconciler) deleteTokenSecrets(ctx context.Context, cr *v1beta1.GrafanaServiceAccount, instance string) error {
+	var secrets corev1.SecretList
+
+	err := r.List(ctx, &secrets,
+		client.InNamespace(cr.Namespace),
+		client.MatchingLabels(buildSecretLabels(cr, instance)),
+	)
+	if err != nil {
+		return fmt.Errorf("listing secrets: %w", err)
+	}
+
+	for _, secret := range secrets.Items {
+		err := r.Delete(ctx, &secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting token secret %q: %w", secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
 // reconcileWithInstance performs the core reconciliation logic for active resources.
 //
 // It orchestrates the complete synchronization process:
 // 1. Ensures the service account exists in Grafana (creates if missing)
 // 2. Updates service account properties to match the spec
-// 3. Manages the lifecycle of authentication tokens and their secrets
+// 3. Manages the lifecycle of authentication tokens and their secrets, rotating them before they expire
+//
+// The resulting service account is recorded in the status of the CR, also if one of the phases fails.
 func (r *GrafanaServiceAccountReconciler) reconcileWithInstance(
 	ctx context.Context,
 	cr *v1beta1.GrafanaServiceAccount,
@@ -287,49 +454,73 @@ func (r *GrafanaServiceAccountReconciler) reconcileWithInstance(
 		return fmt.Errorf("building grafana client: %w", err)
 	}
 
-	err = r.upsertAccount(ctx, gClient, cr)
-	if err != nil {
-		return fmt.Errorf("upserting service account: %w", err)
-	}
+	account, err := r.upsertAccount(ctx, gClient, cr, cr.InstanceAccount(grafana.Name))
 
 	// Ensure tokens are always sorted for stable ordering
 	defer func() {
-		sort.Slice(cr.Status.Account.Tokens, func(i, j int) bool {
-			return cr.Status.Account.Tokens[i].Name < cr.Status.Account.Tokens[j].Name
-		})
+		if account != nil {
+			sort.Slice(account.Tokens, func(i, j int) bool {
+				return account.Tokens[i].Name < account.Tokens[j].Name
+			})
+		}
+
+		cr.SetInstanceAccount(grafana.Name, account)
 	}()
 
+	if err != nil {
+		return fmt.Errorf("upserting service account: %w", err)
+	}
+
 	// Phase 1: Prune orphaned secrets and index valid ones
-	secretsByTokenName, err := r.pruneAndIndexSecrets(ctx, cr)
+	secretsByTokenName, err := r.pruneAndIndexSecrets(ctx, cr, grafana.Name)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	// Phase 2: Separate tokens replaced by a rotation and revoke them after their grace period
+	err = r.retireRotatedTokens(ctx, gClient, cr, account, secretsByTokenName, now)
+	if err != nil {
+		return err
+	}
+
+	// Phase 3: Remove outdated tokens (will be recreated with correct configuration)
+	err = r.removeOutdatedTokens(ctx, gClient, cr, account)
 	if err != nil {
 		return err
 	}
 
-	// Phase 2: Remove outdated tokens (will be recreated with correct configuration)
-	err = r.removeOutdatedTokens(ctx, gClient, cr)
+	// Phase 4: Validate existing tokens and restore their secret references
+	err = r.validateAndRestoreTokenSecrets(ctx, gClient, account, secretsByTokenName)
 	if err != nil {
 		return err
 	}
 
-	// Phase 3: Validate existing tokens and restore their secret references
-	err = r.validateAndRestoreTokenSecrets(ctx, gClient, cr, secretsByTokenName)
+	// Phase 5: Renew tokens which are due for rotation, updating their secrets in place
+	err = r.rotateTokens(ctx, gClient, cr, grafana, account, secretsByTokenName, now)
 	if err != nil {
 		return err
 	}
 
-	// Phase 4: Provision missing tokens
-	tokensToCreate := r.determineMissingTokens(cr)
+	// Phase 6: Provision missing tokens
+	tokensToCreate := r.determineMissingTokens(cr, account)
 
-	err = r.provisionTokens(ctx, gClient, cr, tokensToCreate, secretsByTokenName)
+	err = r.provisionTokens(ctx, gClient, cr, grafana.Name, account, tokensToCreate, secretsByTokenName)
 	if err != nil {
 		return err
 	}
 
-	if len(cr.Status.Account.Tokens) != 0 {
+	if len(account.Tokens) != 0 {
 		// Grafana's create token API doesn't return expiration, requiring a separate fetch
-		return r.populateTokenExpirations(ctx, gClient, cr)
+		err = r.populateTokenExpirations(ctx, gClient, account)
+		if err != nil {
+			return err
+		}
 	}
 
+	setTokenRenewals(cr, account)
+
 	return nil
 }
 
@@ -349,6 +540,7 @@ func (r *GrafanaServiceAccountReconciler) removeOutdatedTokens(
 	ctx context.Context,
 	gClient *genapi.GrafanaHTTPAPI,
 	cr *v1beta1.GrafanaServiceAccount,
+	account *v1beta1.GrafanaServiceAccountInfo,
 ) error {
 	// Build map of desired tokens from spec
 	desiredTokens := make(map[string]v1beta1.GrafanaServiceAccountTokenSpec, len(cr.Spec.Tokens))
@@ -356,20 +548,21 @@ func (r *GrafanaServiceAccountReconciler) removeOutdatedTokens(
 		desiredTokens[token.Name] = token
 	}
 
-	for i := 0; i < len(cr.Status.Account.Tokens); i++ {
-		tokenName := cr.Status.Account.Tokens[i].Name
+	for i := 0; i < len(account.Tokens); i++ {
+		tokenName := account.Tokens[i].Name
 		desiredToken, ok := desiredTokens[tokenName]
 
+		// Expiration of rotated tokens is handled by rotateTokens
 		needsRecreation := !ok ||
-			!isEqualExpirationTime(desiredToken.Expires, cr.Status.Account.Tokens[i].Expires)
+			(desiredToken.Rotation == nil && !isEqualExpirationTime(desiredToken.Expires, account.Tokens[i].Expires))
 
 		if needsRecreation {
-			err := r.removeAccountToken(ctx, gClient, cr.Status.Account.ID, &cr.Status.Account.Tokens[i])
+			err := r.removeAccountToken(ctx, gClient, account.ID, &account.Tokens[i])
 			if err != nil {
 				return fmt.Errorf("removing service account token %q: %w", tokenName, err)
 			}
 
-			cr.Status.Account.Tokens = slices.Delete(cr.Status.Account.Tokens, i, i+1)
+			account.Tokens = slices.Delete(account.Tokens, i, i+1)
 			i--
 		}
 	}
@@ -383,11 +576,13 @@ func (r *GrafanaServiceAccountReconciler) provisionTokens(
 	ctx context.Context,
 	gClient *genapi.GrafanaHTTPAPI,
 	cr *v1beta1.GrafanaServiceAccount,
+	instance string,
+	account *v1beta1.GrafanaServiceAccountInfo,
 	tokensToCreate []v1beta1.GrafanaServiceAccountTokenSpec,
 	secretsByTokenName map[string]corev1.Secret,
 ) error {
 	for _, tokenSpec := range tokensToCreate {
-		tokenStatus, tokenKey, err := r.createToken(ctx, gClient, cr.Status.Account.ID, tokenSpec)
+		tokenStatus, tokenKey, err := r.createToken(ctx, gClient, account.ID, tokenSpec)
 		if err != nil {
 			return fmt.Errorf("creating token %q: %w", tokenSpec.Name, err)
 		}
@@ -404,7 +599,7 @@ func (r *GrafanaServiceAccountReconciler) provisionTokens(
 			}
 		} else {
 			// The secret doesn't exist, so we need to create it.
-			newSecret := buildTokenSecret(ctx, cr, tokenSpec, tokenStatus, tokenKey, r.Scheme)
+			newSecret := buildTokenSecret(ctx, cr, instance, tokenSpec, tokenStatus, tokenKey, r.Scheme)
 
 			err := r.Create(ctx, newSecret)
 			if err != nil {
@@ -419,15 +614,283 @@ func (r *GrafanaServiceAccountReconciler) provisionTokens(
 			Name:      secret.Name,
 		}
 
-		cr.Status.Account.Tokens = append(cr.Status.Account.Tokens, tokenStatus)
+		account.Tokens = append(account.Tokens, tokenStatus)
 	}
 
 	return nil
 }
 
+// retireRotatedTokens separates the tokens replaced by a rotation from the current ones and revokes them once their grace period is over.
+// The current token of a rotated spec token is the one referenced by its secret, or the newest one if there is no secret.
+// Current tokens are renamed to their spec name so that the following phases can match them with the spec
+func (r *GrafanaServiceAccountReconciler) retireRotatedTokens(
+	ctx context.Context,
+	gClient *genapi.GrafanaHTTPAPI,
+	cr *v1beta1.GrafanaServiceAccount,
+	account *v1beta1.GrafanaServiceAccountInfo,
+	secretsByTokenName map[string]corev1.Secret,
+	now time.Time,
+) error {
+	rotations := make(map[string]*v1beta1.GrafanaServiceAccountTokenRotation, len(cr.Spec.Tokens))
+	for _, token := range cr.Spec.Tokens {
+		if token.Rotation != nil {
+			rotations[token.Name] = token.Rotation
+		}
+	}
+
+	if len(rotations) == 0 {
+		return nil
+	}
+
+	current := make(map[string]int, len(rotations))
+	kept := make([]v1beta1.GrafanaServiceAccountTokenStatus, 0, len(account.Tokens))
+	replaced := []v1beta1.GrafanaServiceAccountTokenStatus{}
+
+	for _, token := range account.Tokens {
+		name := rotatedTokenSpecName(token.Name)
+		if _, ok := rotations[name]; !ok {
+			kept = append(kept, token)
+			continue
+		}
+
+		idx, ok := current[name]
+		if !ok {
+			current[name] = len(kept)
+			kept = append(kept, token)
+
+			continue
+		}
+
+		if isCurrentToken(token, kept[idx], secretsByTokenName[name]) {
+			replaced = append(replaced, kept[idx])
+			kept[idx] = token
+		} else {
+			replaced = append(replaced, token)
+		}
+	}
+
+	for name, idx := range current {
+		kept[idx].Name = name
+	}
+
+	retired := []v1beta1.GrafanaServiceAccountRetiredTokenStatus{}
+
+	for _, token := range replaced {
+		name := rotatedTokenSpecName(token.Name)
+
+		// tokens replaced without a recorded rotation, e.g. if the secret was deleted, are revoked right away
+		revokeAt := now
+
+		rotatedAt, err := time.Parse(time.RFC3339, secretsByTokenName[name].Annotations[annotationTokenRotated])
+		if err == nil {
+			revokeAt = rotatedAt.Add(tokenGracePeriod(rotations[name]))
+		}
+
+		if now.Before(revokeAt) {
+			retired = append(retired, v1beta1.GrafanaServiceAccountRetiredTokenStatus{
+				Name:     name,
+				ID:       token.ID,
+				RevokeAt: metav1.NewTime(revokeAt),
+			})
+
+			continue
+		}
+
+		err = deleteGrafanaToken(ctx, gClient, account.ID, token.ID)
+		if err != nil {
+			return fmt.Errorf("revoking rotated token %q: %w", token.Name, err)
+		}
+
+		logf.FromContext(ctx).Info("revoked token replaced by a rotation", "token", name, "tokenID", token.ID)
+		r.Recorder.Eventf(cr, nil, corev1.EventTypeNormal, "TokenRevoked", "RevokeToken", "Revoked token %s (id %d) after its grace period", name, token.ID)
+	}
+
+	account.Tokens = kept
+	account.RetiredTokens = retired
+
+	return nil
+}
+
+// isCurrentToken reports whether candidate rather than current is the token in use, which is the one referenced by the secret or else the newest one
+func isCurrentToken(candidate, current v1beta1.GrafanaServiceAccountTokenStatus, secret corev1.Secret) bool {
+	id, err := strconv.ParseInt(secret.Annotations["operator.grafana.com/service-account-token-id"], 10, 64)
+	if err == nil && (candidate.ID == id || current.ID == id) {
+		return candidate.ID == id
+	}
+
+	return candidate.ID > current.ID
+}
+
+// rotateTokens creates a new token for tokens which are due for renewal and updates their secret in place.
+// The replaced token stays valid for the grace period of the rotation
+func (r *GrafanaServiceAccountReconciler) rotateTokens(
+	ctx context.Context,
+	gClient *genapi.GrafanaHTTPAPI,
+	cr *v1beta1.GrafanaServiceAccount,
+	grafana *v1beta1.Grafana,
+	account *v1beta1.GrafanaServiceAccountInfo,
+	secretsByTokenName map[string]corev1.Secret,
+	now time.Time,
+) error {
+	for _, tokenSpec := range cr.Spec.Tokens {
+		if tokenSpec.Rotation == nil {
+			continue
+		}
+
+		idx := slices.IndexFunc(account.Tokens, func(t v1beta1.GrafanaServiceAccountTokenStatus) bool {
+			return t.Name == tokenSpec.Name
+		})
+		if idx < 0 || !tokenNeedsRenewal(account.Tokens[idx].Expires, tokenSpec.Rotation, now) {
+			continue
+		}
+
+		// tokens without a secret were removed by validateAndRestoreTokenSecrets
+		secret, ok := secretsByTokenName[tokenSpec.Name]
+		if !ok {
+			continue
+		}
+
+		renewed := tokenSpec
+		renewed.Name = rotatedTokenName(tokenSpec.Name, now)
+
+		tokenStatus, tokenKey, err := r.createToken(ctx, gClient, account.ID, renewed)
+		if err != nil {
+			return fmt.Errorf("rotating token %q: %w", tokenSpec.Name, err)
+		}
+
+		renewSecret(&secret, tokenStatus, tokenKey)
+		secret.Annotations[annotationTokenRotated] = now.UTC().Format(time.RFC3339)
+
+		err = r.Update(ctx, &secret)
+		if err != nil {
+			return fmt.Errorf("updating token secret %q: %w", secret.Name, err)
+		}
+
+		secretsByTokenName[tokenSpec.Name] = secret
+
+		previous := account.Tokens[idx]
+		revokeAt := metav1.NewTime(now.Add(tokenGracePeriod(tokenSpec.Rotation)))
+
+		account.RetiredTokens = append(account.RetiredTokens, v1beta1.GrafanaServiceAccountRetiredTokenStatus{
+			Name:     tokenSpec.Name,
+			ID:       previous.ID,
+			RevokeAt: revokeAt,
+		})
+
+		tokenStatus.Name = tokenSpec.Name
+		tokenStatus.Secret = previous.Secret
+		account.Tokens[idx] = tokenStatus
+
+		metrics.ServiceAccountTokenRotations.WithLabelValues(grafana.Namespace, grafana.Name, fmt.Sprintf("%s/%s", cr.Namespace, cr.Name), tokenSpec.Name).Inc()
+
+		logf.FromContext(ctx).Info("rotated token", "token", tokenSpec.Name, "tokenID", tokenStatus.ID, "previousTokenID", previous.ID)
+		r.Recorder.Eventf(cr, &secret, corev1.EventTypeNormal, "TokenRotated", "RotateToken", "Rotated token %s, the previous token is revoked at %s", tokenSpec.Name, revokeAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// tokenNeedsRenewal reports whether a token is within the renewal window of its rotation.
+// Tokens without expiration or valid for longer than the rotation allows, e.g. after validity was shortened, are renewed as well
+func tokenNeedsRenewal(expires *metav1.Time, rotation *v1beta1.GrafanaServiceAccountTokenRotation, now time.Time) bool {
+	// Tolerates the delay between computing the TTL and Grafana creating the token
+	const tokenValiditySlack = time.Minute
+
+	if expires == nil || expires.After(now.Add(rotation.Validity.Duration+tokenValiditySlack)) {
+		return true
+	}
+
+	return !now.Before(tokenRenewAt(expires.Time, rotation))
+}
+
+func tokenRenewAt(expires time.Time, rotation *v1beta1.GrafanaServiceAccountTokenRotation) time.Time {
+	renewBefore := rotation.Validity.Duration / 3
+	if rotation.RenewBefore != nil {
+		renewBefore = rotation.RenewBefore.Duration
+	}
+
+	return expires.Add(-renewBefore)
+}
+
+func tokenGracePeriod(rotation *v1beta1.GrafanaServiceAccountTokenRotation) time.Duration {
+	if rotation == nil || rotation.GracePeriod == nil {
+		return defaultTokenGracePeriod
+	}
+
+	return rotation.GracePeriod.Duration
+}
+
+// rotatedTokenName returns the name of a rotated token in Grafana, token names must be unique within a service account
+func rotatedTokenName(name string, now time.Time) string {
+	return fmt.Sprintf("%s%s%d", name, rotatedTokenInfix, now.Unix())
+}
+
+// rotatedTokenSpecName strips the rotation suffix from the name of a token in Grafana
+func rotatedTokenSpecName(name string) string {
+	idx := strings.LastIndex(name, rotatedTokenInfix)
+	if idx <= 0 {
+		return name
+	}
+
+	_, err := strconv.ParseInt(name[idx+len(rotatedTokenInfix):], 10, 64)
+	if err != nil {
+		return name
+	}
+
+	return name[:idx]
+}
+
+// setTokenRenewals records when tokens with a rotation policy are renewed
+func setTokenRenewals(cr *v1beta1.GrafanaServiceAccount, account *v1beta1.GrafanaServiceAccountInfo) {
+	for _, tokenSpec := range cr.Spec.Tokens {
+		if tokenSpec.Rotation == nil {
+			continue
+		}
+
+		for i, token := range account.Tokens {
+			if token.Name == tokenSpec.Name && token.Expires != nil {
+				account.Tokens[i].RenewAt = new(metav1.NewTime(tokenRenewAt(token.Expires.Time, tokenSpec.Rotation)))
+			}
+		}
+	}
+}
+
+// nextTokenRotation shortens the resync period so that tokens are renewed and retired tokens are revoked on time
+func nextTokenRotation(resync time.Duration, cr *v1beta1.GrafanaServiceAccount, now time.Time) time.Duration {
+	due := []time.Time{}
+
+	for _, account := range cr.InstanceAccounts() {
+		for _, token := range account.Tokens {
+			if token.RenewAt != nil {
+				due = append(due, token.RenewAt.Time)
+			}
+		}
+
+		for _, token := range account.RetiredTokens {
+			due = append(due, token.RevokeAt.Time)
+		}
+	}
+
+	for _, t := range due {
+		until := t.Sub(now)
+		if until > 0 && (resync <= 0 || until < resync) {
+			resync = until
+		}
+	}
+
+	return resync
+}
+
+func clearTokenRotationMetrics(cr *v1beta1.GrafanaServiceAccount) {
+	metrics.ServiceAccountTokenRotations.DeletePartialMatch(prometheus.Labels{"resource": fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)})
+}
+
 // determineMissingTokens returns a sorted list of tokens that are in the spec but not in the current status.
 // These are the tokens that need to be created.
-func (r *GrafanaServiceAccountReconciler) determineMissingTokens(cr *v1beta1.GrafanaServiceAccount) []v1beta1.GrafanaServiceAccountTokenSpec {
+func (r *GrafanaServiceAccountReconciler) determineMissingTokens(
+	cr *v1beta1.GrafanaServiceAccount,
+	account *v1beta1.GrafanaServiceAccountInfo,
+) []v1beta1.GrafanaServiceAccountTokenSpec {
 	// Build map of desired tokens from spec
 	desiredTokens := make(map[string]v1beta1.GrafanaServiceAccountTokenSpec, len(cr.Spec.Tokens))
 	for _, token := range cr.Spec.Tokens {
@@ -435,7 +898,7 @@ func (r *GrafanaServiceAccountReconciler) determineMissingTokens(cr *v1beta1.Gra
 	}
 
 	// Remove tokens that already exist in status
-	for _, token := range cr.Status.Account.Tokens {
+	for _, token := range account.Tokens {
 		delete(desiredTokens, token.Name)
 	}
 
@@ -458,27 +921,27 @@ func (r *GrafanaServiceAccountReconciler) determineMissingTokens(cr *v1beta1.Gra
 func (r *GrafanaServiceAccountReconciler) validateAndRestoreTokenSecrets(
 	ctx context.Context,
 	gClient *genapi.GrafanaHTTPAPI,
-	cr *v1beta1.GrafanaServiceAccount,
+	account *v1beta1.GrafanaServiceAccountInfo,
 	secretsByTokenName map[string]corev1.Secret,
 ) error {
-	for i := 0; i < len(cr.Status.Account.Tokens); i++ {
-		tokenName := cr.Status.Account.Tokens[i].Name
+	for i := 0; i < len(account.Tokens); i++ {
+		tokenName := account.Tokens[i].Name
 		secret, secretExists := secretsByTokenName[tokenName]
 
 		if !secretExists {
-			err := r.removeAccountToken(ctx, gClient, cr.Status.Account.ID, &cr.Status.Account.Tokens[i])
+			err := r.removeAccountToken(ctx, gClient, account.ID, &account.Tokens[i])
 			if err != nil {
 				return fmt.Errorf("removing service account token %q: %w", tokenName, err)
 			}
 
-			cr.Status.Account.Tokens = slices.Delete(cr.Status.Account.Tokens, i, i+1)
+			account.Tokens = slices.Delete(account.Tokens, i, i+1)
 			i--
 
 			continue
 		}
 
 		// Restore secret reference for valid token
-		cr.Status.Account.Tokens[i].Secret = &v1beta1.GrafanaServiceAccountSecretStatus{
+		account.Tokens[i].Secret = &v1beta1.GrafanaServiceAccountSecretStatus{
 			Namespace: secret.Namespace,
 			Name:      secret.Name,
 		}
@@ -503,6 +966,10 @@ func (r *GrafanaServiceAccountReconciler) createToken(
 		cmd.SecondsToLive = int64(time.Until(tokenSpec.Expires.Time).Seconds())
 	}
 
+	if tokenSpec.Rotation != nil {
+		cmd.SecondsToLive = int64(tokenSpec.Rotation.Validity.Seconds())
+	}
+
 	createResp, err := gClient.ServiceAccounts.CreateToken(
 		service_accounts.
 			NewCreateTokenParamsWithContext(ctx).
@@ -518,6 +985,11 @@ func (r *GrafanaServiceAccountReconciler) createToken(
 		ID:   createResp.Payload.ID,
 	}
 
+	if tokenSpec.Rotation != nil {
+		// Approximation until populateTokenExpirations fetches the actual expiration
+		tokenStatus.Expires = new(metav1.NewTime(time.Now().Add(tokenSpec.Rotation.Validity.Duration)))
+	}
+
 	return tokenStatus, []byte(createResp.Payload.Key), nil
 }
 
@@ -526,12 +998,12 @@ func (r *GrafanaServiceAccountReconciler) createToken(
 func (r *GrafanaServiceAccountReconciler) populateTokenExpirations(
 	ctx context.Context,
 	gClient *genapi.GrafanaHTTPAPI,
-	cr *v1beta1.GrafanaServiceAccount,
+	account *v1beta1.GrafanaServiceAccountInfo,
 ) error {
 	listResp, err := gClient.ServiceAccounts.ListTokensWithParams(
 		service_accounts.
 			NewListTokensParamsWithContext(ctx).
-			WithServiceAccountID(cr.Status.Account.ID),
+			WithServiceAccountID(account.ID),
 	)
 	if err != nil {
 		return fmt.Errorf("listing tokens to get expirations: %w", err)
@@ -544,8 +1016,8 @@ func (r *GrafanaServiceAccountReconciler) populateTokenExpirations(
 	}
 
 	// Update tokens in status with their expiration times
-	for i := range cr.Status.Account.Tokens {
-		cr.Status.Account.Tokens[i].Expires = expirations[cr.Status.Account.Tokens[i].ID]
+	for i := range account.Tokens {
+		account.Tokens[i].Expires = expirations[account.Tokens[i].ID]
 	}
 
 	return nil
@@ -557,12 +1029,13 @@ func (r *GrafanaServiceAccountReconciler) populateTokenExpirations(
 func (r *GrafanaServiceAccountReconciler) pruneAndIndexSecrets(
 	ctx context.Context,
 	cr *v1beta1.GrafanaServiceAccount,
+	instance string,
 ) (map[string]corev1.Secret, error) {
 	var secrets corev1.SecretList
 
 	err := r.List(ctx, &secrets,
 		client.InNamespace(cr.Namespace),
-		client.MatchingLabels(buildSecretLabels(cr)),
+		client.MatchingLabels(buildSecretLabels(cr, instance)),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("listing secrets: %w", err)
@@ -586,8 +1059,9 @@ func (r *GrafanaServiceAccountReconciler) pruneAndIndexSecrets(
 		if ok {
 			b, secretHasToken := secret.Data["token"]
 			secretTokenNotEmpty := len(b) > 0
-			secretNameIsValid := (token.SecretName == "" && secret.GenerateName != "") ||
-				(token.SecretName != "" && secret.GenerateName == "" && token.SecretName == secret.Name)
+			secretName := tokenSecretName(cr, instance, *token)
+			secretNameIsValid := (secretName == "" && secret.GenerateName != "") ||
+				(secretName != "" && secret.GenerateName == "" && secretName == secret.Name)
 
 			if secretHasToken && secretTokenNotEmpty && secretNameIsValid {
 				// Keep this secret
@@ -609,16 +1083,18 @@ func (r *GrafanaServiceAccountReconciler) pruneAndIndexSecrets(
 }
 
 // upsertAccount ensures a service account exists in Grafana.
+// It returns the service account to record in the status, which is the current one if it couldn't be updated.
 func (r *GrafanaServiceAccountReconciler) upsertAccount(
 	ctx context.Context,
 	gClient *genapi.GrafanaHTTPAPI,
 	cr *v1beta1.GrafanaServiceAccount,
-) error {
-	if cr.Status.Account != nil {
+	current *v1beta1.GrafanaServiceAccountInfo,
+) (*v1beta1.GrafanaServiceAccountInfo, error) {
+	if current != nil {
 		update, err := gClient.ServiceAccounts.UpdateServiceAccount(
 			service_accounts.
 				NewUpdateServiceAccountParamsWithContext(ctx).
-				WithServiceAccountID(cr.Status.Account.ID).
+				WithServiceAccountID(current.ID).
 				WithBody(&models.UpdateServiceAccountForm{
 					// The form contains a ServiceAccountID field which is unused in Grafana, so it's ignored here.
 					Name:       cr.GetGrafanaName(),
@@ -627,7 +1103,7 @@ func (r *GrafanaServiceAccountReconciler) upsertAccount(
 				}),
 		)
 		if err == nil {
-			cr.Status.Account = &v1beta1.GrafanaServiceAccountInfo{
+			account := &v1beta1.GrafanaServiceAccountInfo{
 				ID:         update.Payload.Serviceaccount.ID,
 				Role:       update.Payload.Serviceaccount.Role,
 				IsDisabled: update.Payload.Serviceaccount.IsDisabled,
@@ -639,16 +1115,16 @@ func (r *GrafanaServiceAccountReconciler) upsertAccount(
 			tokenList, err := gClient.ServiceAccounts.ListTokensWithParams(
 				service_accounts.
 					NewListTokensParamsWithContext(ctx).
-					WithServiceAccountID(cr.Status.Account.ID),
+					WithServiceAccountID(account.ID),
 			)
 			if err != nil {
-				return fmt.Errorf("listing tokens: %w", err)
+				return account, fmt.Errorf("listing tokens: %w", err)
 			}
 
-			cr.Status.Account.Tokens = make([]v1beta1.GrafanaServiceAccountTokenStatus, 0, len(tokenList.Payload))
+			account.Tokens = make([]v1beta1.GrafanaServiceAccountTokenStatus, 0, len(tokenList.Payload))
 			for _, token := range tokenList.Payload {
 				if token != nil {
-					cr.Status.Account.Tokens = append(cr.Status.Account.Tokens, v1beta1.GrafanaServiceAccountTokenStatus{
+					account.Tokens = append(account.Tokens, v1beta1.GrafanaServiceAccountTokenStatus{
 						ID:      token.ID,
 						Name:    token.Name,
 						Expires: convertGrafanaExpiration(token.Expiration),
@@ -656,17 +1132,15 @@ func (r *GrafanaServiceAccountReconciler) upsertAccount(
 				}
 			}
 
-			return nil
+			return account, nil
 		}
 
 		// ATM, service_accounts.UpdateServiceAccountNotFound doesn't have Is, Unwrap, Unwrap.
 		// So, we cannot rely only on errors.Is().
 		_, ok := err.(*service_accounts.UpdateServiceAccountNotFound) //nolint:errorlint
 		if !ok && !errors.Is(err, service_accounts.NewUpdateServiceAccountNotFound()) {
-			return fmt.Errorf("updating service account: %w", err)
+			return current, fmt.Errorf("updating service account: %w", err)
 		}
-
-		cr.Status.Account = nil
 	}
 
 	create, err := gClient.ServiceAccounts.CreateServiceAccount(
@@ -679,18 +1153,16 @@ func (r *GrafanaServiceAccountReconciler) upsertAccount(
 			}),
 	)
 	if err != nil {
-		return fmt.Errorf("creating service account: %w", err)
+		return nil, fmt.Errorf("creating service account: %w", err)
 	}
 
-	cr.Status.Account = &v1beta1.GrafanaServiceAccountInfo{
+	return &v1beta1.GrafanaServiceAccountInfo{
 		ID:         create.Payload.ID,
 		Role:       create.Payload.Role,
 		IsDisabled: create.Payload.IsDisabled,
 		Name:       create.Payload.Name,
 		Login:      create.Payload.Login,
-	}
-
-	return nil
+	}, nil
 }
 
 func (r *GrafanaServiceAccountReconciler) removeAccountToken(
@@ -713,11 +1185,16 @@ func (r *GrafanaServiceAccountReconciler) removeAccountToken(
 		tokenStatus.Secret = nil
 	}
 
+	return deleteGrafanaToken(ctx, gClient, serviceAccountID, tokenStatus.ID)
+}
+
+// deleteGrafanaToken revokes a token in Grafana, tokens which are already gone are ignored
+func deleteGrafanaToken(ctx context.Context, gClient *genapi.GrafanaHTTPAPI, serviceAccountID, tokenID int64) error {
 	_, err := gClient.ServiceAccounts.DeleteTokenWithParams( //nolint:errcheck
 		service_accounts.
 			NewDeleteTokenParamsWithContext(ctx).
 			WithServiceAccountID(serviceAccountID).
-			WithTokenID(tokenStatus.ID),
+			WithTokenID(tokenID),
 	)
 	if err != nil {
 		// ATM, service_accounts.DeleteTokenNotFound doesn't have Is, Unwrap, Unwrap.
@@ -754,16 +1231,26 @@ func isEqualExpirationTime(a, b *metav1.Time) bool {
 	return diff.Abs() <= tokenExpirationDrift
 }
 
-func buildSecretLabels(cr *v1beta1.GrafanaServiceAccount) map[string]string {
+func buildSecretLabels(cr *v1beta1.GrafanaServiceAccount, instance string) map[string]string {
 	return map[string]string{
-		"operator.grafana.com/service-account-instance": cr.Spec.InstanceName,
+		"operator.grafana.com/service-account-instance": instance,
 		"operator.grafana.com/service-account-name":     cr.Name,
 		"operator.grafana.com/service-account-uid":      string(cr.UID),
 	}
 }
 
-func generateSecretName(cr *v1beta1.GrafanaServiceAccount, tokenSpec v1beta1.GrafanaServiceAccountTokenSpec) string {
-	return fmt.Sprintf("%s-%s-%s-", cr.Spec.InstanceName, cr.Name, tokenSpec.Name)
+func generateSecretName(cr *v1beta1.GrafanaServiceAccount, instance string, tokenSpec v1beta1.GrafanaServiceAccountTokenSpec) string {
+	return fmt.Sprintf("%s-%s-%s-", instance, cr.Name, tokenSpec.Name)
+}
+
+// tokenSecretName returns the name of the secret of a token in an instance, empty if the name is generated.
+// Explicit names are suffixed with the instance name when several instances are selected
+func tokenSecretName(cr *v1beta1.GrafanaServiceAccount, instance string, tokenSpec v1beta1.GrafanaServiceAccountTokenSpec) string {
+	if tokenSpec.SecretName == "" || cr.Spec.InstanceName != "" {
+		return tokenSpec.SecretName
+	}
+
+	return fmt.Sprintf("%s-%s", tokenSpec.SecretName, instance)
 }
 
 func extractTokenNameFromSecret(secret *corev1.Secret) (string, bool) {
@@ -779,6 +1266,7 @@ func extractTokenNameFromSecret(secret *corev1.Secret) (string, bool) {
 func buildTokenSecret(
 	ctx context.Context,
 	cr *v1beta1.GrafanaServiceAccount,
+	instance string,
 	tokenSpec v1beta1.GrafanaServiceAccountTokenSpec,
 	tokenStatus v1beta1.GrafanaServiceAccountTokenStatus,
 	tokenKey []byte,
@@ -786,9 +1274,9 @@ func buildTokenSecret(
 ) *corev1.Secret {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      tokenSpec.SecretName,
+			Name:      tokenSecretName(cr, instance, tokenSpec),
 			Namespace: cr.Namespace,
-			Labels:    buildSecretLabels(cr),
+			Labels:    buildSecretLabels(cr, instance),
 			Annotations: map[string]string{
 				"operator.grafana.com/service-account-name":         cr.Name,
 				"operator.grafana.com/service-account-display-name": cr.GetGrafanaName(),
@@ -801,11 +1289,7 @@ func buildTokenSecret(
 	renewSecret(secret, tokenStatus, tokenKey)
 
 	if secret.Name == "" {
-		secret.GenerateName = generateSecretName(cr, tokenSpec)
-	}
-
-	if tokenStatus.Expires != nil {
-		secret.Annotations["operator.grafana.com/service-account-token-expiry"] = tokenStatus.Expires.Format(time.RFC3339)
+		secret.GenerateName = generateSecretName(cr, instance, tokenSpec)
 	}
 
 	resources.SetInheritedLabels(secret, cr.Labels)
@@ -836,6 +1320,218 @@ func renewSecret(
 	}
 
 	secret.Annotations["operator.grafana.com/service-account-token-id"] = strconv.FormatInt(tokenStatus.ID, 10)
+
+	if tokenStatus.Expires != nil {
+		secret.Annotations["operator.grafana.com/service-account-token-expiry"] = tokenStatus.Expires.Format(time.RFC3339)
+	} else {
+		delete(secret.Annotations, "operator.grafana.com/service-account-token-expiry")
+	}
+}
+
+// syncSecretCopies copies the token secrets to the namespaces of spec.secretTargets and removes copies which are no longer desired.
+// Namespaces not allowed by the operator are reported in the condition but don't fail the reconciliation
+func (r *GrafanaServiceAccountReconciler) syncSecretCopies(ctx context.Context, cr *v1beta1.GrafanaServiceAccount) error {
+	if len(cr.Spec.SecretTargets) == 0 && len(cr.Status.SecretCopies) == 0 {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionTokenSecretsCopied)
+		return nil
+	}
+
+	// Copies indexed by the secret they are copied from
+	desired := map[v1beta1.GrafanaServiceAccountSecretStatus]v1beta1.GrafanaServiceAccountSecretStatus{}
+	notAllowed := []string{}
+
+	for _, target := range cr.Spec.SecretTargets {
+		// The token secrets already live in the namespace of the CR
+		if target.Namespace == cr.Namespace {
+			continue
+		}
+
+		if !r.Cfg.secretTargetAllowed(target.Namespace) {
+			notAllowed = append(notAllowed, target.Namespace)
+			continue
+		}
+
+		for _, account := range cr.InstanceAccounts() {
+			for _, token := range account.Tokens {
+				if token.Secret == nil || (len(target.Tokens) > 0 && !slices.Contains(target.Tokens, token.Name)) {
+					continue
+				}
+
+				desired[v1beta1.GrafanaServiceAccountSecretStatus{Namespace: target.Namespace, Name: token.Secret.Name}] = *token.Secret
+			}
+		}
+	}
+
+	copies := make([]v1beta1.GrafanaServiceAccountSecretStatus, 0, len(desired))
+	errs := []error{}
+
+	for dst, src := range desired {
+		err := r.copyTokenSecret(ctx, cr, src, dst)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("copying secret %s to namespace %s: %w", src.Name, dst.Namespace, err))
+
+			// Only copies created earlier are tracked for cleanup
+			if !slices.Contains(cr.Status.SecretCopies, dst) {
+				continue
+			}
+		}
+
+		copies = append(copies, dst)
+	}
+
+	for _, secretCopy := range cr.Status.SecretCopies {
+		if _, ok := desired[secretCopy]; ok {
+			continue
+		}
+
+		err := r.deleteSecretCopy(ctx, cr, secretCopy)
+		if err != nil {
+			errs = append(errs, err)
+			copies = append(copies, secretCopy)
+		}
+	}
+
+	slices.SortFunc(copies, func(a, b v1beta1.GrafanaServiceAccountSecretStatus) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	cr.Status.SecretCopies = copies
+
+	if len(cr.Spec.SecretTargets) == 0 && len(errs) == 0 {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionTokenSecretsCopied)
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:               conditionTokenSecretsCopied,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             conditionReasonApplySuccessful,
+		Message:            fmt.Sprintf("%d token secrets were copied", len(copies)),
+	}
+
+	switch {
+	case len(errs) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditionReasonApplyFailed
+		condition.Message = errors.Join(errs...).Error()
+	case len(notAllowed) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditionReasonSecretTargetNotAllowed
+		condition.Message = fmt.Sprintf("Copying token secrets is not allowed to namespaces: %s", strings.Join(notAllowed, ", "))
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+
+	return errors.Join(errs...)
+}
+
+// copyTokenSecret creates or updates the copy of a token secret, secrets not copied by the CR are left untouched
+func (r *GrafanaServiceAccountReconciler) copyTokenSecret(
+	ctx context.Context,
+	cr *v1beta1.GrafanaServiceAccount,
+	src, dst v1beta1.GrafanaServiceAccountSecretStatus,
+) error {
+	source := &corev1.Secret{}
+
+	err := r.Get(ctx, client.ObjectKey{Namespace: src.Namespace, Name: src.Name}, source)
+	if err != nil {
+		return fmt.Errorf("getting token secret: %w", err)
+	}
+
+	secret := &corev1.Secret{}
+
+	err = r.Get(ctx, client.ObjectKey{Namespace: dst.Namespace, Name: dst.Name}, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		err = r.Create(ctx, buildSecretCopy(cr, source, dst.Namespace))
+		if apierrors.IsAlreadyExists(err) {
+			// Secrets without the common labels are not cached
+			return fmt.Errorf("secret %s/%s already exists and is not managed by the service account", dst.Namespace, dst.Name)
+		}
+
+		return err
+	case err != nil:
+		return err
+	case !isSecretCopyOf(secret, cr):
+		return fmt.Errorf("secret %s/%s already exists and is not managed by the service account", dst.Namespace, dst.Name)
+	}
+
+	desired := buildSecretCopy(cr, source, dst.Namespace)
+	if maps.EqualFunc(secret.Data, desired.Data, bytes.Equal) &&
+		maps.Equal(secret.Labels, desired.Labels) &&
+		maps.Equal(secret.Annotations, desired.Annotations) {
+		return nil
+	}
+
+	secret.Data = desired.Data
+	secret.Labels = desired.Labels
+	secret.Annotations = desired.Annotations
+
+	return r.Update(ctx, secret)
+}
+
+// deleteSecretCopy removes the copy of a token secret, secrets not copied by the CR are left untouched
+func (r *GrafanaServiceAccountReconciler) deleteSecretCopy(
+	ctx context.Context,
+	cr *v1beta1.GrafanaServiceAccount,
+	secretCopy v1beta1.GrafanaServiceAccountSecretStatus,
+) error {
+	secret := &corev1.Secret{}
+
+	err := r.Get(ctx, client.ObjectKey{Namespace: secretCopy.Namespace, Name: secretCopy.Name}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("getting copied secret %s/%s: %w", secretCopy.Namespace, secretCopy.Name, err)
+	}
+
+	if !isSecretCopyOf(secret, cr) {
+		return nil
+	}
+
+	err = r.Delete(ctx, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting copied secret %s/%s: %w", secretCopy.Namespace, secretCopy.Name, err)
+	}
+
+	return nil
+}
+
+// buildSecretCopy returns the copy of a token secret in another namespace.
+// Owner references can't cross namespaces, so the copy is linked to the CR through labels instead
+func buildSecretCopy(cr *v1beta1.GrafanaServiceAccount, source *corev1.Secret, namespace string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Namespace:   namespace,
+			Labels:      maps.Clone(source.Labels),
+			Annotations: maps.Clone(source.Annotations),
+		},
+		Data: maps.Clone(source.Data),
+		Type: source.Type,
+	}
+
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+
+	maps.Copy(secret.Labels, resources.GetCommonLabels())
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+
+	secret.Annotations[annotationServiceAccountNamespace] = cr.Namespace
+
+	return secret
+}
+
+// isSecretCopyOf reports whether the secret was copied by the CR
+func isSecretCopyOf(secret *corev1.Secret, cr *v1beta1.GrafanaServiceAccount) bool {
+	return secret.Labels["operator.grafana.com/service-account-uid"] == string(cr.UID) &&
+		secret.Annotations[annotationServiceAccountNamespace] == cr.Namespace
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -18,19 +18,34 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
 	"time"
 
+	"github.com/go-openapi/strfmt"
 	genapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
 	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/controllers/metrics"
 	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	. "github.com/onsi/ginkgo/v2"
 )
@@ -625,3 +640,402 @@ var _ = Describe("ServiceAccount Controller: Integration Tests", func() {
 		})
 	})
 })
+
+func TestRotatedTokenSpecName(t *testing.T) {
+	now := time.Unix(1767225600, 0)
+
+	assert.Equal(t, "api", rotatedTokenSpecName(rotatedTokenName("api", now)))
+	assert.Equal(t, "api-rotation-x", rotatedTokenSpecName(rotatedTokenName("api-rotation-x", now)))
+	assert.Equal(t, "api", rotatedTokenSpecName("api"))
+	assert.Equal(t, "api-rotation-x", rotatedTokenSpecName("api-rotation-x"), "suffixes which are not timestamps are kept")
+	assert.Equal(t, "-rotation-1", rotatedTokenSpecName("-rotation-1"))
+}
+
+func TestTokenNeedsRenewal(t *testing.T) {
+	now := time.Now()
+	rotation := &v1beta1.GrafanaServiceAccountTokenRotation{
+		Validity:    metav1.Duration{Duration: 3 * time.Hour},
+		RenewBefore: &metav1.Duration{Duration: time.Hour},
+	}
+
+	assert.True(t, tokenNeedsRenewal(nil, rotation, now), "tokens without expiration are renewed")
+	assert.False(t, tokenNeedsRenewal(&metav1.Time{Time: now.Add(2 * time.Hour)}, rotation, now))
+	assert.True(t, tokenNeedsRenewal(&metav1.Time{Time: now.Add(time.Hour)}, rotation, now))
+	assert.True(t, tokenNeedsRenewal(&metav1.Time{Time: now.Add(-time.Hour)}, rotation, now))
+	assert.True(t, tokenNeedsRenewal(&metav1.Time{Time: now.Add(24 * time.Hour)}, rotation, now), "tokens valid for longer than validity are renewed")
+
+	rotation.RenewBefore = nil
+	assert.True(t, tokenRenewAt(now, rotation).Equal(now.Add(-time.Hour)), "renewBefore defaults to a third of validity")
+}
+
+func TestNextTokenRotation(t *testing.T) {
+	now := time.Now()
+
+	cr := &v1beta1.GrafanaServiceAccount{Spec: v1beta1.GrafanaServiceAccountSpec{InstanceName: "grafana"}}
+	assert.Equal(t, 10*time.Minute, nextTokenRotation(10*time.Minute, cr, now))
+
+	cr.Status.Account = &v1beta1.GrafanaServiceAccountInfo{
+		Tokens: []v1beta1.GrafanaServiceAccountTokenStatus{
+			{Name: "fixed"},
+			{Name: "rotated", RenewAt: &metav1.Time{Time: now.Add(8 * time.Minute)}},
+		},
+	}
+	assert.Equal(t, 8*time.Minute, nextTokenRotation(10*time.Minute, cr, now))
+
+	cr.Status.Account.RetiredTokens = []v1beta1.GrafanaServiceAccountRetiredTokenStatus{
+		{Name: "rotated", RevokeAt: metav1.NewTime(now.Add(3 * time.Minute))},
+	}
+	assert.Equal(t, 3*time.Minute, nextTokenRotation(10*time.Minute, cr, now))
+}
+
+// fakeTokenAPI serves the token endpoints of a single Grafana service account from memory
+type fakeTokenAPI struct {
+	mu     sync.Mutex
+	tokens map[int64]*models.TokenDTO
+	nextID int64
+}
+
+func newFakeTokenAPI(t *testing.T, existing ...*models.TokenDTO) (*fakeTokenAPI, *genapi.GrafanaHTTPAPI) {
+	t.Helper()
+
+	api := &fakeTokenAPI{tokens: map[int64]*models.TokenDTO{}}
+	for _, token := range existing {
+		api.tokens[token.ID] = token
+		api.nextID = max(api.nextID, token.ID)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/serviceaccounts/{id}/tokens", api.create)
+	mux.HandleFunc("DELETE /api/serviceaccounts/{id}/tokens/{tokenId}", api.delete)
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	return api, genapi.NewHTTPClientWithConfig(nil, &genapi.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	})
+}
+
+func (f *fakeTokenAPI) create(w http.ResponseWriter, r *http.Request) {
+	var cmd models.AddServiceAccountTokenCommand
+
+	err := json.NewDecoder(r.Body).Decode(&cmd)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	f.tokens[f.nextID] = &models.TokenDTO{
+		ID:         f.nextID,
+		Name:       cmd.Name,
+		Expiration: strfmt.DateTime(time.Now().Add(time.Duration(cmd.SecondsToLive) * time.Second)),
+	}
+
+	writeJSON(w, models.NewAPIKeyResult{ID: f.nextID, Name: cmd.Name, Key: "key-" + strconv.FormatInt(f.nextID, 10)})
+}
+
+func (f *fakeTokenAPI) delete(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(r.PathValue("tokenId"), 10, 64)
+
+	f.mu.Lock()
+	delete(f.tokens, id)
+	f.mu.Unlock()
+
+	writeJSON(w, map[string]string{"message": "Service account token deleted"})
+}
+
+func (f *fakeTokenAPI) exists(id int64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.tokens[id]
+
+	return ok
+}
+
+// statusTokens returns the tokens as loaded by upsertAccount at the start of a reconcile
+func (f *fakeTokenAPI) statusTokens() []v1beta1.GrafanaServiceAccountTokenStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens := []v1beta1.GrafanaServiceAccountTokenStatus{}
+	for _, token := range f.tokens {
+		tokens = append(tokens, v1beta1.GrafanaServiceAccountTokenStatus{
+			ID:      token.ID,
+			Name:    token.Name,
+			Expires: convertGrafanaExpiration(token.Expiration),
+		})
+	}
+
+	return tokens
+}
+
+func TestTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	api, gClient := newFakeTokenAPI(t, &models.TokenDTO{
+		ID:         1,
+		Name:       "api",
+		Expiration: strfmt.DateTime(now.Add(10 * time.Minute)),
+	})
+
+	cr := &v1beta1.GrafanaServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rotation"},
+		Spec: v1beta1.GrafanaServiceAccountSpec{
+			InstanceName: "grafana",
+			Tokens: []v1beta1.GrafanaServiceAccountTokenSpec{{
+				Name: "api",
+				Rotation: &v1beta1.GrafanaServiceAccountTokenRotation{
+					Validity:    metav1.Duration{Duration: time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 20 * time.Minute},
+				},
+			}},
+		},
+		Status: v1beta1.GrafanaServiceAccountStatus{
+			Account: &v1beta1.GrafanaServiceAccountInfo{ID: 1},
+		},
+	}
+
+	secret := buildTokenSecret(ctx, cr, "grafana", cr.Spec.Tokens[0], v1beta1.GrafanaServiceAccountTokenStatus{Name: "api", ID: 1}, []byte("key-1"), nil)
+	secret.Name = "rotation-api"
+
+	cl := tk8s.GetFakeClient(t, secret)
+	recorder := events.NewFakeRecorder(10)
+	r := &GrafanaServiceAccountReconciler{Client: cl, Recorder: recorder}
+	grafana := &v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "grafana"}}
+
+	reconcileTokens := func(now time.Time) {
+		t.Helper()
+
+		cr.Status.Account.Tokens = api.statusTokens()
+		secrets := map[string]corev1.Secret{}
+
+		current := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: "rotation-api"}, current))
+
+		secrets["api"] = *current
+
+		require.NoError(t, r.retireRotatedTokens(ctx, gClient, cr, cr.Status.Account, secrets, now))
+		require.NoError(t, r.rotateTokens(ctx, gClient, cr, grafana, cr.Status.Account, secrets, now))
+	}
+
+	t.Run("tokens within the renewal window are rotated", func(t *testing.T) {
+		reconcileTokens(now)
+
+		require.Len(t, cr.Status.Account.Tokens, 1)
+		assert.Equal(t, "api", cr.Status.Account.Tokens[0].Name)
+		assert.Equal(t, int64(2), cr.Status.Account.Tokens[0].ID)
+
+		require.Len(t, cr.Status.Account.RetiredTokens, 1)
+		assert.Equal(t, int64(1), cr.Status.Account.RetiredTokens[0].ID)
+		assert.True(t, now.Add(defaultTokenGracePeriod).Equal(cr.Status.Account.RetiredTokens[0].RevokeAt.Time))
+
+		updated := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: "rotation-api"}, updated))
+		assert.Equal(t, "key-2", string(updated.Data["token"]), "the secret is updated in place")
+		assert.Equal(t, "2", updated.Annotations["operator.grafana.com/service-account-token-id"])
+		assert.NotEmpty(t, updated.Annotations[annotationTokenRotated])
+
+		assert.True(t, api.exists(1), "the previous token stays valid during the grace period")
+		assert.Contains(t, <-recorder.Events, "TokenRotated")
+
+		counter := &dto.Metric{}
+		require.NoError(t, metrics.ServiceAccountTokenRotations.WithLabelValues("monitoring", "grafana", "default/rotation", "api").Write(counter))
+		assert.InDelta(t, 1, counter.GetCounter().GetValue(), 0)
+	})
+
+	t.Run("retired tokens are kept during the grace period", func(t *testing.T) {
+		reconcileTokens(now.Add(time.Minute))
+
+		require.Len(t, cr.Status.Account.Tokens, 1)
+		assert.Equal(t, "api", cr.Status.Account.Tokens[0].Name, "rotated tokens are matched with their spec")
+		assert.Equal(t, int64(2), cr.Status.Account.Tokens[0].ID)
+		require.Len(t, cr.Status.Account.RetiredTokens, 1)
+		assert.True(t, api.exists(1))
+	})
+
+	t.Run("retired tokens are revoked after the grace period", func(t *testing.T) {
+		reconcileTokens(now.Add(defaultTokenGracePeriod))
+
+		assert.Empty(t, cr.Status.Account.RetiredTokens)
+		assert.False(t, api.exists(1))
+		assert.True(t, api.exists(2))
+		assert.Contains(t, <-recorder.Events, "TokenRevoked")
+	})
+
+	clearTokenRotationMetrics(cr)
+}
+
+func TestTokenSecretName(t *testing.T) {
+	token := v1beta1.GrafanaServiceAccountTokenSpec{Name: "api", SecretName: "api-token"}
+
+	cr := &v1beta1.GrafanaServiceAccount{Spec: v1beta1.GrafanaServiceAccountSpec{InstanceName: "grafana"}}
+	assert.Equal(t, "api-token", tokenSecretName(cr, "grafana", token))
+
+	cr.Spec = v1beta1.GrafanaServiceAccountSpec{InstanceSelector: &metav1.LabelSelector{}}
+	assert.Equal(t, "api-token-grafana", tokenSecretName(cr, "grafana", token), "selected instances get their own secret")
+	assert.Empty(t, tokenSecretName(cr, "grafana", v1beta1.GrafanaServiceAccountTokenSpec{Name: "api"}), "names are generated if unset")
+}
+
+func TestSecretTargetAllowed(t *testing.T) {
+	var cfg *Config
+	assert.False(t, cfg.secretTargetAllowed("apps"))
+
+	cfg = &Config{SecretTargetNamespaces: []string{"apps", "jobs"}}
+	assert.True(t, cfg.secretTargetAllowed("apps"))
+	assert.False(t, cfg.secretTargetAllowed("kube-system"))
+
+	cfg.SecretTargetNamespaces = []string{"*"}
+	assert.True(t, cfg.secretTargetAllowed("kube-system"))
+}
+
+func TestSyncSecretCopies(t *testing.T) {
+	ctx := context.Background()
+
+	cr := &v1beta1.GrafanaServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "ci", UID: "ci-uid"},
+		Spec: v1beta1.GrafanaServiceAccountSpec{
+			InstanceName: "grafana",
+			Tokens:       []v1beta1.GrafanaServiceAccountTokenSpec{{Name: "api"}, {Name: "admin"}},
+			SecretTargets: []v1beta1.GrafanaServiceAccountSecretTarget{
+				{Namespace: "apps", Tokens: []string{"api"}},
+				{Namespace: "kube-system"},
+			},
+		},
+		Status: v1beta1.GrafanaServiceAccountStatus{
+			Account: &v1beta1.GrafanaServiceAccountInfo{
+				ID: 1,
+				Tokens: []v1beta1.GrafanaServiceAccountTokenStatus{
+					{Name: "admin", ID: 1, Secret: &v1beta1.GrafanaServiceAccountSecretStatus{Namespace: "monitoring", Name: "ci-admin"}},
+					{Name: "api", ID: 2, Secret: &v1beta1.GrafanaServiceAccountSecretStatus{Namespace: "monitoring", Name: "ci-api"}},
+				},
+			},
+		},
+	}
+
+	source := buildTokenSecret(ctx, cr, "grafana", cr.Spec.Tokens[0], v1beta1.GrafanaServiceAccountTokenStatus{Name: "api", ID: 2}, []byte("key-2"), nil)
+	source.Name = "ci-api"
+	admin := buildTokenSecret(ctx, cr, "grafana", cr.Spec.Tokens[1], v1beta1.GrafanaServiceAccountTokenStatus{Name: "admin", ID: 1}, []byte("key-1"), nil)
+	admin.Name = "ci-admin"
+	unmanaged := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "jobs", Name: "ci-api"}, Data: map[string][]byte{"token": []byte("other")}}
+
+	cl := tk8s.GetFakeClient(t, source, admin, unmanaged)
+	r := &GrafanaServiceAccountReconciler{Client: cl, Cfg: &Config{SecretTargetNamespaces: []string{"apps", "jobs"}}}
+
+	t.Run("token secrets are copied to allowed namespaces", func(t *testing.T) {
+		require.NoError(t, r.syncSecretCopies(ctx, cr))
+
+		copied := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "apps", Name: "ci-api"}, copied))
+		assert.Equal(t, "key-2", string(copied.Data["token"]))
+		assert.Equal(t, "ci-uid", copied.Labels["operator.grafana.com/service-account-uid"])
+		assert.Equal(t, "grafana-operator", copied.Labels["app.kubernetes.io/managed-by"])
+		assert.Empty(t, copied.OwnerReferences)
+
+		err := cl.Get(ctx, types.NamespacedName{Namespace: "apps", Name: "ci-admin"}, &corev1.Secret{})
+		assert.True(t, apierrors.IsNotFound(err), "only the listed tokens are copied")
+
+		assert.Equal(t, []v1beta1.GrafanaServiceAccountSecretStatus{{Namespace: "apps", Name: "ci-api"}}, cr.Status.SecretCopies)
+
+		condition := meta.FindStatusCondition(cr.Status.Conditions, conditionTokenSecretsCopied)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditionReasonSecretTargetNotAllowed, condition.Reason)
+		assert.Contains(t, condition.Message, "kube-system")
+	})
+
+	t.Run("copies follow the token secret", func(t *testing.T) {
+		source.Data["token"] = []byte("key-3")
+		require.NoError(t, cl.Update(ctx, source))
+
+		require.NoError(t, r.syncSecretCopies(ctx, cr))
+
+		copied := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "apps", Name: "ci-api"}, copied))
+		assert.Equal(t, "key-3", string(copied.Data["token"]))
+	})
+
+	t.Run("secrets not copied by the service account are not overwritten", func(t *testing.T) {
+		cr.Spec.SecretTargets = append(cr.Spec.SecretTargets, v1beta1.GrafanaServiceAccountSecretTarget{Namespace: "jobs"})
+
+		err := r.syncSecretCopies(ctx, cr)
+		require.ErrorContains(t, err, "secret jobs/ci-api already exists and is not managed by the service account")
+
+		existing := &corev1.Secret{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "jobs", Name: "ci-api"}, existing))
+		assert.Equal(t, "other", string(existing.Data["token"]))
+
+		assert.NotContains(t, cr.Status.SecretCopies, v1beta1.GrafanaServiceAccountSecretStatus{Namespace: "jobs", Name: "ci-api"})
+		assert.Equal(t, conditionReasonApplyFailed, meta.FindStatusCondition(cr.Status.Conditions, conditionTokenSecretsCopied).Reason)
+	})
+
+	t.Run("copies are removed with their target", func(t *testing.T) {
+		cr.Spec.SecretTargets = nil
+
+		require.NoError(t, r.syncSecretCopies(ctx, cr))
+
+		err := cl.Get(ctx, types.NamespacedName{Namespace: "apps", Name: "ci-api"}, &corev1.Secret{})
+		assert.True(t, apierrors.IsNotFound(err))
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "jobs", Name: "ci-api"}, &corev1.Secret{}))
+
+		assert.Empty(t, cr.Status.SecretCopies)
+		assert.Nil(t, meta.FindStatusCondition(cr.Status.Conditions, conditionTokenSecretsCopied))
+	})
+}
+
+func TestRemoveUnselectedInstances(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cr := &v1beta1.GrafanaServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "ci", UID: "ci-uid"},
+		Spec: v1beta1.GrafanaServiceAccountSpec{
+			InstanceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}},
+			Tokens:           []v1beta1.GrafanaServiceAccountTokenSpec{{Name: "api"}},
+		},
+		Status: v1beta1.GrafanaServiceAccountStatus{
+			Instances: []v1beta1.GrafanaServiceAccountInstanceStatus{
+				{Instance: "deleted", Account: v1beta1.GrafanaServiceAccountInfo{ID: 1}},
+				{Instance: "selected", Account: v1beta1.GrafanaServiceAccountInfo{ID: 2}},
+				{Instance: "starting", Account: v1beta1.GrafanaServiceAccountInfo{ID: 3}},
+			},
+		},
+	}
+
+	deletedSecret := buildTokenSecret(ctx, cr, "deleted", cr.Spec.Tokens[0], v1beta1.GrafanaServiceAccountTokenStatus{Name: "api", ID: 1}, []byte("key-1"), nil)
+	deletedSecret.Name = "deleted-ci-api"
+	selectedSecret := buildTokenSecret(ctx, cr, "selected", cr.Spec.Tokens[0], v1beta1.GrafanaServiceAccountTokenStatus{Name: "api", ID: 1}, []byte("key-1"), nil)
+	selectedSecret.Name = "selected-ci-api"
+
+	starting := &v1beta1.Grafana{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "monitoring",
+		Name:      "starting",
+		Labels:    map[string]string{"dashboards": "grafana"},
+	}}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deletedSecret, selectedSecret, starting).Build()
+	r := &GrafanaServiceAccountReconciler{Client: cl}
+
+	selected := []v1beta1.Grafana{{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "selected"}}}
+	require.NoError(t, r.removeUnselectedInstances(ctx, cr, selected))
+
+	assert.Nil(t, cr.InstanceAccount("deleted"), "accounts of deleted instances are removed")
+	assert.NotNil(t, cr.InstanceAccount("selected"))
+	assert.NotNil(t, cr.InstanceAccount("starting"), "instances which are not ready are kept")
+
+	err := cl.Get(ctx, types.NamespacedName{Namespace: "monitoring", Name: "deleted-ci-api"}, &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err), "token secrets of removed accounts are deleted")
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "monitoring", Name: "selected-ci-api"}, &corev1.Secret{}))
+}
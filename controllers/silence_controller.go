@@ -0,0 +1,445 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+)
+
+const (
+	conditionSilenceSynchronized = "SilenceSynchronized"
+
+	defaultSilenceCreatedBy = "grafana-operator"
+)
+
+// GrafanaSilenceReconciler reconciles a GrafanaSilence object
+type GrafanaSilenceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Cfg    *Config
+}
+
+func (r *GrafanaSilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx).WithName("GrafanaSilenceReconciler")
+	ctx = logf.IntoContext(ctx, log)
+
+	cr := &v1beta1.GrafanaSilence{}
+
+	err := r.Get(ctx, req.NamespacedName, cr)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, LogMsgGettingCR)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingCR, err)
+	}
+
+	if cr.GetDeletionTimestamp() != nil {
+		// Check if resource needs clean up
+		if controllerutil.ContainsFinalizer(cr, grafanaFinalizer) {
+			if err := r.finalize(ctx, cr); err != nil {
+				log.Error(err, LogMsgRunningFinalizer)
+				return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgRunningFinalizer, err)
+			}
+
+			if err := removeFinalizer(ctx, r.Client, cr); err != nil {
+				log.Error(err, LogMsgRemoveFinalizer)
+				return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgRemoveFinalizer, err)
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	defer UpdateStatus(ctx, r.Client, cr)
+
+	if cr.Spec.Suspend {
+		setSuspended(&cr.Status.Conditions, cr.Generation, conditionReasonApplySuspended)
+		return ctrl.Result{}, nil
+	}
+
+	removeSuspended(&cr.Status.Conditions)
+
+	instances, err := GetScopedMatchingInstances(ctx, r.Client, cr)
+	if err != nil {
+		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionSilenceSynchronized)
+		log.Error(err, LogMsgGettingInstances)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
+	}
+
+	// Runs before the instances are checked, so that silences are expired when no instance is selected anymore
+	unselected, removeErrors := r.removeUnselectedInstances(ctx, cr, instances)
+
+	if len(instances) == 0 {
+		cr.Status.Silences = unselected
+
+		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionSilenceSynchronized)
+		log.Error(ErrNoMatchingInstances, LogMsgNoMatchingInstances)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgNoMatchingInstances, ErrNoMatchingInstances)
+	}
+
+	removeNoMatchingInstance(&cr.Status.Conditions)
+	log.V(1).Info(DbgMsgFoundMatchingInstances, "count", len(instances))
+
+	now := time.Now()
+	applyErrors := removeErrors
+
+	silences := make([]v1beta1.GrafanaSilenceInstanceStatus, 0, len(instances)+len(unselected))
+
+	for _, grafana := range instances {
+		key := fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)
+		status := silenceStatus(cr, key)
+
+		err := r.reconcileWithInstance(ctx, &grafana, cr, &status, now)
+		if err != nil {
+			applyErrors[key] = err.Error()
+		}
+
+		silences = append(silences, status)
+	}
+
+	cr.Status.Silences = append(silences, unselected...)
+
+	condition := buildSynchronizedCondition("Silence", conditionSilenceSynchronized, cr.Generation, applyErrors, len(instances)+len(removeErrors))
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+
+	if len(applyErrors) > 0 {
+		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
+		log.Error(err, LogMsgApplyErrors)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgApplyErrors, err)
+	}
+
+	startsAt, endsAt := silenceWindow(cr)
+
+	return ctrl.Result{RequeueAfter: nextSilenceTransition(r.Cfg.requeueAfter(cr.Spec.ResyncPeriod), now, startsAt, endsAt)}, nil
+}
+
+// removeUnselectedInstances expires the silences of instances which no longer match the instance selector and returns
+// the status of those which are still tracked: silences of instances which are not ready, or failed to be expired
+func (r *GrafanaSilenceReconciler) removeUnselectedInstances(ctx context.Context, cr *v1beta1.GrafanaSilence, instances []v1beta1.Grafana) ([]v1beta1.GrafanaSilenceInstanceStatus, map[string]string) {
+	log := logf.FromContext(ctx)
+
+	var tracked []v1beta1.GrafanaSilenceInstanceStatus
+
+	removeErrors := make(map[string]string)
+
+	for _, status := range cr.Status.Silences {
+		if slices.ContainsFunc(instances, func(g v1beta1.Grafana) bool { return fmt.Sprintf("%s/%s", g.Namespace, g.Name) == status.Instance }) {
+			continue
+		}
+
+		namespace, name, _ := strings.Cut(status.Instance, "/")
+		grafana := &v1beta1.Grafana{}
+
+		err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, grafana)
+		switch {
+		case apierrors.IsNotFound(err):
+			// The silence was removed along with the instance
+			continue
+		case err != nil:
+			removeErrors[status.Instance] = err.Error()
+		case grafana.Status.Stage != v1beta1.OperatorStageComplete || grafana.Status.StageStatus != v1beta1.OperatorStageResultSuccess:
+			// Not ready instances are not matched, their silences are expired once they can be reached again
+		default:
+			err = r.removeFromInstance(ctx, grafana, cr)
+			if err == nil {
+				err = grafana.RemoveNamespacedResource(ctx, r.Client, cr)
+			}
+
+			if err == nil {
+				log.Info("expired silence of unselected instance", "instance", status.Instance)
+				continue
+			}
+
+			removeErrors[status.Instance] = err.Error()
+		}
+
+		tracked = append(tracked, status)
+	}
+
+	return tracked, removeErrors
+}
+
+func (r *GrafanaSilenceReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaSilence, status *v1beta1.GrafanaSilenceInstanceStatus, now time.Time) error {
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
+	if err != nil {
+		return fmt.Errorf("building grafana client: %w", err)
+	}
+
+	err = syncSilence(ctx, gClient, cr, status, now)
+	if err != nil {
+		return err
+	}
+
+	// Update grafana instance Status
+	return instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource())
+}
+
+// syncSilence creates or updates the silence of cr and records its id and state in status.
+// Silences which expired before the end of their window, e.g. expired from the Grafana UI, are created again
+func syncSilence(ctx context.Context, gClient *genapi.GrafanaHTTPAPI, cr *v1beta1.GrafanaSilence, status *v1beta1.GrafanaSilenceInstanceStatus, now time.Time) error {
+	log := logf.FromContext(ctx)
+
+	remote, err := getSilence(gClient, status.ID)
+	if err != nil {
+		return err
+	}
+
+	active := remote != nil && remote.Status != nil && remote.Status.State != grafanaclient.SilenceStateExpired
+	desired := desiredSilence(cr)
+
+	if !desired.EndsAt.After(now) {
+		// the window is over, e.g. endsAt was moved into the past
+		if active {
+			err = grafanaclient.DeleteSilence(gClient, remote.ID)
+			if err != nil {
+				return fmt.Errorf("expiring silence: %w", err)
+			}
+		}
+
+		status.State = grafanaclient.SilenceStateExpired
+
+		return nil
+	}
+
+	if active {
+		if silenceUpToDate(remote, desired, now) {
+			status.State = remote.Status.State
+			return nil
+		}
+
+		desired.ID = remote.ID
+	} else if remote != nil {
+		log.Info("silence expired before the end of its window, creating it again", "silenceID", remote.ID)
+	}
+
+	id, err := grafanaclient.PostSilence(gClient, desired)
+	if err != nil {
+		return fmt.Errorf("posting silence: %w", err)
+	}
+
+	status.ID = id
+	status.State = silenceState(desired.StartsAt, desired.EndsAt, now)
+
+	return nil
+}
+
+// getSilence returns the silence with id, or nil if there is none
+func getSilence(gClient *genapi.GrafanaHTTPAPI, id string) (*grafanaclient.Silence, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	remote, err := grafanaclient.GetSilence(gClient, id)
+	if errors.Is(err, grafanaclient.ErrSilenceNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting silence: %w", err)
+	}
+
+	return remote, nil
+}
+
+// silenceStatus returns the recorded silence of the instance key, or an empty one if there is none yet
+func silenceStatus(cr *v1beta1.GrafanaSilence, key string) v1beta1.GrafanaSilenceInstanceStatus {
+	for _, status := range cr.Status.Silences {
+		if status.Instance == key {
+			return status
+		}
+	}
+
+	return v1beta1.GrafanaSilenceInstanceStatus{Instance: key}
+}
+
+// silenceWindow returns the start and end of the silence, start defaults to the creation of cr
+func silenceWindow(cr *v1beta1.GrafanaSilence) (time.Time, time.Time) {
+	startsAt := cr.CreationTimestamp.Time
+	if cr.Spec.StartsAt != nil {
+		startsAt = cr.Spec.StartsAt.Time
+	}
+
+	switch {
+	case cr.Spec.EndsAt != nil:
+		return startsAt, cr.Spec.EndsAt.Time
+	case cr.Spec.Duration != nil:
+		return startsAt, startsAt.Add(cr.Spec.Duration.Duration)
+	default:
+		return startsAt, startsAt
+	}
+}
+
+func desiredSilence(cr *v1beta1.GrafanaSilence) *grafanaclient.Silence {
+	startsAt, endsAt := silenceWindow(cr)
+
+	matchers := make([]grafanaclient.SilenceMatcher, 0, len(cr.Spec.Matchers))
+	for _, m := range cr.Spec.Matchers {
+		matchers = append(matchers, grafanaclient.SilenceMatcher{
+			Name:    m.Name,
+			Value:   m.Value,
+			IsRegex: m.IsRegex,
+			IsEqual: m.IsEqual,
+		})
+	}
+
+	return &grafanaclient.Silence{
+		Matchers:  matchers,
+		StartsAt:  startsAt.UTC(),
+		EndsAt:    endsAt.UTC(),
+		CreatedBy: cmp.Or(cr.Spec.CreatedBy, defaultSilenceCreatedBy),
+		Comment:   cr.Spec.Comment,
+	}
+}
+
+func silenceUpToDate(remote, desired *grafanaclient.Silence, now time.Time) bool {
+	// the Alertmanager moves start times in the past to the time the silence is created
+	startMatches := remote.StartsAt.Equal(desired.StartsAt) || (!desired.StartsAt.After(now) && !remote.StartsAt.After(now))
+
+	return startMatches &&
+		remote.EndsAt.Equal(desired.EndsAt) &&
+		remote.Comment == desired.Comment &&
+		remote.CreatedBy == desired.CreatedBy &&
+		slices.Equal(sortedMatchers(remote.Matchers), sortedMatchers(desired.Matchers))
+}
+
+func sortedMatchers(matchers []grafanaclient.SilenceMatcher) []grafanaclient.SilenceMatcher {
+	return slices.SortedFunc(slices.Values(matchers), func(a, b grafanaclient.SilenceMatcher) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Value, b.Value))
+	})
+}
+
+func silenceState(startsAt, endsAt, now time.Time) string {
+	switch {
+	case now.Before(startsAt):
+		return grafanaclient.SilenceStatePending
+	case now.Before(endsAt):
+		return grafanaclient.SilenceStateActive
+	default:
+		return grafanaclient.SilenceStateExpired
+	}
+}
+
+// nextSilenceTransition shortens the resync period so that the state is updated once the silence starts and ends
+func nextSilenceTransition(resync time.Duration, now, startsAt, endsAt time.Time) time.Duration {
+	for _, t := range []time.Time{startsAt, endsAt} {
+		until := t.Sub(now)
+		if until > 0 && (resync <= 0 || until < resync) {
+			resync = until
+		}
+	}
+
+	return resync
+}
+
+func (r *GrafanaSilenceReconciler) finalize(ctx context.Context, cr *v1beta1.GrafanaSilence) error {
+	log := logf.FromContext(ctx)
+	log.Info("Finalizing GrafanaSilence")
+
+	instances, err := GetScopedMatchingInstances(ctx, r.Client, cr)
+	if err != nil {
+		log.Error(err, LogMsgGettingInstances)
+		return fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
+	}
+
+	for _, instance := range instances {
+		if err := r.removeFromInstance(ctx, &instance, cr); err != nil {
+			return fmt.Errorf("removing silence from instance: %w", err)
+		}
+
+		// Update grafana instance Status
+		err = instance.RemoveNamespacedResource(ctx, r.Client, cr)
+		if err != nil {
+			return fmt.Errorf("removing silence from Grafana cr: %w", err)
+		}
+	}
+
+	// silences of instances which were deselected but could not be expired yet
+	_, removeErrors := r.removeUnselectedInstances(ctx, cr, instances)
+	if len(removeErrors) > 0 {
+		return fmt.Errorf("removing silence from unselected instances: %v", removeErrors)
+	}
+
+	return nil
+}
+
+func (r *GrafanaSilenceReconciler) removeFromInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaSilence) error {
+	status := silenceStatus(cr, fmt.Sprintf("%s/%s", instance.Namespace, instance.Name))
+
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
+	if isOrgRemoved(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("building grafana client: %w", err)
+	}
+
+	return expireSilence(gClient, status.ID)
+}
+
+// expireSilence expires the silence with id, silences which are gone or already expired are left alone
+func expireSilence(gClient *genapi.GrafanaHTTPAPI, id string) error {
+	remote, err := getSilence(gClient, id)
+	if err != nil {
+		return err
+	}
+
+	if remote == nil || remote.Status == nil || remote.Status.State == grafanaclient.SilenceStateExpired {
+		return nil
+	}
+
+	err = grafanaclient.DeleteSilence(gClient, id)
+	if err != nil {
+		return fmt.Errorf("expiring silence: %w", err)
+	}
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GrafanaSilenceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.GrafanaSilence{}).
+		WithEventFilter(ignoreStatusUpdates()).
+		Complete(r)
+}
@@ -0,0 +1,404 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("Silence Reconciler: Provoke Conditions", func() {
+	matchers := []v1beta1.SilenceMatcher{
+		{Name: "namespace", Value: "databases", IsEqual: true},
+	}
+
+	tests := []struct {
+		name    string
+		meta    metav1.ObjectMeta
+		spec    v1beta1.GrafanaSilenceSpec
+		want    metav1.Condition
+		wantErr string
+	}{
+		{
+			name: ".spec.suspend=true",
+			meta: objectMetaSuspended,
+			spec: v1beta1.GrafanaSilenceSpec{
+				GrafanaCommonSpec: commonSpecSuspended,
+				Matchers:          matchers,
+				Duration:          &metav1.Duration{Duration: time.Hour},
+				Comment:           "Suspended",
+			},
+			want: metav1.Condition{
+				Type:   conditionSuspended,
+				Reason: conditionReasonApplySuspended,
+			},
+		},
+		{
+			name: "GetScopedMatchingInstances returns empty list",
+			meta: objectMetaNoMatchingInstances,
+			spec: v1beta1.GrafanaSilenceSpec{
+				GrafanaCommonSpec: commonSpecNoMatchingInstances,
+				Matchers:          matchers,
+				Duration:          &metav1.Duration{Duration: time.Hour},
+				Comment:           "NoMatchingInstances",
+			},
+			want: metav1.Condition{
+				Type:   conditionNoMatchingInstance,
+				Reason: conditionReasonEmptyAPIReply,
+			},
+			wantErr: ErrNoMatchingInstances.Error(),
+		},
+		{
+			name: "Failed to apply to instance",
+			meta: objectMetaApplyFailed,
+			spec: v1beta1.GrafanaSilenceSpec{
+				GrafanaCommonSpec: commonSpecApplyFailed,
+				Matchers:          matchers,
+				Duration:          &metav1.Duration{Duration: time.Hour},
+				Comment:           "ApplyFailed",
+			},
+			want: metav1.Condition{
+				Type:   conditionSilenceSynchronized,
+				Reason: conditionReasonApplyFailed,
+			},
+			wantErr: LogMsgApplyErrors,
+		},
+		{
+			name: "Successfully applied resource to instance",
+			meta: objectMetaSynchronized,
+			spec: v1beta1.GrafanaSilenceSpec{
+				GrafanaCommonSpec: commonSpecSynchronized,
+				Matchers:          matchers,
+				Duration:          &metav1.Duration{Duration: time.Hour},
+				Comment:           "Synchronized",
+			},
+			want: metav1.Condition{
+				Type:   conditionSilenceSynchronized,
+				Reason: conditionReasonApplySuccessful,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		It(tt.name, func() {
+			cr := &v1beta1.GrafanaSilence{
+				ObjectMeta: tt.meta,
+				Spec:       tt.spec,
+			}
+
+			r := &GrafanaSilenceReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			reconcileAndValidateCondition(r, cr, tt.want, tt.wantErr)
+		})
+	}
+})
+
+// fakeAlertmanager serves the silence endpoints of the Grafana Alertmanager from memory
+type fakeAlertmanager struct {
+	url      string
+	mu       sync.Mutex
+	silences map[string]*grafanaclient.Silence
+	created  int
+}
+
+func newFakeAlertmanager(t *testing.T) (*fakeAlertmanager, *genapi.GrafanaHTTPAPI) {
+	t.Helper()
+
+	am := &fakeAlertmanager{silences: map[string]*grafanaclient.Silence{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/alertmanager/grafana/api/v2/silences", am.post)
+	mux.HandleFunc("GET /api/alertmanager/grafana/api/v2/silence/{id}", am.get)
+	mux.HandleFunc("DELETE /api/alertmanager/grafana/api/v2/silence/{id}", am.delete)
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	am.url = ts.URL
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	return am, genapi.NewHTTPClientWithConfig(nil, &genapi.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	})
+}
+
+func (am *fakeAlertmanager) post(w http.ResponseWriter, r *http.Request) {
+	silence := &grafanaclient.Silence{}
+
+	err := json.NewDecoder(r.Body).Decode(silence)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	// like the Alertmanager, start times in the past are moved to now
+	if silence.StartsAt.Before(time.Now()) {
+		silence.StartsAt = time.Now().UTC()
+	}
+
+	if _, ok := am.silences[silence.ID]; !ok {
+		am.created++
+		silence.ID = string(rune('a' + am.created - 1))
+	}
+
+	silence.Status = &grafanaclient.SilenceStatus{State: grafanaclient.SilenceStateActive}
+	am.silences[silence.ID] = silence
+
+	writeJSON(w, map[string]string{"silenceID": silence.ID})
+}
+
+func (am *fakeAlertmanager) get(w http.ResponseWriter, r *http.Request) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	silence, ok := am.silences[r.PathValue("id")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, silence)
+}
+
+func (am *fakeAlertmanager) delete(w http.ResponseWriter, r *http.Request) {
+	am.expire(r.PathValue("id"))
+}
+
+func (am *fakeAlertmanager) expire(id string) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if silence, ok := am.silences[id]; ok {
+		silence.Status.State = grafanaclient.SilenceStateExpired
+	}
+}
+
+func (am *fakeAlertmanager) state(id string) string {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	return am.silences[id].Status.State
+}
+
+func TestSilenceWindow(t *testing.T) {
+	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	start := metav1.NewTime(created.Add(time.Hour))
+	end := metav1.NewTime(created.Add(3 * time.Hour))
+
+	tests := []struct {
+		name      string
+		spec      v1beta1.GrafanaSilenceSpec
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "duration from creation",
+			spec:      v1beta1.GrafanaSilenceSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+			wantStart: created,
+			wantEnd:   created.Add(time.Hour),
+		},
+		{
+			name:      "duration from startsAt",
+			spec:      v1beta1.GrafanaSilenceSpec{StartsAt: &start, Duration: &metav1.Duration{Duration: time.Hour}},
+			wantStart: start.Time,
+			wantEnd:   start.Add(time.Hour),
+		},
+		{
+			name:      "endsAt",
+			spec:      v1beta1.GrafanaSilenceSpec{StartsAt: &start, EndsAt: &end},
+			wantStart: start.Time,
+			wantEnd:   end.Time,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1beta1.GrafanaSilence{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+				Spec:       tt.spec,
+			}
+
+			gotStart, gotEnd := silenceWindow(cr)
+			assert.True(t, tt.wantStart.Equal(gotStart), "start %s", gotStart)
+			assert.True(t, tt.wantEnd.Equal(gotEnd), "end %s", gotEnd)
+		})
+	}
+}
+
+func TestSilenceState(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, grafanaclient.SilenceStatePending, silenceState(now.Add(time.Minute), now.Add(time.Hour), now))
+	assert.Equal(t, grafanaclient.SilenceStateActive, silenceState(now.Add(-time.Minute), now.Add(time.Hour), now))
+	assert.Equal(t, grafanaclient.SilenceStateExpired, silenceState(now.Add(-time.Hour), now, now))
+}
+
+func TestNextSilenceTransition(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, 10*time.Minute, nextSilenceTransition(10*time.Minute, now, now.Add(-time.Hour), now.Add(time.Hour)))
+	assert.Equal(t, 5*time.Minute, nextSilenceTransition(10*time.Minute, now, now.Add(5*time.Minute), now.Add(time.Hour)))
+	assert.Equal(t, 5*time.Minute, nextSilenceTransition(10*time.Minute, now, now.Add(-time.Hour), now.Add(5*time.Minute)))
+	assert.Equal(t, time.Hour, nextSilenceTransition(0, now, now.Add(-time.Hour), now.Add(time.Hour)), "disabled resyncs still requeue at the end")
+	assert.Equal(t, time.Duration(0), nextSilenceTransition(0, now, now.Add(-2*time.Hour), now.Add(-time.Hour)))
+}
+
+func TestSyncSilence(t *testing.T) {
+	ctx := context.Background()
+	am, gClient := newFakeAlertmanager(t)
+
+	cr := &v1beta1.GrafanaSilence{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "maintenance",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second)),
+		},
+		Spec: v1beta1.GrafanaSilenceSpec{
+			Matchers: []v1beta1.SilenceMatcher{
+				{Name: "namespace", Value: "databases", IsEqual: true},
+				{Name: "alertname", Value: "Disk.*", IsRegex: true, IsEqual: true},
+			},
+			Duration: &metav1.Duration{Duration: time.Hour},
+			Comment:  "Database maintenance",
+		},
+	}
+
+	status := v1beta1.GrafanaSilenceInstanceStatus{Instance: "default/grafana"}
+
+	t.Run("silence is created", func(t *testing.T) {
+		require.NoError(t, syncSilence(ctx, gClient, cr, &status, time.Now()))
+		assert.Equal(t, "a", status.ID)
+		assert.Equal(t, grafanaclient.SilenceStateActive, status.State)
+		assert.Equal(t, defaultSilenceCreatedBy, am.silences["a"].CreatedBy)
+	})
+
+	t.Run("up to date silence is left alone", func(t *testing.T) {
+		require.NoError(t, syncSilence(ctx, gClient, cr, &status, time.Now()))
+		assert.Equal(t, "a", status.ID)
+		assert.Equal(t, 1, am.created)
+	})
+
+	t.Run("changed silence is updated in place", func(t *testing.T) {
+		cr.Spec.Comment = "Extended database maintenance"
+		cr.Spec.Duration = &metav1.Duration{Duration: 2 * time.Hour}
+
+		require.NoError(t, syncSilence(ctx, gClient, cr, &status, time.Now()))
+		assert.Equal(t, "a", status.ID)
+		assert.Equal(t, "Extended database maintenance", am.silences["a"].Comment)
+		assert.Equal(t, 1, am.created)
+	})
+
+	t.Run("silence expired early is created again", func(t *testing.T) {
+		am.expire("a")
+
+		require.NoError(t, syncSilence(ctx, gClient, cr, &status, time.Now()))
+		assert.Equal(t, "b", status.ID)
+		assert.Equal(t, grafanaclient.SilenceStateActive, status.State)
+	})
+
+	t.Run("silence is expired once its window is over", func(t *testing.T) {
+		require.NoError(t, syncSilence(ctx, gClient, cr, &status, time.Now().Add(3*time.Hour)))
+		assert.Equal(t, grafanaclient.SilenceStateExpired, status.State)
+		assert.Equal(t, grafanaclient.SilenceStateExpired, am.state("b"))
+		assert.Equal(t, 2, am.created, "silences are not created after their window")
+	})
+
+	t.Run("expiring gone silences succeeds", func(t *testing.T) {
+		require.NoError(t, expireSilence(gClient, "b"))
+		require.NoError(t, expireSilence(gClient, "missing"))
+		require.NoError(t, expireSilence(gClient, ""))
+	})
+}
+
+func TestSilenceRemoveUnselectedInstances(t *testing.T) {
+	ctx := context.Background()
+	am, gClient := newFakeAlertmanager(t)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cr := &v1beta1.GrafanaSilence{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "maintenance", CreationTimestamp: metav1.Now()},
+		Spec: v1beta1.GrafanaSilenceSpec{
+			Matchers: []v1beta1.SilenceMatcher{{Name: "namespace", Value: "databases", IsEqual: true}},
+			Duration: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+
+	deselected := v1beta1.GrafanaSilenceInstanceStatus{Instance: "default/deselected"}
+	require.NoError(t, syncSilence(ctx, gClient, cr, &deselected, time.Now()))
+
+	cr.Status.Silences = []v1beta1.GrafanaSilenceInstanceStatus{
+		deselected,
+		{Instance: "default/deleted", ID: "deleted"},
+		{Instance: "default/starting", ID: "starting"},
+		{Instance: "default/selected", ID: "selected"},
+	}
+
+	apiKey := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "grafana-api-key"},
+		Data:       map[string][]byte{"key": []byte("key")},
+	}
+
+	grafana := func(name string, ready bool) *v1beta1.Grafana {
+		cr := &v1beta1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: v1beta1.GrafanaSpec{
+				External: &v1beta1.External{
+					URL: am.url,
+					APIKey: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: apiKey.Name},
+						Key:                  "key",
+					},
+				},
+			},
+		}
+
+		if ready {
+			cr.Status = v1beta1.GrafanaStatus{
+				Stage:       v1beta1.OperatorStageComplete,
+				StageStatus: v1beta1.OperatorStageResultSuccess,
+				AdminURL:    am.url,
+			}
+		}
+
+		return cr
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(apiKey, grafana("deselected", true), grafana("starting", false)).
+		WithStatusSubresource(&v1beta1.Grafana{}).
+		Build()
+	r := &GrafanaSilenceReconciler{Client: cl}
+
+	tracked, removeErrors := r.removeUnselectedInstances(ctx, cr, []v1beta1.Grafana{*grafana("selected", true)})
+	assert.Empty(t, removeErrors)
+	assert.Equal(t, []v1beta1.GrafanaSilenceInstanceStatus{{Instance: "default/starting", ID: "starting"}}, tracked,
+		"silences of instances which are not ready are kept until they can be expired")
+	assert.Equal(t, grafanaclient.SilenceStateExpired, am.state(deselected.ID), "silences of deselected instances are expired")
+}
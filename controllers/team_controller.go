@@ -0,0 +1,334 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/teams"
+	"github.com/grafana/grafana-openapi-client-go/client/users"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+)
+
+const (
+	conditionTeamSynchronized = "TeamSynchronized"
+)
+
+// GrafanaTeamReconciler reconciles a GrafanaTeam object
+type GrafanaTeamReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Cfg    *Config
+}
+
+func (r *GrafanaTeamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx).WithName("GrafanaTeamReconciler")
+	ctx = logf.IntoContext(ctx, log)
+
+	cr := &v1beta1.GrafanaTeam{}
+
+	err := r.Get(ctx, req.NamespacedName, cr)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, LogMsgGettingCR)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingCR, err)
+	}
+
+	if cr.GetDeletionTimestamp() != nil {
+		// Check if resource needs clean up
+		if controllerutil.ContainsFinalizer(cr, grafanaFinalizer) {
+			if err := r.finalize(ctx, cr); err != nil {
+				log.Error(err, LogMsgRunningFinalizer)
+				return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgRunningFinalizer, err)
+			}
+
+			if err := removeFinalizer(ctx, r.Client, cr); err != nil {
+				log.Error(err, LogMsgRemoveFinalizer)
+				return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgRemoveFinalizer, err)
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	defer UpdateStatus(ctx, r.Client, cr)
+
+	if cr.Spec.Suspend {
+		setSuspended(&cr.Status.Conditions, cr.Generation, conditionReasonApplySuspended)
+		return ctrl.Result{}, nil
+	}
+
+	removeSuspended(&cr.Status.Conditions)
+
+	instances, err := GetScopedMatchingInstances(ctx, r.Client, cr)
+	if err != nil {
+		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionTeamSynchronized)
+		cr.Status.TeamID = 0
+		log.Error(err, LogMsgGettingInstances)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
+	}
+
+	if len(instances) == 0 {
+		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionTeamSynchronized)
+		cr.Status.TeamID = 0
+		log.Error(ErrNoMatchingInstances, LogMsgNoMatchingInstances)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgNoMatchingInstances, ErrNoMatchingInstances)
+	}
+
+	removeNoMatchingInstance(&cr.Status.Conditions)
+	log.V(1).Info(DbgMsgFoundMatchingInstances, "count", len(instances))
+
+	applyErrors := make(map[string]string)
+	teamIDs := make(map[int64]struct{})
+
+	for _, grafana := range instances {
+		teamID, err := r.reconcileWithInstance(ctx, &grafana, cr)
+		if err != nil {
+			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
+			continue
+		}
+
+		teamIDs[teamID] = struct{}{}
+	}
+
+	// Team IDs are allocated per instance, only surface the ID when it is unambiguous
+	cr.Status.TeamID = 0
+
+	if len(teamIDs) == 1 && len(applyErrors) == 0 {
+		for id := range teamIDs {
+			cr.Status.TeamID = id
+		}
+	}
+
+	condition := buildSynchronizedCondition("Team", conditionTeamSynchronized, cr.Generation, applyErrors, len(instances))
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+
+	if len(applyErrors) > 0 {
+		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
+		log.Error(err, LogMsgApplyErrors)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgApplyErrors, err)
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
+}
+
+func (r *GrafanaTeamReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaTeam) (int64, error) {
+	orgID, err := getOrgID(instance, cr.Namespace, cr.Spec.OrgRef)
+	if err != nil {
+		return 0, err
+	}
+
+	gClient, err := grafanaclient.NewGeneratedGrafanaClientForOrg(ctx, r.Client, instance, orgID)
+	if err != nil {
+		return 0, fmt.Errorf("building grafana client: %w", err)
+	}
+
+	team, err := getTeamByName(gClient, cr.GetGrafanaName())
+	if err != nil {
+		return 0, err
+	}
+
+	var teamID int64
+
+	if team == nil {
+		resp, err := gClient.Teams.CreateTeam(&models.CreateTeamCommand{
+			Name:  new(cr.GetGrafanaName()),
+			Email: cr.Spec.Email,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("creating team: %w", err)
+		}
+
+		teamID = resp.Payload.TeamID
+	} else {
+		teamID = *team.ID
+
+		if team.Email != cr.Spec.Email {
+			_, err = gClient.Teams.UpdateTeam(strconv.FormatInt(teamID, 10), &models.UpdateTeamCommand{ //nolint:errcheck
+				Name:  cr.GetGrafanaName(),
+				Email: cr.Spec.Email,
+			})
+			if err != nil {
+				return 0, fmt.Errorf("updating team: %w", err)
+			}
+		}
+	}
+
+	err = r.syncMembers(gClient, teamID, cr.Spec.Members)
+	if err != nil {
+		return 0, err
+	}
+
+	// Update grafana instance Status
+	err = instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(strconv.FormatInt(orgID, 10), strconv.FormatInt(teamID, 10)))
+	if err != nil {
+		return 0, err
+	}
+
+	return teamID, nil
+}
+
+// syncMembers makes the team members in Grafana match the desired members
+func (r *GrafanaTeamReconciler) syncMembers(gClient *genapi.GrafanaHTTPAPI, teamID int64, members []v1beta1.GrafanaTeamMember) error {
+	id := strconv.FormatInt(teamID, 10)
+
+	desired := make(map[int64]string, len(members))
+
+	for _, member := range members {
+		user, err := gClient.Users.GetUserByLoginOrEmail(member.Identifier())
+		if err != nil {
+			if IsErrorType[*users.GetUserByLoginOrEmailNotFound](err) {
+				return fmt.Errorf("user %q does not exist", member.Identifier())
+			}
+
+			return fmt.Errorf("getting user %q: %w", member.Identifier(), err)
+		}
+
+		desired[user.Payload.ID] = member.Identifier()
+	}
+
+	current, err := gClient.Teams.GetTeamMembers(id)
+	if err != nil {
+		return fmt.Errorf("getting team members: %w", err)
+	}
+
+	existing := make(map[int64]bool, len(current.Payload))
+
+	for _, member := range current.Payload {
+		existing[member.UserID] = true
+
+		if _, ok := desired[member.UserID]; ok {
+			continue
+		}
+
+		_, err = gClient.Teams.RemoveTeamMember(member.UserID, id) //nolint:errcheck
+		if err != nil && IsNotErrorType[*teams.RemoveTeamMemberNotFound](err) {
+			return fmt.Errorf("removing team member %q: %w", member.Login, err)
+		}
+	}
+
+	for userID, identifier := range desired {
+		if existing[userID] {
+			continue
+		}
+
+		_, err = gClient.Teams.AddTeamMember(id, &models.AddTeamMemberCommand{UserID: &userID}) //nolint:errcheck
+		if err != nil {
+			return fmt.Errorf("adding team member %q: %w", identifier, err)
+		}
+	}
+
+	return nil
+}
+
+// getTeamByName returns the team with an exact name match, or nil if it does not exist
+func getTeamByName(gClient *genapi.GrafanaHTTPAPI, name string) (*models.TeamDTO, error) {
+	resp, err := gClient.Teams.SearchTeams(teams.NewSearchTeamsParams().WithName(&name))
+	if err != nil {
+		return nil, fmt.Errorf("searching teams: %w", err)
+	}
+
+	for _, team := range resp.Payload.Teams {
+		if team.Name != nil && *team.Name == name && team.ID != nil {
+			return team, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *GrafanaTeamReconciler) finalize(ctx context.Context, cr *v1beta1.GrafanaTeam) error {
+	log := logf.FromContext(ctx)
+	log.Info("Finalizing GrafanaTeam")
+
+	instances, err := GetScopedMatchingInstances(ctx, r.Client, cr)
+	if err != nil {
+		log.Error(err, LogMsgGettingInstances)
+		return fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
+	}
+
+	for _, instance := range instances {
+		if err := r.removeFromInstance(ctx, &instance, cr); err != nil {
+			return fmt.Errorf("removing team from instance: %w", err)
+		}
+
+		// Update grafana instance Status
+		err = instance.RemoveNamespacedResource(ctx, r.Client, cr)
+		if err != nil {
+			return fmt.Errorf("removing team from Grafana cr: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *GrafanaTeamReconciler) removeFromInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaTeam) error {
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
+	if isOrgRemoved(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("building grafana client: %w", err)
+	}
+
+	team, err := getTeamByName(gClient, cr.GetGrafanaName())
+	if err != nil {
+		return err
+	}
+
+	if team == nil {
+		return nil
+	}
+
+	_, err = gClient.Teams.DeleteTeamByID(strconv.FormatInt(*team.ID, 10)) //nolint:errcheck
+	if err != nil && IsNotErrorType[*teams.DeleteTeamByIDNotFound](err) {
+		return fmt.Errorf("deleting team: %w", err)
+	}
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GrafanaTeamReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.GrafanaTeam{}).
+		WithEventFilter(ignoreStatusUpdates()).
+		Complete(r)
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("Team Reconciler: Provoke Conditions", func() {
+	tests := []struct {
+		name    string
+		meta    metav1.ObjectMeta
+		spec    v1beta1.GrafanaTeamSpec
+		want    metav1.Condition
+		wantErr string
+	}{
+		{
+			name: ".spec.suspend=true",
+			meta: objectMetaSuspended,
+			spec: v1beta1.GrafanaTeamSpec{
+				GrafanaCommonSpec: commonSpecSuspended,
+			},
+			want: metav1.Condition{
+				Type:   conditionSuspended,
+				Reason: conditionReasonApplySuspended,
+			},
+		},
+		{
+			name: "GetScopedMatchingInstances returns empty list",
+			meta: objectMetaNoMatchingInstances,
+			spec: v1beta1.GrafanaTeamSpec{
+				GrafanaCommonSpec: commonSpecNoMatchingInstances,
+			},
+			want: metav1.Condition{
+				Type:   conditionNoMatchingInstance,
+				Reason: conditionReasonEmptyAPIReply,
+			},
+			wantErr: ErrNoMatchingInstances.Error(),
+		},
+		{
+			name: "Failed to apply to instance",
+			meta: objectMetaApplyFailed,
+			spec: v1beta1.GrafanaTeamSpec{
+				GrafanaCommonSpec: commonSpecApplyFailed,
+			},
+			want: metav1.Condition{
+				Type:   conditionTeamSynchronized,
+				Reason: conditionReasonApplyFailed,
+			},
+			wantErr: LogMsgApplyErrors,
+		},
+		{
+			name: "Successfully applied resource to instance",
+			meta: objectMetaSynchronized,
+			spec: v1beta1.GrafanaTeamSpec{
+				GrafanaCommonSpec: commonSpecSynchronized,
+				Members: []v1beta1.GrafanaTeamMember{
+					{Login: "admin"},
+				},
+			},
+			want: metav1.Condition{
+				Type:   conditionTeamSynchronized,
+				Reason: conditionReasonApplySuccessful,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		It(tt.name, func() {
+			cr := &v1beta1.GrafanaTeam{
+				ObjectMeta: tt.meta,
+				Spec:       tt.spec,
+			}
+
+			r := &GrafanaTeamReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			reconcileAndValidateCondition(r, cr, tt.want, tt.wantErr)
+		})
+	}
+})
+
+var _ = Describe("Team Reconciler: Membership sync", func() {
+	It("Removes members added outside of the operator", func() {
+		t := GinkgoT()
+
+		cr := &v1beta1.GrafanaTeam{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "membership-sync",
+			},
+			Spec: v1beta1.GrafanaTeamSpec{
+				GrafanaCommonSpec: commonSpecSynchronized,
+				Email:             "team@example.com",
+			},
+		}
+
+		r := &GrafanaTeamReconciler{Client: cl, Scheme: cl.Scheme()}
+
+		err := cl.Create(testCtx, cr)
+		require.NoError(t, err)
+
+		req := tk8s.GetRequest(t, cr)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		err = r.Get(testCtx, req.NamespacedName, cr)
+		require.NoError(t, err)
+		require.NotZero(t, cr.Status.TeamID)
+
+		gClient, err := grafanaclient.NewGeneratedGrafanaClient(testCtx, cl, externalGrafanaCr)
+		require.NoError(t, err)
+
+		teamID := strconv.FormatInt(cr.Status.TeamID, 10)
+
+		admin, err := gClient.Users.GetUserByLoginOrEmail("admin")
+		require.NoError(t, err)
+
+		_, err = gClient.Teams.AddTeamMember(teamID, &models.AddTeamMemberCommand{UserID: &admin.Payload.ID}) //nolint:errcheck
+		require.NoError(t, err)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		members, err := gClient.Teams.GetTeamMembers(teamID)
+		require.NoError(t, err)
+		require.Empty(t, members.Payload)
+
+		err = cl.Delete(testCtx, cr)
+		require.NoError(t, err)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		team, err := getTeamByName(gClient, cr.GetGrafanaName())
+		require.NoError(t, err)
+		require.Nil(t, team)
+	})
+})
@@ -0,0 +1,449 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/admin_users"
+	"github.com/grafana/grafana-openapi-client-go/client/org"
+	"github.com/grafana/grafana-openapi-client-go/client/users"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+)
+
+const (
+	conditionUserSynchronized = "UserSynchronized"
+
+	userPasswordSecretIndexKey = ".spec.password.secretKeyRef"
+)
+
+// GrafanaUserReconciler reconciles a GrafanaUser object
+type GrafanaUserReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Cfg    *Config
+}
+
+func (r *GrafanaUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx).WithName("GrafanaUserReconciler")
+	ctx = logf.IntoContext(ctx, log)
+
+	cr := &v1beta1.GrafanaUser{}
+
+	err := r.Get(ctx, req.NamespacedName, cr)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, LogMsgGettingCR)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingCR, err)
+	}
+
+	if cr.GetDeletionTimestamp() != nil {
+		// Check if resource needs clean up
+		if controllerutil.ContainsFinalizer(cr, grafanaFinalizer) {
+			if err := r.finalize(ctx, cr); err != nil {
+				log.Error(err, LogMsgRunningFinalizer)
+				return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgRunningFinalizer, err)
+			}
+
+			if err := removeFinalizer(ctx, r.Client, cr); err != nil {
+				log.Error(err, LogMsgRemoveFinalizer)
+				return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgRemoveFinalizer, err)
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	defer UpdateStatus(ctx, r.Client, cr)
+
+	if cr.Spec.Suspend {
+		setSuspended(&cr.Status.Conditions, cr.Generation, conditionReasonApplySuspended)
+		return ctrl.Result{}, nil
+	}
+
+	removeSuspended(&cr.Status.Conditions)
+
+	var passwordVersion string
+
+	password, _, err := getReferencedValue(ctx, r.Client, cr.Namespace, cr.Spec.Password)
+	if err == nil {
+		passwordVersion, err = getPasswordSecretVersion(ctx, r.Client, cr)
+	}
+
+	if err != nil {
+		setInvalidSpec(&cr.Status.Conditions, cr.Generation, "InvalidPassword", err.Error())
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionUserSynchronized)
+
+		return ctrl.Result{}, fmt.Errorf("resolving password: %w", err)
+	}
+
+	removeInvalidSpec(&cr.Status.Conditions)
+
+	instances, err := GetScopedMatchingInstances(ctx, r.Client, cr)
+	if err != nil {
+		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionUserSynchronized)
+		cr.Status.UserID = 0
+		log.Error(err, LogMsgGettingInstances)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
+	}
+
+	if len(instances) == 0 {
+		setNoMatchingInstancesCondition(&cr.Status.Conditions, cr.Generation, err)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, conditionUserSynchronized)
+		cr.Status.UserID = 0
+		log.Error(ErrNoMatchingInstances, LogMsgNoMatchingInstances)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgNoMatchingInstances, ErrNoMatchingInstances)
+	}
+
+	removeNoMatchingInstance(&cr.Status.Conditions)
+	log.V(1).Info(DbgMsgFoundMatchingInstances, "count", len(instances))
+
+	// Passwords of existing users are only updated when the referenced Secret changed
+	rotate := cr.Status.PasswordSecretVersion != passwordVersion
+
+	applyErrors := make(map[string]string)
+	userIDs := make(map[int64]struct{})
+
+	for _, grafana := range instances {
+		userID, err := r.reconcileWithInstance(ctx, &grafana, cr, password, rotate)
+		if err != nil {
+			applyErrors[fmt.Sprintf("%s/%s", grafana.Namespace, grafana.Name)] = err.Error()
+			continue
+		}
+
+		userIDs[userID] = struct{}{}
+	}
+
+	// User IDs are allocated per instance, only surface the ID when it is unambiguous
+	cr.Status.UserID = 0
+
+	if len(userIDs) == 1 && len(applyErrors) == 0 {
+		for id := range userIDs {
+			cr.Status.UserID = id
+		}
+	}
+
+	// Retry the rotation on all instances until it succeeded everywhere
+	if rotate && len(applyErrors) == 0 {
+		cr.Status.PasswordSecretVersion = passwordVersion
+		cr.Status.LastPasswordRotation = new(metav1.Now())
+	}
+
+	condition := buildSynchronizedCondition("User", conditionUserSynchronized, cr.Generation, applyErrors, len(instances))
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+
+	if len(applyErrors) > 0 {
+		err = fmt.Errorf(FmtStrApplyErrors, applyErrors)
+		log.Error(err, LogMsgApplyErrors)
+
+		return ctrl.Result{}, fmt.Errorf("%s: %w", LogMsgApplyErrors, err)
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.requeueAfter(cr.Spec.ResyncPeriod)}, nil
+}
+
+func (r *GrafanaUserReconciler) reconcileWithInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaUser, password string, rotate bool) (int64, error) {
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
+	if err != nil {
+		return 0, fmt.Errorf("building grafana client: %w", err)
+	}
+
+	user, err := getUserByLogin(gClient, cr.GetGrafanaLogin())
+	if err != nil {
+		return 0, err
+	}
+
+	if user == nil {
+		resp, err := gClient.AdminUsers.AdminCreateUser(&models.AdminCreateUserForm{
+			Login:    cr.GetGrafanaLogin(),
+			Email:    cr.Spec.Email,
+			Name:     cr.Spec.Name,
+			Password: models.Password(password),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("creating user: %w", err)
+		}
+
+		user = &models.UserProfileDTO{
+			ID:    resp.Payload.ID,
+			Login: cr.GetGrafanaLogin(),
+			Email: cr.Spec.Email,
+			Name:  cr.Spec.Name,
+		}
+	} else {
+		err = r.updateUser(gClient, cr, user, password, rotate)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if user.IsGrafanaAdmin != cr.Spec.GrafanaAdmin {
+		_, err = gClient.AdminUsers.AdminUpdateUserPermissions(user.ID, &models.AdminUpdateUserPermissionsForm{ //nolint:errcheck
+			IsGrafanaAdmin: cr.Spec.GrafanaAdmin,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("updating server admin permission: %w", err)
+		}
+	}
+
+	if user.IsDisabled != cr.Spec.Disabled {
+		if cr.Spec.Disabled {
+			_, err = gClient.AdminUsers.AdminDisableUser(user.ID) //nolint:errcheck
+		} else {
+			_, err = gClient.AdminUsers.AdminEnableUser(user.ID) //nolint:errcheck
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("updating disabled state: %w", err)
+		}
+	}
+
+	err = syncOrgRole(gClient, user, cr.GetRole())
+	if err != nil {
+		return 0, err
+	}
+
+	// Update grafana instance Status
+	err = instance.AddNamespacedResource(ctx, r.Client, cr, cr.NamespacedResource(strconv.FormatInt(user.ID, 10)))
+	if err != nil {
+		return 0, err
+	}
+
+	return user.ID, nil
+}
+
+func (r *GrafanaUserReconciler) updateUser(gClient *genapi.GrafanaHTTPAPI, cr *v1beta1.GrafanaUser, user *models.UserProfileDTO, password string, rotate bool) error {
+	if user.Email != cr.Spec.Email || user.Name != cr.Spec.Name {
+		_, err := gClient.Users.UpdateUser(user.ID, &models.UpdateUserCommand{ //nolint:errcheck
+			Login: user.Login,
+			Email: cr.Spec.Email,
+			Name:  cr.Spec.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("updating user: %w", err)
+		}
+	}
+
+	if rotate {
+		_, err := gClient.AdminUsers.AdminUpdateUserPassword(user.ID, &models.AdminUpdateUserPasswordForm{ //nolint:errcheck
+			Password: models.Password(password),
+		})
+		if err != nil {
+			return fmt.Errorf("updating password: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// syncOrgRole adds the user to the organization of the client or updates its role
+func syncOrgRole(gClient *genapi.GrafanaHTTPAPI, user *models.UserProfileDTO, role string) error {
+	resp, err := gClient.Org.GetOrgUsersForCurrentOrg(org.NewGetOrgUsersForCurrentOrgParams().WithQuery(&user.Login))
+	if err != nil {
+		return fmt.Errorf("listing organization users: %w", err)
+	}
+
+	for _, orgUser := range resp.Payload {
+		if orgUser.UserID != user.ID {
+			continue
+		}
+
+		if orgUser.Role == role {
+			return nil
+		}
+
+		_, err = gClient.Org.UpdateOrgUserForCurrentOrg(user.ID, &models.UpdateOrgUserCommand{Role: role}) //nolint:errcheck
+		if err != nil {
+			return fmt.Errorf("updating organization role: %w", err)
+		}
+
+		return nil
+	}
+
+	_, err = gClient.Org.AddOrgUserToCurrentOrg(&models.AddOrgUserCommand{LoginOrEmail: user.Login, Role: role}) //nolint:errcheck
+	if err != nil {
+		return fmt.Errorf("adding user to organization: %w", err)
+	}
+
+	return nil
+}
+
+// getUserByLogin returns the user with the given login or email, or nil if it does not exist
+func getUserByLogin(gClient *genapi.GrafanaHTTPAPI, login string) (*models.UserProfileDTO, error) {
+	resp, err := gClient.Users.GetUserByLoginOrEmail(login)
+	if err != nil {
+		if IsErrorType[*users.GetUserByLoginOrEmailNotFound](err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("getting user %q: %w", login, err)
+	}
+
+	return resp.Payload, nil
+}
+
+// getPasswordSecretVersion identifies the revision of the referenced password without deriving anything from it.
+// It changes whenever the Secret is updated or replaced, or another key is referenced
+func getPasswordSecretVersion(ctx context.Context, cl client.Client, cr *v1beta1.GrafanaUser) (string, error) {
+	ref := cr.Spec.Password.SecretKeyRef
+	if ref == nil {
+		return "", errors.New("password must be read from a Secret")
+	}
+
+	secret := &corev1.Secret{}
+
+	err := cl.Get(ctx, client.ObjectKey{Namespace: cr.Namespace, Name: ref.Name}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s/%s", secret.UID, ref.Key, secret.ResourceVersion), nil
+}
+
+func (r *GrafanaUserReconciler) finalize(ctx context.Context, cr *v1beta1.GrafanaUser) error {
+	log := logf.FromContext(ctx)
+	log.Info("Finalizing GrafanaUser")
+
+	instances, err := GetScopedMatchingInstances(ctx, r.Client, cr)
+	if err != nil {
+		log.Error(err, LogMsgGettingInstances)
+		return fmt.Errorf("%s: %w", LogMsgGettingInstances, err)
+	}
+
+	for _, instance := range instances {
+		if err := r.removeFromInstance(ctx, &instance, cr); err != nil {
+			return fmt.Errorf("removing user from instance: %w", err)
+		}
+
+		// Update grafana instance Status
+		err = instance.RemoveNamespacedResource(ctx, r.Client, cr)
+		if err != nil {
+			return fmt.Errorf("removing user from Grafana cr: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *GrafanaUserReconciler) removeFromInstance(ctx context.Context, instance *v1beta1.Grafana, cr *v1beta1.GrafanaUser) error {
+	gClient, err := newOrgScopedClient(ctx, r.Client, instance, cr.Namespace, cr.Spec.OrgRef)
+	if isOrgRemoved(err) {
+		// Users are not deleted together with their organization
+		gClient, err = grafanaclient.NewGeneratedGrafanaClient(ctx, r.Client, instance)
+	}
+
+	if err != nil {
+		return fmt.Errorf("building grafana client: %w", err)
+	}
+
+	user, err := getUserByLogin(gClient, cr.GetGrafanaLogin())
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	_, err = gClient.AdminUsers.AdminDeleteUser(user.ID) //nolint:errcheck
+	if err != nil && IsNotErrorType[*admin_users.AdminDeleteUserNotFound](err) {
+		return fmt.Errorf("deleting user: %w", err)
+	}
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GrafanaUserReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	// Index the users by the Secret holding their password
+	if err := mgr.GetCache().IndexField(ctx, &v1beta1.GrafanaUser{}, userPasswordSecretIndexKey,
+		r.indexPasswordSecret()); err != nil {
+		return fmt.Errorf("failed setting secret index fields: %w", err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.GrafanaUser{}, builder.WithPredicates(
+			ignoreStatusUpdates(),
+		)).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForSecretChange),
+		).
+		Complete(r)
+}
+
+func (r *GrafanaUserReconciler) indexPasswordSecret() func(o client.Object) []string {
+	return func(o client.Object) []string {
+		user, ok := o.(*v1beta1.GrafanaUser)
+		if !ok {
+			panic(fmt.Sprintf("Expected a GrafanaUser, got %T", o))
+		}
+
+		if user.Spec.Password.SecretKeyRef == nil {
+			return nil
+		}
+
+		return []string{fmt.Sprintf("%s/%s", user.Namespace, user.Spec.Password.SecretKeyRef.Name)}
+	}
+}
+
+func (r *GrafanaUserReconciler) requestsForSecretChange(ctx context.Context, o client.Object) []reconcile.Request {
+	var list v1beta1.GrafanaUserList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		userPasswordSecretIndexKey: fmt.Sprintf("%s/%s", o.GetNamespace(), o.GetName()),
+	}); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list users for watch mapping")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for _, user := range list.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: user.Namespace,
+			Name:      user.Name,
+		}})
+	}
+
+	return reqs
+}
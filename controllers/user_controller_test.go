@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+func TestGetPasswordSecretVersion(t *testing.T) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "password", UID: "6f1c2a"},
+		Data:       map[string][]byte{"password": []byte("secret"), "other": []byte("changed")},
+	}
+	fakeClient := tk8s.GetFakeClient(t, secret)
+
+	userWithKey := func(key string) *v1beta1.GrafanaUser {
+		return &v1beta1.GrafanaUser{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "user"},
+			Spec: v1beta1.GrafanaUserSpec{Password: v1beta1.ValueFromSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "password"},
+				Key:                  key,
+			}}},
+		}
+	}
+
+	first, err := getPasswordSecretVersion(ctx, fakeClient, userWithKey("password"))
+	require.NoError(t, err)
+	assert.NotContains(t, first, "secret", "nothing is derived from the password")
+
+	other, err := getPasswordSecretVersion(ctx, fakeClient, userWithKey("other"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, other)
+
+	secret.Data["password"] = []byte("rotated")
+	require.NoError(t, fakeClient.Update(ctx, secret))
+
+	updated, err := getPasswordSecretVersion(ctx, fakeClient, userWithKey("password"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, updated)
+}
+
+func createUserPasswordSecret(name, password string) corev1.SecretKeySelector {
+	GinkgoHelper()
+
+	t := GinkgoT()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+		},
+		StringData: map[string]string{"password": password},
+	}
+
+	err := cl.Create(testCtx, secret)
+	require.NoError(t, client.IgnoreAlreadyExists(err))
+
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  "password",
+	}
+}
+
+var _ = Describe("User Reconciler: Provoke Conditions", func() {
+	tests := []struct {
+		name     string
+		meta     metav1.ObjectMeta
+		spec     v1beta1.GrafanaUserSpec
+		password bool
+		want     metav1.Condition
+		wantErr  string
+	}{
+		{
+			name: ".spec.suspend=true",
+			meta: objectMetaSuspended,
+			spec: v1beta1.GrafanaUserSpec{
+				GrafanaCommonSpec: commonSpecSuspended,
+			},
+			want: metav1.Condition{
+				Type:   conditionSuspended,
+				Reason: conditionReasonApplySuspended,
+			},
+		},
+		{
+			name: "Password Secret does not exist",
+			meta: objectMetaInvalidSpec,
+			spec: v1beta1.GrafanaUserSpec{
+				GrafanaCommonSpec: commonSpecInvalidSpec,
+			},
+			want: metav1.Condition{
+				Type:   conditionInvalidSpec,
+				Reason: "InvalidPassword",
+			},
+			wantErr: "resolving password",
+		},
+		{
+			name: "GetScopedMatchingInstances returns empty list",
+			meta: objectMetaNoMatchingInstances,
+			spec: v1beta1.GrafanaUserSpec{
+				GrafanaCommonSpec: commonSpecNoMatchingInstances,
+			},
+			password: true,
+			want: metav1.Condition{
+				Type:   conditionNoMatchingInstance,
+				Reason: conditionReasonEmptyAPIReply,
+			},
+			wantErr: ErrNoMatchingInstances.Error(),
+		},
+		{
+			name: "Failed to apply to instance",
+			meta: objectMetaApplyFailed,
+			spec: v1beta1.GrafanaUserSpec{
+				GrafanaCommonSpec: commonSpecApplyFailed,
+			},
+			password: true,
+			want: metav1.Condition{
+				Type:   conditionUserSynchronized,
+				Reason: conditionReasonApplyFailed,
+			},
+			wantErr: LogMsgApplyErrors,
+		},
+		{
+			name: "Successfully applied resource to instance",
+			meta: objectMetaSynchronized,
+			spec: v1beta1.GrafanaUserSpec{
+				GrafanaCommonSpec: commonSpecSynchronized,
+				Email:             "synchronized@example.com",
+				Role:              "Editor",
+			},
+			password: true,
+			want: metav1.Condition{
+				Type:   conditionUserSynchronized,
+				Reason: conditionReasonApplySuccessful,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		It(tt.name, func() {
+			cr := &v1beta1.GrafanaUser{
+				ObjectMeta: tt.meta,
+				Spec:       tt.spec,
+			}
+
+			cr.Spec.Password.SecretKeyRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing-user-password"},
+				Key:                  "password",
+			}
+
+			if tt.password {
+				cr.Spec.Password.SecretKeyRef = new(createUserPasswordSecret("user-password", "provoke-conditions"))
+			}
+
+			r := &GrafanaUserReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			reconcileAndValidateCondition(r, cr, tt.want, tt.wantErr)
+		})
+	}
+})
+
+var _ = Describe("User Reconciler: Password rotation", func() {
+	It("Updates the password when the Secret changes", func() {
+		t := GinkgoT()
+
+		selector := createUserPasswordSecret("user-rotation", "first-password")
+
+		cr := &v1beta1.GrafanaUser{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "rotation",
+			},
+			Spec: v1beta1.GrafanaUserSpec{
+				GrafanaCommonSpec: commonSpecSynchronized,
+				Password:          v1beta1.ValueFromSource{SecretKeyRef: &selector},
+				Disabled:          true,
+			},
+		}
+
+		r := &GrafanaUserReconciler{Client: cl, Scheme: cl.Scheme()}
+
+		err := cl.Create(testCtx, cr)
+		require.NoError(t, err)
+
+		req := tk8s.GetRequest(t, cr)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		err = r.Get(testCtx, req.NamespacedName, cr)
+		require.NoError(t, err)
+		require.NotZero(t, cr.Status.UserID)
+		require.NotNil(t, cr.Status.LastPasswordRotation)
+
+		firstVersion := cr.Status.PasswordSecretVersion
+		require.NotEmpty(t, firstVersion)
+
+		gClient, err := grafanaclient.NewGeneratedGrafanaClient(testCtx, cl, externalGrafanaCr)
+		require.NoError(t, err)
+
+		user, err := getUserByLogin(gClient, cr.GetGrafanaLogin())
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.True(t, user.IsDisabled)
+
+		By("Reconciling again without changes")
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		err = r.Get(testCtx, req.NamespacedName, cr)
+		require.NoError(t, err)
+		assert.Equal(t, firstVersion, cr.Status.PasswordSecretVersion)
+
+		By("Changing the password in the Secret")
+
+		secret := &corev1.Secret{}
+
+		err = cl.Get(testCtx, client.ObjectKey{Namespace: "default", Name: selector.Name}, secret)
+		require.NoError(t, err)
+
+		secret.Data["password"] = []byte("second-password")
+
+		err = cl.Update(testCtx, secret)
+		require.NoError(t, err)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		err = r.Get(testCtx, req.NamespacedName, cr)
+		require.NoError(t, err)
+		assert.NotEqual(t, firstVersion, cr.Status.PasswordSecretVersion)
+
+		By("Deleting the user")
+
+		err = cl.Delete(testCtx, cr)
+		require.NoError(t, err)
+
+		_, err = r.Reconcile(testCtx, req)
+		require.NoError(t, err)
+
+		user, err = getUserByLogin(gClient, cr.GetGrafanaLogin())
+		require.NoError(t, err)
+		require.Nil(t, user)
+	})
+})
@@ -0,0 +1,540 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/controllers/content"
+	"github.com/grafana/grafana-operator/v5/pkg/oci"
+)
+
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafana,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanas,verbs=create;update,versions=v1beta1,name=vgrafana.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanaalertrulegroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanaalertrulegroups,verbs=create;update,versions=v1beta1,name=vgrafanaalertrulegroup.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanacontactpoint,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanacontactpoints,verbs=create;update,versions=v1beta1,name=vgrafanacontactpoint.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanadashboard,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanadashboards,verbs=create;update,versions=v1beta1,name=vgrafanadashboard.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-grafana-integreatly-org-v1beta1-grafanadashboard,mutating=true,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanadashboards,verbs=create;update,versions=v1beta1,name=mgrafanadashboard.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanadatasource,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanadatasources,verbs=create;update,versions=v1beta1,name=vgrafanadatasource.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-grafana-integreatly-org-v1beta1-grafanadatasource,mutating=true,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanadatasources,verbs=create;update,versions=v1beta1,name=mgrafanadatasource.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanafolder,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanafolders,verbs=create;update,versions=v1beta1,name=vgrafanafolder.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanalibrarypanel,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanalibrarypanels,verbs=create;update,versions=v1beta1,name=vgrafanalibrarypanel.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-grafana-integreatly-org-v1beta1-grafanalibrarypanel,mutating=true,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanalibrarypanels,verbs=create;update,versions=v1beta1,name=mgrafanalibrarypanel.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanamanifest,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanamanifests,verbs=create;update,versions=v1beta1,name=vgrafanamanifest.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanamutetiming,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanamutetimings,verbs=create;update,versions=v1beta1,name=vgrafanamutetiming.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafananotificationpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafananotificationpolicies,verbs=create;update,versions=v1beta1,name=vgrafananotificationpolicy.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafananotificationpolicyroute,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafananotificationpolicyroutes,verbs=create;update,versions=v1beta1,name=vgrafananotificationpolicyroute.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafananotificationtemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafananotificationtemplates,verbs=create;update,versions=v1beta1,name=vgrafananotificationtemplate.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanaorganization,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanaorganizations,verbs=create;update,versions=v1beta1,name=vgrafanaorganization.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanaserviceaccount,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanaserviceaccounts,verbs=create;update,versions=v1beta1,name=vgrafanaserviceaccount.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanasilence,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanasilences,verbs=create;update,versions=v1beta1,name=vgrafanasilence.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanateam,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanateams,verbs=create;update,versions=v1beta1,name=vgrafanateam.grafana.integreatly.org,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-grafana-integreatly-org-v1beta1-grafanauser,mutating=false,failurePolicy=fail,sideEffects=None,groups=grafana.integreatly.org,resources=grafanausers,verbs=create;update,versions=v1beta1,name=vgrafanauser.grafana.integreatly.org,admissionReviewVersions=v1
+
+// specValidator rejects objects that would otherwise only fail inside Reconcile with an InvalidSpec condition.
+// Updates to objects that were already invalid are admitted with a warning, so that finalizers
+// and metadata of objects created before the webhook was enabled can still be changed.
+type specValidator[T client.Object] struct {
+	validate func(T) error
+}
+
+func (v *specValidator[T]) ValidateCreate(_ context.Context, obj T) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+func (v *specValidator[T]) ValidateUpdate(_ context.Context, oldObj, newObj T) (admission.Warnings, error) {
+	if newObj.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+
+	err := v.validate(newObj)
+	if err == nil {
+		return nil, nil
+	}
+
+	if v.validate(oldObj) != nil {
+		return admission.Warnings{err.Error()}, nil
+	}
+
+	return nil, err
+}
+
+func (v *specValidator[T]) ValidateDelete(_ context.Context, _ T) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// pluginDefaulter normalizes plugin lists by removing duplicates and keeping the highest requested version
+type pluginDefaulter[T client.Object] struct {
+	plugins func(T) *v1beta1.PluginList
+}
+
+func (d *pluginDefaulter[T]) Default(_ context.Context, obj T) error {
+	plugins := d.plugins(obj)
+	if len(*plugins) == 0 {
+		return nil
+	}
+
+	// Invalid versions are dropped by Sanitize, leave them for the validator to reject
+	if validatePlugins(*plugins) != nil {
+		return nil
+	}
+
+	*plugins = plugins.Sanitize()
+
+	return nil
+}
+
+func validatePlugins(plugins v1beta1.PluginList) error {
+	var errs []error
+
+	for _, plugin := range plugins {
+		if plugin.HasInvalidVersion() {
+			errs = append(errs, fmt.Errorf("plugin %s has an invalid version %q", plugin.Name, plugin.Version))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateGrafana(cr *v1beta1.Grafana) error {
+	if cr.Spec.PluginPolicy == nil {
+		return nil
+	}
+
+	var errs []error
+
+	for _, rule := range cr.Spec.PluginPolicy.Allowed {
+		if rule.Versions == "" {
+			continue
+		}
+
+		if _, err := oci.ParseSemverConstraint(rule.Versions); err != nil {
+			errs = append(errs, fmt.Errorf("plugin policy of %s: %w", rule.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateDashboard(cr *v1beta1.GrafanaDashboard) error {
+	return errors.Join(
+		content.ValidateSourceTypes(cr),
+		validatePlugins(cr.Spec.Plugins),
+	)
+}
+
+func validateLibraryPanel(cr *v1beta1.GrafanaLibraryPanel) error {
+	return errors.Join(
+		content.ValidateSourceTypes(cr, content.SourceTypeGrafanaCom),
+		validatePlugins(cr.Spec.Plugins),
+	)
+}
+
+func validateDatasource(cr *v1beta1.GrafanaDatasource) error {
+	return validatePlugins(cr.Spec.Plugins)
+}
+
+func validateAlertRuleGroup(cr *v1beta1.GrafanaAlertRuleGroup) error {
+	// The folder is resolved at reconcile time and has no influence on the validity of the rules
+	_, err := crToModel(cr, "")
+
+	return err
+}
+
+func validateManifest(cr *v1beta1.GrafanaManifest) error {
+	_, err := ParsePatches(cr.Spec.Patch)
+	if err != nil {
+		return fmt.Errorf("%s: %w", LogMsgParsingPatches, err)
+	}
+
+	return nil
+}
+
+func validateNotificationPolicy(cr *v1beta1.GrafanaNotificationPolicy) error {
+	if cr.Spec.Route != nil && !cr.Spec.Route.IsRouteSelectorMutuallyExclusive() {
+		return ErrConflictRouteSelectorAndRoute
+	}
+
+	return nil
+}
+
+func validateNotificationPolicyRoute(cr *v1beta1.GrafanaNotificationPolicyRoute) error {
+	if !cr.Spec.IsRouteSelectorMutuallyExclusive() {
+		return ErrConflictRouteSelectorAndRoute
+	}
+
+	return nil
+}
+
+func validateContactPoint(cr *v1beta1.GrafanaContactPoint) error {
+	// The fallback fills spec.receivers from the deprecated top level receiver, keep the object untouched
+	cr = cr.DeepCopy()
+
+	err := (&GrafanaContactPointReconciler{}).TopLevelReceiverFallback(cr)
+	if err != nil {
+		return err
+	}
+
+	if len(cr.Spec.Receivers) == 0 {
+		return ErrMissingContactPointReceiver
+	}
+
+	return nil
+}
+
+func validateMuteTiming(cr *v1beta1.GrafanaMuteTiming) error {
+	var errs []error
+
+	for i, interval := range cr.Spec.TimeIntervals {
+		if interval == nil {
+			continue
+		}
+
+		for _, weekday := range interval.Weekdays {
+			for day := range strings.SplitSeq(weekday, ":") {
+				if !slices.Contains(weekdays, strings.ToLower(strings.TrimSpace(day))) {
+					errs = append(errs, fmt.Errorf("time interval %d: invalid weekday %q", i, weekday))
+					break
+				}
+			}
+		}
+
+		for _, times := range interval.Times {
+			if times == nil {
+				continue
+			}
+
+			start, err := parseTimeOfDay(times.StartTime)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("time interval %d: start time: %w", i, err))
+				continue
+			}
+
+			end, err := parseTimeOfDay(times.EndTime)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("time interval %d: end time: %w", i, err))
+				continue
+			}
+
+			if start >= end {
+				errs = append(errs, fmt.Errorf("time interval %d: start time %s must be before end time %s", i, times.StartTime, times.EndTime))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+var weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// parseTimeOfDay parses the HH:MM times of mute timings into minutes, 24:00 marks the end of the day
+func parseTimeOfDay(value string) (int, error) {
+	hours, minutes, ok := strings.Cut(value, ":")
+	if !ok || len(minutes) != 2 {
+		return 0, fmt.Errorf("%q is not in the HH:MM format", value)
+	}
+
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 24 {
+		return 0, fmt.Errorf("%q has an invalid hour", value)
+	}
+
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("%q has invalid minutes", value)
+	}
+
+	return h*60 + m, nil
+}
+
+func validateNotificationTemplate(cr *v1beta1.GrafanaNotificationTemplate) error {
+	// Functions are provided by Grafana, only the syntax is checked
+	tree := parse.New(cr.Spec.Name)
+	tree.Mode = parse.SkipFuncCheck | parse.ParseComments
+
+	_, err := tree.Parse(cr.Spec.Template, "", "", map[string]*parse.Tree{})
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+
+	return nil
+}
+
+func validateSilence(cr *v1beta1.GrafanaSilence) error {
+	var errs []error
+
+	for _, matcher := range cr.Spec.Matchers {
+		if !matcher.IsRegex {
+			continue
+		}
+
+		// Alertmanager anchors the expressions of matchers
+		_, err := regexp.Compile("^(?:" + matcher.Value + ")$")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("matcher %s: %w", matcher.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateOrganization(cr *v1beta1.GrafanaOrganization) error {
+	var errs []error
+
+	seen := make(map[string]struct{}, len(cr.Spec.Users))
+
+	for _, user := range cr.Spec.Users {
+		if _, ok := seen[user.Identifier()]; ok {
+			errs = append(errs, fmt.Errorf("user %q is listed more than once", user.Identifier()))
+		}
+
+		seen[user.Identifier()] = struct{}{}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateTeam(cr *v1beta1.GrafanaTeam) error {
+	errs := []error{validateEmail(cr.Spec.Email)}
+
+	seen := make(map[string]struct{}, len(cr.Spec.Members))
+
+	for _, member := range cr.Spec.Members {
+		if _, ok := seen[member.Identifier()]; ok {
+			errs = append(errs, fmt.Errorf("member %q is listed more than once", member.Identifier()))
+		}
+
+		seen[member.Identifier()] = struct{}{}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateServiceAccount(cr *v1beta1.GrafanaServiceAccount) error {
+	var errs []error
+
+	if cr.Spec.InstanceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(cr.Spec.InstanceSelector); err != nil {
+			errs = append(errs, fmt.Errorf("invalid instance selector: %w", err))
+		}
+	}
+
+	tokens := make([]string, 0, len(cr.Spec.Tokens))
+	secretNames := make([]string, 0, len(cr.Spec.Tokens))
+
+	for _, token := range cr.Spec.Tokens {
+		tokens = append(tokens, token.Name)
+
+		if token.SecretName == "" {
+			continue
+		}
+
+		if slices.Contains(secretNames, token.SecretName) {
+			errs = append(errs, fmt.Errorf("secret %q is used by more than one token", token.SecretName))
+		}
+
+		secretNames = append(secretNames, token.SecretName)
+	}
+
+	for _, target := range cr.Spec.SecretTargets {
+		for _, token := range target.Tokens {
+			if !slices.Contains(tokens, token) {
+				errs = append(errs, fmt.Errorf("secret target %s references the unknown token %q", target.Namespace, token))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateUser(cr *v1beta1.GrafanaUser) error {
+	return validateEmail(cr.Spec.Email)
+}
+
+func validateEmail(email string) error {
+	if email == "" {
+		return nil
+	}
+
+	_, err := mail.ParseAddress(email)
+	if err != nil {
+		return fmt.Errorf("invalid email %q: %w", email, err)
+	}
+
+	return nil
+}
+
+// genericValidate adapts the Validate function of a GenericReconciler to the webhook
+func genericValidate[T any, PT interface {
+	*T
+	v1beta1.CommonResource
+	v1beta1.CommonSpecResource
+}](r *GenericReconciler[T, PT]) func(PT) error {
+	return func(cr PT) error {
+		if err := r.Validate(cr); err != nil {
+			return fmt.Errorf("%s: %w", err.Reason, err.Err)
+		}
+
+		return nil
+	}
+}
+
+// SetupWebhooksWithManager registers the validating and defaulting admission webhooks with the webhook server of the manager
+func SetupWebhooksWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr, &v1beta1.Grafana{}).
+		WithValidator(&specValidator[*v1beta1.Grafana]{validate: validateGrafana}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("Grafana: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaAlertRuleGroup{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaAlertRuleGroup]{validate: validateAlertRuleGroup}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaAlertRuleGroup: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaContactPoint{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaContactPoint]{validate: validateContactPoint}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaContactPoint: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaDashboard{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaDashboard]{validate: validateDashboard}).
+		WithDefaulter(&pluginDefaulter[*v1beta1.GrafanaDashboard]{plugins: func(cr *v1beta1.GrafanaDashboard) *v1beta1.PluginList {
+			return &cr.Spec.Plugins
+		}}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaDashboard: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaDatasource{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaDatasource]{validate: validateDatasource}).
+		WithDefaulter(&pluginDefaulter[*v1beta1.GrafanaDatasource]{plugins: func(cr *v1beta1.GrafanaDatasource) *v1beta1.PluginList {
+			return &cr.Spec.Plugins
+		}}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaDatasource: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaFolder{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaFolder]{validate: genericValidate(NewGenericFolderReconciler(mgr.GetClient(), nil))}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaFolder: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaLibraryPanel{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaLibraryPanel]{validate: validateLibraryPanel}).
+		WithDefaulter(&pluginDefaulter[*v1beta1.GrafanaLibraryPanel]{plugins: func(cr *v1beta1.GrafanaLibraryPanel) *v1beta1.PluginList {
+			return &cr.Spec.Plugins
+		}}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaLibraryPanel: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaManifest{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaManifest]{validate: validateManifest}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaManifest: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaMuteTiming{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaMuteTiming]{validate: validateMuteTiming}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaMuteTiming: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaNotificationPolicy{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaNotificationPolicy]{validate: validateNotificationPolicy}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaNotificationPolicy: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaNotificationPolicyRoute{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaNotificationPolicyRoute]{validate: validateNotificationPolicyRoute}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaNotificationPolicyRoute: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaNotificationTemplate{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaNotificationTemplate]{validate: validateNotificationTemplate}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaNotificationTemplate: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaOrganization{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaOrganization]{validate: validateOrganization}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaOrganization: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaServiceAccount{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaServiceAccount]{validate: validateServiceAccount}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaServiceAccount: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaSilence{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaSilence]{validate: validateSilence}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaSilence: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaTeam{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaTeam]{validate: validateTeam}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaTeam: %w", err)
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr, &v1beta1.GrafanaUser{}).
+		WithValidator(&specValidator[*v1beta1.GrafanaUser]{validate: validateUser}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("GrafanaUser: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSpecValidator(t *testing.T) {
+	ctx := context.Background()
+	v := &specValidator[*v1beta1.GrafanaDashboard]{validate: validateDashboard}
+
+	valid := &v1beta1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: "dashboard"},
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{JSON: "{}"},
+		},
+	}
+
+	invalid := valid.DeepCopy()
+	invalid.Spec.URL = "https://example.com/dashboard.json"
+
+	t.Run("create rejects invalid objects", func(t *testing.T) {
+		_, err := v.ValidateCreate(ctx, invalid)
+		require.ErrorContains(t, err, "more than one source types found")
+
+		_, err = v.ValidateCreate(ctx, valid)
+		require.NoError(t, err)
+	})
+
+	t.Run("update rejects invalidating a valid object", func(t *testing.T) {
+		_, err := v.ValidateUpdate(ctx, valid, invalid)
+		require.Error(t, err)
+	})
+
+	t.Run("update warns when the object was already invalid", func(t *testing.T) {
+		warnings, err := v.ValidateUpdate(ctx, invalid, invalid)
+		require.NoError(t, err)
+		assert.Len(t, warnings, 1)
+	})
+
+	t.Run("update allows objects being deleted", func(t *testing.T) {
+		deleting := invalid.DeepCopy()
+		deleting.DeletionTimestamp = &metav1.Time{}
+
+		warnings, err := v.ValidateUpdate(ctx, valid, deleting)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+}
+
+func TestPluginDefaulter(t *testing.T) {
+	ctx := context.Background()
+	d := &pluginDefaulter[*v1beta1.GrafanaDashboard]{plugins: func(cr *v1beta1.GrafanaDashboard) *v1beta1.PluginList {
+		return &cr.Spec.Plugins
+	}}
+
+	t.Run("duplicates are merged into the highest version", func(t *testing.T) {
+		cr := &v1beta1.GrafanaDashboard{
+			Spec: v1beta1.GrafanaDashboardSpec{
+				Plugins: v1beta1.PluginList{
+					{Name: "b", Version: "1.0.0"},
+					{Name: "a", Version: "1.0.0"},
+					{Name: "a", Version: "2.0.0"},
+				},
+			},
+		}
+
+		err := d.Default(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, v1beta1.PluginList{
+			{Name: "a", Version: "2.0.0"},
+			{Name: "b", Version: "1.0.0"},
+		}, cr.Spec.Plugins)
+	})
+
+	t.Run("invalid versions are left for the validator", func(t *testing.T) {
+		plugins := v1beta1.PluginList{
+			{Name: "a", Version: "1.0"},
+			{Name: "a", Version: "2.0.0"},
+		}
+		cr := &v1beta1.GrafanaDashboard{Spec: v1beta1.GrafanaDashboardSpec{Plugins: plugins}}
+
+		err := d.Default(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, plugins, cr.Spec.Plugins)
+
+		require.ErrorContains(t, validatePlugins(cr.Spec.Plugins), `plugin a has an invalid version "1.0"`)
+	})
+}
+
+func TestWebhookValidations(t *testing.T) {
+	t.Run("library panels do not support grafana.com", func(t *testing.T) {
+		cr := &v1beta1.GrafanaLibraryPanel{
+			ObjectMeta: metav1.ObjectMeta{Name: "panel"},
+			Spec: v1beta1.GrafanaLibraryPanelSpec{
+				GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+					GrafanaCom: &v1beta1.GrafanaComContentReference{ID: 1},
+				},
+			},
+		}
+
+		require.ErrorContains(t, validateLibraryPanel(cr), "is disabled")
+	})
+
+	t.Run("alert rules with an invalid duration", func(t *testing.T) {
+		cr := &v1beta1.GrafanaAlertRuleGroup{
+			Spec: v1beta1.GrafanaAlertRuleGroupSpec{
+				Rules: []v1beta1.AlertRule{
+					{Title: "rule", For: new("five minutes")},
+				},
+			},
+		}
+
+		require.ErrorContains(t, validateAlertRuleGroup(cr), "invalid 'for' duration")
+	})
+
+	t.Run("manifest patches that do not parse", func(t *testing.T) {
+		cr := &v1beta1.GrafanaManifest{
+			Spec: v1beta1.GrafanaManifestSpec{
+				Patch: &v1beta1.Patch{Scripts: []string{".spec | ."}},
+			},
+		}
+		require.NoError(t, validateManifest(cr))
+
+		cr.Spec.Patch.Scripts = append(cr.Spec.Patch.Scripts, ".spec |")
+		require.ErrorContains(t, validateManifest(cr), "script 1 failed to parse")
+	})
+
+	t.Run("folders referencing themselves as parent", func(t *testing.T) {
+		validate := genericValidate(NewGenericFolderReconciler(nil, nil))
+
+		cr := &v1beta1.GrafanaFolder{
+			Spec: v1beta1.GrafanaFolderSpec{
+				CustomUID:       "folder",
+				ParentFolderUID: "folder",
+			},
+		}
+
+		require.ErrorIs(t, validate(cr), ErrCyclicFolder)
+	})
+
+	t.Run("contact points without receivers", func(t *testing.T) {
+		cr := &v1beta1.GrafanaContactPoint{}
+		require.ErrorIs(t, validateContactPoint(cr), ErrMissingContactPointReceiver)
+
+		cr.Spec.Type = "email"                                      //nolint:staticcheck
+		cr.Spec.Settings = &apiextensionsv1.JSON{Raw: []byte(`{}`)} //nolint:staticcheck
+		require.NoError(t, validateContactPoint(cr))
+		assert.Empty(t, cr.Spec.Receivers)
+	})
+
+	t.Run("mute timings with invalid times or weekdays", func(t *testing.T) {
+		cr := &v1beta1.GrafanaMuteTiming{
+			Spec: v1beta1.GrafanaMuteTimingSpec{
+				TimeIntervals: []*v1beta1.TimeInterval{{
+					Weekdays: []string{"monday:friday", "Sunday"},
+					Times:    []*v1beta1.TimeRange{{StartTime: "00:00", EndTime: "24:00"}},
+				}},
+			},
+		}
+		require.NoError(t, validateMuteTiming(cr))
+
+		cr.Spec.TimeIntervals[0].Weekdays = []string{"monday:fryday"}
+		cr.Spec.TimeIntervals[0].Times = []*v1beta1.TimeRange{
+			{StartTime: "9:00", EndTime: "7"},
+			{StartTime: "18:00", EndTime: "09:00"},
+			{StartTime: "24:30", EndTime: "25:00"},
+		}
+
+		err := validateMuteTiming(cr)
+		require.ErrorContains(t, err, `invalid weekday "monday:fryday"`)
+		require.ErrorContains(t, err, `"7" is not in the HH:MM format`)
+		require.ErrorContains(t, err, "start time 18:00 must be before end time 09:00")
+		require.ErrorContains(t, err, `"24:30" has invalid minutes`)
+	})
+
+	t.Run("notification templates are checked for syntax only", func(t *testing.T) {
+		cr := &v1beta1.GrafanaNotificationTemplate{
+			Spec: v1beta1.GrafanaNotificationTemplateSpec{
+				Name:     "alerts",
+				Template: `{{ define "alerts.title" }}{{ .Alerts.Firing | len }} firing {{ toUpper .Status }}{{ end }}`,
+			},
+		}
+		require.NoError(t, validateNotificationTemplate(cr))
+
+		cr.Spec.Template = `{{ define "alerts.title" }}{{ .Status }}`
+		require.ErrorContains(t, validateNotificationTemplate(cr), "parsing template")
+	})
+
+	t.Run("notification policy routes using routeSelector and routes", func(t *testing.T) {
+		cr := &v1beta1.GrafanaNotificationPolicyRoute{}
+		cr.Spec.RouteSelector = &metav1.LabelSelector{}
+		require.NoError(t, validateNotificationPolicyRoute(cr))
+
+		cr.Spec.Routes = []*v1beta1.Route{{}}
+		require.ErrorIs(t, validateNotificationPolicyRoute(cr), ErrConflictRouteSelectorAndRoute)
+	})
+
+	t.Run("silences with invalid regular expressions", func(t *testing.T) {
+		cr := &v1beta1.GrafanaSilence{
+			Spec: v1beta1.GrafanaSilenceSpec{
+				Matchers: []v1beta1.SilenceMatcher{
+					{Name: "alertname", Value: "Node(Down"},
+					{Name: "instance", Value: "node-.*", IsRegex: true},
+				},
+			},
+		}
+		require.NoError(t, validateSilence(cr))
+
+		cr.Spec.Matchers[0].IsRegex = true
+		require.ErrorContains(t, validateSilence(cr), "matcher alertname")
+	})
+
+	t.Run("organizations and teams listing users twice", func(t *testing.T) {
+		org := &v1beta1.GrafanaOrganization{
+			Spec: v1beta1.GrafanaOrganizationSpec{
+				Users: []v1beta1.GrafanaOrganizationUser{
+					{Login: "alice", Role: "Viewer"},
+					{Email: "bob@example.com", Role: "Viewer"},
+					{Login: "alice", Role: "Admin"},
+				},
+			},
+		}
+		require.ErrorContains(t, validateOrganization(org), `user "alice" is listed more than once`)
+
+		team := &v1beta1.GrafanaTeam{
+			Spec: v1beta1.GrafanaTeamSpec{
+				Members: []v1beta1.GrafanaTeamMember{{Login: "alice"}, {Email: "bob@example.com"}},
+			},
+		}
+		require.NoError(t, validateTeam(team))
+
+		team.Spec.Members = append(team.Spec.Members, v1beta1.GrafanaTeamMember{Email: "bob@example.com"})
+		require.ErrorContains(t, validateTeam(team), `member "bob@example.com" is listed more than once`)
+	})
+
+	t.Run("users and teams with an invalid email", func(t *testing.T) {
+		user := &v1beta1.GrafanaUser{Spec: v1beta1.GrafanaUserSpec{Email: "alice@example.com"}}
+		require.NoError(t, validateUser(user))
+
+		user.Spec.Email = "alice"
+		require.ErrorContains(t, validateUser(user), `invalid email "alice"`)
+
+		team := &v1beta1.GrafanaTeam{Spec: v1beta1.GrafanaTeamSpec{Email: "platform@"}}
+		require.ErrorContains(t, validateTeam(team), `invalid email "platform@"`)
+	})
+	t.Run("plugin policies with an invalid version range", func(t *testing.T) {
+		cr := &v1beta1.Grafana{
+			Spec: v1beta1.GrafanaSpec{
+				PluginPolicy: &v1beta1.GrafanaPluginPolicy{
+					Allowed: []v1beta1.GrafanaPluginPolicyRule{
+						{Name: "grafana-clock-panel", Versions: ">=2.0.0 <3.0.0"},
+						{Name: "grafana-piechart-panel"},
+					},
+				},
+			},
+		}
+		require.NoError(t, validateGrafana(cr))
+
+		cr.Spec.PluginPolicy.Allowed[1].Versions = "~two"
+		require.ErrorContains(t, validateGrafana(cr), `plugin policy of grafana-piechart-panel: invalid semver constraint "~two"`)
+	})
+
+	t.Run("service accounts with conflicting secrets or unknown tokens", func(t *testing.T) {
+		cr := &v1beta1.GrafanaServiceAccount{
+			Spec: v1beta1.GrafanaServiceAccountSpec{
+				Tokens: []v1beta1.GrafanaServiceAccountTokenSpec{
+					{Name: "ci", SecretName: "grafana-token"},
+					{Name: "backup"},
+				},
+				SecretTargets: []v1beta1.GrafanaServiceAccountSecretTarget{
+					{Namespace: "ci", Tokens: []string{"ci"}},
+				},
+			},
+		}
+		require.NoError(t, validateServiceAccount(cr))
+
+		cr.Spec.Tokens[1].SecretName = "grafana-token"
+		cr.Spec.SecretTargets[0].Tokens = append(cr.Spec.SecretTargets[0].Tokens, "deploy")
+
+		err := validateServiceAccount(cr)
+		require.ErrorContains(t, err, `secret "grafana-token" is used by more than one token`)
+		require.ErrorContains(t, err, `secret target ci references the unknown token "deploy"`)
+	})
+}
@@ -37,6 +37,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -48,6 +49,7 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -61,10 +63,13 @@ import (
 
 	"github.com/grafana/grafana-operator/v5/api/v1beta1"
 	"github.com/grafana/grafana-operator/v5/controllers"
+	operatorconfig "github.com/grafana/grafana-operator/v5/controllers/config"
+	contentcache "github.com/grafana/grafana-operator/v5/controllers/content/cache"
 	"github.com/grafana/grafana-operator/v5/controllers/resources"
 	"github.com/grafana/grafana-operator/v5/embeds"
 	"github.com/grafana/grafana-operator/v5/pkg/autodetect"
 	"github.com/grafana/grafana-operator/v5/pkg/featureflags"
+	"github.com/grafana/grafana-operator/v5/pkg/plugininstaller"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -94,6 +99,25 @@ var operatorConfig struct {
 	MaxConcurrentReconciles int           `name:"max-concurrent-reconciles" default:"1"     env:"MAX_CONCURRENT_RECONCILES" help:"Maximum number of concurrent reconciles for dashboard, datasource, folder controllers."`
 	MemLimitRatio           float64       `name:"gomemlimit-ratio"          default:"0.9"   env:"GOMEMLIMIT_RATIO"          help:"The limit defaults to 90% of either the CGroup (v2 or v1) with fallback to the System. Results in less GC cycles when there's memory to spare and more when nearing the limit to reduce OOM kills."`
 	ResyncPeriod            time.Duration `name:"default-resync-period"     default:"10m"   env:"DEFAULT_RESYNC_PERIOD"     help:"Controls the default .spec.resyncPeriod when undefined on CRs."`
+	EnableWebhooks          bool          `name:"enable-webhooks"           default:"false" env:"ENABLE_WEBHOOKS"           help:"Serve the validating and defaulting admission webhooks. Requires the webhook configurations and a serving certificate in --webhook-cert-dir."`
+	WebhookCertDir          string        `name:"webhook-cert-dir"                          env:"WEBHOOK_CERT_DIR"          help:"Directory containing tls.crt and tls.key for the webhook server. Defaults to <temp-dir>/k8s-webhook-server/serving-certs."`
+
+	DriftPolicy string `name:"default-drift-policy" default:"enforce" enum:"enforce,detect,ignore" env:"DEFAULT_DRIFT_POLICY" help:"Controls the default .spec.driftPolicy when undefined on CRs. One of 'enforce', 'detect' or 'ignore'."`
+
+	PluginInstallerImage string `name:"plugin-installer-image" env:"PLUGIN_INSTALLER_IMAGE" help:"Image of the init container installing plugins from archives into Grafana pods, the operator image of the same release. Defaults to ghcr.io/grafana/grafana-operator:<operator version>, required for development builds."`
+
+	ServiceAccountSecretTargets string `name:"service-account-secret-targets" env:"SERVICE_ACCOUNT_SECRET_TARGETS" help:"Comma separated namespaces GrafanaServiceAccounts may copy their token secrets to through .spec.secretTargets, '*' allows any namespace. If empty, copying is disabled."`
+
+	ContentCache        string `name:"content-cache"          default:"memory"                enum:"memory,disk" env:"CONTENT_CACHE"          help:"Where content fetched from URLs, grafana.com, OCI artifacts and git repositories is cached. One of 'memory' or 'disk'."`
+	ContentCacheDir     string `name:"content-cache-dir"      default:"/tmp/dashboards/cache"                    env:"CONTENT_CACHE_DIR"      help:"Directory of the disk content cache."`
+	ContentCacheMaxSize int64  `name:"content-cache-max-size" default:"256"                                      env:"CONTENT_CACHE_MAX_SIZE" help:"Maximum size of the content cache in MiB, least recently used content is evicted first."`
+
+	EnablePrometheusRules                   bool   `name:"enable-prometheus-rules"                      default:"false" env:"ENABLE_PROMETHEUS_RULES"                      help:"Convert PrometheusRules into GrafanaAlertRuleGroups. Only effective when the PrometheusRule CRD is installed."`
+	PrometheusRuleSelector                  string `name:"prometheus-rule-selector"                                     env:"PROMETHEUS_RULE_SELECTOR"                     help:"Label selector of the PrometheusRules to convert, e.g. 'grafana=managed'. If empty, all PrometheusRules are converted."`
+	PrometheusRuleDatasourceUID             string `name:"prometheus-rule-datasource-uid"                               env:"PROMETHEUS_RULE_DATASOURCE_UID"               help:"UID of the datasource queried by converted rules. Can be overridden with the 'operator.grafana.com/datasource-uid' annotation."`
+	PrometheusRuleFolderUID                 string `name:"prometheus-rule-folder-uid"                                   env:"PROMETHEUS_RULE_FOLDER_UID"                   help:"UID of the folder storing converted rule groups. Can be overridden with the 'operator.grafana.com/folder-uid' or 'operator.grafana.com/folder-ref' annotation."`
+	PrometheusRuleInstanceSelector          string `name:"prometheus-rule-instance-selector"                            env:"PROMETHEUS_RULE_INSTANCE_SELECTOR"            help:"Label selector of the Grafana instances receiving converted rule groups. If empty, all instances are selected."`
+	PrometheusRuleAllowCrossNamespaceImport bool   `name:"prometheus-rule-allow-cross-namespace-import" default:"false" env:"PROMETHEUS_RULE_ALLOW_CROSS_NAMESPACE_IMPORT" help:"Allow converted rule groups to be applied to Grafana instances in other namespaces."`
 
 	ZapDevel           bool   `name:"zap-devel"            default:"false"                                                         help:"Development Mode defaults(encoder=consoleEncoder,logLevel=Debug,stackTraceLevel=Warn)"`
 	ZapEncoder         string `name:"zap-encoder"          default:"console" enum:"console,json"                                   help:"Zap log encoding ('json' or 'console')"`
@@ -178,6 +202,17 @@ func configureZap() (zap.Options, error) {
 }
 
 func main() { //nolint:gocyclo
+	// The init container of Grafana pods runs the operator image to install plugins from archives
+	if len(os.Args) > 1 && os.Args[1] == plugininstaller.Command {
+		err := plugininstaller.Main(ctrl.SetupSignalHandler(), os.Args[2:])
+		if err != nil {
+			fmt.Println(err.Error()) //nolint:forbidigo
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	kong.Parse(&operatorConfig,
 		kong.Name("grafana-operator"),
 		kong.UsageOnError(),
@@ -202,6 +237,21 @@ func main() { //nolint:gocyclo
 		os.Exit(1)
 	}
 
+	contentCacheMaxSize := operatorConfig.ContentCacheMaxSize << 20
+
+	switch operatorConfig.ContentCache {
+	case "disk":
+		store, err := contentcache.NewDiskStore(operatorConfig.ContentCacheDir, contentCacheMaxSize)
+		if err != nil {
+			setupLog.Error(err, "failed to setup content cache")
+			os.Exit(1)
+		}
+
+		contentcache.SetStore(store)
+	default:
+		contentcache.SetStore(contentcache.NewLRUStore(contentCacheMaxSize))
+	}
+
 	// Optimize GC cycles by setting and periodically updating GOMEMLIMIT.
 	memlimit.SetGoMemLimitWithOpts( //nolint:errcheck
 		memlimit.WithRatio(operatorConfig.MemLimitRatio),
@@ -242,10 +292,19 @@ func main() { //nolint:gocyclo
 		os.Exit(1)
 	}
 
+	hasPrometheusRuleCRD := false
+	if operatorConfig.EnablePrometheusRules {
+		hasPrometheusRuleCRD, err = cluster.HasPrometheusRuleCRD()
+		if err != nil {
+			setupLog.Error(err, "failed to test for PrometheusRule CRD")
+			os.Exit(1)
+		}
+	}
+
 	mgrOptions := ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsserver.Options{BindAddress: operatorConfig.MetricsAddr},
-		WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443}),
+		WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443, CertDir: operatorConfig.WebhookCertDir}),
 		HealthProbeBindAddress: operatorConfig.ProbeAddr,
 		LeaderElection:         operatorConfig.EnableLeaderElection,
 		LeaderElectionID:       fmt.Sprintf("grafana-operator-%x", leHash.Sum(nil)),
@@ -287,6 +346,7 @@ func main() { //nolint:gocyclo
 			&corev1.ServiceAccount{}:        cacheLabelConfig,
 			&networkingv1.Ingress{}:         cacheLabelConfig,
 			&corev1.PersistentVolumeClaim{}: cacheLabelConfig,
+			&policyv1.PodDisruptionBudget{}: cacheLabelConfig,
 			&corev1.ConfigMap{}:             cacheLabelConfig, // Matching just labeled ConfigMaps and Secrets greatly reduces cache size
 			&corev1.Secret{}:                cacheLabelConfig, // Omitting labels or supporting custom labels would require changes in Grafana Reconciler
 		}
@@ -340,7 +400,12 @@ func main() { //nolint:gocyclo
 
 	ctrlCfg := &controllers.Config{
 		ResyncPeriod: operatorConfig.ResyncPeriod,
+		DriftPolicy:  v1beta1.DriftPolicy(operatorConfig.DriftPolicy),
 	}
+	if operatorConfig.ServiceAccountSecretTargets != "" {
+		ctrlCfg.SecretTargetNamespaces = strings.Split(operatorConfig.ServiceAccountSecretTargets, ",")
+	}
+
 	// Register controllers
 	if err = (&controllers.GrafanaReconciler{
 		Client:          mgr.GetClient(),
@@ -348,6 +413,8 @@ func main() { //nolint:gocyclo
 		IsOpenShift:     isOpenShift,
 		HasHTTPRouteCRD: hasHTTPRouteCRD,
 		ClusterDomain:   operatorConfig.ClusterDomain,
+
+		PluginInstallerImage: pluginInstallerImage(),
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Grafana")
 		os.Exit(1)
@@ -372,9 +439,10 @@ func main() { //nolint:gocyclo
 	}
 
 	if err = (&controllers.GrafanaServiceAccountReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Cfg:    ctrlCfg,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Cfg:      ctrlCfg,
+		Recorder: mgr.GetEventRecorder("GrafanaServiceAccount"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GrafanaServiceAccount")
 		os.Exit(1)
@@ -450,6 +518,42 @@ func main() { //nolint:gocyclo
 		os.Exit(1)
 	}
 
+	if err = (&controllers.GrafanaSilenceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Cfg:    ctrlCfg,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GrafanaSilence")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.GrafanaTeamReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Cfg:    ctrlCfg,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GrafanaTeam")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.GrafanaUserReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Cfg:    ctrlCfg,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GrafanaUser")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.GrafanaOrganizationReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Cfg:    ctrlCfg,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GrafanaOrganization")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.GrafanaManifestReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -459,8 +563,25 @@ func main() { //nolint:gocyclo
 		setupLog.Error(err, "unable to create controller", "controller", "GrafanaManifest")
 		os.Exit(1)
 	}
+	if hasPrometheusRuleCRD {
+		if err = setupPrometheusRuleReconciler(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PrometheusRule")
+			os.Exit(1)
+		}
+	} else if operatorConfig.EnablePrometheusRules {
+		setupLog.Info("skipping PrometheusRule conversion as the PrometheusRule CRD was not found in the cluster")
+	}
 	//+kubebuilder:scaffold:builder
 
+	if operatorConfig.EnableWebhooks {
+		if err = controllers.SetupWebhooksWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook")
+			os.Exit(1)
+		}
+
+		setupLog.Info("admission webhooks enabled")
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -471,6 +592,13 @@ func main() { //nolint:gocyclo
 		os.Exit(1)
 	}
 
+	if operatorConfig.EnableWebhooks {
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook ready check")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting operator")
 
 	if err := mgr.Start(ctx); err != nil {
@@ -481,6 +609,21 @@ func main() { //nolint:gocyclo
 	setupLog.Info("shutting down operator")
 }
 
+// pluginInstallerImage defaults to the operator image of the running release.
+// Builds without a release version have no such image, installing plugin archives fails until one is configured
+func pluginInstallerImage() string {
+	if operatorConfig.PluginInstallerImage != "" {
+		return operatorConfig.PluginInstallerImage
+	}
+
+	if embeds.Version == "dev" {
+		setupLog.Info("no plugin installer image configured for a development build, set --plugin-installer-image to install plugins from archives")
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%s", operatorconfig.OperatorImage, embeds.Version)
+}
+
 func getNamespaceConfig(namespaces string, labelSelectors labels.Selector) map[string]cache.Config {
 	defaultNamespaces := map[string]cache.Config{}
 	for v := range strings.SplitSeq(namespaces, ",") {
@@ -560,3 +703,28 @@ func getLabelSelectors(watchLabelSelectors string) (labels.Selector, error) {
 
 	return labelSelectors, nil
 }
+
+func setupPrometheusRuleReconciler(mgr ctrl.Manager) error {
+	selector, err := labels.Parse(operatorConfig.PrometheusRuleSelector)
+	if err != nil {
+		return fmt.Errorf("unable to parse 'PROMETHEUS_RULE_SELECTOR=%s': %w", operatorConfig.PrometheusRuleSelector, err)
+	}
+
+	instanceSelector, err := metav1.ParseToLabelSelector(operatorConfig.PrometheusRuleInstanceSelector)
+	if err != nil {
+		return fmt.Errorf("unable to parse 'PROMETHEUS_RULE_INSTANCE_SELECTOR=%s': %w", operatorConfig.PrometheusRuleInstanceSelector, err)
+	}
+
+	return (&controllers.PrometheusRuleReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("PrometheusRule"),
+		Selector: selector,
+		Conversion: controllers.PrometheusRuleConversion{
+			DatasourceUID:             operatorConfig.PrometheusRuleDatasourceUID,
+			FolderUID:                 operatorConfig.PrometheusRuleFolderUID,
+			InstanceSelector:          instanceSelector,
+			AllowCrossNamespaceImport: operatorConfig.PrometheusRuleAllowCrossNamespaceImport,
+		},
+	}).SetupWithManager(mgr)
+}
@@ -63,3 +63,8 @@ func (c *ClusterDiscovery) IsOpenshift() (bool, error) {
 func (c *ClusterDiscovery) HasHTTPRouteCRD() (bool, error) {
 	return c.hasKind("gateway.networking.k8s.io/v1", "HTTPRoute")
 }
+
+// Tests if the PrometheusRule CRD of the Prometheus Operator is present
+func (c *ClusterDiscovery) HasPrometheusRuleCRD() (bool, error) {
+	return c.hasKind("monitoring.coreos.com/v1", "PrometheusRule")
+}
@@ -0,0 +1,323 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// redactedValue replaces secure contact point settings in responses of the provisioning API
+const redactedValue = "[REDACTED]"
+
+type ruleGroupKey struct {
+	folderUID string
+	group     string
+}
+
+func (e *Exporter) exportAlertRuleGroups(ctx context.Context) ([]client.Object, error) {
+	rules, err := e.client.Provisioning.GetAlertRules()
+	if err != nil {
+		return nil, fmt.Errorf("listing alert rules: %w", err)
+	}
+
+	// The provisioning API has no endpoint listing groups, derive them from the rules
+	var groups []ruleGroupKey
+
+	for _, rule := range rules.Payload {
+		if rule.FolderUID == nil || rule.RuleGroup == nil {
+			continue
+		}
+
+		key := ruleGroupKey{folderUID: *rule.FolderUID, group: *rule.RuleGroup}
+		if !slices.Contains(groups, key) {
+			groups = append(groups, key)
+		}
+	}
+
+	names := nameRegistry{}
+
+	objects := make([]client.Object, 0, len(groups))
+
+	for _, key := range groups {
+		resp, err := e.client.Provisioning.GetAlertRuleGroup(key.group, key.folderUID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching alert rule group %s in folder %s: %w", key.group, key.folderUID, err)
+		}
+
+		cr, err := e.alertRuleGroupToCR(resp.Payload, names.unique(key.group, key.folderUID))
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, cr)
+	}
+
+	return objects, nil
+}
+
+// alertRuleGroupToCR is the inverse of the model conversion in the GrafanaAlertRuleGroup controller
+func (e *Exporter) alertRuleGroupToCR(group *models.AlertRuleGroup, name string) (*v1beta1.GrafanaAlertRuleGroup, error) {
+	cr := &v1beta1.GrafanaAlertRuleGroup{
+		TypeMeta:   typeMeta(KindAlertRuleGroup),
+		ObjectMeta: e.objectMeta(name),
+		Spec: v1beta1.GrafanaAlertRuleGroupSpec{
+			GrafanaCommonSpec: e.commonSpec(),
+			Name:              group.Title,
+			Interval:          metav1.Duration{Duration: time.Duration(group.Interval) * time.Second},
+			Rules:             make([]v1beta1.AlertRule, 0, len(group.Rules)),
+		},
+	}
+
+	cr.Spec.FolderRef, cr.Spec.FolderUID = e.folderReference(group.FolderUID)
+
+	for _, rule := range group.Rules {
+		converted, err := alertRuleFromModel(rule)
+		if err != nil {
+			return nil, fmt.Errorf("converting alert rule %s of group %s: %w", rule.UID, group.Title, err)
+		}
+
+		cr.Spec.Rules = append(cr.Spec.Rules, converted)
+	}
+
+	return cr, nil
+}
+
+func alertRuleFromModel(rule *models.ProvisionedAlertRule) (v1beta1.AlertRule, error) {
+	r := v1beta1.AlertRule{
+		Annotations: rule.Annotations,
+		Condition:   deref(rule.Condition),
+		Data:        make([]*v1beta1.AlertQuery, 0, len(rule.Data)),
+		IsPaused:    rule.IsPaused,
+		Labels:      rule.Labels,
+		NoDataState: rule.NoDataState,
+		Title:       deref(rule.Title),
+		UID:         rule.UID,
+	}
+
+	if rule.ExecErrState != nil {
+		r.ExecErrState = *rule.ExecErrState
+	}
+
+	if rule.For != nil {
+		r.For = new(formatDuration(time.Duration(*rule.For)))
+	}
+
+	if rule.KeepFiringFor != 0 {
+		r.KeepFiringFor = &metav1.Duration{Duration: time.Duration(rule.KeepFiringFor)}
+	}
+
+	if rule.MissingSeriesEvalsToResolve != 0 {
+		r.MissingSeriesEvalsToResolve = new(rule.MissingSeriesEvalsToResolve)
+	}
+
+	if rule.NotificationSettings != nil {
+		r.NotificationSettings = &v1beta1.NotificationSettings{
+			Receiver:            deref(rule.NotificationSettings.Receiver),
+			GroupBy:             rule.NotificationSettings.GroupBy,
+			GroupWait:           rule.NotificationSettings.GroupWait,
+			GroupInterval:       rule.NotificationSettings.GroupInterval,
+			RepeatInterval:      rule.NotificationSettings.RepeatInterval,
+			MuteTimeIntervals:   rule.NotificationSettings.MuteTimeIntervals,
+			ActiveTimeIntervals: rule.NotificationSettings.ActiveTimeIntervals,
+		}
+	}
+
+	if rule.Record != nil {
+		r.Record = &v1beta1.Record{
+			From:                deref(rule.Record.From),
+			Metric:              deref(rule.Record.Metric),
+			TargetDatasourceUID: rule.Record.TargetDatasourceUID,
+		}
+	}
+
+	for _, q := range rule.Data {
+		query := &v1beta1.AlertQuery{
+			DatasourceUID:     q.DatasourceUID,
+			QueryType:         q.QueryType,
+			RefID:             q.RefID,
+			RelativeTimeRange: q.RelativeTimeRange,
+		}
+
+		if q.Model != nil {
+			model, err := json.Marshal(q.Model)
+			if err != nil {
+				return v1beta1.AlertRule{}, fmt.Errorf("encoding model of query %s: %w", q.RefID, err)
+			}
+
+			query.Model = &apiextensionsv1.JSON{Raw: model}
+		}
+
+		r.Data = append(r.Data, query)
+	}
+
+	return r, nil
+}
+
+func (e *Exporter) exportContactPoints(ctx context.Context) ([]client.Object, error) {
+	resp, err := e.client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParamsWithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("listing contact points: %w", err)
+	}
+
+	// Every integration is returned separately, a contact point consists of all integrations sharing a name
+	var order []string
+
+	receivers := map[string][]*models.EmbeddedContactPoint{}
+
+	for _, cp := range resp.Payload {
+		if _, ok := receivers[cp.Name]; !ok {
+			order = append(order, cp.Name)
+		}
+
+		receivers[cp.Name] = append(receivers[cp.Name], cp)
+	}
+
+	names := nameRegistry{}
+
+	objects := make([]client.Object, 0, len(order))
+
+	for _, cpName := range order {
+		cr, err := e.contactPointToCR(cpName, receivers[cpName], names.unique(cpName, ""))
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, cr)
+	}
+
+	return objects, nil
+}
+
+func (e *Exporter) contactPointToCR(cpName string, integrations []*models.EmbeddedContactPoint, name string) (*v1beta1.GrafanaContactPoint, error) {
+	cr := &v1beta1.GrafanaContactPoint{
+		TypeMeta:   typeMeta(KindContactPoint),
+		ObjectMeta: e.objectMeta(name),
+		Spec: v1beta1.GrafanaContactPointSpec{
+			GrafanaCommonSpec: e.commonSpec(),
+			Name:              cpName,
+			Receivers:         make([]v1beta1.ContactPointReceiver, 0, len(integrations)),
+		},
+	}
+
+	for i, integration := range integrations {
+		receiver := v1beta1.ContactPointReceiver{
+			CustomUID:             integration.UID,
+			Type:                  deref(integration.Type),
+			DisableResolveMessage: integration.DisableResolveMessage,
+		}
+
+		settings := map[string]any{}
+		if integration.Settings != nil {
+			raw, err := json.Marshal(integration.Settings)
+			if err != nil {
+				return nil, fmt.Errorf("encoding settings of contact point %s: %w", cpName, err)
+			}
+
+			if err := json.Unmarshal(raw, &settings); err != nil {
+				return nil, fmt.Errorf("decoding settings of contact point %s: %w", cpName, err)
+			}
+		}
+
+		// Secure settings are redacted by Grafana, reference them from a Secret that has to be created separately
+		var secureFields []string
+
+		for field, value := range settings {
+			if value == redactedValue {
+				secureFields = append(secureFields, field)
+			}
+		}
+
+		slices.Sort(secureFields)
+
+		for _, field := range secureFields {
+			delete(settings, field)
+
+			key := field
+			if len(integrations) > 1 {
+				key = fmt.Sprintf("%d-%s", i, field)
+			}
+
+			receiver.ValuesFrom = append(receiver.ValuesFrom, secretValueFrom(field, credentialsSecretName(name), key))
+		}
+
+		raw, err := json.Marshal(settings)
+		if err != nil {
+			return nil, fmt.Errorf("encoding settings of contact point %s: %w", cpName, err)
+		}
+
+		receiver.Settings = &apiextensionsv1.JSON{Raw: raw}
+
+		cr.Spec.Receivers = append(cr.Spec.Receivers, receiver)
+	}
+
+	return cr, nil
+}
+
+func (e *Exporter) exportMuteTimings(ctx context.Context) ([]client.Object, error) {
+	resp, err := e.client.Provisioning.GetMuteTimings()
+	if err != nil {
+		return nil, fmt.Errorf("listing mute timings: %w", err)
+	}
+
+	names := nameRegistry{}
+
+	objects := make([]client.Object, 0, len(resp.Payload))
+
+	for _, mt := range resp.Payload {
+		// The CR uses the field names of the API, convert through json instead of mapping every field
+		raw, err := json.Marshal(mt.TimeIntervals)
+		if err != nil {
+			return nil, fmt.Errorf("encoding time intervals of mute timing %s: %w", mt.Name, err)
+		}
+
+		var intervals []*v1beta1.TimeInterval
+		if err := json.Unmarshal(raw, &intervals); err != nil {
+			return nil, fmt.Errorf("decoding time intervals of mute timing %s: %w", mt.Name, err)
+		}
+
+		objects = append(objects, &v1beta1.GrafanaMuteTiming{
+			TypeMeta:   typeMeta(KindMuteTiming),
+			ObjectMeta: e.objectMeta(names.unique(mt.Name, "")),
+			Spec: v1beta1.GrafanaMuteTimingSpec{
+				GrafanaCommonSpec: e.commonSpec(),
+				Name:              mt.Name,
+				TimeIntervals:     intervals,
+				Editable:          true,
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+// formatDuration drops the zero units time.Duration.String appends, e.g. 5m0s becomes 5m
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
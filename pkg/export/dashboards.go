@@ -0,0 +1,82 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const searchTypeDashboard = "dash-db"
+
+func (e *Exporter) exportDashboards(ctx context.Context) ([]client.Object, error) {
+	names := nameRegistry{}
+
+	var objects []client.Object
+
+	for page := int64(1); ; page++ {
+		params := search.NewSearchParamsWithContext(ctx).
+			WithType(new(searchTypeDashboard)).
+			WithLimit(new(pageLimit)).
+			WithPage(new(page))
+
+		resp, err := e.client.Search.Search(params)
+		if err != nil {
+			return nil, fmt.Errorf("searching dashboards: %w", err)
+		}
+
+		for _, hit := range resp.Payload {
+			dashboard, err := e.exportDashboard(hit.UID, names.unique(hit.Title, hit.UID))
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, dashboard)
+		}
+
+		if int64(len(resp.Payload)) < pageLimit {
+			return objects, nil
+		}
+	}
+}
+
+func (e *Exporter) exportDashboard(uid, name string) (*v1beta1.GrafanaDashboard, error) {
+	resp, err := e.client.Dashboards.GetDashboardByUID(uid)
+	if err != nil {
+		return nil, fmt.Errorf("fetching dashboard %s: %w", uid, err)
+	}
+
+	model, ok := resp.Payload.Dashboard.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("dashboard %s has an unexpected model type %T", uid, resp.Payload.Dashboard)
+	}
+
+	// Both are assigned by Grafana and would conflict with the instances the dashboard is imported into
+	delete(model, "id")
+	delete(model, "version")
+
+	dashboardJSON, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding dashboard %s: %w", uid, err)
+	}
+
+	cr := &v1beta1.GrafanaDashboard{
+		TypeMeta:   typeMeta(KindDashboard),
+		ObjectMeta: e.objectMeta(name),
+		Spec: v1beta1.GrafanaDashboardSpec{
+			GrafanaCommonSpec: e.commonSpec(),
+			GrafanaContentSpec: v1beta1.GrafanaContentSpec{
+				JSON: string(dashboardJSON),
+			},
+		},
+	}
+
+	if resp.Payload.Meta != nil {
+		cr.Spec.FolderRef, cr.Spec.FolderUID = e.folderReference(resp.Payload.Meta.FolderUID)
+	}
+
+	return cr, nil
+}
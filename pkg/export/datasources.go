@@ -0,0 +1,130 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (e *Exporter) exportDatasources(ctx context.Context) ([]client.Object, error) {
+	resp, err := e.client.Datasources.GetDataSources()
+	if err != nil {
+		return nil, fmt.Errorf("listing datasources: %w", err)
+	}
+
+	names := nameRegistry{}
+
+	objects := make([]client.Object, 0, len(resp.Payload))
+
+	for _, item := range resp.Payload {
+		ds, err := e.client.Datasources.GetDataSourceByUID(item.UID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching datasource %s: %w", item.UID, err)
+		}
+
+		cr, err := e.datasourceToCR(ds.Payload, names.unique(item.Name, item.UID))
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, cr)
+	}
+
+	return objects, nil
+}
+
+func (e *Exporter) datasourceToCR(ds *models.DataSource, name string) (*v1beta1.GrafanaDatasource, error) {
+	internal := &v1beta1.GrafanaDatasourceInternal{
+		UID:           ds.UID,
+		Name:          ds.Name,
+		Type:          ds.Type,
+		URL:           ds.URL,
+		Access:        string(ds.Access),
+		Database:      ds.Database,
+		User:          ds.User,
+		BasicAuthUser: ds.BasicAuthUser,
+	}
+
+	if ds.IsDefault {
+		internal.IsDefault = new(true)
+	}
+
+	if ds.BasicAuth {
+		internal.BasicAuth = new(true)
+	}
+
+	if ds.JSONData != nil {
+		jsonData, err := json.Marshal(ds.JSONData)
+		if err != nil {
+			return nil, fmt.Errorf("encoding jsonData of datasource %s: %w", ds.UID, err)
+		}
+
+		if string(jsonData) != "{}" {
+			internal.JSONData = jsonData
+		}
+	}
+
+	cr := &v1beta1.GrafanaDatasource{
+		TypeMeta:   typeMeta(KindDatasource),
+		ObjectMeta: e.objectMeta(name),
+		Spec: v1beta1.GrafanaDatasourceSpec{
+			GrafanaCommonSpec: e.commonSpec(),
+			Datasource:        internal,
+		},
+	}
+
+	// Secure values are never returned by Grafana, reference them from a Secret that has to be created separately.
+	// The datasource controller substitutes ${key} within the target path with the value of the Secret key
+	secureFields := make([]string, 0, len(ds.SecureJSONFields))
+	for field, set := range ds.SecureJSONFields {
+		if set {
+			secureFields = append(secureFields, field)
+		}
+	}
+
+	if len(secureFields) == 0 {
+		return cr, nil
+	}
+
+	slices.Sort(secureFields)
+
+	secureJSONData := make(map[string]string, len(secureFields))
+	for _, field := range secureFields {
+		secureJSONData[field] = fmt.Sprintf("${%s}", field)
+		cr.Spec.ValuesFrom = append(cr.Spec.ValuesFrom, secretValueFrom("secureJsonData."+field, credentialsSecretName(name), field))
+	}
+
+	secure, err := json.Marshal(secureJSONData)
+	if err != nil {
+		return nil, fmt.Errorf("encoding secureJsonData of datasource %s: %w", ds.UID, err)
+	}
+
+	internal.SecureJSONData = secure
+
+	return cr, nil
+}
+
+// credentialsSecretName is the name of the Secret that is expected to hold the secure values of an exported resource
+func credentialsSecretName(name string) string {
+	return resourceName(name + "-credentials")
+}
+
+func secretValueFrom(targetPath, secretName, key string) v1beta1.ValueFrom {
+	return v1beta1.ValueFrom{
+		TargetPath: targetPath,
+		ValueFrom: v1beta1.ValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export converts the state of an existing Grafana instance into grafana-operator custom resources
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	genapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	KindFolder         = "GrafanaFolder"
+	KindDatasource     = "GrafanaDatasource"
+	KindDashboard      = "GrafanaDashboard"
+	KindAlertRuleGroup = "GrafanaAlertRuleGroup"
+	KindContactPoint   = "GrafanaContactPoint"
+	KindMuteTiming     = "GrafanaMuteTiming"
+)
+
+// AllKinds lists the exportable kinds in the order they are written
+var AllKinds = []string{
+	KindFolder,
+	KindDatasource,
+	KindDashboard,
+	KindAlertRuleGroup,
+	KindContactPoint,
+	KindMuteTiming,
+}
+
+// Exporter reads resources through the Grafana HTTP API and returns them as custom resources
+type Exporter struct {
+	client *genapi.GrafanaHTTPAPI
+
+	// namespace and instanceSelector are set on all exported resources
+	namespace        string
+	instanceSelector *metav1.LabelSelector
+
+	// folderNames maps folder UIDs to the name of the exported GrafanaFolder
+	folderNames map[string]string
+}
+
+func NewExporter(gClient *genapi.GrafanaHTTPAPI, namespace string, instanceSelector *metav1.LabelSelector) *Exporter {
+	if instanceSelector == nil {
+		instanceSelector = &metav1.LabelSelector{}
+	}
+
+	return &Exporter{
+		client:           gClient,
+		namespace:        namespace,
+		instanceSelector: instanceSelector,
+	}
+}
+
+// Export returns the resources of the requested kinds, grouped by kind in the order of AllKinds
+func (e *Exporter) Export(ctx context.Context, kinds []string) ([]client.Object, error) {
+	for _, kind := range kinds {
+		if !slices.Contains(AllKinds, kind) {
+			return nil, fmt.Errorf("unsupported kind %q, must be one of %s", kind, strings.Join(AllKinds, ", "))
+		}
+	}
+
+	// Folders are always fetched as they are needed to resolve folderRef of other kinds
+	folders, err := e.exportFolders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("exporting folders: %w", err)
+	}
+
+	exporters := map[string]func(context.Context) ([]client.Object, error){
+		KindFolder: func(context.Context) ([]client.Object, error) {
+			return folders, nil
+		},
+		KindDatasource:     e.exportDatasources,
+		KindDashboard:      e.exportDashboards,
+		KindAlertRuleGroup: e.exportAlertRuleGroups,
+		KindContactPoint:   e.exportContactPoints,
+		KindMuteTiming:     e.exportMuteTimings,
+	}
+
+	var objects []client.Object
+
+	for _, kind := range AllKinds {
+		if !slices.Contains(kinds, kind) {
+			continue
+		}
+
+		exported, err := exporters[kind](ctx)
+		if err != nil {
+			return nil, fmt.Errorf("exporting %s: %w", kind, err)
+		}
+
+		objects = append(objects, exported...)
+	}
+
+	return objects, nil
+}
+
+func (e *Exporter) objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: e.namespace,
+	}
+}
+
+func (e *Exporter) commonSpec() v1beta1.GrafanaCommonSpec {
+	return v1beta1.GrafanaCommonSpec{
+		InstanceSelector: e.instanceSelector.DeepCopy(),
+	}
+}
+
+// folderReference returns the name of the exported GrafanaFolder for the uid,
+// an empty name together with the uid is returned when the folder is unknown
+func (e *Exporter) folderReference(folderUID string) (string, string) {
+	if folderUID == "" {
+		return "", ""
+	}
+
+	if name, ok := e.folderNames[folderUID]; ok {
+		return name, ""
+	}
+
+	return "", folderUID
+}
+
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: v1beta1.SchemeGroupVersion.String(),
+		Kind:       kind,
+	}
+}
+
+// WriteYAML writes the objects as a multi document YAML stream
+func WriteYAML(w io.Writer, objects []client.Object) error {
+	for i, obj := range objects {
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+
+		if err := writeObject(w, obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeObject(w io.Writer, obj client.Object) error {
+	// Round trip through a map to drop empty metadata and status fields
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("encoding %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+
+	var content map[string]any
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return err
+	}
+
+	delete(content, "status")
+
+	if metadata, ok := content["metadata"].(map[string]any); ok {
+		delete(metadata, "creationTimestamp")
+	}
+
+	out, err := yaml.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("encoding %s %s as yaml: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+
+	_, err = w.Write(out)
+
+	return err
+}
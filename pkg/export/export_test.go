@@ -0,0 +1,315 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	grafanaclient "github.com/grafana/grafana-operator/v5/controllers/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func jsonHandler(t *testing.T, body any) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}
+}
+
+// newFakeGrafana serves the read endpoints used by the exporter
+func newFakeGrafana(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/folders", func(w http.ResponseWriter, r *http.Request) {
+		folders := []map[string]any{}
+
+		switch r.URL.Query().Get("parentUid") {
+		case "":
+			folders = append(folders, map[string]any{"uid": "team-a", "title": "Team A"})
+		case "team-a":
+			folders = append(folders, map[string]any{"uid": "team-a-prod", "title": "Production", "parentUid": "team-a"})
+		}
+
+		jsonHandler(t, folders)(w, r)
+	})
+	mux.HandleFunc("GET /api/search", jsonHandler(t, []map[string]any{
+		{"uid": "dash-1", "title": "Node Exporter", "type": "dash-db"},
+		{"uid": "dash-2", "title": "Node Exporter", "type": "dash-db"},
+	}))
+	mux.HandleFunc("GET /api/dashboards/uid/{uid}", func(w http.ResponseWriter, r *http.Request) {
+		uid := r.PathValue("uid")
+		folderUID := ""
+
+		if uid == "dash-1" {
+			folderUID = "team-a-prod"
+		}
+
+		jsonHandler(t, map[string]any{
+			"dashboard": map[string]any{"id": 12, "uid": uid, "title": "Node Exporter", "version": 3},
+			"meta":      map[string]any{"folderUid": folderUID},
+		})(w, r)
+	})
+	mux.HandleFunc("GET /api/datasources", jsonHandler(t, []map[string]any{
+		{"uid": "prom", "name": "Prometheus"},
+	}))
+	mux.HandleFunc("GET /api/datasources/uid/prom", jsonHandler(t, map[string]any{
+		"id":               4,
+		"uid":              "prom",
+		"name":             "Prometheus",
+		"type":             "prometheus",
+		"url":              "http://prometheus:9090",
+		"access":           "proxy",
+		"isDefault":        true,
+		"basicAuth":        true,
+		"basicAuthUser":    "grafana",
+		"jsonData":         map[string]any{"timeInterval": "30s"},
+		"secureJsonFields": map[string]bool{"basicAuthPassword": true, "httpHeaderValue1": false},
+	}))
+	mux.HandleFunc("GET /api/v1/provisioning/alert-rules", jsonHandler(t, []map[string]any{
+		{"uid": "rule-1", "folderUID": "team-a", "ruleGroup": "availability"},
+		{"uid": "rule-2", "folderUID": "team-a", "ruleGroup": "availability"},
+		{"uid": "rule-3", "folderUID": "unmanaged", "ruleGroup": "availability"},
+	}))
+	mux.HandleFunc("GET /api/v1/provisioning/folder/{folderUID}/rule-groups/{group}", func(w http.ResponseWriter, r *http.Request) {
+		jsonHandler(t, map[string]any{
+			"title":     r.PathValue("group"),
+			"folderUid": r.PathValue("folderUID"),
+			"interval":  60,
+			"rules": []map[string]any{{
+				"uid":          "rule-1",
+				"title":        "Instance down",
+				"condition":    "B",
+				"for":          "5m",
+				"execErrState": "Error",
+				"noDataState":  "NoData",
+				"data": []map[string]any{{
+					"refId":             "A",
+					"datasourceUid":     "prom",
+					"relativeTimeRange": map[string]any{"from": 600},
+					"model":             map[string]any{"expr": "up == 0"},
+				}},
+				"notification_settings": map[string]any{"receiver": "On-call"},
+			}},
+		})(w, r)
+	})
+	mux.HandleFunc("GET /api/v1/provisioning/contact-points", jsonHandler(t, []map[string]any{
+		{"uid": "oncall-email", "name": "On-call", "type": "email", "settings": map[string]any{"addresses": "oncall@example.com"}},
+		{"uid": "oncall-slack", "name": "On-call", "type": "slack", "settings": map[string]any{"recipient": "#alerts", "url": "[REDACTED]"}},
+	}))
+	mux.HandleFunc("GET /api/v1/provisioning/mute-timings", jsonHandler(t, []map[string]any{{
+		"name": "Weekends",
+		"time_intervals": []map[string]any{{
+			"weekdays": []string{"saturday", "sunday"},
+			"times":    []map[string]any{{"start_time": "00:00", "end_time": "23:59"}},
+		}},
+	}}))
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func exportAll(t *testing.T) map[string][]client.Object {
+	t.Helper()
+
+	server := newFakeGrafana(t)
+
+	gClient, err := grafanaclient.NewGeneratedGrafanaClientWithCredentials(context.Background(), server.URL, grafanaclient.Credentials{APIKey: "token"}, nil)
+	require.NoError(t, err)
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}}
+
+	objects, err := NewExporter(gClient, "monitoring", selector).Export(context.Background(), AllKinds)
+	require.NoError(t, err)
+
+	byKind := map[string][]client.Object{}
+
+	for _, obj := range objects {
+		assert.Equal(t, "monitoring", obj.GetNamespace())
+
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		byKind[kind] = append(byKind[kind], obj)
+	}
+
+	return byKind
+}
+
+func TestExportFolders(t *testing.T) {
+	folders := exportAll(t)[KindFolder]
+	require.Len(t, folders, 2)
+
+	parent := folders[0].(*v1beta1.GrafanaFolder)
+	assert.Equal(t, "team-a", parent.Name)
+	assert.Equal(t, "team-a", parent.Spec.CustomUID)
+	assert.Equal(t, "Team A", parent.Spec.Title)
+	assert.Empty(t, parent.Spec.ParentFolderRef)
+	assert.Equal(t, "grafana", parent.Spec.InstanceSelector.MatchLabels["dashboards"])
+
+	child := folders[1].(*v1beta1.GrafanaFolder)
+	assert.Equal(t, "production", child.Name)
+	assert.Equal(t, "team-a", child.Spec.ParentFolderRef)
+}
+
+func TestExportDashboards(t *testing.T) {
+	dashboards := exportAll(t)[KindDashboard]
+	require.Len(t, dashboards, 2)
+
+	first := dashboards[0].(*v1beta1.GrafanaDashboard)
+	assert.Equal(t, "node-exporter", first.Name)
+	assert.Equal(t, "production", first.Spec.FolderRef)
+	assert.Empty(t, first.Spec.FolderUID)
+	assert.NotContains(t, first.Spec.JSON, `"id"`)
+	assert.NotContains(t, first.Spec.JSON, `"version"`)
+	assert.Contains(t, first.Spec.JSON, `"uid": "dash-1"`)
+
+	// Titles are not unique, the uid is appended on collisions
+	second := dashboards[1].(*v1beta1.GrafanaDashboard)
+	assert.Equal(t, "node-exporter-dash-2", second.Name)
+	assert.Empty(t, second.Spec.FolderRef)
+}
+
+func TestExportDatasources(t *testing.T) {
+	datasources := exportAll(t)[KindDatasource]
+	require.Len(t, datasources, 1)
+
+	ds := datasources[0].(*v1beta1.GrafanaDatasource)
+	assert.Equal(t, "prometheus", ds.Name)
+	assert.Equal(t, "prom", ds.Spec.Datasource.UID)
+	assert.Equal(t, "proxy", ds.Spec.Datasource.Access)
+	assert.True(t, *ds.Spec.Datasource.IsDefault)
+	assert.True(t, *ds.Spec.Datasource.BasicAuth)
+	assert.JSONEq(t, `{"timeInterval":"30s"}`, string(ds.Spec.Datasource.JSONData))
+	assert.JSONEq(t, `{"basicAuthPassword":"${basicAuthPassword}"}`, string(ds.Spec.Datasource.SecureJSONData))
+
+	require.Len(t, ds.Spec.ValuesFrom, 1)
+	assert.Equal(t, "secureJsonData.basicAuthPassword", ds.Spec.ValuesFrom[0].TargetPath)
+	assert.Equal(t, "prometheus-credentials", ds.Spec.ValuesFrom[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "basicAuthPassword", ds.Spec.ValuesFrom[0].ValueFrom.SecretKeyRef.Key)
+}
+
+func TestExportAlertRuleGroups(t *testing.T) {
+	groups := exportAll(t)[KindAlertRuleGroup]
+	require.Len(t, groups, 2)
+
+	group := groups[0].(*v1beta1.GrafanaAlertRuleGroup)
+	assert.Equal(t, "availability", group.Name)
+	assert.Equal(t, "availability", group.Spec.Name)
+	assert.Equal(t, "team-a", group.Spec.FolderRef)
+	assert.Equal(t, time.Minute, group.Spec.Interval.Duration)
+
+	require.Len(t, group.Spec.Rules, 1)
+	rule := group.Spec.Rules[0]
+	assert.Equal(t, "Instance down", rule.Title)
+	assert.Equal(t, "5m", *rule.For)
+	assert.Equal(t, "Error", rule.ExecErrState)
+	assert.Equal(t, "On-call", rule.NotificationSettings.Receiver)
+	require.Len(t, rule.Data, 1)
+	assert.JSONEq(t, `{"expr":"up == 0"}`, string(rule.Data[0].Model.Raw))
+
+	// Folders that were not exported are referenced by uid
+	unmanaged := groups[1].(*v1beta1.GrafanaAlertRuleGroup)
+	assert.Equal(t, "availability-unmanaged", unmanaged.Name)
+	assert.Empty(t, unmanaged.Spec.FolderRef)
+	assert.Equal(t, "unmanaged", unmanaged.Spec.FolderUID)
+}
+
+func TestExportContactPoints(t *testing.T) {
+	contactPoints := exportAll(t)[KindContactPoint]
+	require.Len(t, contactPoints, 1)
+
+	cp := contactPoints[0].(*v1beta1.GrafanaContactPoint)
+	assert.Equal(t, "on-call", cp.Name)
+	assert.Equal(t, "On-call", cp.Spec.Name)
+	require.Len(t, cp.Spec.Receivers, 2)
+
+	email := cp.Spec.Receivers[0]
+	assert.Equal(t, "email", email.Type)
+	assert.Empty(t, email.ValuesFrom)
+
+	slack := cp.Spec.Receivers[1]
+	assert.Equal(t, "oncall-slack", slack.CustomUID)
+	assert.JSONEq(t, `{"recipient":"#alerts"}`, string(slack.Settings.Raw))
+	require.Len(t, slack.ValuesFrom, 1)
+	assert.Equal(t, "url", slack.ValuesFrom[0].TargetPath)
+	assert.Equal(t, "on-call-credentials", slack.ValuesFrom[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "1-url", slack.ValuesFrom[0].ValueFrom.SecretKeyRef.Key)
+}
+
+func TestExportMuteTimings(t *testing.T) {
+	muteTimings := exportAll(t)[KindMuteTiming]
+	require.Len(t, muteTimings, 1)
+
+	mt := muteTimings[0].(*v1beta1.GrafanaMuteTiming)
+	assert.Equal(t, "weekends", mt.Name)
+	assert.True(t, mt.Spec.Editable)
+	require.Len(t, mt.Spec.TimeIntervals, 1)
+	assert.Equal(t, []string{"saturday", "sunday"}, mt.Spec.TimeIntervals[0].Weekdays)
+	assert.Equal(t, "00:00", mt.Spec.TimeIntervals[0].Times[0].StartTime)
+}
+
+func TestExportUnsupportedKind(t *testing.T) {
+	_, err := NewExporter(nil, "default", nil).Export(context.Background(), []string{"GrafanaLibraryPanel"})
+	require.ErrorContains(t, err, "unsupported kind")
+}
+
+func TestWriteYAML(t *testing.T) {
+	folders := exportAll(t)[KindFolder]
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteYAML(&buf, folders))
+
+	out := buf.String()
+	assert.Contains(t, out, "apiVersion: grafana.integreatly.org/v1beta1\nkind: GrafanaFolder\n")
+	assert.Contains(t, out, "\n---\n")
+	assert.NotContains(t, out, "status:")
+	assert.NotContains(t, out, "creationTimestamp")
+}
+
+func TestResourceName(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Node Exporter / Full", want: "node-exporter-full"},
+		{title: "  Kubernetes :: Pods  ", want: "kubernetes-pods"},
+		{title: "Ünicode", want: "nicode"},
+		{title: "---", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, resourceName(tt.title))
+		})
+	}
+}
+
+func TestNameRegistryUnique(t *testing.T) {
+	names := nameRegistry{}
+
+	assert.Equal(t, "overview", names.unique("Overview", "abc"))
+	assert.Equal(t, "overview-def", names.unique("Overview", "def"))
+	assert.Equal(t, "overview-def-2", names.unique("Overview", "def"))
+	assert.Equal(t, "xyz", names.unique("***", "xyz"))
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0s", formatDuration(0))
+	assert.Equal(t, "30s", formatDuration(30*time.Second))
+	assert.Equal(t, "5m", formatDuration(5*time.Minute))
+	assert.Equal(t, "1h", formatDuration(time.Hour))
+	assert.Equal(t, "1h30m", formatDuration(90*time.Minute))
+	assert.Equal(t, "1m30s", formatDuration(90*time.Second))
+}
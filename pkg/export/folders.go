@@ -0,0 +1,81 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const pageLimit int64 = 1000
+
+// exportFolders walks the folder tree top down, so parents are always written before their children
+func (e *Exporter) exportFolders(ctx context.Context) ([]client.Object, error) {
+	e.folderNames = map[string]string{}
+	names := nameRegistry{}
+
+	var objects []client.Object
+
+	queue := []string{""}
+	for len(queue) > 0 {
+		parentUID := queue[0]
+		queue = queue[1:]
+
+		hits, err := e.listFolders(ctx, parentUID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range hits {
+			// Instances without nested folders ignore parentUid and return the top level folders again
+			if _, seen := e.folderNames[hit.UID]; seen || hit.ParentUID != parentUID {
+				continue
+			}
+
+			name := names.unique(hit.Title, hit.UID)
+			e.folderNames[hit.UID] = name
+
+			objects = append(objects, &v1beta1.GrafanaFolder{
+				TypeMeta:   typeMeta(KindFolder),
+				ObjectMeta: e.objectMeta(name),
+				Spec: v1beta1.GrafanaFolderSpec{
+					GrafanaCommonSpec: e.commonSpec(),
+					CustomUID:         hit.UID,
+					Title:             hit.Title,
+					ParentFolderRef:   e.folderNames[hit.ParentUID],
+				},
+			})
+
+			queue = append(queue, hit.UID)
+		}
+	}
+
+	return objects, nil
+}
+
+func (e *Exporter) listFolders(ctx context.Context, parentUID string) ([]*models.FolderSearchHit, error) {
+	var hits []*models.FolderSearchHit
+
+	for page := int64(1); ; page++ {
+		params := folders.NewGetFoldersParamsWithContext(ctx).
+			WithLimit(new(pageLimit)).
+			WithPage(new(page))
+		if parentUID != "" {
+			params.SetParentUID(new(parentUID))
+		}
+
+		resp, err := e.client.Folders.GetFolders(params)
+		if err != nil {
+			return nil, fmt.Errorf("listing folders below %q: %w", parentUID, err)
+		}
+
+		hits = append(hits, resp.Payload...)
+
+		if int64(len(resp.Payload)) < pageLimit {
+			return hits, nil
+		}
+	}
+}
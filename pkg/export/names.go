@@ -0,0 +1,60 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// resourceName converts a Grafana title into a valid DNS-1123 subdomain
+func resourceName(title string) string {
+	var b strings.Builder
+
+	dash := false
+
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+
+			dash = true
+		}
+	}
+
+	name := strings.Trim(b.String(), "-")
+	if len(name) > validation.DNS1123SubdomainMaxLength {
+		name = strings.Trim(name[:validation.DNS1123SubdomainMaxLength], "-")
+	}
+
+	return name
+}
+
+// nameRegistry hands out unique resource names per kind
+type nameRegistry map[string]struct{}
+
+// unique returns the name derived from title, the uid is appended when the name is empty or already taken
+func (n nameRegistry) unique(title, uid string) string {
+	name := resourceName(title)
+
+	if _, taken := n[name]; taken || name == "" {
+		name = resourceName(strings.TrimSpace(name + " " + uid))
+	}
+
+	// Titles are not unique in Grafana, fall back to a counter for identical title and uid pairs
+	for i := 2; ; i++ {
+		if _, taken := n[name]; !taken {
+			break
+		}
+
+		name = resourceName(strings.Join([]string{resourceName(title), uid, strconv.Itoa(i)}, " "))
+	}
+
+	n[name] = struct{}{}
+
+	return name
+}
@@ -0,0 +1,110 @@
+package oci
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dockerConfigJSON mirrors the relevant subset of the kubernetes.io/dockerconfigjson secret format.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+type dockerConfigAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"` // base64("username:password")
+}
+
+// AuthFromPullSecret returns the credentials of registryHost in a kubernetes.io/dockerconfigjson secret, nil if there are none
+func AuthFromPullSecret(ctx context.Context, cl client.Client, namespace, secretName, registryHost string) (auth.CredentialFunc, error) {
+	secret := &corev1.Secret{}
+
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return nil, err
+	}
+
+	if secret.Type != corev1.SecretTypeDockerConfigJson {
+		return nil, fmt.Errorf("pull secret %s/%s must be type %s, got %s",
+			namespace, secretName, corev1.SecretTypeDockerConfigJson, secret.Type)
+	}
+
+	raw, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return nil, fmt.Errorf("pull secret %s/%s missing key %s", namespace, secretName, corev1.DockerConfigJsonKey)
+	}
+
+	credFunc, err := AuthFromDockerConfig(raw, registryHost)
+	if err != nil {
+		return nil, fmt.Errorf("parse pull secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	return credFunc, nil
+}
+
+// AuthFromDockerConfig returns the credentials of registryHost in a .dockerconfigjson, nil if there are none
+func AuthFromDockerConfig(raw []byte, registryHost string) (auth.CredentialFunc, error) {
+	var cfg dockerConfigJSON
+
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, err
+	}
+
+	for host, a := range cfg.Auths {
+		if !hostMatches(host, registryHost) {
+			continue
+		}
+
+		username, password := a.Username, a.Password
+
+		if a.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(a.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("decode auth field: %w", err)
+			}
+
+			parts := strings.SplitN(string(decoded), ":", 2)
+			if len(parts) == 2 {
+				username, password = parts[0], parts[1]
+			}
+		}
+
+		return auth.StaticCredential(registryHost, auth.Credential{Username: username, Password: password}), nil
+	}
+
+	return nil, nil
+}
+
+// hostMatches returns true when the config host key matches the registry hostname.
+// Docker config files may store the registry as "https://index.docker.io/v1/" for Docker Hub,
+// or plain hostname for other registries.
+func hostMatches(configHost, registryHost string) bool {
+	if !strings.Contains(configHost, "://") {
+		configHost = "https://" + configHost
+	}
+
+	u, err := url.Parse(configHost)
+	if err != nil {
+		return false
+	}
+
+	return u.Host == registryHost
+}
+
+// NewAuthClient returns the client of a repository authenticating with credFunc, anonymous if nil
+func NewAuthClient(credFunc auth.CredentialFunc) *auth.Client {
+	return &auth.Client{
+		Client:     retry.DefaultClient,
+		Cache:      auth.NewCache(),
+		Credential: credFunc,
+	}
+}
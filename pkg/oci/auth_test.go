@@ -0,0 +1,48 @@
+package oci
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeDockerConfigJSON builds a kubernetes.io/dockerconfigjson secret payload for registryHost.
+func makeDockerConfigJSON(t *testing.T, registryHost, username, password string) []byte {
+	t.Helper()
+
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	cfg := map[string]any{
+		"auths": map[string]any{
+			registryHost: map[string]any{"auth": auth},
+		},
+	}
+
+	raw, err := json.Marshal(cfg)
+	require.NoError(t, err)
+
+	return raw
+}
+
+func TestHostMatches(t *testing.T) {
+	cases := []struct {
+		configHost   string
+		registryHost string
+		want         bool
+	}{
+		{"ghcr.io", "ghcr.io", true},
+		{"https://ghcr.io", "ghcr.io", true},
+		{"https://index.docker.io/v1/", "index.docker.io", true},
+		{"gcr.io", "ghcr.io", false},
+		{"https://registry.example.com", "registry.example.com", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s~%s", tc.configHost, tc.registryHost), func(t *testing.T) {
+			assert.Equal(t, tc.want, hostMatches(tc.configHost, tc.registryHost))
+		})
+	}
+}
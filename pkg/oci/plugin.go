@@ -0,0 +1,143 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+)
+
+// ResolvePluginArchive resolves the reference of an OCI plugin source to the digest of its manifest. Returns the
+// digest reference the Grafana pods pull and the sha256 checksum of the archive
+func ResolvePluginArchive(ctx context.Context, cl client.Client, namespace string, source *v1beta1.GrafanaPluginOCISource) (string, string, error) {
+	repo, reference, err := newRepository(source.Reference, source.InsecurePlainHTTP)
+	if err != nil {
+		return "", "", err
+	}
+
+	var credFunc auth.CredentialFunc
+
+	if source.PullSecretRef != nil {
+		credFunc, err = AuthFromPullSecret(ctx, cl, namespace, source.PullSecretRef.Name, repo.Reference.Registry)
+		if err != nil {
+			return "", "", fmt.Errorf("resolve pull secret: %w", err)
+		}
+	}
+
+	repo.Client = NewAuthClient(credFunc)
+
+	desc, err := repo.Resolve(ctx, reference)
+	if err != nil {
+		return "", "", fmt.Errorf("resolve oci reference %s: %w", source.Reference, err)
+	}
+
+	layer, err := pluginArchiveLayer(ctx, repo, desc)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", source.Reference, err)
+	}
+
+	if layer.Digest.Algorithm() != "sha256" {
+		return "", "", fmt.Errorf("%s: unsupported digest algorithm %s of the plugin archive", source.Reference, layer.Digest.Algorithm())
+	}
+
+	pinned := fmt.Sprintf("%s/%s@%s", repo.Reference.Registry, repo.Reference.Repository, desc.Digest)
+
+	return pinned, layer.Digest.Encoded(), nil
+}
+
+// FetchPluginArchive pulls the plugin archive of an OCI artifact with the credentials of a .dockerconfigjson, if any.
+// The archive is verified against the digest of its layer
+func FetchPluginArchive(ctx context.Context, reference string, dockerConfig []byte, plainHTTP bool) ([]byte, error) {
+	repo, ref, err := newRepository(reference, plainHTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	var credFunc auth.CredentialFunc
+
+	if len(dockerConfig) > 0 {
+		credFunc, err = AuthFromDockerConfig(dockerConfig, repo.Reference.Registry)
+		if err != nil {
+			return nil, fmt.Errorf("parse docker config: %w", err)
+		}
+	}
+
+	repo.Client = NewAuthClient(credFunc)
+
+	desc, err := repo.Resolve(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolve oci reference %s: %w", reference, err)
+	}
+
+	layer, err := pluginArchiveLayer(ctx, repo, desc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", reference, err)
+	}
+
+	archive, err := content.FetchAll(ctx, repo, layer)
+	if err != nil {
+		return nil, fmt.Errorf("fetch layer %s of %s: %w", layer.Digest, reference, err)
+	}
+
+	return archive, nil
+}
+
+func newRepository(reference string, plainHTTP bool) (*remote.Repository, string, error) {
+	parsed, err := registry.ParseReference(reference)
+	if err != nil {
+		return nil, "", fmt.Errorf("parse oci reference %q: %w", reference, err)
+	}
+
+	if parsed.Reference == "" {
+		return nil, "", fmt.Errorf("oci reference %q must include tag or digest", reference)
+	}
+
+	repo, err := remote.NewRepository(parsed.Registry + "/" + parsed.Repository)
+	if err != nil {
+		return nil, "", fmt.Errorf("parse oci reference %q: %w", reference, err)
+	}
+
+	repo.PlainHTTP = plainHTTP
+
+	return repo, parsed.Reference, nil
+}
+
+// pluginArchiveLayer returns the only layer of the artifact or the one titled *.zip
+func pluginArchiveLayer(ctx context.Context, repo *remote.Repository, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	manifestBytes, err := content.FetchAll(ctx, repo, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("pull oci manifest: %w", err)
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("parse manifest: %w", err)
+	}
+
+	if len(manifest.Layers) == 1 {
+		return manifest.Layers[0], nil
+	}
+
+	archives := []ocispec.Descriptor{}
+
+	for _, layer := range manifest.Layers {
+		if strings.HasSuffix(layer.Annotations[ocispec.AnnotationTitle], ".zip") {
+			archives = append(archives, layer)
+		}
+	}
+
+	if len(archives) != 1 {
+		return ocispec.Descriptor{}, fmt.Errorf("expected a single layer or a single layer titled *.zip, found %d layers and %d archives", len(manifest.Layers), len(archives))
+	}
+
+	return archives[0], nil
+}
@@ -0,0 +1,189 @@
+package oci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/grafana-operator/v5/api/v1beta1"
+	"github.com/grafana/grafana-operator/v5/pkg/tk8s"
+)
+
+// fakeRegistry serves the manifests and blobs of artifacts through the pull API of the OCI distribution spec
+type fakeRegistry struct {
+	blobs map[string][]byte // "sha256:..." -> content
+	// "<repo>/<reference>" -> manifest, the reference is a tag or digest
+	manifests map[string][]byte
+
+	requireAuth bool
+	user, pass  string
+}
+
+// newFakeRegistry starts a registry without artifacts, returning it and the host:port it is reachable on
+func newFakeRegistry(t *testing.T) (*fakeRegistry, string) {
+	t.Helper()
+
+	r := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+
+	srv := httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(srv.Close)
+
+	return r, strings.TrimPrefix(srv.URL, "http://")
+}
+
+// pushArtifact stores an artifact with a layer per file titled with its name, returning the digest of the manifest
+func (r *fakeRegistry) pushArtifact(t *testing.T, repo, tag string, files map[string][]byte) string {
+	t.Helper()
+
+	layers := make([]ocispec.Descriptor, 0, len(files))
+
+	for name, content := range files {
+		d := sha256Digest(content)
+		r.blobs[d] = content
+		layers = append(layers, ocispec.Descriptor{
+			MediaType:   "application/zip",
+			Digest:      digest.Digest(d),
+			Size:        int64(len(content)),
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		})
+	}
+
+	manifest := ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.DescriptorEmptyJSON,
+		Layers:    layers,
+	}
+	manifest.SchemaVersion = 2
+
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	manifestDigest := sha256Digest(manifestBytes)
+	r.manifests[repo+"/"+tag] = manifestBytes
+	r.manifests[repo+"/"+manifestDigest] = manifestBytes
+
+	return manifestDigest
+}
+
+func (r *fakeRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if r.requireAuth {
+		user, pass, ok := req.BasicAuth()
+		if !ok || user != r.user || pass != r.pass {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+	}
+
+	rest := strings.TrimPrefix(req.URL.Path, "/v2/")
+
+	var (
+		body      []byte
+		ok        bool
+		mediaType = "application/octet-stream"
+	)
+
+	if repo, ref, found := strings.Cut(rest, "/manifests/"); found {
+		body, ok = r.manifests[repo+"/"+ref]
+		mediaType = ocispec.MediaTypeImageManifest
+	} else if _, ref, found := strings.Cut(rest, "/blobs/"); found {
+		body, ok = r.blobs[ref]
+	}
+
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Docker-Content-Digest", sha256Digest(body))
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	w.Write(body) //nolint:errcheck
+}
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestResolveAndFetchPluginArchive(t *testing.T) {
+	archive := []byte("PK plugin archive")
+
+	t.Run("private registry", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		reg.requireAuth = true
+		reg.user, reg.pass = "user", "pass"
+		manifestDigest := reg.pushArtifact(t, "plugins/clock-panel", "2.1.8", map[string][]byte{"clock-panel.zip": archive})
+
+		rawCfg := makeDockerConfigJSON(t, host, "user", "pass")
+		cl := tk8s.GetFakeClient(t, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "regcred", Namespace: "grafana"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: rawCfg},
+		})
+
+		reference, checksum, err := ResolvePluginArchive(context.Background(), cl, "grafana", &v1beta1.GrafanaPluginOCISource{
+			Reference:         host + "/plugins/clock-panel:2.1.8",
+			PullSecretRef:     &corev1.LocalObjectReference{Name: "regcred"},
+			InsecurePlainHTTP: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, host+"/plugins/clock-panel@"+manifestDigest, reference)
+		assert.Equal(t, strings.TrimPrefix(sha256Digest(archive), "sha256:"), checksum)
+
+		got, err := FetchPluginArchive(context.Background(), reference, rawCfg, true)
+		require.NoError(t, err)
+		assert.Equal(t, archive, got)
+
+		_, err = FetchPluginArchive(context.Background(), reference, nil, true)
+		require.Error(t, err, "credentials of the docker config are required")
+	})
+
+	t.Run("archive layer is ambiguous", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		reg.pushArtifact(t, "plugins/clock-panel", "2.1.8", map[string][]byte{
+			"a.zip": archive,
+			"b.zip": archive,
+		})
+
+		_, _, err := ResolvePluginArchive(context.Background(), tk8s.GetFakeClient(t), "grafana", &v1beta1.GrafanaPluginOCISource{
+			Reference:         host + "/plugins/clock-panel:2.1.8",
+			InsecurePlainHTTP: true,
+		})
+		require.ErrorContains(t, err, "found 2 layers and 2 archives")
+	})
+
+	t.Run("archive titled zip next to other layers", func(t *testing.T) {
+		reg, host := newFakeRegistry(t)
+		reg.pushArtifact(t, "plugins/clock-panel", "2.1.8", map[string][]byte{
+			"README.md":       []byte("# clock panel"),
+			"clock-panel.zip": archive,
+		})
+
+		_, checksum, err := ResolvePluginArchive(context.Background(), tk8s.GetFakeClient(t), "grafana", &v1beta1.GrafanaPluginOCISource{
+			Reference:         host + "/plugins/clock-panel:2.1.8",
+			InsecurePlainHTTP: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, strings.TrimPrefix(sha256Digest(archive), "sha256:"), checksum)
+	})
+}
@@ -0,0 +1,78 @@
+package oci
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// ParseSemverConstraint parses ranges like ">=1.4.0 <2.0.0 || 3.x" and additionally supports the tilde
+// (~1.4 = >=1.4.0 <1.5.0) and caret (^1.4.2 = >=1.4.2 <2.0.0) shorthands
+func ParseSemverConstraint(constraint string) (semver.Range, error) {
+	var alternatives []string
+
+	for alternative := range strings.SplitSeq(constraint, "||") {
+		terms := strings.Fields(alternative)
+
+		for i, term := range terms {
+			var err error
+
+			switch {
+			case strings.HasPrefix(term, "~"):
+				terms[i], err = expandSemverShorthand(term[1:], false)
+			case strings.HasPrefix(term, "^"):
+				terms[i], err = expandSemverShorthand(term[1:], true)
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid semver constraint %q: %w", constraint, err)
+			}
+		}
+
+		alternatives = append(alternatives, strings.Join(terms, " "))
+	}
+
+	r, err := semver.ParseRange(strings.Join(alternatives, " || "))
+	if err != nil {
+		return nil, fmt.Errorf("invalid semver constraint %q: %w", constraint, err)
+	}
+
+	return r, nil
+}
+
+// expandSemverShorthand turns the version of a tilde or caret constraint into a range. Tilde allows patch
+// updates if the minor version is given and minor updates otherwise, caret allows all updates not changing
+// the left-most non-zero part
+func expandSemverShorthand(version string, caret bool) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) > 3 {
+		return "", fmt.Errorf("invalid version %q", version)
+	}
+
+	numbers := make([]uint64, 3)
+
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid version %q", version)
+		}
+
+		numbers[i] = n
+	}
+
+	lower := semver.Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}
+	upper := semver.Version{Major: lower.Major + 1}
+
+	switch {
+	case caret && lower.Major == 0 && len(parts) > 1 && (lower.Minor > 0 || len(parts) == 2):
+		upper = semver.Version{Minor: lower.Minor + 1}
+	case caret && lower.Major == 0 && len(parts) == 3:
+		upper = semver.Version{Patch: lower.Patch + 1}
+	case !caret && len(parts) > 1:
+		upper = semver.Version{Major: lower.Major, Minor: lower.Minor + 1}
+	}
+
+	return fmt.Sprintf(">=%s <%s", lower, upper), nil
+}
@@ -0,0 +1,44 @@
+package oci
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemverConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{constraint: "~1.4", matches: []string{"1.4.0", "1.4.9"}, rejects: []string{"1.3.9", "1.5.0"}},
+		{constraint: "~1.4.2", matches: []string{"1.4.2", "1.4.9"}, rejects: []string{"1.4.1", "1.5.0"}},
+		{constraint: "~1", matches: []string{"1.0.0", "1.9.0"}, rejects: []string{"0.9.0", "2.0.0"}},
+		{constraint: "^1.4.2", matches: []string{"1.4.2", "1.9.0"}, rejects: []string{"1.4.1", "2.0.0"}},
+		{constraint: "^0.2.3", matches: []string{"0.2.3", "0.2.9"}, rejects: []string{"0.3.0"}},
+		{constraint: "^0.0.3", matches: []string{"0.0.3"}, rejects: []string{"0.0.4"}},
+		{constraint: ">=1.4.0 <2.0.0", matches: []string{"1.4.0", "1.9.9"}, rejects: []string{"2.0.0"}},
+		{constraint: "~1.4 || ^3.0.0", matches: []string{"1.4.1", "3.2.0"}, rejects: []string{"2.0.0", "4.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			matches, err := ParseSemverConstraint(tt.constraint)
+			require.NoError(t, err)
+
+			for _, v := range tt.matches {
+				assert.True(t, matches(semver.MustParse(v)), v)
+			}
+
+			for _, v := range tt.rejects {
+				assert.False(t, matches(semver.MustParse(v)), v)
+			}
+		})
+	}
+
+	_, err := ParseSemverConstraint("~one")
+	require.Error(t, err)
+}
@@ -0,0 +1,256 @@
+// Package plugininstaller unpacks plugin archives into the plugins directory of Grafana. It runs as the init container
+// of Grafana pods using the operator image, so that plugins can be installed without access to grafana.com
+package plugininstaller
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-operator/v5/pkg/oci"
+)
+
+const (
+	// Command is the first argument of the operator binary running the installer instead of the operator
+	Command = "install-plugins"
+
+	// ArchivesEnvVar holds the JSON encoded list of archives to install
+	ArchivesEnvVar = "GF_PLUGIN_ARCHIVES"
+
+	// maxUnpackedSize limits the size of a single unpacked archive
+	maxUnpackedSize = 1 << 30
+
+	downloadTimeout = 5 * time.Minute
+)
+
+// Archive is a plugin archive and where the installer loads it from, exactly one of OCI, URL and File is set
+type Archive struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+
+	// OCI is the digest reference of an artifact holding the archive
+	OCI               string `json:"oci,omitempty"`
+	InsecurePlainHTTP bool   `json:"insecurePlainHTTP,omitempty"`
+	// DockerConfig is the path of a mounted .dockerconfigjson holding the credentials of the registry
+	DockerConfig string `json:"dockerConfig,omitempty"`
+
+	URL string `json:"url,omitempty"`
+
+	// File is the path of an archive mounted from a ConfigMap or PersistentVolumeClaim
+	File string `json:"file,omitempty"`
+
+	// SHA256 is the hex encoded checksum of the archive, verified if set
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Main installs the archives listed in ArchivesEnvVar into the plugins directory passed as the only argument
+func Main(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s <plugins directory>", Command)
+	}
+
+	archives := []Archive{}
+
+	err := json.Unmarshal([]byte(os.Getenv(ArchivesEnvVar)), &archives)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", ArchivesEnvVar, err)
+	}
+
+	return Install(ctx, args[0], archives, &http.Client{Timeout: downloadTimeout})
+}
+
+// Install loads, verifies and unpacks the archives into dir. Previously installed versions of the plugins are replaced
+func Install(ctx context.Context, dir string, archives []Archive, httpClient *http.Client) error {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	for _, archive := range archives {
+		data, err := load(ctx, archive, httpClient)
+		if err != nil {
+			return fmt.Errorf("loading plugin %s: %w", archive.Name, err)
+		}
+
+		if archive.SHA256 != "" {
+			sum := sha256.Sum256(data)
+			if hex.EncodeToString(sum[:]) != archive.SHA256 {
+				return fmt.Errorf("checksum of plugin %s does not match: expected sha256 %s, got %s", archive.Name, archive.SHA256, hex.EncodeToString(sum[:]))
+			}
+		}
+
+		err = unpack(data, dir, archive.Name)
+		if err != nil {
+			return fmt.Errorf("unpacking plugin %s: %w", archive.Name, err)
+		}
+
+		slog.Info("installed plugin", "plugin", archive.Name, "version", archive.Version)
+	}
+
+	return nil
+}
+
+func load(ctx context.Context, archive Archive, httpClient *http.Client) ([]byte, error) {
+	switch {
+	case archive.OCI != "":
+		var dockerConfig []byte
+
+		if archive.DockerConfig != "" {
+			raw, err := os.ReadFile(archive.DockerConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			dockerConfig = raw
+		}
+
+		return oci.FetchPluginArchive(ctx, archive.OCI, dockerConfig, archive.InsecurePlainHTTP)
+	case archive.URL != "":
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, archive.URL, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := httpClient.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("downloading %s failed with status %d", archive.URL, response.StatusCode)
+		}
+
+		return io.ReadAll(response.Body)
+	case archive.File != "":
+		return os.ReadFile(archive.File)
+	default:
+		return nil, errors.New("no source")
+	}
+}
+
+// unpack extracts a zip archive into the directory of the plugin within dir, replacing a previous installation.
+// Archives hold either the plugin files or a single plugin directory at their root, whose name is ignored
+func unpack(data []byte, dir, name string) error {
+	if !filepath.IsLocal(name) || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid plugin name %q", name)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	roots := map[string]bool{}
+	flat := false
+
+	var size uint64
+
+	for _, f := range zr.File {
+		if !filepath.IsLocal(f.Name) || strings.Contains(f.Name, `\`) {
+			return fmt.Errorf("archive contains invalid path %q", f.Name)
+		}
+
+		if !f.Mode().IsDir() && !f.Mode().IsRegular() {
+			return fmt.Errorf("archive contains unsupported file %q", f.Name)
+		}
+
+		size += f.UncompressedSize64
+		if size > maxUnpackedSize {
+			return fmt.Errorf("archive exceeds %d bytes", maxUnpackedSize)
+		}
+
+		root, _, nested := strings.Cut(f.Name, "/")
+		if !nested && !f.Mode().IsDir() {
+			flat = true
+		}
+
+		roots[root] = true
+	}
+
+	if !flat && len(roots) > 1 {
+		return fmt.Errorf("archive of plugin %s holds %d directories at its root, expected a single plugin directory", name, len(roots))
+	}
+
+	// Only the directory of the plugin is replaced, other plugins are left untouched
+	dir = filepath.Join(dir, name)
+
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		rel := path.Clean(f.Name)
+		if !flat {
+			_, rel, _ = strings.Cut(rel, "/")
+		}
+
+		if rel == "" {
+			continue
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(rel)) // #nosec G305 -- validated above
+
+		if f.Mode().IsDir() {
+			err = os.MkdirAll(target, 0o755)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err = extractFile(f, target)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractFile(f *zip.File, target string) error {
+	err := os.MkdirAll(filepath.Dir(target), 0o755)
+	if err != nil {
+		return err
+	}
+
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// keep the executable bit of backend plugin binaries
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode().Perm()|0o600) // #nosec G302 G304
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, io.LimitReader(src, maxUnpackedSize))
+	if err != nil {
+		return err
+	}
+
+	return dst.Close()
+}
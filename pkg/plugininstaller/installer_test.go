@@ -0,0 +1,141 @@
+package plugininstaller
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func TestInstall(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("file and url archives", func(t *testing.T) {
+		dir := t.TempDir()
+
+		clock := makeZip(t, map[string]string{"grafana-clock-panel/plugin.json": `{"id":"grafana-clock-panel"}`})
+		clockFile := filepath.Join(t.TempDir(), "clock.zip")
+		require.NoError(t, os.WriteFile(clockFile, clock, 0o600))
+
+		flat := makeZip(t, map[string]string{"plugin.json": `{"id":"flat-panel"}`, "module.js": "x"})
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write(flat) //nolint:errcheck
+		}))
+		defer srv.Close()
+
+		// a previously installed version is replaced
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "grafana-clock-panel", "old"), 0o755))
+
+		err := Install(ctx, dir, []Archive{
+			{Name: "grafana-clock-panel", Version: "2.1.8", File: clockFile, SHA256: checksum(clock)},
+			{Name: "flat-panel", Version: "1.0.0", URL: srv.URL + "/flat.zip", SHA256: checksum(flat)},
+		}, srv.Client())
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(dir, "grafana-clock-panel", "plugin.json"))
+		assert.NoDirExists(t, filepath.Join(dir, "grafana-clock-panel", "old"))
+		assert.FileExists(t, filepath.Join(dir, "flat-panel", "plugin.json"), "flat archives are unpacked into a directory named after the plugin")
+		assert.FileExists(t, filepath.Join(dir, "flat-panel", "module.js"))
+	})
+
+	t.Run("only the directory of the plugin is replaced", func(t *testing.T) {
+		dir := t.TempDir()
+
+		// the root directory of the archive does not need to match the plugin name
+		clock := makeZip(t, map[string]string{"clock-panel-2.1.8/plugin.json": "{}", "clock-panel-2.1.8/img/logo.svg": "x"})
+		clockFile := filepath.Join(t.TempDir(), "clock.zip")
+		require.NoError(t, os.WriteFile(clockFile, clock, 0o600))
+
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "clock-panel-2.1.8", "keep"), 0o755))
+
+		err := Install(ctx, dir, []Archive{{Name: "grafana-clock-panel", File: clockFile}}, http.DefaultClient)
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(dir, "grafana-clock-panel", "plugin.json"))
+		assert.FileExists(t, filepath.Join(dir, "grafana-clock-panel", "img", "logo.svg"))
+		assert.DirExists(t, filepath.Join(dir, "clock-panel-2.1.8", "keep"), "directories of other plugins are kept")
+	})
+
+	t.Run("several directories at the root", func(t *testing.T) {
+		dir := t.TempDir()
+
+		multi := makeZip(t, map[string]string{"a-panel/plugin.json": "{}", "b-panel/plugin.json": "{}"})
+		multiFile := filepath.Join(t.TempDir(), "multi.zip")
+		require.NoError(t, os.WriteFile(multiFile, multi, 0o600))
+
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "b-panel", "keep"), 0o755))
+
+		err := Install(ctx, dir, []Archive{{Name: "a-panel", File: multiFile}}, http.DefaultClient)
+		require.ErrorContains(t, err, "expected a single plugin directory")
+		assert.DirExists(t, filepath.Join(dir, "b-panel", "keep"))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		dir := t.TempDir()
+
+		clock := makeZip(t, map[string]string{"grafana-clock-panel/plugin.json": "{}"})
+		clockFile := filepath.Join(t.TempDir(), "clock.zip")
+		require.NoError(t, os.WriteFile(clockFile, clock, 0o600))
+
+		err := Install(ctx, dir, []Archive{
+			{Name: "grafana-clock-panel", File: clockFile, SHA256: checksum([]byte("other"))},
+		}, http.DefaultClient)
+		require.ErrorContains(t, err, "checksum of plugin grafana-clock-panel does not match")
+		assert.NoDirExists(t, filepath.Join(dir, "grafana-clock-panel"))
+	})
+
+	t.Run("path traversal", func(t *testing.T) {
+		dir := t.TempDir()
+
+		evil := makeZip(t, map[string]string{"../evil/plugin.json": "{}"})
+		evilFile := filepath.Join(t.TempDir(), "evil.zip")
+		require.NoError(t, os.WriteFile(evilFile, evil, 0o600))
+
+		err := Install(ctx, dir, []Archive{{Name: "evil", File: evilFile}}, http.DefaultClient)
+		require.ErrorContains(t, err, "invalid path")
+	})
+
+	t.Run("failed download", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		defer srv.Close()
+
+		err := Install(ctx, t.TempDir(), []Archive{{Name: "missing", URL: srv.URL, SHA256: checksum(nil)}}, srv.Client())
+		require.ErrorContains(t, err, "failed with status 404")
+	})
+}